@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,10 +23,20 @@ import (
 // ─────────────────────────────────────────────────────────────────────────────
 
 var (
-	addAuthorFlag string
-	addAtFlag     string
+	addAuthorFlag    string
+	addAtFlag        string
+	addKindFlag      string
+	addDedupeFlag    bool
+	addContinueFlag  bool
+	addStdinFlag     bool
+	addSkipDupesFlag bool
 )
 
+// addDedupeWindow is how close in time a --dedupe'd entry must be to the
+// day's last entry, with the same body, kind, and author, to be taken as
+// a retry of it rather than logged again.
+const addDedupeWindow = 5 * time.Minute
+
 // addCmd is the graduated `rk add` capture command (v1-T4, reckon-uv09):
 // appends a timestamped, authored entry to today's (or --date's) log day
 // file under log/<date>.md. Does not touch the legacy DB-journal `rk log`
@@ -33,11 +44,13 @@ var (
 // index itself (capture -> explicit `rk index` -> query is the intended
 // flow, matching `rk query`'s own no-auto-reconcile contract).
 var addCmd = &cobra.Command{
-	Use:          "add <text...>",
-	Short:        "Capture a timestamped log entry into the vault",
-	Long:         "Append a timestamped, authored entry to today's (or --date's) log day file under log/<date>.md.",
+	Use:   "add <text...>",
+	Short: "Capture a timestamped log entry into the vault",
+	Long: "Append a timestamped, authored entry to today's (or --date's) log day file under log/<date>.md. " +
+		"With --stdin the entry carries piped input, such as a command's output, as an indented block " +
+		"under any text given as arguments; input over 1 MiB is truncated with a marker.",
 	SilenceUsage: true,
-	Args:         cobra.MinimumNArgs(1),
+	Args:         cobra.ArbitraryArgs, // arity depends on --stdin; checked in runAddE
 	RunE:         runAddE,
 }
 
@@ -45,6 +58,11 @@ func init() {
 	f := addCmd.Flags()
 	f.StringVar(&addAuthorFlag, "author", "", "Author to record (default: $RECKON_AUTHOR, $USER, or \"local\")")
 	f.StringVar(&addAtFlag, "at", "", "Entry time HH:MM, 24-hour (default: current UTC time)")
+	f.StringVar(&addKindFlag, "kind", "", "One-word entry kind, e.g. win or intention (queryable as the kind prop)")
+	f.BoolVar(&addDedupeFlag, "dedupe", false, "Skip the entry if the day's last entry is identical and at most 5 minutes older (a retried capture)")
+	f.BoolVar(&addContinueFlag, "continue", false, "Append the text as a new line of the day's last entry instead of logging a new one")
+	f.BoolVar(&addStdinFlag, "stdin", false, "Log piped stdin (e.g. command output) verbatim under the text given as arguments")
+	f.BoolVar(&addSkipDupesFlag, "skip-duplicates", false, "With --kind intention or win, skip the entry if the day already has one like it (same text, ignoring case and spacing)")
 }
 
 // resetAddFlags restores add flag variables to their defaults and clears the
@@ -52,7 +70,12 @@ func init() {
 func resetAddFlags(cmd *cobra.Command) {
 	addAuthorFlag = ""
 	addAtFlag = ""
-	for _, name := range []string{"author", "at"} {
+	addKindFlag = ""
+	addDedupeFlag = false
+	addContinueFlag = false
+	addStdinFlag = false
+	addSkipDupesFlag = false
+	for _, name := range []string{"author", "at", "kind", "dedupe", "continue", "stdin", "skip-duplicates"} {
 		if fl := cmd.Flags().Lookup(name); fl != nil {
 			fl.Changed = false
 		}
@@ -68,16 +91,40 @@ func resetAddFlags(cmd *cobra.Command) {
 // caller rather than normal shell usage.
 var embeddedHeaderRe = regexp.MustCompile(`(?m)^## `)
 
+// entryKindRe is what --kind accepts: one word the log-entry header's kind
+// slot parses back (internal/node/logparser.go's entryHeaderFieldsRe), so
+// no whitespace and no "·" separator.
+var entryKindRe = regexp.MustCompile(`^[^\s·]+$`)
+
 // logAddResult is the structured summary of one `rk add` run.
 type logAddResult struct {
 	Path string `json:"path"` // vault-relative: "log/<date>.md"
 	ID   string `json:"id"`   // the new entry's ULID
 	Day  string `json:"day"`  // the day file's date, e.g. "2026-07-05"
 	Time string `json:"time"` // the entry's reconstructed time, e.g. "2026-07-05T09:15:00Z"
+	// Duplicate is set when --dedupe found the entry already logged; ID,
+	// Time are then the existing entry's and nothing was written.
+	Duplicate bool `json:"duplicate,omitempty"`
+	// Continued is set when --continue appended to the existing entry ID
+	// rather than logging a new one.
+	Continued bool `json:"continued,omitempty"`
+	// Truncated is set when --stdin's input exceeded maxStdinSize and was
+	// cut short.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 func (r logAddResult) Pretty() string {
-	return fmt.Sprintf("add: logged to %s (id %s, time %s)", r.Path, r.ID, r.Time)
+	if r.Continued {
+		return fmt.Sprintf("add: continued entry %s in %s (time %s)", r.ID, r.Path, r.Time)
+	}
+	if r.Duplicate {
+		return fmt.Sprintf("add: already logged to %s (id %s, time %s); skipped duplicate", r.Path, r.ID, r.Time)
+	}
+	msg := fmt.Sprintf("add: logged to %s (id %s, time %s)", r.Path, r.ID, r.Time)
+	if r.Truncated {
+		msg += fmt.Sprintf("; stdin truncated to %d bytes", maxStdinSize)
+	}
+	return msg
 }
 
 func runAddE(cmd *cobra.Command, args []string) error {
@@ -87,15 +134,39 @@ func runAddE(cmd *cobra.Command, args []string) error {
 	if embeddedHeaderRe.MatchString(author) {
 		return fmt.Errorf(`add: author must not contain a line starting with "## " (would be mis-split as a new entry)`)
 	}
-	body := strings.TrimSpace(strings.Join(args, " "))
-	if body == "" {
-		return fmt.Errorf("add: empty body text")
+	if !addStdinFlag && len(args) == 0 {
+		return fmt.Errorf("add: missing entry text (pass it as arguments, or use --stdin)")
+	}
+	if addStdinFlag && addContinueFlag {
+		return fmt.Errorf("add: --continue does not support --stdin")
 	}
+	body := strings.TrimSpace(strings.Join(args, " "))
 	if embeddedHeaderRe.MatchString(body) {
 		return fmt.Errorf(`add: body must not contain a line starting with "## " (would be mis-split as a new entry)`)
 	}
+	kind := strings.TrimSpace(addKindFlag)
+	if addKindFlag != "" && !entryKindRe.MatchString(kind) {
+		return fmt.Errorf("add: --kind must be a single word without \"·\", got %q", addKindFlag)
+	}
+	if addContinueFlag && (addAtFlag != "" || addKindFlag != "" || addDedupeFlag) {
+		return fmt.Errorf("add: --continue does not support --at/--kind/--dedupe (the entry keeps its own header)")
+	}
+	if addSkipDupesFlag && !slices.Contains(addDuplicateKinds, kind) {
+		return fmt.Errorf("add: --skip-duplicates needs --kind %s", strings.Join(addDuplicateKinds, " or "))
+	}
+	truncated := false
+	if addStdinFlag {
+		raw, cut, err := readStdinCapped(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("add: %w", err)
+		}
+		body, truncated = stdinBlock(body, raw, cut), cut
+	}
+	if body == "" {
+		return fmt.Errorf("add: empty body text")
+	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -109,6 +180,7 @@ func runAddE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("add: %w", err)
 	}
+	hhmm = journalHHMM(hhmm)
 
 	cfg, err := config.LoadWithOverrides(vaultFlag, "")
 	if err != nil {
@@ -120,13 +192,39 @@ func runAddE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("add: create log dir: %w", err)
 	}
 
-	res, err := appendLogEntry(logDir, day, hhmm, author, body)
-	if err != nil {
-		return err
+	var res logAddResult
+	dup := false
+	if addDedupeFlag {
+		if res, dup, err = findDuplicateLogEntry(logDir, day, hhmm, kind, author, body); err != nil {
+			return err
+		}
+	}
+	if !dup && slices.Contains(addDuplicateKinds, kind) {
+		like, found, err := findSimilarKindEntry(logDir, day, kind, body)
+		if err != nil {
+			return err
+		}
+		switch {
+		case found && addSkipDupesFlag:
+			res, dup = like, true
+		case found && !quietFlag:
+			fmt.Fprintf(cmd.ErrOrStderr(), "add: warning: %s already has an entry of kind %s like this (id %s); logging it anyway (--skip-duplicates skips it)\n", day, kind, like.ID)
+		}
+	}
+	switch {
+	case addContinueFlag:
+		if res, err = continueLastLogEntry(logDir, day, body); err != nil {
+			return err
+		}
+	case !dup:
+		if res, err = appendKindLogEntry(logDir, day, hhmm, kind, author, body); err != nil {
+			return err
+		}
 	}
 
+	res.Truncated = truncated && !dup
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
@@ -134,31 +232,23 @@ func runAddE(cmd *cobra.Command, args []string) error {
 }
 
 // effectiveLogDate returns the date of the log day file to write: the
-// validated --date flag when the user explicitly set it (delegating to
-// getEffectiveDate for its format validation), else the current UTC
-// calendar date -- deliberately NOT getEffectiveDate()'s own local-clock
-// default.
+// validated --date flag when the user explicitly set it (relative forms
+// like "yesterday" resolve against the current journal day), else the
+// current journal day itself -- journalNow's date, so the day rollover
+// applies.
 //
 // This is the C1 fix (reckon-uv09 review): appendLogEntry composes the
 // entry's `time` field as `day + "T" + hhmm + ":00Z"`, and resolveAtTime's
-// default hhmm is already UTC wall-clock. If day came from
-// getEffectiveDate()'s LOCAL default, the two halves of that composed
-// RFC3339 value would come from two different clocks -- e.g. a Sydney user
-// at local 2026-07-05 08:30 would get day="2026-07-05" (local) +
-// hhmm="22:30" (UTC, since it's already 2026-07-04 22:30 UTC), producing
-// the impossible instant "2026-07-05T22:30:00Z" a full day off from the
-// true UTC instant "2026-07-04T22:30:00Z". Defaulting the day to UTC here
-// keeps both halves on one clock.
-//
-// getEffectiveDate() itself is intentionally left untouched: today.go/
-// week.go and the legacy journal readers rely on its local-clock semantics,
-// and it still owns --date's format validation (used here on the
-// explicit-flag path only).
+// default hhmm is UTC wall-clock. Taking the day from the process's LOCAL
+// clock instead would put the two halves of that composed RFC3339
+// value on two different clocks -- e.g. a Sydney user at local 2026-07-05
+// 08:30 would get day="2026-07-05" (local) + hhmm="22:30" (UTC, since it's
+// already 2026-07-04 22:30 UTC), producing the impossible instant
+// "2026-07-05T22:30:00Z" a full day off from the true UTC instant
+// "2026-07-04T22:30:00Z". journalNow is UTC-based (todoNow), so both
+// halves share one clock.
 func effectiveLogDate() (string, error) {
-	if dateFlag != "" {
-		return getEffectiveDate()
-	}
-	return time.Now().UTC().Format("2006-01-02"), nil
+	return resolveDateFlag(journalNow())
 }
 
 // resolveAtTime validates and returns the HH:MM string for the new entry:
@@ -181,8 +271,125 @@ func resolveAtTime(at string) (string, error) {
 // absent, or appends a node.RenderLogEntry block strictly at EOF if present.
 // The ULID mints at real wall-clock node.Mint() regardless of --at.
 func appendLogEntry(logDir, day, hhmm, author, body string) (logAddResult, error) {
+	return appendKindLogEntry(logDir, day, hhmm, "", author, body)
+}
+
+// findDuplicateLogEntry reports whether day's last entry already is the
+// entry `rk add --dedupe` is about to write: same body, kind, and author,
+// stamped no more than addDedupeWindow before hhmm. Bodies compare without
+// surrounding whitespace, since the parser drops the leading indent of a
+// --stdin block logged with no message. A missing day file has no
+// duplicate.
+func findDuplicateLogEntry(logDir, day, hhmm, kind, author, body string) (logAddResult, bool, error) {
+	path, relPath := logDayFile(logDir, day)
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return logAddResult{}, false, nil
+	}
+	if err != nil {
+		return logAddResult{}, false, fmt.Errorf("add: read %s: %w", relPath, err)
+	}
+	nodes, err := node.LogParser{}.Parse(raw, node.Loc{File: relPath})
+	if err != nil {
+		return logAddResult{}, false, fmt.Errorf("add: parse %s: %w", relPath, err)
+	}
+	last := nodes[len(nodes)-1]
+	if last.Type != "log-entry" || strings.TrimSpace(last.Body) != strings.TrimSpace(body) || last.Props["kind"] != kind || last.Author != author {
+		return logAddResult{}, false, nil
+	}
+	prev, err := time.Parse(time.RFC3339, last.Time)
+	if err != nil {
+		return logAddResult{}, false, nil
+	}
+	now, err := time.Parse(time.RFC3339, node.EntryTime(day, hhmm))
+	if err != nil {
+		return logAddResult{}, false, nil
+	}
+	if gap := now.Sub(prev); gap < 0 || gap > addDedupeWindow {
+		return logAddResult{}, false, nil
+	}
+	return logAddResult{Path: relPath, ID: last.ULID, Day: day, Time: last.Time, Duplicate: true}, true, nil
+}
+
+// addDuplicateKinds are the entry kinds `rk add` checks for a likely
+// duplicate on the same day: a day's plan and wins are short lists, where
+// the same item twice (say, an intention copied in and then typed again) is
+// almost always a mistake.
+var addDuplicateKinds = []string{"intention", "win"}
+
+// findSimilarKindEntry reports day's first entry of kind whose body matches
+// body ignoring case and spacing. Unlike --dedupe, any such entry that day
+// counts, however long ago it was logged or by whom.
+func findSimilarKindEntry(logDir, day, kind, body string) (logAddResult, bool, error) {
+	path, relPath := logDayFile(logDir, day)
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return logAddResult{}, false, nil
+	}
+	if err != nil {
+		return logAddResult{}, false, fmt.Errorf("add: read %s: %w", relPath, err)
+	}
+	nodes, err := node.LogParser{}.Parse(raw, node.Loc{File: relPath})
+	if err != nil {
+		return logAddResult{}, false, fmt.Errorf("add: parse %s: %w", relPath, err)
+	}
+	want := normalizeEntryText(body)
+	for _, n := range nodes {
+		if n.Type == "log-entry" && n.Props["kind"] == kind && normalizeEntryText(n.Body) == want {
+			return logAddResult{Path: relPath, ID: n.ULID, Day: day, Time: n.Time, Duplicate: true}, true, nil
+		}
+	}
+	return logAddResult{}, false, nil
+}
+
+// normalizeEntryText folds s to lower case with its runs of whitespace
+// collapsed to single spaces, for comparing entries written slightly
+// differently.
+func normalizeEntryText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// continueLastLogEntry is `rk add --continue`: it appends text as a new line
+// of day's last entry's body (via editLogEntry, so the header and markers
+// stay as they are), for a follow-on thought that needs no timestamp of its
+// own. A day with no entries yet is an error.
+func continueLastLogEntry(logDir, day, text string) (logAddResult, error) {
+	path, relPath := logDayFile(logDir, day)
+	raw, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return logAddResult{}, fmt.Errorf("add: read %s: %w", relPath, err)
+	}
+	var last *node.Node
+	if err == nil {
+		nodes, err := node.LogParser{}.Parse(raw, node.Loc{File: relPath})
+		if err != nil {
+			return logAddResult{}, fmt.Errorf("add: parse %s: %w", relPath, err)
+		}
+		for i := len(nodes) - 1; i >= 0 && last == nil; i-- {
+			if nodes[i].Type == "log-entry" {
+				last = nodes[i]
+			}
+		}
+	}
+	if last == nil {
+		return logAddResult{}, fmt.Errorf("add: --continue: no log entry on %s to continue (not found)", day)
+	}
+	if err := editLogEntry(path, last.ULID, strings.TrimSpace(last.Body)+"\n"+text); err != nil {
+		return logAddResult{}, fmt.Errorf("add: --continue: %w", err)
+	}
+	return logAddResult{Path: relPath, ID: last.ULID, Day: day, Time: last.Time, Continued: true}, nil
+}
+
+// appendKindLogEntry is appendLogEntry with the header's optional kind word
+// ("## HH:MM kind · author", which LogParser indexes as the kind prop);
+// kind "" writes the plain header.
+func appendKindLogEntry(logDir, day, hhmm, kind, author, body string) (logAddResult, error) {
 	id := node.Mint()
-	block := node.RenderLogEntry(hhmm, author, id, body)
+	header := hhmm
+	if kind != "" {
+		header += " " + kind
+	}
+	block := node.RenderLogEntry(header, author, id, body)
 	return writeLogEntryBlock(logDir, day, hhmm, id, block)
 }
 
@@ -209,16 +416,35 @@ func appendDidLogEntry(logDir, day, hhmm, author, body, didTarget string) (logAd
 	return writeLogEntryBlock(logDir, day, hhmm, id, block)
 }
 
+// logDayFile returns the path of day's file under logDir, and that path
+// relative to the vault, as the journal_layout setting lays it out. A day
+// file still where the other layout puts it (the setting changed but `rk
+// migrate log-layout` has not run) is used where it is, so a day never
+// ends up split across two files.
+func logDayFile(logDir, day string) (path, relPath string) {
+	other := config.JournalLayoutNested
+	if journalLayout == config.JournalLayoutNested {
+		other = config.JournalLayoutFlat
+	}
+	rel := config.JournalDayPath(journalLayout, day)
+	if _, err := os.Stat(filepath.Join(logDir, filepath.FromSlash(rel))); os.IsNotExist(err) {
+		alt := config.JournalDayPath(other, day)
+		if _, err := os.Stat(filepath.Join(logDir, filepath.FromSlash(alt))); err == nil {
+			rel = alt
+		}
+	}
+	return filepath.Join(logDir, filepath.FromSlash(rel)), "log/" + rel
+}
+
 // writeLogEntryBlock is the shared create-or-append tail for appendLogEntry
-// and appendDidLogEntry: create log/<day>.md via the NewNode -> Render ->
-// Parse -> writeFileAtomic recipe if absent, else append block strictly at
-// EOF. block is the exact, already-rendered entry bytes (either
-// node.RenderLogEntry's or node.RenderLogEntryWithDid's output); id/hhmm are
-// only needed to compose the returned logAddResult.
+// and appendDidLogEntry: create the day file (see logDayFile) via the
+// NewNode -> Render -> Parse -> writeFileAtomic recipe if absent, else
+// append block strictly at EOF. block is the exact, already-rendered entry
+// bytes (either node.RenderLogEntry's or node.RenderLogEntryWithDid's
+// output); id/hhmm are only needed to compose the returned logAddResult.
 func writeLogEntryBlock(logDir, day, hhmm, id, block string) (logAddResult, error) {
-	path := filepath.Join(logDir, day+".md")
-	entryTime := day + "T" + hhmm + ":00Z"
-	relPath := "log/" + day + ".md"
+	path, relPath := logDayFile(logDir, day)
+	entryTime := node.EntryTime(day, hhmm)
 
 	raw, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
@@ -230,6 +456,9 @@ func writeLogEntryBlock(logDir, day, hhmm, id, block string) (logAddResult, erro
 		if perr != nil {
 			return logAddResult{}, fmt.Errorf("add: parse rendered day file: %w", perr)
 		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return logAddResult{}, fmt.Errorf("add: create %s: %w", filepath.Dir(relPath), err)
+		}
 		if err := writeFileAtomic(path, parsed.Serialize()); err != nil {
 			return logAddResult{}, fmt.Errorf("add: write: %w", err)
 		}
@@ -257,3 +486,63 @@ func writeLogEntryBlock(logDir, day, hhmm, id, block string) (logAddResult, erro
 	}
 	return logAddResult{Path: relPath, ID: id, Day: day, Time: entryTime}, nil
 }
+
+// editLogEntry rewrites the body of the entry whose `id:: <id>` marker
+// follows its header in the day file at path, leaving the header, the id::
+// (and any did::) marker lines, the trailing blank-line spacing, and every
+// sibling entry byte-identical. v1 log entries carry their note text as the
+// entry body itself, so this is the log's note-edit verb (the TUI log pane's
+// "e" key).
+func editLogEntry(path, id, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return fmt.Errorf("log edit: empty body text")
+	}
+	if embeddedHeaderRe.MatchString(body) {
+		return fmt.Errorf(`log edit: body must not contain a line starting with "## " (would be mis-split as a new entry)`)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("log edit: read %s: %w", path, err)
+	}
+	if bytes.Contains(raw, []byte("\r\n")) {
+		return fmt.Errorf("log edit: CRLF line endings are not supported (reckon-vj55): %s", path)
+	}
+	day, err := node.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("log edit: parse %s: %w", path, err)
+	}
+
+	for _, e := range day.SplitEntries() {
+		block := string(raw[e.Span.Start:e.Span.End])
+		idLine := e.Header + "\nid:: " + id + "\n"
+		if !strings.HasPrefix(block, idLine) {
+			continue
+		}
+		prefix := idLine
+		if rest := block[len(prefix):]; strings.HasPrefix(rest, "did:: ") {
+			if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+				prefix += rest[:nl+1]
+			}
+		}
+		oldBody := block[len(prefix):]
+		tail := oldBody[len(strings.TrimRight(oldBody, "\n")):]
+		if tail == "" {
+			tail = "\n"
+		}
+
+		updated, err := day.ReplaceEntryBody(e, prefix+body+tail)
+		if err != nil {
+			return fmt.Errorf("log edit: %w", err)
+		}
+		if _, err := node.Parse(updated); err != nil {
+			return fmt.Errorf("log edit: parse edited day file: %w", err)
+		}
+		if err := writeFileAtomic(path, updated); err != nil {
+			return fmt.Errorf("log edit: write: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("log edit: no entry %s in %s (not found)", id, path)
+}
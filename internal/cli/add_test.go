@@ -11,9 +11,9 @@
 // this package): setupQueryVault, writeTestNode, resetCLIFlags, buildIndex,
 // runQuery, parseNDJSONMaps, countNDJSONLines (query_test.go); mustDecodeJSON
 // (todo_test.go); mustWriteFile, mustReadFile, isValidULID (adopt_test.go);
-// getEffectiveDate, resolveAuthor (root.go/todo.go, same package -- called
-// directly below to derive the exact expected day/author rather than
-// re-deriving that logic independently in the test).
+// resolveAuthor (todo.go, same package -- called directly below to derive
+// the exact expected author rather than re-deriving that logic
+// independently in the test).
 //
 // ─────────────────────────────────────────────────────────────────────────
 // Pinned contract (plan.md "New — internal/cli/add.go"):
@@ -35,12 +35,12 @@
 // introduce a literal "## " line (EC-9, defensive -- args are space-joined so
 // this mostly guards embedded-newline/programmatic callers) are both
 // rejected with a non-zero exit and no file write. File selection is
-// log/<effectiveLogDate()>.md (--date, when given, picks the day FILE via
-// getEffectiveDate(); when --date is omitted the day defaults to the
-// current UTC calendar date, NOT getEffectiveDate()'s own local-clock
-// default -- see effectiveLogDate's doc comment in add.go, reckon-uv09
-// review C1: this keeps the day-file date and the entry's HH:MM, which
-// already defaults to UTC below, on one shared clock); entry time is
+// log/<effectiveLogDate()>.md (--date, when given, picks the day FILE;
+// when --date is omitted the day defaults to the current UTC journal day,
+// never the local calendar date -- see effectiveLogDate's doc comment in
+// add.go, reckon-uv09 review C1: this keeps the day-file date and the
+// entry's HH:MM, which already defaults to UTC below, on one shared
+// clock); entry time is
 // --at HH:MM if given, else current UTC HH:MM (--at backfills the time
 // WITHIN whichever day file was selected -- a distinct concern from --date).
 // The day file's frontmatter is `type: log-day`, aliases containing the
@@ -87,11 +87,10 @@ func runAdd(t *testing.T, vault string, args ...string) (stdout, stderr string,
 }
 
 // utcToday is the day rk add defaults to when --date is NOT given
-// (effectiveLogDate in add.go): the current UTC calendar date -- NOT
-// getEffectiveDate()'s own local-clock default. Tests below that omit
-// --date must derive their expected "today" from this helper rather than
-// from getEffectiveDate() directly, or they'd only coincidentally pass on a
-// UTC-clocked test host (reckon-uv09 review, C1: the whole point of the fix
+// (effectiveLogDate in add.go): the current UTC calendar date -- NOT the
+// local calendar date. Tests below that omit --date must derive their
+// expected "today" from this helper rather than from time.Now() directly,
+// or they'd only coincidentally pass on a UTC-clocked test host (reckon-uv09 review, C1: the whole point of the fix
 // is that the day file's date and the entry's HH:MM now share one clock).
 func utcToday() string { return time.Now().UTC().Format("2006-01-02") }
 
@@ -425,6 +424,190 @@ func TestAddCmd_AtFlagBackfillsTimeIndependentOfULIDMintTime(t *testing.T) {
 	}
 }
 
+// TestAddCmd_KindFlag: --kind writes the header's kind word, so a win or
+// an intention is a log entry with a queryable kind prop; a kind the header
+// could not parse back is rejected.
+func TestAddCmd_KindFlag(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	today := utcToday()
+	if _, stderr, err := runAdd(t, vault, "Shipped it", "--kind", "win", "--at", "17:30", "--author", "sam"); err != nil {
+		t.Fatalf("rk add --kind: %v\nstderr: %s", err, stderr)
+	}
+	if src := mustReadFile(t, dayLogPath(vault, today)); !strings.Contains(src, "## 17:30 win · sam\n") {
+		t.Errorf("day file should carry the kind word in the header:\n%s", src)
+	}
+	entries := parseLogDayFile(t, vault, today)[1:]
+	if len(entries) != 1 || entries[0].Props["kind"] != "win" || entries[0].Body != "Shipped it" {
+		t.Errorf("parsed entry = %+v, want kind win and the plain body", entries)
+	}
+
+	for _, bad := range []string{"big win", "a·b", " "} {
+		resetCLIFlags()
+		if _, _, err := runAdd(t, vault, "nope", "--kind", bad); err == nil || !strings.Contains(err.Error(), "--kind") {
+			t.Errorf("--kind %q err = %v, want a --kind error", bad, err)
+		}
+	}
+}
+
+// TestAddCmd_RelativeDate: --date accepts relative forms, resolved against
+// the UTC date, so yesterday's log can be tidied without typing the date.
+func TestAddCmd_RelativeDate(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	if _, stderr, err := runAdd(t, vault, "late entry", "--date", "yesterday", "--at", "21:00"); err != nil {
+		t.Fatalf("rk add --date yesterday: %v\nstderr: %s", err, stderr)
+	}
+	entries := parseLogDayFile(t, vault, yesterday)[1:]
+	if len(entries) != 1 || entries[0].Body != "late entry" {
+		t.Errorf("yesterday's entries = %+v, want the backfilled entry", entries)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runAdd(t, vault, "nope", "--date", "someday"); err == nil || !strings.Contains(err.Error(), "--date") {
+		t.Errorf("--date someday err = %v, want a --date error", err)
+	}
+}
+
+// TestAddCmd_Dedupe: a retried capture under --dedupe (same body, kind,
+// and author within five minutes of the day's last entry) returns the
+// existing entry instead of appending; a later or different entry, or one
+// without --dedupe, is logged as usual.
+func TestAddCmd_Dedupe(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	const date = "2026-07-05"
+
+	add := func(at string, extra ...string) logAddResult {
+		t.Helper()
+		resetCLIFlags()
+		args := append([]string{"deployed api", "--date", date, "--at", at, "--author", "sam", "--json"}, extra...)
+		out, stderr, err := runAdd(t, vault, args...)
+		if err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res logAddResult
+		mustDecodeJSON(t, out, &res)
+		return res
+	}
+
+	first := add("09:00", "--dedupe")
+	retry := add("09:03", "--dedupe")
+	if !retry.Duplicate || retry.ID != first.ID || retry.Time != first.Time {
+		t.Errorf("retry = %+v, want the first entry %s reported as a duplicate", retry, first.ID)
+	}
+	if late := add("09:10", "--dedupe"); late.Duplicate {
+		t.Errorf("an entry past the window should be logged, got %+v", late)
+	}
+	if plain := add("09:10"); plain.Duplicate {
+		t.Errorf("without --dedupe the same entry should be logged again, got %+v", plain)
+	}
+	if won := add("09:11", "--dedupe", "--kind", "win"); won.Duplicate {
+		t.Errorf("a different kind is not a duplicate, got %+v", won)
+	}
+	if n := len(parseLogDayFile(t, vault, date)) - 1; n != 4 {
+		t.Errorf("day file has %d entries, want 4 (the retry skipped)", n)
+	}
+}
+
+// TestAddCmd_DuplicateKinds: an intention or win matching one already on
+// the day (ignoring case and spacing) is logged with a warning by default
+// and skipped under --skip-duplicates; other kinds are never checked.
+func TestAddCmd_DuplicateKinds(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	const date = "2026-07-05"
+
+	add := func(text string, extra ...string) (logAddResult, string) {
+		t.Helper()
+		resetCLIFlags()
+		args := append([]string{text, "--date", date, "--json"}, extra...)
+		out, stderr, err := runAdd(t, vault, args...)
+		if err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res logAddResult
+		mustDecodeJSON(t, out, &res)
+		return res, stderr
+	}
+
+	first, _ := add("Ship the release", "--kind", "intention", "--at", "08:00")
+	again, stderr := add("ship  the RELEASE", "--kind", "intention", "--at", "09:00")
+	if again.Duplicate || !strings.Contains(stderr, "kind intention like this (id "+first.ID+")") {
+		t.Errorf("a likely duplicate = %+v, stderr %q; want it logged with a warning naming %s", again, stderr, first.ID)
+	}
+	skipped, _ := add("Ship the release", "--kind", "intention", "--at", "10:00", "--skip-duplicates")
+	if !skipped.Duplicate || skipped.ID != first.ID {
+		t.Errorf("--skip-duplicates = %+v, want the existing %s reported and nothing written", skipped, first.ID)
+	}
+	if _, stderr := add("Ship the release", "--kind", "win", "--at", "11:00", "--skip-duplicates"); stderr != "" {
+		t.Errorf("a win is not a duplicate of an intention, stderr %q", stderr)
+	}
+	if _, stderr := add("Ship the release", "--kind", "note", "--at", "12:00"); stderr != "" {
+		t.Errorf("kind note is never checked, stderr %q", stderr)
+	}
+	if n := len(parseLogDayFile(t, vault, date)) - 1; n != 4 {
+		t.Errorf("day file has %d entries, want 4 (the --skip-duplicates one skipped)", n)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runAdd(t, vault, "x", "--date", date, "--skip-duplicates"); err == nil || !strings.Contains(err.Error(), "--kind intention or win") {
+		t.Errorf("--skip-duplicates without a kind: err = %v, want a usage error", err)
+	}
+}
+
+func TestAddCmd_Continue(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	const date = "2026-07-05"
+
+	if _, _, err := runAdd(t, vault, "and more", "--date", date, "--continue"); err == nil || !strings.Contains(err.Error(), "no log entry") {
+		t.Fatalf("--continue on an empty day: err = %v, want no log entry", err)
+	}
+
+	resetCLIFlags()
+	if _, stderr, err := runAdd(t, vault, "first thought", "--date", date, "--at", "09:00"); err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	out, stderr, err := runAdd(t, vault, "deployed api", "--date", date, "--at", "10:00", "--json")
+	if err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	var last logAddResult
+	mustDecodeJSON(t, out, &last)
+
+	resetCLIFlags()
+	out, stderr, err = runAdd(t, vault, "and also fixed the tests", "--date", date, "--continue", "--json")
+	if err != nil {
+		t.Fatalf("rk add --continue: %v\nstderr: %s", err, stderr)
+	}
+	var res logAddResult
+	mustDecodeJSON(t, out, &res)
+	if !res.Continued || res.ID != last.ID || res.Time != last.Time {
+		t.Errorf("--continue = %+v, want entry %s continued", res, last.ID)
+	}
+
+	nodes := parseLogDayFile(t, vault, date)
+	if len(nodes) != 3 {
+		t.Fatalf("day file has %d entries, want 2", len(nodes)-1)
+	}
+	if got, want := strings.TrimSpace(nodes[2].Body), "deployed api\nand also fixed the tests"; got != want {
+		t.Errorf("continued body = %q, want %q", got, want)
+	}
+	if strings.TrimSpace(nodes[1].Body) != "first thought" {
+		t.Errorf("earlier entry changed: %q", nodes[1].Body)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runAdd(t, vault, "x", "--date", date, "--continue", "--kind", "win"); err == nil {
+		t.Error("--continue --kind: want an error")
+	}
+}
+
 func TestAddCmd_AtFlagInvalidFormatRejected(t *testing.T) {
 	vault, _ := setupQueryVault(t)
 	t.Cleanup(resetCLIFlags)
@@ -437,8 +620,8 @@ func TestAddCmd_AtFlagInvalidFormatRejected(t *testing.T) {
 // ─────────────────────────────────────────────────────────────────────────────
 // C1 regression (reckon-uv09 code review): the day file's date and the
 // entry's HH:MM must come from ONE clock when --date/--at are both
-// omitted. Before the fix, the day came from getEffectiveDate()'s LOCAL
-// default while HH:MM came from resolveAtTime()'s UTC default; near a day
+// omitted. Before the fix, the day came from the LOCAL calendar date
+// while HH:MM came from resolveAtTime()'s UTC default; near a day
 // boundary on a non-UTC host the two would disagree by a full day (e.g. a
 // Sydney host at local 2026-07-05 08:30 would produce
 // "2026-07-05T22:30:00Z" instead of the true UTC instant
@@ -653,7 +836,7 @@ func TestAddCmd_AuthorEmbeddedHeaderLineGuard(t *testing.T) {
 // a clock-injection seam that plan.md does not name anywhere for add.go (unlike,
 // e.g., todo.go's mintTodoULID seam for a different scenario) -- inventing one
 // here would mean testing a seam this test-writing phase itself invented,
-// not a pinned contract. getEffectiveDate()'s wall-clock-now default (the
+// not a pinned contract. effectiveLogDate's wall-clock-now default (the
 // mechanism EC-10 recommends file selection follow) is already exercised
 // implicitly by every other test in this file that omits --date, and its
 // --date override half is exercised explicitly by
@@ -747,3 +930,247 @@ func TestAddCmd_DateFlagTargetsPastDay(t *testing.T) {
 		t.Errorf("Time = %q, want the form %sT<HH:MM>:00Z (current wall-clock HH:MM, no --at given)", res.Time, date)
 	}
 }
+
+// editLogEntry rewrites only the target entry's body: its header, id::,
+// did::, and the sibling entries stay byte-identical.
+func TestEditLogEntry_ReplacesOnlyTargetBody(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	logDir := filepath.Join(vault, "log")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		t.Fatalf("mkdir log dir: %v", err)
+	}
+	day := "2026-03-04"
+	first, err := appendLogEntry(logDir, day, "09:00", "tester", "first thing")
+	if err != nil {
+		t.Fatalf("appendLogEntry: %v", err)
+	}
+	second, err := appendDidLogEntry(logDir, day, "10:00", "tester", "did the thing", "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatalf("appendDidLogEntry: %v", err)
+	}
+	third, err := appendLogEntry(logDir, day, "11:00", "tester", "last thing")
+	if err != nil {
+		t.Fatalf("appendLogEntry: %v", err)
+	}
+	path := dayLogPath(vault, day)
+	before := mustReadFile(t, path)
+
+	if err := editLogEntry(path, second.ID, "  did the thing, properly  "); err != nil {
+		t.Fatalf("editLogEntry: %v", err)
+	}
+	got := mustReadFile(t, path)
+	want := strings.Replace(before, "did:: 01ARZ3NDEKTSV4RRFFQ69G5FAV\ndid the thing\n", "did:: 01ARZ3NDEKTSV4RRFFQ69G5FAV\ndid the thing, properly\n", 1)
+	if got != want {
+		t.Fatalf("edited day file mismatch\n--- want ---\n%s\n--- got ---\n%s", want, got)
+	}
+
+	if err := editLogEntry(path, third.ID, "last thing, revised"); err != nil {
+		t.Fatalf("editLogEntry (last entry): %v", err)
+	}
+	entries := parseLogDayFile(t, vault, day)[1:]
+	if len(entries) != 3 {
+		t.Fatalf("want 3 entries after edits, got %d", len(entries))
+	}
+	for i, want := range map[int]struct{ id, body string }{
+		0: {first.ID, "first thing"},
+		1: {second.ID, "did the thing, properly"},
+		2: {third.ID, "last thing, revised"},
+	} {
+		if entries[i].ULID != want.id || entries[i].Body != want.body {
+			t.Errorf("entry %d = (%s, %q), want (%s, %q)", i, entries[i].ULID, entries[i].Body, want.id, want.body)
+		}
+	}
+}
+
+func TestEditLogEntry_Rejections(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	logDir := filepath.Join(vault, "log")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		t.Fatalf("mkdir log dir: %v", err)
+	}
+	res, err := appendLogEntry(logDir, "2026-03-04", "09:00", "tester", "first thing")
+	if err != nil {
+		t.Fatalf("appendLogEntry: %v", err)
+	}
+	path := dayLogPath(vault, "2026-03-04")
+	before := mustReadFile(t, path)
+
+	for name, tc := range map[string]struct{ id, body, want string }{
+		"empty body":      {res.ID, "   ", "empty body"},
+		"embedded header": {res.ID, "oops\n## 12:00 · x", `"## "`},
+		"unknown id":      {"01ARZ3NDEKTSV4RRFFQ69G5FAV", "new text", "(not found)"},
+	} {
+		err := editLogEntry(path, tc.id, tc.body)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want one containing %q", name, err, tc.want)
+		}
+	}
+	if got := mustReadFile(t, path); got != before {
+		t.Errorf("rejected edits modified the day file:\n%s", got)
+	}
+}
+
+// TestAddCmd_DayRollover: before day_rollover, "today" is still the previous
+// date, so a 01:30 entry lands in the previous day's log and the agenda's
+// today is that day too; at or after it, the calendar date.
+func TestAddCmd_DayRollover(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "day_rollover: \"03:00\"\n")
+
+	prev := todoNow
+	t.Cleanup(func() { todoNow = prev })
+	todoNow = func() time.Time { return time.Date(2026, 3, 10, 1, 30, 0, 0, time.UTC) }
+
+	out, stderr, err := runAdd(t, vault, "late night fix", "--json")
+	if err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	var res logAddResult
+	mustDecodeJSON(t, out, &res)
+	if res.Path != "log/2026-03-09.md" {
+		t.Errorf("entry at 01:30 with day_rollover 03:00 went to %s, want log/2026-03-09.md", res.Path)
+	}
+	if got := currentJournalDate(); got != "2026-03-09" {
+		t.Errorf("currentJournalDate() at 01:30 = %s, want 2026-03-09", got)
+	}
+
+	resetCLIFlags()
+	todoNow = func() time.Time { return time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC) }
+	out, stderr, err = runAdd(t, vault, "early start", "--json")
+	if err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	mustDecodeJSON(t, out, &res)
+	if res.Path != "log/2026-03-10.md" {
+		t.Errorf("entry at 03:00 with day_rollover 03:00 went to %s, want log/2026-03-10.md", res.Path)
+	}
+}
+
+// TestAddCmd_DayRolloverPastMidnight: an entry logged after midnight but
+// before day_rollover is written as 25:30 in the previous day's file, so it
+// is placed at its real instant, after the evening's entries, and the day's
+// gaps run through midnight rather than 24 hours back.
+func TestAddCmd_DayRolloverPastMidnight(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "day_rollover: \"03:00\"\n")
+	prev := todoNow
+	t.Cleanup(func() { todoNow = prev })
+	todoNow = func() time.Time { return time.Date(2026, 3, 10, 1, 30, 0, 0, time.UTC) }
+
+	if _, stderr, err := runAdd(t, vault, "evening review", "--at", "23:00"); err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	out, stderr, err := runAdd(t, vault, "late night fix", "--at", "01:30", "--json")
+	if err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	var res logAddResult
+	mustDecodeJSON(t, out, &res)
+	if res.Path != "log/2026-03-09.md" || res.Time != "2026-03-10T01:30:00Z" {
+		t.Errorf("entry at 01:30 = %s at %s, want log/2026-03-09.md at 2026-03-10T01:30:00Z", res.Path, res.Time)
+	}
+	if got := mustReadFile(t, filepath.Join(vault, "log", "2026-03-09.md")); !strings.Contains(got, "## 25:30 · ") {
+		t.Errorf("day file has no 25:30 header:\n%s", got)
+	}
+
+	resetCLIFlags()
+	out, stderr, err = runToday(t, vault, "gaps", "--json")
+	if err != nil {
+		t.Fatalf("rk today gaps: %v\nstderr: %s", err, stderr)
+	}
+	var gaps todayGapsResult
+	mustDecodeJSON(t, out, &gaps)
+	if gaps.Date != "2026-03-09" || len(gaps.Gaps) != 1 || gaps.Gaps[0].Start != "23:00" || gaps.Gaps[0].Minutes != 150 {
+		t.Errorf("gaps = %+v, want 2026-03-09's one gap, 23:00-01:30 (150m)", gaps)
+	}
+}
+
+// TestAddCmd_InvalidDayRollover: a day_rollover that does not parse is
+// reported, not silently read as midnight.
+func TestAddCmd_InvalidDayRollover(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "day_rollover: 3am\n")
+
+	_, stderr, _ := runAdd(t, vault, "late night fix")
+	if !strings.Contains(stderr, "invalid day_rollover") {
+		t.Errorf("stderr = %q, want the invalid day_rollover reported", stderr)
+	}
+}
+
+// TestAddCmd_Stdin: --stdin logs piped output as an indented block under
+// the message, one entry however the output looks, and truncates oversized
+// input with a marker instead of failing.
+func TestAddCmd_Stdin(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	RootCmd.SetIn(strings.NewReader("ok  \tpkg/a\r\n\n## not a header\nid:: nope\nFAIL\n\n"))
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+
+	out, stderr, err := runAdd(t, vault, "--stdin", "test run", "--date", "2026-04-01", "--json")
+	if err != nil {
+		t.Fatalf("rk add --stdin: %v\nstderr: %s", err, stderr)
+	}
+	var res logAddResult
+	mustDecodeJSON(t, out, &res)
+	if res.Truncated {
+		t.Errorf("small input reported truncated: %+v", res)
+	}
+	nodes := parseLogDayFile(t, vault, "2026-04-01")
+	if len(nodes) != 2 {
+		t.Fatalf("want the day and one entry, got %d nodes", len(nodes))
+	}
+	wantBody := "test run\n\n    ok  \tpkg/a\n\n    ## not a header\n    id:: nope\n    FAIL"
+	if got := strings.TrimSpace(nodes[1].Body); got != wantBody {
+		t.Errorf("entry body = %q, want %q", got, wantBody)
+	}
+
+	resetCLIFlags()
+	big := strings.Repeat("0123456789abcdef0123456789abcdef0123456789abcdef012345678901234\n", maxStdinSize/64+10)
+	RootCmd.SetIn(strings.NewReader(big))
+	out, stderr, err = runAdd(t, vault, "--stdin", "--date", "2026-04-02", "--json")
+	if err != nil {
+		t.Fatalf("rk add --stdin (oversized): %v\nstderr: %s", err, stderr)
+	}
+	mustDecodeJSON(t, out, &res)
+	if !res.Truncated {
+		t.Errorf("oversized input not reported truncated: %+v", res)
+	}
+	raw := mustReadFile(t, dayLogPath(vault, "2026-04-02"))
+	if !strings.Contains(raw, "\n\n[stdin truncated to 1048576 bytes]\n") || len(raw) > maxStdinSize*5/4+1024 {
+		t.Errorf("truncated entry missing its marker or too long (%d bytes)", len(raw))
+	}
+
+	// --dedupe catches a retried pipe with no message, whose block the
+	// parser reads back without its leading indent.
+	for i, want := range []bool{false, true} {
+		resetCLIFlags()
+		RootCmd.SetIn(strings.NewReader("ok  pkg/a\nFAIL pkg/b\n"))
+		out, stderr, err := runAdd(t, vault, "--stdin", "--dedupe", "--date", "2026-04-03", "--at", "10:00", "--json")
+		if err != nil {
+			t.Fatalf("rk add --stdin --dedupe #%d: %v\nstderr: %s", i+1, err, stderr)
+		}
+		var got logAddResult
+		mustDecodeJSON(t, out, &got)
+		if got.Duplicate != want {
+			t.Errorf("rk add --stdin --dedupe #%d: duplicate = %v, want %v", i+1, got.Duplicate, want)
+		}
+	}
+
+	resetCLIFlags()
+	RootCmd.SetIn(strings.NewReader(""))
+	if _, _, err := runAdd(t, vault, "--stdin"); err == nil || !strings.Contains(err.Error(), "empty body text") {
+		t.Errorf("--stdin with nothing piped: err = %v, want empty body text", err)
+	}
+	resetCLIFlags()
+	if _, _, err := runAdd(t, vault); err == nil || !strings.Contains(err.Error(), "missing entry text") {
+		t.Errorf("no text and no --stdin: err = %v, want missing entry text", err)
+	}
+}
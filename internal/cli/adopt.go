@@ -88,7 +88,7 @@ func (r adoptResult) Pretty() string {
 }
 
 func runAdoptE(cmd *cobra.Command, args []string) error {
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -109,7 +109,7 @@ func runAdoptE(cmd *cobra.Command, args []string) error {
 	}
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
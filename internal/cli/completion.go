@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Dynamic shell completion for todo and note refs. Cobra calls these from
+// its hidden __complete command, before any PersistentPreRunE, so they read
+// the vault's files directly (the index may be stale, and reconciling it
+// would be slow) and never fail: a vault that cannot be read completes
+// nothing rather than printing an error into the user's shell.
+
+func init() {
+	for _, c := range []*cobra.Command{todoShowCmd, todoEditCmd, todoNoteCmd, todoCheckCmd, todoDoneCmd, todoReopenCmd, todoSplitCmd, todoParentCmd, todoPinCmd, todoUnpinCmd} {
+		c.ValidArgsFunction = completeFirstArgs(todoParentCmd, completeTodoRefs)
+	}
+	todoDeleteCmd.ValidArgsFunction = completeTodoRefs
+	for _, c := range []*cobra.Command{noteShowCmd, noteTouchCmd, noteRenameCmd} {
+		c.ValidArgsFunction = completeFirstArgs(nil, completeNoteRefs)
+	}
+}
+
+// completeFirstArgs wraps complete so it only completes a command's first
+// argument, or under twoRefs (whose two arguments are both refs) its
+// first two.
+func completeFirstArgs(twoRefs *cobra.Command, complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 1 || len(args) == 1 && cmd != twoRefs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeTodoRefs completes a durable todo ref: the ULID and aliases of
+// every todo not yet archived (under --project, only that project's),
+// described by the todo's title.
+func completeTodoRefs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	files, err := todoFiles(filepath.Join(cfg.VaultDir, "todos"), todoProjectFlag)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []cobra.Completion
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || n.ULID == "" || n.Props["state"] == "archived" {
+			continue
+		}
+		out = appendRefCompletions(out, toComplete, firstBodyLine(n.Body), append([]string{n.ULID}, n.Aliases...)...)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNoteRefs completes a note ref: every note's slug (its filename)
+// and aliases, described by its title.
+func completeNoteRefs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	files, err := noteFiles(filepath.Join(cfg.VaultDir, "notes"))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []cobra.Completion
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok {
+			continue
+		}
+		slug := strings.TrimSuffix(filepath.Base(path), ".md")
+		out = appendRefCompletions(out, toComplete, n.Props["title"], append([]string{slug}, n.Aliases...)...)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// appendRefCompletions appends each of refs that starts with toComplete to
+// out, described by desc. A note's slug is usually also an alias, so a ref
+// repeated in refs is added once.
+func appendRefCompletions(out []cobra.Completion, toComplete, desc string, refs ...string) []cobra.Completion {
+	for i, ref := range refs {
+		if ref != "" && strings.HasPrefix(ref, toComplete) && !slices.Contains(refs[:i], ref) {
+			out = append(out, cobra.CompletionWithDesc(ref, desc))
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// runComplete runs cobra's hidden __complete command for args (the last
+// being the word under completion) and returns its output lines.
+func runComplete(t *testing.T, args ...string) (lines []string, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"__complete"}, args...))
+	if err := RootCmd.Execute(); err != nil {
+		t.Fatalf("__complete %v: %v", args, err)
+	}
+	return strings.Split(strings.TrimSpace(outBuf.String()), "\n"), errBuf.String()
+}
+
+// TestRefCompletion: todo and note ref arguments complete to the vault's
+// IDs, aliases, and slugs with titles as descriptions; only ref positions
+// complete; an unreadable vault completes nothing, silently.
+func TestRefCompletion(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	open, archived := node.Mint(), node.Mint()
+	writeTodoFixture(t, vault, open, "open", "", "Renew the passport.", "aliases: [passport]")
+	writeTodoFixture(t, vault, archived, "archived", "", "Old chore.")
+	if _, _, err := runNote(t, vault, "create", "Trip Plan"); err != nil {
+		t.Fatalf("note create: %v", err)
+	}
+	resetCLIFlags()
+
+	lines, _ := runComplete(t, "todo", "show", "--vault", vault, "")
+	got := strings.Join(lines, "\n")
+	if !strings.Contains(got, open+"\tRenew the passport.") || !strings.Contains(got, "passport\tRenew the passport.") || strings.Contains(got, archived) {
+		t.Errorf("todo show completions:\n%s", got)
+	}
+	if lines[len(lines)-1] != ":4" {
+		t.Errorf("directive = %q, want :4 (no file completion)", lines[len(lines)-1])
+	}
+
+	resetCLIFlags()
+	lines, _ = runComplete(t, "todo", "done", "--vault", vault, "pass")
+	if len(lines) != 2 || lines[0] != "passport\tRenew the passport." {
+		t.Errorf("prefix completion = %q, want just the alias", lines)
+	}
+
+	resetCLIFlags()
+	lines, _ = runComplete(t, "todo", "show", "--vault", vault, open, "")
+	if len(lines) != 1 {
+		t.Errorf("second argument of todo show completed %q, want nothing", lines)
+	}
+
+	resetCLIFlags()
+	lines, _ = runComplete(t, "note", "show", "--vault", vault, "tr")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "trip-plan\t") {
+		t.Errorf("note show completions = %q, want the trip-plan slug", lines)
+	}
+
+	resetCLIFlags()
+	lines, stderr := runComplete(t, "todo", "show", "--vault", t.TempDir()+"/missing", "")
+	// Cobra itself notes the directive on stderr, which shells discard.
+	if len(lines) != 1 || strings.Contains(stderr, "rror") {
+		t.Errorf("missing vault: completions %q, stderr %q; want none, without an error", lines, stderr)
+	}
+}
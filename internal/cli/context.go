@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// todoContextFile holds the active context tag, relative to the vault. Like
+// the settings file it lives under .reckon/, which the index never walks.
+const todoContextFile = ".reckon/context"
+
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Show the context tag every new todo gets",
+	Long: "A context is one tag, set with `rk context set <tag>` while working on something, that every " +
+		"new durable todo gets along with the default_todo_tags setting and any --tags, until " +
+		"`rk context clear`. `rk todo list` shows it in its header. Bare `rk context` prints it.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runContextE,
+}
+
+var contextSetCmd = &cobra.Command{
+	Use:          "set <tag>",
+	Short:        "Tag every new todo with <tag> until cleared",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runContextSetE,
+}
+
+var contextClearCmd = &cobra.Command{
+	Use:          "clear",
+	Short:        "Stop tagging new todos with the context",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runContextClearE,
+}
+
+func init() {
+	contextCmd.AddCommand(contextSetCmd, contextClearCmd)
+}
+
+// contextResult is the output of every `rk context` command: the context
+// now active, "" for none.
+type contextResult struct {
+	Context string `json:"context"`
+}
+
+func (r contextResult) Pretty() string {
+	if r.Context == "" {
+		return "context: none"
+	}
+	return "context: #" + r.Context
+}
+
+func runContextE(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("context: load config: %w", err)
+	}
+	tag, err := readTodoContext(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("context: %w", err)
+	}
+	return printContext(cmd, tag)
+}
+
+func runContextSetE(cmd *cobra.Command, args []string) error {
+	tags := parseTagInput(args[0])
+	if len(tags) != 1 || strings.ContainsAny(tags[0], " \t") {
+		return fmt.Errorf("context set: want one tag, without spaces or commas, got %q", args[0])
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("context set: load config: %w", err)
+	}
+	path := filepath.Join(cfg.VaultDir, filepath.FromSlash(todoContextFile))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("context set: create %s: %w", filepath.Dir(todoContextFile), err)
+	}
+	if err := writeFileAtomic(path, []byte(tags[0]+"\n")); err != nil {
+		return fmt.Errorf("context set: write: %w", err)
+	}
+	return printContext(cmd, tags[0])
+}
+
+func runContextClearE(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("context clear: load config: %w", err)
+	}
+	err = os.Remove(filepath.Join(cfg.VaultDir, filepath.FromSlash(todoContextFile)))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("context clear: %w", err)
+	}
+	return printContext(cmd, "")
+}
+
+func printContext(cmd *cobra.Command, tag string) error {
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(contextResult{Context: tag}); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// readTodoContext returns the vault's active context tag, "" when none is
+// set.
+func readTodoContext(vaultDir string) (string, error) {
+	raw, err := os.ReadFile(filepath.Join(vaultDir, filepath.FromSlash(todoContextFile)))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read context: %w", err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// newTodoTags returns the tags a new durable todo starts with: the
+// default_todo_tags setting, then the active context, then extra, without
+// repeats.
+func newTodoTags(vaultDir string, settings *config.Settings, extra []string) ([]string, error) {
+	tag, err := readTodoContext(vaultDir)
+	if err != nil {
+		return nil, err
+	}
+	all := append(append([]string{}, settings.DefaultTodoTags...), tag)
+	return parseTagInput(strings.Join(append(all, extra...), ",")), nil
+}
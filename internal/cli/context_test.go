@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runContext runs `rk context <args...>` against vault.
+func runContext(t *testing.T, vault string, args ...string) (stdout string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"context", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), err
+}
+
+// TestContextTags: new todos get default_todo_tags, then the context, then
+// --tags; `todo list` names the context; clearing it stops the tagging.
+func TestContextTags(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "default_todo_tags: [q3]\n")
+
+	if _, err := runContext(t, vault, "set", "sprint 12"); err == nil || !strings.Contains(err.Error(), "want one tag") {
+		t.Errorf("context set with a space: err = %v, want one tag", err)
+	}
+	out, err := runContext(t, vault, "set", "#sprint-12")
+	if err != nil {
+		t.Fatalf("context set: %v", err)
+	}
+	if strings.TrimSpace(out) != "context: #sprint-12" {
+		t.Errorf("context set output = %q", out)
+	}
+
+	out, _, err = runTodo(t, vault, "add", "--tags", "urgent,q3", "--json", "Fix the login bug")
+	if err != nil {
+		t.Fatalf("todo add: %v", err)
+	}
+	resetCLIFlags()
+	var added todoAddResult
+	mustDecodeJSON(t, out, &added)
+	if raw := mustReadFile(t, filepath.Join(vault, added.Path)); !strings.Contains(raw, "tags: [q3, sprint-12, urgent]") {
+		t.Errorf("todo with a context and --tags:\n%s", raw)
+	}
+
+	out, _, err = runTodo(t, vault, "list")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	if first, _, _ := strings.Cut(out, "\n"); first != "todo: 1 item(s) (context #sprint-12)" {
+		t.Errorf("todo list header = %q", first)
+	}
+
+	if out, err := runContext(t, vault, "clear"); err != nil || strings.TrimSpace(out) != "context: none" {
+		t.Fatalf("context clear: %q, %v", out, err)
+	}
+	if out, err := runContext(t, vault); err != nil || strings.TrimSpace(out) != "context: none" {
+		t.Errorf("context after clear: %q, %v", out, err)
+	}
+	out, _, err = runTodo(t, vault, "add", "--json", "Write the retro")
+	if err != nil {
+		t.Fatalf("todo add: %v", err)
+	}
+	mustDecodeJSON(t, out, &added)
+	if raw := mustReadFile(t, filepath.Join(vault, added.Path)); !strings.Contains(raw, "tags: [q3]\n") {
+		t.Errorf("todo after context clear:\n%s", raw)
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateRange is an inclusive YYYY-MM-DD window; an empty bound is open.
+// Vault dates are zero-padded ISO strings, so plain string comparison
+// orders them correctly.
+type dateRange struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// contains reports whether date falls inside r. An empty date never does:
+// a filter on a date field excludes items without that field.
+func (r dateRange) contains(date string) bool {
+	if date == "" {
+		return false
+	}
+	if r.From != "" && date < r.From {
+		return false
+	}
+	if r.To != "" && date > r.To {
+		return false
+	}
+	return true
+}
+
+// parseDateFilter parses a date-filter expression relative to today (a UTC
+// date, see todoNow):
+//
+//	today | this-week | past | <endpoint> | <endpoint>..<endpoint>
+//
+// "this-week" is Monday through Sunday of today's week; "past" is every
+// date before today. Either side of a ".." range may be omitted for an
+// open bound. Endpoints are resolved by resolveDateEndpoint.
+func parseDateFilter(expr string, today time.Time) (dateRange, error) {
+	expr = strings.TrimSpace(expr)
+	day := func(t time.Time) string { return t.Format("2006-01-02") }
+	switch expr {
+	case "":
+		return dateRange{}, fmt.Errorf("empty date filter")
+	case "this-week":
+		offset := (int(today.Weekday()) + 6) % 7 // days since Monday
+		monday := today.AddDate(0, 0, -offset)
+		return dateRange{From: day(monday), To: day(monday.AddDate(0, 0, 6))}, nil
+	case "past":
+		return dateRange{To: day(today.AddDate(0, 0, -1))}, nil
+	}
+
+	if from, to, ok := strings.Cut(expr, ".."); ok {
+		var r dateRange
+		var err error
+		if strings.TrimSpace(from) == "" && strings.TrimSpace(to) == "" {
+			return dateRange{}, fmt.Errorf("invalid date range %q: both ends are empty", expr)
+		}
+		if strings.TrimSpace(from) != "" {
+			if r.From, err = resolveDateEndpoint(from, today); err != nil {
+				return dateRange{}, err
+			}
+		}
+		if strings.TrimSpace(to) != "" {
+			if r.To, err = resolveDateEndpoint(to, today); err != nil {
+				return dateRange{}, err
+			}
+		}
+		if r.From != "" && r.To != "" && r.From > r.To {
+			return dateRange{}, fmt.Errorf("invalid date range %q: start is after end", expr)
+		}
+		return r, nil
+	}
+
+	d, err := resolveDateEndpoint(expr, today)
+	if err != nil {
+		return dateRange{}, err
+	}
+	return dateRange{From: d, To: d}, nil
+}
+
+// relativeDayRe matches a signed day/week offset such as "-7d", "+2w", "3d".
+var relativeDayRe = regexp.MustCompile(`^([+-]?)(\d+)([dw])$`)
+
+// resolveDateEndpoint resolves one side of a date filter to YYYY-MM-DD:
+// today, yesterday, tomorrow, an absolute YYYY-MM-DD, or a relative offset
+// from today (-7d, +2w). Unlike the TUI's ParseRelativeDate, past dates are
+// allowed: filters look backward as often as forward.
+func resolveDateEndpoint(s string, today time.Time) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "today":
+		return today.Format("2006-01-02"), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1).Format("2006-01-02"), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format("2006-01-02"), nil
+	}
+	if m := relativeDayRe.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return "", fmt.Errorf("invalid relative date %q: %w", s, err)
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return today.AddDate(0, 0, n).Format("2006-01-02"), nil
+	}
+	d, err := parseSchedDate(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q (want YYYY-MM-DD, today, yesterday, tomorrow, or an offset like -7d/+2w)", s)
+	}
+	return d.Format("2006-01-02"), nil
+}
@@ -0,0 +1,49 @@
+package cli
+
+import "testing"
+
+func TestParseDateFilter(t *testing.T) {
+	today := mustUTCDate(t, "2026-07-08") // a Wednesday
+	cases := []struct {
+		expr     string
+		from, to string
+	}{
+		{"today", "2026-07-08", "2026-07-08"},
+		{"2026-07-01", "2026-07-01", "2026-07-01"},
+		{"this-week", "2026-07-06", "2026-07-12"},
+		{"past", "", "2026-07-07"},
+		{"2026-07-01..2026-07-07", "2026-07-01", "2026-07-07"},
+		{"-7d..today", "2026-07-01", "2026-07-08"},
+		{"yesterday..+1w", "2026-07-07", "2026-07-15"},
+		{"..2026-07-07", "", "2026-07-07"},
+		{"2026-07-07..", "2026-07-07", ""},
+	}
+	for _, tc := range cases {
+		got, err := parseDateFilter(tc.expr, today)
+		if err != nil {
+			t.Errorf("parseDateFilter(%q): %v", tc.expr, err)
+			continue
+		}
+		if got.From != tc.from || got.To != tc.to {
+			t.Errorf("parseDateFilter(%q) = %+v, want {%s %s}", tc.expr, got, tc.from, tc.to)
+		}
+	}
+
+	for _, bad := range []string{"", "..", "soon", "2026-07-08..2026-07-01", "2026-13-01"} {
+		if _, err := parseDateFilter(bad, today); err == nil {
+			t.Errorf("parseDateFilter(%q): expected error", bad)
+		}
+	}
+}
+
+func TestDateRangeContains(t *testing.T) {
+	r := dateRange{From: "2026-07-01", To: "2026-07-07"}
+	for date, want := range map[string]bool{
+		"2026-07-01": true, "2026-07-07": true, "2026-07-04": true,
+		"2026-06-30": false, "2026-07-08": false, "": false,
+	} {
+		if got := r.contains(date); got != want {
+			t.Errorf("contains(%q) = %v, want %v", date, got, want)
+		}
+	}
+}
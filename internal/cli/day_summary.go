@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// daySummary is the end-of-day wrap-up `rk today --summary` prints and the
+// `rk tui` summary overlay renders; both build it with buildDaySummary so
+// they always agree.
+type daySummary struct {
+	Date       string `json:"date"`
+	TodosDone  int    `json:"todos_done"`  // distinct todos a did:: log entry that day completed
+	TodosTotal int    `json:"todos_total"` // done plus what is still on the day's agenda
+	Wins       int    `json:"wins"`        // log entries of kind "win"
+	LogEntries int    `json:"log_entries"`
+	// FirstEntry/LastEntry are the day's earliest and latest log entry
+	// times (HH:MM); ActiveMinutes is the span between them.
+	FirstEntry    string `json:"first_entry,omitempty"`
+	LastEntry     string `json:"last_entry,omitempty"`
+	ActiveMinutes int    `json:"active_minutes"`
+}
+
+func (s daySummary) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d/%d todos done, %d win(s), %d log entr", s.Date, s.TodosDone, s.TodosTotal, s.Wins, s.LogEntries)
+	if s.LogEntries == 1 {
+		b.WriteString("y")
+	} else {
+		b.WriteString("ies")
+	}
+	if s.FirstEntry != "" {
+		fmt.Fprintf(&b, ", active %s-%s (%s)", s.FirstEntry, s.LastEntry, formatMinutes(s.ActiveMinutes))
+	}
+	return b.String()
+}
+
+// formatMinutes renders a duration in minutes as "2h05m" or "45m".
+func formatMinutes(m int) string {
+	if m < 60 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh%02dm", m/60, m%60)
+}
+
+// todosDoneByDay maps each journal day from..to (YYYY-MM-DD, inclusive)
+// with a did:: log entry to the distinct todos those entries completed: by
+// ULID where the target resolves (so an alias and its ULID count once), as
+// written where it does not. rk today --summary, rk stats, and rk heatmap
+// all count completions through it.
+func todosDoneByDay(db *sql.DB, from, to string) (map[string][]string, error) {
+	rows, err := db.Query(`SELECT DISTINCT `+logEntryDaySQL+`, COALESCE(e.dst_key, e.dst) FROM edges e
+		JOIN nodes n ON n.id = e.src
+		WHERE e.rel = 'did' AND n.type = 'log-entry' AND `+logEntryDaySQL+` BETWEEN ? AND ?`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query completed todos: %w", err)
+	}
+	defer rows.Close()
+	done := map[string][]string{}
+	for rows.Next() {
+		var day, todo string
+		if err := rows.Scan(&day, &todo); err != nil {
+			return nil, fmt.Errorf("scan completed todo: %w", err)
+		}
+		done[day] = append(done[day], todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate completed todos: %w", err)
+	}
+	return done, nil
+}
+
+// buildDaySummary computes day's (YYYY-MM-DD) summary from the index: its
+// log entries, the todos its did:: entries completed, and what is still on
+// its agenda (buildAgenda, today.go).
+func buildDaySummary(db *sql.DB, day string) (daySummary, error) {
+	s := daySummary{Date: day}
+
+	rows, err := db.Query(`SELECT n.time, COALESCE(p.value, '') FROM nodes n
+		LEFT JOIN node_props p ON p.id = n.id AND p.key = 'kind'
+		WHERE n.type = 'log-entry' AND `+logEntryDaySQL+` = ? ORDER BY n.time`, day)
+	if err != nil {
+		return daySummary{}, fmt.Errorf("summary: query log entries: %w", err)
+	}
+	var first, last time.Time
+	for rows.Next() {
+		var ts, kind string
+		if err := rows.Scan(&ts, &kind); err != nil {
+			rows.Close()
+			return daySummary{}, fmt.Errorf("summary: scan log entry: %w", err)
+		}
+		s.LogEntries++
+		if kind == "win" {
+			s.Wins++
+		}
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() {
+			first = t
+		}
+		last = t
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return daySummary{}, fmt.Errorf("summary: iterate log entries: %w", err)
+	}
+	rows.Close()
+	if !first.IsZero() {
+		s.FirstEntry = first.Format("15:04")
+		s.LastEntry = last.Format("15:04")
+		s.ActiveMinutes = int(last.Sub(first).Minutes())
+	}
+
+	done, err := todosDoneByDay(db, day, day)
+	if err != nil {
+		return daySummary{}, fmt.Errorf("summary: %w", err)
+	}
+	s.TodosDone = len(done[day])
+
+	items, _, err := buildAgenda(db, day)
+	if err != nil {
+		return daySummary{}, err
+	}
+	s.TodosTotal = s.TodosDone
+	for _, it := range items {
+		if !it.ReadOnly {
+			s.TodosTotal++
+		}
+	}
+	return s, nil
+}
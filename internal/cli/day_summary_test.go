@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodaySummary: `rk today --summary` counts the day's log entries and
+// wins, the todos its did:: entries completed against what is still on
+// the agenda, and the span from the first entry to the last.
+func TestTodaySummary(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	done := node.Mint()
+	writeTodoFixture(t, vault, done, "done", "2026-07-10", "Finished.")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-10", "Still open.")
+
+	const date = "2026-07-10"
+	mustWriteFile(t, dayLogPath(vault, date), "---\n"+
+		"id: "+node.Mint()+"\n"+
+		"type: log-day\n"+
+		"aliases: "+date+"\n"+
+		"---\n"+
+		"# "+date+"\n\n"+
+		"## 09:00 · mike\nid:: "+node.Mint()+"\ndid:: "+done+"\ncompleted todo\n\n"+
+		"## 11:30 win · mike\nid:: "+node.Mint()+"\nShipped it.\n\n"+
+		"## 17:15 · mike\nid:: "+node.Mint()+"\nWrapping up.\n")
+
+	out, stderr, err := runToday(t, vault, "--summary", "--json")
+	if err != nil {
+		t.Fatalf("today --summary: %v\nstderr: %s", err, stderr)
+	}
+	var sum daySummary
+	mustDecodeJSON(t, out, &sum)
+	want := daySummary{Date: date, TodosDone: 1, TodosTotal: 2, Wins: 1, LogEntries: 3,
+		FirstEntry: "09:00", LastEntry: "17:15", ActiveMinutes: 495}
+	if sum != want {
+		t.Errorf("summary = %+v, want %+v", sum, want)
+	}
+
+	resetCLIFlags()
+	out, _, err = runToday(t, vault, "--summary")
+	if err != nil {
+		t.Fatalf("today --summary: %v", err)
+	}
+	if !strings.Contains(out, "1/2 todos done, 1 win(s), 3 log entries, active 09:00-17:15 (8h15m)") {
+		t.Errorf("pretty summary:\n%s", out)
+	}
+}
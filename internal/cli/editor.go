@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorCommand returns the editor an --edit flag launches, split into
+// argv so EDITOR="code --wait" works: $VISUAL, else $EDITOR, else vi. The
+// program must be on PATH, so a typo fails before any temp file is made.
+func editorCommand() ([]string, error) {
+	raw := os.Getenv("VISUAL")
+	if strings.TrimSpace(raw) == "" {
+		raw = os.Getenv("EDITOR")
+	}
+	if strings.TrimSpace(raw) == "" {
+		raw = "vi"
+	}
+	argv := strings.Fields(raw)
+	if _, err := exec.LookPath(argv[0]); err != nil {
+		return nil, fmt.Errorf("editor %q not found (set $VISUAL or $EDITOR): %w", argv[0], err)
+	}
+	return argv, nil
+}
+
+// runEditor is the seam editText launches the editor through, attached to
+// the terminal; tests replace it with a function that writes the file.
+var runEditor = func(argv []string, path string) error {
+	c := exec.Command(argv[0], append(argv[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c.Run()
+}
+
+// editText opens initial in the user's editor and returns the saved text,
+// trimmed of surrounding whitespace ("" when the user saved nothing).
+func editText(initial string) (string, error) {
+	argv, err := editorCommand()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "rk-edit-*.md")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+	if err := runEditor(argv, path); err != nil {
+		return "", fmt.Errorf("run editor %s: %w", argv[0], err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read edited file: %w", err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
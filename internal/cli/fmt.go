@@ -0,0 +1,355 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var fmtCheckFlag bool
+
+func resetFmtFlags(cmd *cobra.Command) {
+	fmtCheckFlag = false
+	if fl := cmd.Flags().Lookup("check"); fl != nil {
+		fl.Changed = false
+	}
+}
+
+// fmtCmd normalizes hand-edited vault files. Like adopt it works on the
+// truth files alone and leaves the index to pick the edits up. rk's own
+// writes need no such pass: creates render canonically (node.Render) and
+// edits are byte-preserving splices of already-canonical files.
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path...]",
+	Short: "Normalize the layout of hand-edited vault files",
+	Long: "Rewrite the vault's markdown files (or just the given files and directories) in canonical form: " +
+		"frontmatter keys in the order rk writes them (id, type, time, author, aliases, then the rest by " +
+		"name), no trailing whitespace outside code blocks, checkboxes spaced as `- [ ] text`, and exactly " +
+		"one newline at the end. A file is only rewritten if its fields and its text, whitespace aside, are " +
+		"unchanged by the pass; anything else is reported and left alone. With --check nothing is written: " +
+		"the files that need formatting are listed and the command fails, for use in a git hook.",
+	SilenceUsage: true,
+	Args:         cobra.ArbitraryArgs,
+	RunE:         runFmtE,
+}
+
+func init() {
+	fmtCmd.Flags().BoolVar(&fmtCheckFlag, "check", false, "List files that need formatting, without changing them; fail if there are any")
+}
+
+// fmtResult is the structured summary of one `rk fmt` run. Paths are
+// vault-relative.
+type fmtResult struct {
+	Check   bool       `json:"check"`
+	Checked int        `json:"checked"`
+	Changed []string   `json:"changed"` // formatted, or under --check needing it
+	Skipped []fmtIssue `json:"skipped"`
+}
+
+// fmtIssue is a file `rk fmt` left alone, and why.
+type fmtIssue struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (r fmtResult) Pretty() string {
+	var b strings.Builder
+	if r.Check {
+		fmt.Fprintf(&b, "fmt: %d of %d file(s) need formatting", len(r.Changed), r.Checked)
+	} else {
+		fmt.Fprintf(&b, "fmt: formatted %d of %d file(s)", len(r.Changed), r.Checked)
+	}
+	for _, p := range r.Changed {
+		fmt.Fprintf(&b, "\n  %s", p)
+	}
+	for _, s := range r.Skipped {
+		fmt.Fprintf(&b, "\n  skipped %s: %s", s.Path, s.Reason)
+	}
+	return b.String()
+}
+
+func runFmtE(cmd *cobra.Command, args []string) error {
+	defer resetFmtFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("fmt: load config: %w", err)
+	}
+	absVault, err := filepath.Abs(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("fmt: resolve vault dir: %w", err)
+	}
+
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{absVault}
+	}
+	res := fmtResult{Check: fmtCheckFlag, Changed: []string{}, Skipped: []fmtIssue{}}
+	for _, p := range paths {
+		if err := fmtPath(absVault, p, fmtCheckFlag, &res); err != nil {
+			return fmt.Errorf("fmt: %w", err)
+		}
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	if fmtCheckFlag && len(res.Changed) > 0 {
+		return fmt.Errorf("fmt: %d file(s) need formatting (run `rk fmt`)", len(res.Changed))
+	}
+	return nil
+}
+
+// fmtPath formats argPath, a markdown file or a directory walked with the
+// index's own skip rules, into res. Paths outside the vault are an error.
+func fmtPath(absVault, argPath string, check bool, res *fmtResult) error {
+	abs, err := filepath.Abs(argPath)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", argPath, err)
+	}
+	rel, err := filepath.Rel(absVault, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("path %q is outside the vault root %q", argPath, absVault)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", argPath, err)
+	}
+	if !info.IsDir() {
+		if !strings.HasSuffix(abs, ".md") {
+			return fmt.Errorf("%s is not a markdown file", argPath)
+		}
+		fmtFile(abs, filepath.ToSlash(rel), check, res)
+		return nil
+	}
+	return filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != abs && index.ShouldSkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !index.Indexable(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(absVault, path)
+		if err != nil {
+			return err
+		}
+		fmtFile(path, filepath.ToSlash(rel), check, res)
+		return nil
+	})
+}
+
+// fmtFile formats one file into res, writing it unless check.
+func fmtFile(path, rel string, check bool, res *fmtResult) {
+	res.Checked++
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		res.Skipped = append(res.Skipped, fmtIssue{Path: rel, Reason: err.Error()})
+		return
+	}
+	formatted, err := formatVaultFile(raw)
+	if err != nil {
+		res.Skipped = append(res.Skipped, fmtIssue{Path: rel, Reason: err.Error()})
+		return
+	}
+	if bytes.Equal(formatted, raw) {
+		return
+	}
+	if !check {
+		if err := writeFileAtomic(path, formatted); err != nil {
+			res.Skipped = append(res.Skipped, fmtIssue{Path: rel, Reason: fmt.Sprintf("write: %v", err)})
+			return
+		}
+	}
+	res.Changed = append(res.Changed, rel)
+}
+
+// fmtCanonicalKeys are the frontmatter keys node.Render writes first, in
+// its order.
+var fmtCanonicalKeys = []string{"id", "type", "time", "author", "aliases"}
+
+// fmtFrontmatterKeyRe matches a line starting a frontmatter entry.
+var fmtFrontmatterKeyRe = regexp.MustCompile(`^([A-Za-z0-9_-]+):`)
+
+// fmtCheckboxRe matches a task-list line with irregular spacing: "-[ ]x",
+// "-  [x]   x", "* [ ]x". The text may not open with "(", "[", or ":",
+// where the brackets are a link rather than a checkbox.
+var fmtCheckboxRe = regexp.MustCompile(`^([ \t]*)(-[ \t]*|\*[ \t]+)\[([ xX])\][ \t]*([^(\[:].*)?$`)
+
+// formatVaultFile returns raw in canonical layout (see fmtCmd). It refuses
+// (with an error) CRLF files, files the node parser rejects, and any result
+// that would change the file's fields or its non-whitespace text.
+func formatVaultFile(raw []byte) ([]byte, error) {
+	if bytes.Contains(raw, []byte("\r\n")) {
+		return nil, fmt.Errorf("CRLF line endings are not supported")
+	}
+	before, err := node.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	text := string(raw)
+	var fm, body string
+	if rest, ok := strings.CutPrefix(text, "---\n"); ok {
+		if i := strings.Index(rest, "\n---\n"); i >= 0 {
+			fm, body = rest[:i+1], rest[i+len("\n---\n"):]
+		} else if strings.HasSuffix(rest, "\n---") {
+			fm, body = strings.TrimSuffix(rest, "---"), ""
+		}
+	}
+	if fm == "" {
+		body = text
+	}
+
+	var out strings.Builder
+	if fm != "" {
+		out.WriteString("---\n")
+		out.WriteString(formatFrontmatter(fm, before))
+		out.WriteString("---\n")
+	}
+	out.WriteString(formatBody(body))
+	formatted := []byte(out.String())
+
+	after, err := node.Parse(formatted)
+	if err != nil || !sameNodeView(before, after) {
+		return nil, fmt.Errorf("formatting would change the file's content; fix it by hand")
+	}
+	return formatted, nil
+}
+
+// formatFrontmatter trims trailing whitespace from fm's lines and puts its
+// entries (a key line plus any indented block-list lines under it) in
+// canonical order: fmtCanonicalKeys, then n's props by name, then its typed
+// links by name. Frontmatter it cannot split into entries (comments, blank
+// lines, repeated keys) keeps its order.
+func formatFrontmatter(fm string, n *node.Node) string {
+	lines := strings.Split(strings.TrimSuffix(fm, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	type entry struct {
+		key   string
+		lines []string
+	}
+	var entries []entry
+	seen := map[string]bool{}
+	for _, line := range lines {
+		if m := fmtFrontmatterKeyRe.FindStringSubmatch(line); m != nil {
+			if seen[m[1]] {
+				return strings.Join(lines, "\n") + "\n"
+			}
+			seen[m[1]] = true
+			entries = append(entries, entry{key: m[1], lines: []string{line}})
+			continue
+		}
+		if len(entries) == 0 || strings.TrimSpace(line) == "" || (line[0] != ' ' && line[0] != '\t') {
+			return strings.Join(lines, "\n") + "\n"
+		}
+		entries[len(entries)-1].lines = append(entries[len(entries)-1].lines, line)
+	}
+
+	rank := func(key string) int {
+		for i, k := range fmtCanonicalKeys {
+			if k == key {
+				return i
+			}
+		}
+		if _, ok := n.Props[key]; ok {
+			return len(fmtCanonicalKeys)
+		}
+		return len(fmtCanonicalKeys) + 1
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ri, rj := rank(entries[i].key), rank(entries[j].key)
+		if ri != rj {
+			return ri < rj
+		}
+		if ri < len(fmtCanonicalKeys) {
+			return false
+		}
+		return entries[i].key < entries[j].key
+	})
+	var b strings.Builder
+	for _, e := range entries {
+		for _, line := range e.lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// formatBody trims trailing whitespace and respaces checkboxes on body's
+// lines outside fenced code blocks (indented ones too, as in a list item),
+// and ends it with exactly one newline. Two or more trailing spaces before
+// another line of text are a markdown hard break, kept as exactly two.
+func formatBody(body string) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		if fence := strings.TrimLeft(line, " \t"); strings.HasPrefix(fence, "```") || strings.HasPrefix(fence, "~~~") {
+			inFence = !inFence
+			lines[i] = strings.TrimRight(line, " \t")
+			continue
+		}
+		if inFence {
+			continue
+		}
+		hardBreak := strings.HasSuffix(line, "  ") && i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" &&
+			!strings.HasPrefix(strings.TrimSpace(line), "#")
+		line = strings.TrimRight(line, " \t")
+		if m := fmtCheckboxRe.FindStringSubmatch(line); m != nil {
+			line = m[1] + m[2][:1] + " [" + m[3] + "]"
+			if m[4] != "" {
+				line += " " + m[4]
+			}
+		}
+		if hardBreak && line != "" {
+			line += "  "
+		}
+		lines[i] = line
+	}
+	out := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+// sameNodeView reports whether a and b parse to the same fields and the
+// same body text, ignoring whitespace and the order of links (which
+// follows the frontmatter's key order).
+func sameNodeView(a, b *node.Node) bool {
+	squash := func(s string) string { return strings.Join(strings.Fields(s), "") }
+	sorted := func(links []node.Link) []node.Link {
+		out := append([]node.Link{}, links...)
+		sort.Slice(out, func(i, j int) bool { return fmt.Sprint(out[i]) < fmt.Sprint(out[j]) })
+		return out
+	}
+	return a.ULID == b.ULID && a.Type == b.Type && a.Time == b.Time && a.Author == b.Author &&
+		reflect.DeepEqual(a.Aliases, b.Aliases) && reflect.DeepEqual(a.Props, b.Props) &&
+		reflect.DeepEqual(sorted(a.Links), sorted(b.Links)) && squash(a.Body) == squash(b.Body)
+}
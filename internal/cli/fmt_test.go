@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runFmt runs `rk fmt <args...>` against vault.
+func runFmt(t *testing.T, vault string, args ...string) (stdout string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"fmt", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), err
+}
+
+// TestFmt: --check lists a hand-edited file without touching it and fails;
+// a plain run rewrites it canonically, leaving code blocks alone; a second
+// --check is clean.
+func TestFmt(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	dir := filepath.Join(vault, "scratch")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "plan.md")
+	src := "---\ntitle: Plan  \ntype: note\nid: 01J0000000000000000000PLAN\n---\n" +
+		"# Plan   \n\n-[ ]call the bank\n* [x]   send the form\n- [see](http://x)\n\n" +
+		"```\nkeep  \n-[ ]code\n```\n\n\n"
+	mustWriteFile(t, path, src)
+	want := "---\nid: 01J0000000000000000000PLAN\ntype: note\ntitle: Plan\n---\n" +
+		"# Plan\n\n- [ ] call the bank\n* [x] send the form\n- [see](http://x)\n\n" +
+		"```\nkeep  \n-[ ]code\n```\n"
+
+	out, err := runFmt(t, vault, "--check", dir)
+	if err == nil || !strings.Contains(err.Error(), "1 file(s) need formatting") {
+		t.Errorf("fmt --check: err = %v, want 1 file(s) need formatting", err)
+	}
+	if !strings.Contains(out, "scratch/plan.md") {
+		t.Errorf("fmt --check output does not list the file:\n%s", out)
+	}
+	if got := mustReadFile(t, path); got != src {
+		t.Errorf("fmt --check rewrote the file:\n%q", got)
+	}
+
+	resetCLIFlags()
+	if _, err := runFmt(t, vault, dir); err != nil {
+		t.Fatalf("fmt: %v", err)
+	}
+	if got := mustReadFile(t, path); got != want {
+		t.Errorf("formatted file:\n%q\nwant\n%q", got, want)
+	}
+
+	resetCLIFlags()
+	out, err = runFmt(t, vault, "--check", path)
+	if err != nil {
+		t.Errorf("fmt --check after fmt: %v\n%s", err, out)
+	}
+
+	if _, err := runFmt(t, vault, filepath.Dir(vault)); err == nil || !strings.Contains(err.Error(), "outside the vault") {
+		t.Errorf("fmt outside the vault: err = %v, want outside the vault", err)
+	}
+}
+
+// TestFmtBody_HardBreaks: trailing spaces before another line of text are a
+// hard break and stay (as two); before a blank line, or after a heading,
+// they go.
+func TestFmtBody_HardBreaks(t *testing.T) {
+	got := formatBody("# Title   \nroses are red   \nviolets are blue  \n\nend \n")
+	want := "# Title\nroses are red  \nviolets are blue\n\nend\n"
+	if got != want {
+		t.Errorf("formatBody = %q, want %q", got, want)
+	}
+}
+
+// TestFmtBody_IndentedFence: a fence indented under a list item still fences
+// its contents off from reformatting.
+func TestFmtBody_IndentedFence(t *testing.T) {
+	body := "- step one\n\n    ```\n    -[ ]literal  \n    ```\n-[ ]after\n"
+	want := "- step one\n\n    ```\n    -[ ]literal  \n    ```\n- [ ] after\n"
+	if got := formatBody(body); got != want {
+		t.Errorf("formatBody = %q, want %q", got, want)
+	}
+}
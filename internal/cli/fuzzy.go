@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/sahilm/fuzzy"
+	"github.com/spf13/cobra"
+)
+
+// fuzzyCandidate is one thing a --match query can resolve to: Ref is what
+// the caller's normal ref lookup accepts (a ULID, or a slug for a note with
+// none), Label is the human text the query is matched against (a title).
+type fuzzyCandidate struct {
+	Ref   string
+	Label string
+}
+
+// maxListedCandidates caps how many candidates an ambiguous-match error
+// spells out.
+const maxListedCandidates = 10
+
+// fuzzyCandidates adapts a candidate slice to fuzzy.Source.
+type fuzzyCandidates []fuzzyCandidate
+
+func (c fuzzyCandidates) String(i int) string { return c[i].Label }
+func (c fuzzyCandidates) Len() int            { return len(c) }
+
+// matchPolicy is how resolveFuzzy narrows and picks among fuzzy matches.
+type matchPolicy struct {
+	// margin is how far, in percent, the best score must beat the
+	// runner-up's to be picked; negative never picks among several.
+	margin int
+	// threshold drops matches scoring below it; 0 or less keeps them all.
+	threshold int
+	// maxCandidates rejects a query with more matches than this as too
+	// broad to pick from; 0 is no limit.
+	maxCandidates int
+}
+
+// resolveFuzzy resolves query against cands' labels, shared by every
+// --match flag (`rk note show/rename/touch`, `rk todo done/reopen`). A
+// unique case-insensitive exact label match wins outright; otherwise the
+// fuzzy (sahilm/fuzzy) matches scoring at least p.threshold are kept and
+// the one left wins, or, among several, the best one if its score beats
+// the runner-up's by p.margin percent (a negative margin, as under
+// --strict-match, never picks among several). No match is a "(not found)"
+// error; more than p.maxCandidates is a "too many matches" error; an
+// ambiguous one is an error listing the candidates so the user can refine
+// the query or pass a ref directly.
+func resolveFuzzy(query string, cands []fuzzyCandidate, p matchPolicy) (fuzzyCandidate, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return fuzzyCandidate{}, fmt.Errorf("empty --match query")
+	}
+
+	var exact []fuzzyCandidate
+	for _, c := range cands {
+		if strings.EqualFold(c.Label, query) {
+			exact = append(exact, c)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil
+	}
+
+	matches := fuzzy.FindFrom(query, fuzzyCandidates(cands))
+	if p.threshold > 0 {
+		kept := matches[:0]
+		for _, m := range matches {
+			if m.Score >= p.threshold {
+				kept = append(kept, m)
+			}
+		}
+		if len(kept) == 0 && len(matches) > 0 {
+			return fuzzyCandidate{}, fmt.Errorf("no match for %q scoring at least %d (not found)", query, p.threshold)
+		}
+		matches = kept
+	}
+	switch {
+	case len(matches) == 0:
+		return fuzzyCandidate{}, fmt.Errorf("no match for %q (not found)", query)
+	case p.maxCandidates > 0 && len(matches) > p.maxCandidates:
+		return fuzzyCandidate{}, fmt.Errorf("too many matches for %q (%d, limit %d); refine the query or pass a ref", query, len(matches), p.maxCandidates)
+	case len(matches) == 1, clearWinner(matches, p.margin):
+		return cands[matches[0].Index], nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d matches for %q; refine the query or pass a ref:", len(matches), query)
+	for i, m := range matches {
+		if i == maxListedCandidates {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(matches)-maxListedCandidates)
+			break
+		}
+		c := cands[m.Index]
+		fmt.Fprintf(&b, "\n  %s  %s", c.Ref, c.Label)
+	}
+	return fuzzyCandidate{}, errors.New(b.String())
+}
+
+// clearWinner reports whether matches' best score (fuzzy.FindFrom sorts
+// best first) beats the runner-up's by at least margin percent. A
+// runner-up scoring 0 or less is beaten by any positive best score.
+func clearWinner(matches fuzzy.Matches, margin int) bool {
+	if margin < 0 || len(matches) < 2 {
+		return false
+	}
+	best, next := matches[0].Score, matches[1].Score
+	if best <= 0 || best == next {
+		return false
+	}
+	if next <= 0 {
+		return true
+	}
+	return (best-next)*100 >= next*margin
+}
+
+// loadMatchPolicy builds resolveFuzzy's policy from the vault's match_*
+// settings. strict is --strict-match, which never picks among several
+// matches; threshold is --match-threshold, or negative to use the
+// match_threshold setting (see thresholdOverride).
+func loadMatchPolicy(vaultDir string, strict bool, threshold int) (matchPolicy, error) {
+	settings, err := config.LoadSettings(vaultDir)
+	if err != nil {
+		return matchPolicy{}, err
+	}
+	p := matchPolicy{
+		margin:        settings.MatchMargin,
+		threshold:     settings.MatchThreshold,
+		maxCandidates: settings.MatchMaxCandidates,
+	}
+	if strict {
+		p.margin = -1
+	}
+	if threshold >= 0 {
+		p.threshold = threshold
+	}
+	return p, nil
+}
+
+// thresholdOverride returns value when cmd's --match-threshold flag was
+// given, and -1 (defer to the match_threshold setting) when it was not. A
+// given value must be a fuzzy score, 0 or more, as the setting must.
+func thresholdOverride(cmd *cobra.Command, value int) (int, error) {
+	fl := cmd.Flags().Lookup("match-threshold")
+	if fl == nil || !fl.Changed {
+		return -1, nil
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("--match-threshold must be a fuzzy score, 0 or more, got %d", value)
+	}
+	return value, nil
+}
+
+// noteMatchCandidates lists every note under notesDir as a fuzzy candidate
+// labelled by its title prop (falling back to the filename slug).
+// Unparsable/CRLF files are skipped, same policy as findNoteByRefOrAlias.
+func noteMatchCandidates(notesDir string) ([]fuzzyCandidate, error) {
+	files, err := noteFiles(notesDir)
+	if err != nil {
+		return nil, err
+	}
+	var out []fuzzyCandidate
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok {
+			continue
+		}
+		slug := strings.TrimSuffix(filepath.Base(path), ".md")
+		ref := n.ULID
+		if ref == "" {
+			ref = slug
+		}
+		label := n.Props["title"]
+		if label == "" {
+			label = slug
+		}
+		out = append(out, fuzzyCandidate{Ref: ref, Label: label})
+	}
+	return out, nil
+}
+
+// todoMatchCandidates lists every durable todo under todosDir (in project,
+// or any project for "") as a fuzzy candidate labelled by its title (first
+// non-blank body line, the same derivation the index uses).
+func todoMatchCandidates(todosDir, project string) ([]fuzzyCandidate, error) {
+	files, err := todoFiles(todosDir, project)
+	if err != nil {
+		return nil, err
+	}
+	var out []fuzzyCandidate
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || n.ULID == "" {
+			continue
+		}
+		out = append(out, fuzzyCandidate{Ref: n.ULID, Label: firstBodyLine(n.Body)})
+	}
+	return out, nil
+}
+
+// parseCandidateFile reads and parses path, reporting ok=false for an
+// unreadable, CRLF, or unparsable file.
+func parseCandidateFile(path string) (*node.Node, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil || bytes.Contains(raw, []byte("\r\n")) {
+		return nil, false
+	}
+	n, err := node.Parse(raw)
+	if err != nil {
+		return nil, false
+	}
+	return n, true
+}
+
+// firstBodyLine returns body's first non-blank line, trimmed.
+func firstBodyLine(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if t := strings.TrimSpace(line); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// resolveNoteMatch resolves a --match title query to a ref for a note in
+// vaultDir's notes/ directory, under cmd's --strict-match and
+// --match-threshold (see loadMatchPolicy).
+func resolveNoteMatch(cmd *cobra.Command, vaultDir, query string) (string, error) {
+	threshold, err := thresholdOverride(cmd, noteMatchThresholdFlag)
+	if err != nil {
+		return "", err
+	}
+	policy, err := loadMatchPolicy(vaultDir, noteStrictMatchFlag, threshold)
+	if err != nil {
+		return "", err
+	}
+	cands, err := noteMatchCandidates(filepath.Join(vaultDir, "notes"))
+	if err != nil {
+		return "", fmt.Errorf("scan notes dir: %w", err)
+	}
+	c, err := resolveFuzzy(query, cands, policy)
+	if err != nil {
+		return "", err
+	}
+	return c.Ref, nil
+}
+
+// resolveTodoMatch resolves a --match title query to the ULID of a durable
+// todo in vaultDir's todos/ directory (only --project's, under it), under
+// cmd's --strict-match and --match-threshold (see loadMatchPolicy).
+func resolveTodoMatch(cmd *cobra.Command, vaultDir, query string) (string, error) {
+	threshold, err := thresholdOverride(cmd, todoMatchThresholdFlag)
+	if err != nil {
+		return "", err
+	}
+	policy, err := loadMatchPolicy(vaultDir, todoStrictMatchFlag, threshold)
+	if err != nil {
+		return "", err
+	}
+	cands, err := todoMatchCandidates(filepath.Join(vaultDir, "todos"), todoProjectFlag)
+	if err != nil {
+		return "", err
+	}
+	c, err := resolveFuzzy(query, cands, policy)
+	if err != nil {
+		return "", err
+	}
+	return c.Ref, nil
+}
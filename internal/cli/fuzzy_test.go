@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+func TestResolveFuzzy(t *testing.T) {
+	cands := []fuzzyCandidate{
+		{Ref: "A", Label: "PAS Entity Model"},
+		{Ref: "B", Label: "Billing pipeline"},
+		{Ref: "C", Label: "Billing"},
+	}
+
+	if c, err := resolveFuzzy("entity", cands, matchPolicy{margin: 100}); err != nil || c.Ref != "A" {
+		t.Errorf("unique fuzzy match = %+v, %v; want A", c, err)
+	}
+	// "billing" fuzzy-matches both B and C, but exactly one label equals it.
+	if c, err := resolveFuzzy("BILLING", cands, matchPolicy{margin: 100}); err != nil || c.Ref != "C" {
+		t.Errorf("exact label match = %+v, %v; want C", c, err)
+	}
+	if _, err := resolveFuzzy("bil", cands, matchPolicy{margin: 100}); err == nil || !strings.Contains(err.Error(), "2 matches") {
+		t.Errorf("ambiguous match err = %v, want a 2-candidate error", err)
+	}
+	if _, err := resolveFuzzy("zzz", cands, matchPolicy{margin: 100}); err == nil || !strings.Contains(err.Error(), "(not found)") {
+		t.Errorf("no match err = %v, want (not found)", err)
+	}
+
+	// "bil" scores Billing higher than Billing pipeline (fewer unmatched
+	// characters): by about half again, which clears a 30% margin only.
+	if c, err := resolveFuzzy("bil", cands, matchPolicy{margin: 30}); err != nil || c.Ref != "C" {
+		t.Errorf("clear winner at margin 30 = %+v, %v; want C", c, err)
+	}
+	if _, err := resolveFuzzy("bil", cands, matchPolicy{margin: -1}); err == nil {
+		t.Error("a negative (strict) margin should never pick among several matches")
+	}
+
+	// "bil" scores Billing 26 and Billing pipeline 17: a threshold between
+	// them leaves one match, and one above both leaves none.
+	if c, err := resolveFuzzy("bil", cands, matchPolicy{margin: -1, threshold: 20}); err != nil || c.Ref != "C" {
+		t.Errorf("threshold 20 = %+v, %v; want C alone", c, err)
+	}
+	if _, err := resolveFuzzy("bil", cands, matchPolicy{threshold: 30}); err == nil || !strings.Contains(err.Error(), "scoring at least 30 (not found)") {
+		t.Errorf("threshold 30 err = %v, want a (not found) naming the threshold", err)
+	}
+	if _, err := resolveFuzzy("bil", cands, matchPolicy{margin: 30, maxCandidates: 1}); err == nil || !strings.Contains(err.Error(), "too many matches") {
+		t.Errorf("maxCandidates 1 err = %v, want too many matches even with a clear winner", err)
+	}
+}
+
+// TestTodoDone_MatchMargin: with match_margin low enough, --match picks
+// the clearly best title, and --strict-match turns that back into an error.
+func TestTodoDone_MatchMargin(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "match_margin: 30\n")
+	best := node.Mint()
+	writeTodoFixture(t, vault, best, "open", "", "Billing")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Billing pipeline")
+
+	_, _, err := runTodo(t, vault, "done", "--match", "bil", "--strict-match")
+	if err == nil || !strings.Contains(err.Error(), "2 matches") {
+		t.Fatalf("--strict-match err = %v, want the ambiguous-match error", err)
+	}
+
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "done", "--match", "bil", "--json")
+	if err != nil {
+		t.Fatalf("todo done --match: %v", err)
+	}
+	var res todoDoneResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != best {
+		t.Errorf("done id = %s, want the clearly best match %s", res.ID, best)
+	}
+}
+
+func TestNoteShow_Match(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	for _, title := range []string{"PAS Entity Model", "Weekly Review"} {
+		resetCLIFlags()
+		if _, _, err := runNote(t, vault, "create", title); err != nil {
+			t.Fatalf("note create %q: %v", title, err)
+		}
+	}
+
+	resetCLIFlags()
+	out, _, err := runNote(t, vault, "show", "--match", "entity mod", "--json")
+	if err != nil {
+		t.Fatalf("note show --match: %v", err)
+	}
+	var res noteShowResult
+	mustDecodeJSON(t, out, &res)
+	if res.Title != "PAS Entity Model" {
+		t.Errorf("title = %q, want PAS Entity Model", res.Title)
+	}
+}
+
+func TestTodoDone_Match(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	out, _, err := runTodo(t, vault, "add", "renew passport", "--json")
+	if err != nil {
+		t.Fatalf("todo add: %v", err)
+	}
+	var added todoAddResult
+	mustDecodeJSON(t, out, &added)
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "done", "--match", "passport", "--json")
+	if err != nil {
+		t.Fatalf("todo done --match: %v", err)
+	}
+	var res todoDoneResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != added.ID || res.State != "done" {
+		t.Errorf("done result = %+v, want id %s done", res, added.ID)
+	}
+}
+
+// TestTodoDone_MatchThreshold: --match-threshold overrides the
+// match_threshold setting for one command, and must not be negative.
+func TestTodoDone_MatchThreshold(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "match_threshold: 30\n")
+	best := node.Mint()
+	writeTodoFixture(t, vault, best, "open", "", "Billing")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Billing pipeline")
+
+	_, _, err := runTodo(t, vault, "done", "--match", "bil")
+	if err == nil || !strings.Contains(err.Error(), "(not found)") {
+		t.Fatalf("below match_threshold err = %v, want (not found)", err)
+	}
+
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "done", "--match", "bil", "--match-threshold", "20", "--strict-match", "--json")
+	if err != nil {
+		t.Fatalf("todo done --match-threshold 20: %v", err)
+	}
+	if !strings.Contains(out, best) {
+		t.Errorf("todo done --match-threshold 20 = %s, want %s done", out, best)
+	}
+
+	resetCLIFlags()
+	_, _, err = runTodo(t, vault, "reopen", "--match", "bil", "--match-threshold", "-1")
+	if err == nil || !strings.Contains(err.Error(), "--match-threshold must be a fuzzy score, 0 or more, got -1") {
+		t.Errorf("--match-threshold -1 err = %v, want it rejected", err)
+	}
+}
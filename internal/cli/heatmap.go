@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// heatmap flag variables, reset (with their pflag Changed state) by
+// resetHeatmapFlags after every run.
+var (
+	heatmapYearFlag    int
+	heatmapMetricFlag  string
+	heatmapNoColorFlag bool
+)
+
+func resetHeatmapFlags(cmd *cobra.Command) {
+	heatmapYearFlag = 0
+	heatmapMetricFlag = heatmapMetricActivity
+	heatmapNoColorFlag = false
+	for _, name := range []string{"year", "metric", "no-color"} {
+		if fl := cmd.Flags().Lookup(name); fl != nil {
+			fl.Changed = false
+		}
+	}
+}
+
+// `rk heatmap --metric` values: what one day's count is.
+const (
+	heatmapMetricActivity = "activity" // log entries plus todos completed
+	heatmapMetricLogs     = "logs"     // log entries
+	heatmapMetricTasks    = "tasks"    // distinct todos a did:: entry completed
+	heatmapMetricWins     = "wins"     // log entries of kind "win"
+)
+
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Show a year of daily activity as a weeks × weekdays grid",
+	Long: "Print a contribution-graph style grid for one year: a column per week (Monday first), a row per " +
+		"weekday, each day shaded by how much happened on it relative to the year's busiest day. " +
+		"--metric picks what counts: activity (log entries plus todos completed, the default), logs, tasks, or wins. " +
+		"On a terminal the grid is colored; with --no-color, --plain, or when piped it uses the symbols . - + * # instead.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runHeatmapE,
+}
+
+func init() {
+	f := heatmapCmd.Flags()
+	f.IntVar(&heatmapYearFlag, "year", 0, "Year to show (default: this year)")
+	f.StringVar(&heatmapMetricFlag, "metric", heatmapMetricActivity, "What to count per day: activity, logs, tasks, or wins")
+	f.BoolVar(&heatmapNoColorFlag, "no-color", false, "Shade days with symbols instead of terminal colors")
+}
+
+// heatmapResult is `rk heatmap`'s output. Days lists only days with a
+// non-zero count, in date order.
+type heatmapResult struct {
+	Year       int          `json:"year"`
+	Metric     string       `json:"metric"`
+	Total      int          `json:"total"`
+	ActiveDays int          `json:"active_days"`
+	Max        int          `json:"max"` // the busiest day's count
+	Days       []heatmapDay `json:"days"`
+
+	color bool // shade with ANSI colors rather than symbols
+}
+
+// heatmapDay is one day's count in a heatmapResult.
+type heatmapDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// heatmapLevels is how many shades a non-empty day can get; level 0 is a
+// day with nothing.
+const heatmapLevels = 4
+
+// heatmapSymbols and heatmapColors shade levels 0..heatmapLevels without
+// and with color (xterm-256 greys to greens).
+var (
+	heatmapSymbols = []string{".", "-", "+", "*", "#"}
+	heatmapColors  = []string{"\x1b[38;5;238m", "\x1b[38;5;22m", "\x1b[38;5;28m", "\x1b[38;5;34m", "\x1b[38;5;46m"}
+)
+
+var heatmapMetricNames = map[string]string{
+	heatmapMetricActivity: "log entries + todos done",
+	heatmapMetricLogs:     "log entries",
+	heatmapMetricTasks:    "todos done",
+	heatmapMetricWins:     "wins",
+}
+
+// heatmapLevel buckets count against the year's max into 0..heatmapLevels;
+// any activity at all is at least level 1.
+func heatmapLevel(count, max int) int {
+	if count <= 0 || max <= 0 {
+		return 0
+	}
+	return (count*heatmapLevels + max - 1) / max
+}
+
+func (r heatmapResult) Pretty() string {
+	counts := make(map[string]int, len(r.Days))
+	for _, d := range r.Days {
+		counts[d.Date] = d.Count
+	}
+	first := time.Date(r.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(r.Year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	// Weeks run Monday to Sunday; the grid starts on the Monday on or
+	// before January 1st.
+	start := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+	weeks := int(last.Sub(start).Hours()/24)/7 + 1
+
+	cell := func(level int) string {
+		if r.color {
+			return heatmapColors[level] + "■" + ansiReset + " "
+		}
+		return heatmapSymbols[level] + " "
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "heatmap: %d %s, %d total over %d active day(s), busiest %d\n",
+		r.Year, heatmapMetricNames[r.Metric], r.Total, r.ActiveDays, r.Max)
+
+	months := []byte(strings.Repeat(" ", 2*weeks+2))
+	for m := time.January; m <= time.December; m++ {
+		col := int(time.Date(r.Year, m, 1, 0, 0, 0, 0, time.UTC).Sub(start).Hours()/24) / 7
+		copy(months[2*col:], m.String()[:3])
+	}
+	b.WriteString("    " + strings.TrimRight(string(months), " "))
+
+	for wd := 0; wd < 7; wd++ {
+		day := start.AddDate(0, 0, wd)
+		fmt.Fprintf(&b, "\n%s ", day.Weekday().String()[:3])
+		var row strings.Builder
+		for w := 0; w < weeks; w++ {
+			d := day.AddDate(0, 0, 7*w)
+			if d.Before(first) || d.After(last) {
+				row.WriteString("  ")
+				continue
+			}
+			row.WriteString(cell(heatmapLevel(counts[d.Format("2006-01-02")], r.Max)))
+		}
+		b.WriteString(strings.TrimRight(row.String(), " "))
+	}
+
+	b.WriteString("\n    less ")
+	for level := 0; level <= heatmapLevels; level++ {
+		b.WriteString(cell(level))
+	}
+	b.WriteString("more")
+	return b.String()
+}
+
+func runHeatmapE(cmd *cobra.Command, args []string) error {
+	defer resetHeatmapFlags(cmd)
+
+	if _, ok := heatmapMetricNames[heatmapMetricFlag]; !ok {
+		return fmt.Errorf("heatmap: --metric must be %s, %s, %s, or %s, got %q%s",
+			heatmapMetricActivity, heatmapMetricLogs, heatmapMetricTasks, heatmapMetricWins, heatmapMetricFlag,
+			config.Suggest(heatmapMetricFlag, heatmapMetricActivity, heatmapMetricLogs, heatmapMetricTasks, heatmapMetricWins))
+	}
+	year := heatmapYearFlag
+	if year == 0 {
+		year = journalNow().Year()
+	}
+	if year < 1 || year > 9999 {
+		return fmt.Errorf("heatmap: invalid --year %d", year)
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("heatmap: load config: %w", err)
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("heatmap: open index: %w", err)
+	}
+	defer ix.Close()
+
+	st, err := ix.Reconcile()
+	if err != nil {
+		return fmt.Errorf("heatmap: reconcile index: %w", err)
+	}
+	warnSkippedJournals(cmd, "heatmap", st.Warnings)
+
+	res, err := buildHeatmap(ix.DB(), year, heatmapMetricFlag)
+	if err != nil {
+		return err
+	}
+	res.color = isTerminal(cmd.OutOrStdout()) && !heatmapNoColorFlag && !plainFlag
+	return newOutput(cmd, mode).Print(res)
+}
+
+// buildHeatmap counts metric per day of year from the index.
+func buildHeatmap(db *sql.DB, year int, metric string) (heatmapResult, error) {
+	res := heatmapResult{Year: year, Metric: metric, Days: []heatmapDay{}}
+	prefix := fmt.Sprintf("%04d-", year)
+
+	var queries []string
+	if metric == heatmapMetricActivity || metric == heatmapMetricLogs {
+		queries = append(queries, `SELECT `+logEntryDaySQL+`, COUNT(*) FROM nodes n
+			WHERE n.type = 'log-entry' AND `+logEntryDaySQL+` LIKE ? || '%' GROUP BY 1`)
+	}
+	if metric == heatmapMetricWins {
+		queries = append(queries, `SELECT `+logEntryDaySQL+`, COUNT(*) FROM nodes n
+			JOIN node_props p ON p.id = n.id AND p.key = 'kind' AND p.value = 'win'
+			WHERE n.type = 'log-entry' AND `+logEntryDaySQL+` LIKE ? || '%' GROUP BY 1`)
+	}
+
+	counts := map[string]int{}
+	if metric == heatmapMetricActivity || metric == heatmapMetricTasks {
+		done, err := todosDoneByDay(db, prefix+"01-01", prefix+"12-31")
+		if err != nil {
+			return heatmapResult{}, fmt.Errorf("heatmap: %w", err)
+		}
+		for day, todos := range done {
+			counts[day] += len(todos)
+		}
+	}
+	for _, q := range queries {
+		rows, err := db.Query(q, prefix)
+		if err != nil {
+			return heatmapResult{}, fmt.Errorf("heatmap: query %s: %w", metric, err)
+		}
+		for rows.Next() {
+			var day string
+			var n int
+			if err := rows.Scan(&day, &n); err != nil {
+				rows.Close()
+				return heatmapResult{}, fmt.Errorf("heatmap: scan %s: %w", metric, err)
+			}
+			counts[day] += n
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return heatmapResult{}, fmt.Errorf("heatmap: iterate %s: %w", metric, err)
+		}
+		rows.Close()
+	}
+
+	for d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		n := counts[day]
+		if n == 0 {
+			continue
+		}
+		res.Days = append(res.Days, heatmapDay{Date: day, Count: n})
+		res.Total += n
+		res.ActiveDays++
+		if n > res.Max {
+			res.Max = n
+		}
+	}
+	return res, nil
+}
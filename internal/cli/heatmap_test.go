@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+func runHeatmap(t *testing.T, vault string, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"heatmap", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), errBuf.String(), err
+}
+
+// TestHeatmap: each metric counts its own thing per day, only the chosen
+// year is counted, and piped output shades the grid with symbols.
+func TestHeatmap(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	for _, e := range []struct{ date, kind string }{
+		{"2026-07-08", "win"}, {"2026-07-08", ""}, {"2026-07-09", ""}, {"2025-12-31", ""},
+	} {
+		resetCLIFlags()
+		args := []string{"entry", "--date", e.date, "--at", "09:00"}
+		if e.kind != "" {
+			args = append(args, "--kind", e.kind)
+		}
+		if _, stderr, err := runAdd(t, vault, args...); err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", args, err, stderr)
+		}
+	}
+	id := node.Mint()
+	writeTodoFixture(t, vault, id, "open", "2026-07-10", "Ship it.")
+	resetCLIFlags()
+	if _, stderr, err := runToday(t, vault, "act", id, "x"); err != nil {
+		t.Fatalf("rk today act x: %v\nstderr: %s", err, stderr)
+	}
+
+	days := func(metric string) string {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runHeatmap(t, vault, "--metric", metric, "--json")
+		if err != nil {
+			t.Fatalf("rk heatmap --metric %s: %v\nstderr: %s", metric, err, stderr)
+		}
+		var res heatmapResult
+		mustDecodeJSON(t, out, &res)
+		var parts []string
+		for _, d := range res.Days {
+			parts = append(parts, d.Date[5:]+"="+strings.Repeat("|", d.Count))
+		}
+		return strings.Join(parts, " ")
+	}
+	// The completion's did:: entry is itself a log entry on 07-10.
+	for metric, want := range map[string]string{
+		"activity": "07-08=|| 07-09=| 07-10=||",
+		"logs":     "07-08=|| 07-09=| 07-10=|",
+		"tasks":    "07-10=|",
+		"wins":     "07-08=|",
+	} {
+		if got := days(metric); got != want {
+			t.Errorf("--metric %s days = %q, want %q", metric, got, want)
+		}
+	}
+
+	resetCLIFlags()
+	out, _, err := runHeatmap(t, vault, "--year", "2025")
+	if err != nil {
+		t.Fatalf("rk heatmap --year 2025: %v", err)
+	}
+	if !strings.HasPrefix(out, "heatmap: 2025 log entries + todos done, 1 total") || strings.Contains(out, "\x1b[") {
+		t.Errorf("piped 2025 heatmap should count one day and use no colors:\n%s", out)
+	}
+	if !strings.Contains(out, "Wed ") || !strings.Contains(out, "less . - + * # more") {
+		t.Errorf("heatmap grid should label weekdays and show the symbol legend:\n%s", out)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runHeatmap(t, vault, "--metric", "notes"); err == nil || !strings.Contains(err.Error(), "--metric must be") {
+		t.Errorf("--metric notes err = %v, want the allowed values", err)
+	}
+	resetCLIFlags()
+	if _, _, err := runHeatmap(t, vault, "--metric", "taks"); err == nil || !strings.Contains(err.Error(), `got "taks" (did you mean "tasks"?)`) {
+		t.Errorf("--metric taks err = %v, want a suggestion of tasks", err)
+	}
+}
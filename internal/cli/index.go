@@ -19,7 +19,7 @@ var indexCmd = &cobra.Command{
 	Long: "Rebuild the per-device property-graph index cache from the vault text. " +
 		"The index is derived and disposable; this performs a full, deterministic rebuild.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+		mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 		if err != nil {
 			return err
 		}
@@ -35,7 +35,10 @@ var indexCmd = &cobra.Command{
 		}
 		defer ix.Close()
 
+		prog := newProgressReporter(cmd.ErrOrStderr(), "reindexed", "files")
+		ix.SetProgress(prog.Update)
 		st, err := ix.Rebuild()
+		prog.Done()
 		if err != nil {
 			return fmt.Errorf("index: rebuild: %w", err)
 		}
@@ -54,7 +57,7 @@ var indexCmd = &cobra.Command{
 		if mode == output.Pretty && quietFlag {
 			return nil
 		}
-		return output.New(cmd.OutOrStdout(), mode).Print(res)
+		return newOutput(cmd, mode).Print(res)
 	},
 }
 
@@ -86,6 +89,8 @@ func (r indexResult) Pretty() string {
 			fmt.Fprintf(&b, "duplicate ULID %s: %s", w.ULID, strings.Join(w.Files, ", "))
 		case "alias_collision":
 			fmt.Fprintf(&b, "alias %q on %d nodes: %s", w.Alias, len(w.NodeKeys), strings.Join(w.Files, ", "))
+		case "unparsable":
+			fmt.Fprintf(&b, "unparsable %s: %s", w.Files[0], w.Error)
 		default:
 			b.WriteString(w.Kind)
 		}
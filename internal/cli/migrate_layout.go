@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// migrateLogLayoutCmd moves the vault's journal day files to where the
+// journal_layout setting puts them. The index finds a day by its date
+// alias, so a moved file keeps every ref to it resolving; the reconcile at
+// the end only picks up the new paths.
+var migrateLogLayoutCmd = &cobra.Command{
+	Use:   "log-layout",
+	Short: "Move journal day files to the journal_layout setting's layout",
+	Long: "Move every day file under log/ to where the journal_layout setting puts it: log/DAY.md when " +
+		"flat, log/YYYY/YYYY-MM/DAY.md when nested. Set journal_layout in .reckon/config.yaml first, " +
+		"then run this. A file whose new path is already taken is reported and left where it " +
+		"is; directories the move empties are removed. With --dry-run nothing is moved.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runMigrateLogLayoutE,
+}
+
+var migrateLogLayoutDryRunFlag bool
+
+func init() {
+	migrateLogLayoutCmd.Flags().BoolVar(&migrateLogLayoutDryRunFlag, "dry-run", false, "List the moves without making them")
+
+	migrateCmd.AddCommand(migrateLogLayoutCmd)
+}
+
+func resetMigrateLogLayoutFlags(cmd *cobra.Command) {
+	migrateLogLayoutDryRunFlag = false
+	if fl := cmd.Flags().Lookup("dry-run"); fl != nil {
+		fl.Changed = false
+	}
+}
+
+// migrateLogLayoutResult is the structured summary of one `rk migrate
+// log-layout` run. Paths are vault-relative.
+type migrateLogLayoutResult struct {
+	Layout    string              `json:"layout"`
+	DryRun    bool                `json:"dry_run"`
+	Moved     []migrateLogMove    `json:"moved"`
+	Unchanged int                 `json:"unchanged"`
+	Skipped   []migrateLogSkipped `json:"skipped"`
+}
+
+type migrateLogMove struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type migrateLogSkipped struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (r migrateLogLayoutResult) Pretty() string {
+	var b strings.Builder
+	verb := "moved"
+	if r.DryRun {
+		verb = "would move"
+	}
+	fmt.Fprintf(&b, "log-layout: %s %d day file(s) to the %s layout, %d already in place, %d skipped",
+		verb, len(r.Moved), r.Layout, r.Unchanged, len(r.Skipped))
+	for _, m := range r.Moved {
+		fmt.Fprintf(&b, "\n  %s -> %s", m.From, m.To)
+	}
+	for _, s := range r.Skipped {
+		fmt.Fprintf(&b, "\n  skipped %s (%s)", s.Path, s.Reason)
+	}
+	return b.String()
+}
+
+func runMigrateLogLayoutE(cmd *cobra.Command, args []string) error {
+	defer resetMigrateLogLayoutFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("log-layout: load config: %w", err)
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("log-layout: %w", err)
+	}
+
+	res, err := migrateLogLayout(cfg.VaultDir, settings.JournalLayout, migrateLogLayoutDryRunFlag)
+	if err != nil {
+		return fmt.Errorf("log-layout: %w", err)
+	}
+	if !res.DryRun && len(res.Moved) > 0 {
+		ix, err := index.Open(cfg)
+		if err != nil {
+			return fmt.Errorf("log-layout: open index: %w", err)
+		}
+		defer ix.Close()
+		if _, err := ix.Reconcile(); err != nil {
+			return fmt.Errorf("log-layout: reconcile index: %w", err)
+		}
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateLogLayout moves each log-day file under vaultDir's log/ to its
+// path under layout. Every file is found before any is moved, so a file
+// moved into a directory the walk has yet to reach is never seen twice.
+func migrateLogLayout(vaultDir, layout string, dryRun bool) (migrateLogLayoutResult, error) {
+	res := migrateLogLayoutResult{Layout: layout, DryRun: dryRun, Moved: []migrateLogMove{}, Skipped: []migrateLogSkipped{}}
+	logDir := filepath.Join(vaultDir, "log")
+
+	var files []string
+	err := filepath.WalkDir(logDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == logDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return res, fmt.Errorf("walk log: %w", err)
+	}
+
+	for _, path := range files {
+		from := relTodoPath(vaultDir, path)
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "log-day" {
+			res.Skipped = append(res.Skipped, migrateLogSkipped{Path: from, Reason: "not a log day file"})
+			continue
+		}
+		day := logDayOf(path, n.Aliases)
+		if day == "" {
+			res.Skipped = append(res.Skipped, migrateLogSkipped{Path: from, Reason: "no date alias or date filename"})
+			continue
+		}
+		to := "log/" + config.JournalDayPath(layout, day)
+		if to == from {
+			res.Unchanged++
+			continue
+		}
+		target := filepath.Join(vaultDir, filepath.FromSlash(to))
+		if _, err := os.Stat(target); err == nil {
+			res.Skipped = append(res.Skipped, migrateLogSkipped{Path: from, Reason: to + " already exists"})
+			continue
+		}
+		res.Moved = append(res.Moved, migrateLogMove{From: from, To: to})
+		if dryRun {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return res, fmt.Errorf("create %s: %w", filepath.Dir(to), err)
+		}
+		if err := os.Rename(path, target); err != nil {
+			return res, fmt.Errorf("move %s: %w", from, err)
+		}
+		removeEmptyDirs(filepath.Dir(path), logDir)
+	}
+	return res, nil
+}
+
+// logDayOf returns the YYYY-MM-DD day a log-day file is for: its first
+// date alias, else its filename when that is a date, else "".
+func logDayOf(path string, aliases []string) string {
+	for _, a := range append(append([]string{}, aliases...), strings.TrimSuffix(filepath.Base(path), ".md")) {
+		if _, err := time.Parse("2006-01-02", a); err == nil {
+			return a
+		}
+	}
+	return ""
+}
+
+// removeEmptyDirs removes dir and then each parent it empties, stopping
+// at (and never removing) stop.
+func removeEmptyDirs(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop+string(filepath.Separator)) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runMigrateLogLayout executes `rk migrate log-layout --vault <vault>
+// [args...]` through RootCmd and returns stdout plus the command's error.
+func runMigrateLogLayout(t *testing.T, vault string, args ...string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	RootCmd.SetOut(&buf)
+	RootCmd.SetErr(&buf)
+	RootCmd.SetArgs(append([]string{"migrate", "log-layout", "--vault", vault}, args...))
+	err := RootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestJournalLayout(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	add := func(day, text string) {
+		t.Helper()
+		resetCLIFlags()
+		if _, stderr, err := runAdd(t, vault, "--date", day, "--at", "09:00", text); err != nil {
+			t.Fatalf("rk add %s: %v\nstderr: %s", day, err, stderr)
+		}
+	}
+	flat := filepath.Join(vault, "log", "2026-03-04.md")
+	nested := filepath.Join(vault, "log", "2026", "2026-03", "2026-03-04.md")
+
+	add("2026-03-04", "flat entry")
+	if _, err := os.Stat(flat); err != nil {
+		t.Fatalf("default layout did not write %s: %v", flat, err)
+	}
+
+	// Switching layouts keeps appending to a day where it already is, and
+	// files new days the new way.
+	writeVaultSettings(t, vault, "journal_layout: nested\n")
+	add("2026-03-04", "second entry")
+	add("2026-03-05", "nested entry")
+	if got := mustReadFile(t, flat); !strings.Contains(got, "second entry") {
+		t.Errorf("second entry not appended to the existing flat file:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(vault, "log", "2026", "2026-03", "2026-03-05.md")); err != nil {
+		t.Errorf("new day not filed nested: %v", err)
+	}
+
+	resetCLIFlags()
+	out, err := runMigrateLogLayout(t, vault, "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("log-layout --dry-run: %v", err)
+	}
+	var dry migrateLogLayoutResult
+	mustDecodeJSON(t, out, &dry)
+	if len(dry.Moved) != 1 || dry.Moved[0].From != "log/2026-03-04.md" || dry.Moved[0].To != "log/2026/2026-03/2026-03-04.md" || dry.Unchanged != 1 {
+		t.Errorf("dry run = %+v, want the flat file moved and one in place", dry)
+	}
+	if _, err := os.Stat(flat); err != nil {
+		t.Fatalf("--dry-run moved %s: %v", flat, err)
+	}
+
+	resetCLIFlags()
+	if _, err := runMigrateLogLayout(t, vault); err != nil {
+		t.Fatalf("log-layout: %v", err)
+	}
+	if _, err := os.Stat(flat); !os.IsNotExist(err) {
+		t.Errorf("%s left behind (err %v)", flat, err)
+	}
+	if got := mustReadFile(t, nested); !strings.Contains(got, "flat entry") || !strings.Contains(got, "second entry") {
+		t.Errorf("moved day file lost entries:\n%s", got)
+	}
+
+	resetCLIFlags()
+	stdout, stderr, err := runQuery(t, vault, "SELECT DISTINCT loc FROM nodes WHERE type='log-day' ORDER BY loc")
+	if err != nil {
+		t.Fatalf("rk query: %v\nstderr: %s", err, stderr)
+	}
+	if strings.Contains(stdout, "log/2026-03-04.md") || !strings.Contains(stdout, "log/2026/2026-03/2026-03-04.md") {
+		t.Errorf("index not reconciled to the new paths:\n%s", stdout)
+	}
+
+	// Back to flat: the year and month directories it empties go too.
+	writeVaultSettings(t, vault, "journal_layout: flat\n")
+	resetCLIFlags()
+	if _, err := runMigrateLogLayout(t, vault); err != nil {
+		t.Fatalf("log-layout back to flat: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(vault, "log"))
+	if err != nil {
+		t.Fatalf("read log dir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if got := strings.Join(names, " "); got != "2026-03-04.md 2026-03-05.md" {
+		t.Errorf("log/ after moving back = %q, want just the two flat day files", got)
+	}
+}
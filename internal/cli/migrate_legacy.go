@@ -176,7 +176,7 @@ func runMigrateLegacyE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("import: --dry-run and --verify are mutually exclusive")
 	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -203,7 +203,7 @@ func runMigrateLegacyE(cmd *cobra.Command, args []string) error {
 		}
 		res := toMigrateLegacyVerifyResult(vr)
 		if !(mode == output.Pretty && quietFlag) {
-			if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+			if err := newOutput(cmd, mode).Print(res); err != nil {
 				return err
 			}
 		}
@@ -214,14 +214,17 @@ func runMigrateLegacyE(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	prog := newProgressReporter(cmd.ErrOrStderr(), "imported", "records")
+	imp.Progress = func(done int) { prog.Update(done, 0) }
 	report, err := imp.Run()
+	prog.Done()
 	if err != nil {
 		return fmt.Errorf("import: %w", err)
 	}
 	res := toMigrateLegacyResult(report)
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
@@ -163,8 +163,9 @@ func TestMigrateLegacy_MutualExclusivity(t *testing.T) {
 
 // TestMigrateSubcommandSurface asserts the two-level `migrate legacy`
 // structure: `migrate` is registered on RootCmd, has no RunE of its own
-// (mirrors noteCmd), and its only child is `legacy`. Located by name via
-// RootCmd.Commands(), never by referencing a production var directly.
+// (mirrors noteCmd), and its only children are `legacy` and `log-layout`.
+// Located by name via RootCmd.Commands(), never by referencing a
+// production var directly.
 func TestMigrateSubcommandSurface(t *testing.T) {
 	var migrateCommand *cobra.Command
 	for _, cmd := range RootCmd.Commands() {
@@ -184,8 +185,8 @@ func TestMigrateSubcommandSurface(t *testing.T) {
 	for _, cmd := range migrateCommand.Commands() {
 		names[cmd.Name()] = true
 	}
-	if len(names) != 1 || !names["legacy"] {
-		t.Errorf("migrate subcommands = %v, want exactly {\"legacy\"}", names)
+	if len(names) != 2 || !names["legacy"] || !names["log-layout"] {
+		t.Errorf("migrate subcommands = %v, want exactly {\"legacy\", \"log-layout\"}", names)
 	}
 }
 
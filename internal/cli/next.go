@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/oklog/ulid/v2"
+	"github.com/spf13/cobra"
+)
+
+// nextDueHorizon is how many days before its deadline a todo starts
+// scoring for being due soon.
+const nextDueHorizon = 14
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Suggest which todo to work on next",
+	Long: "Score every ready durable todo and print the best one, with why it was picked. A todo is ready " +
+		"when it is open or in progress, not scheduled after today, and not waiting on a todo it depends on " +
+		"that is still open. The score adds points for each day overdue, each day closer than two weeks to " +
+		"the deadline, the priority (C 1, B 2, A 3), and each week since the todo was created, each times " +
+		"its weight in the next_weights setting; ties go to the oldest todo. --count suggests several.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runNextE,
+}
+
+var (
+	nextTagFlag     string
+	nextProjectFlag string
+	nextCountFlag   int
+)
+
+func init() {
+	f := nextCmd.Flags()
+	f.StringVar(&nextTagFlag, "tag", "", "Only suggest todos carrying this tag")
+	f.StringVar(&nextProjectFlag, "project", "", "Only suggest todos in this project, a subdirectory of todos/")
+	f.IntVar(&nextCountFlag, "count", 1, "How many todos to suggest")
+}
+
+func resetNextFlags(cmd *cobra.Command) {
+	nextTagFlag = ""
+	nextProjectFlag = ""
+	nextCountFlag = 1
+	for _, name := range []string{"tag", "project", "count"} {
+		if fl := cmd.Flags().Lookup(name); fl != nil {
+			fl.Changed = false
+		}
+	}
+}
+
+// nextResult is `rk next`'s output: the suggested todos, best first, out
+// of how many were ready.
+type nextResult struct {
+	Items []nextItem `json:"items"`
+	Ready int        `json:"ready"`
+}
+
+// nextItem is one suggested todo and the parts of its score.
+type nextItem struct {
+	ID        string   `json:"id"`
+	Path      string   `json:"path"`
+	Title     string   `json:"title"`
+	Project   string   `json:"project,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Priority  string   `json:"priority,omitempty"`
+	Scheduled string   `json:"scheduled,omitempty"`
+	Deadline  string   `json:"deadline,omitempty"`
+	Score     int      `json:"score"`
+	Reasons   []string `json:"reasons"` // e.g. "overdue 3d", "priority A"
+}
+
+func (r nextResult) Pretty() string {
+	if len(r.Items) == 0 {
+		return "next: nothing is ready to work on"
+	}
+	var b strings.Builder
+	for i, it := range r.Items {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if len(r.Items) > 1 {
+			fmt.Fprintf(&b, "%d. ", i+1)
+		} else {
+			b.WriteString("next: ")
+		}
+		fmt.Fprintf(&b, "%s (%s)", it.Title, it.ID)
+		if len(it.Reasons) > 0 {
+			fmt.Fprintf(&b, "\n  why: %s", strings.Join(it.Reasons, ", "))
+		}
+		var ctx []string
+		if it.Project != "" {
+			ctx = append(ctx, "project "+it.Project)
+		}
+		for _, tag := range it.Tags {
+			ctx = append(ctx, "#"+tag)
+		}
+		if len(ctx) > 0 {
+			fmt.Fprintf(&b, "\n  %s", strings.Join(ctx, " "))
+		}
+	}
+	fmt.Fprintf(&b, "\nfinished? rk todo done %s", r.Items[0].ID)
+	return b.String()
+}
+
+func runNextE(cmd *cobra.Command, args []string) error {
+	defer resetNextFlags(cmd)
+
+	if nextCountFlag < 1 {
+		return fmt.Errorf("next: --count must be at least 1, got %d", nextCountFlag)
+	}
+	project := nextProjectFlag
+	if project != "" {
+		if err := config.ValidateProjectName(project); err != nil {
+			return fmt.Errorf("next: --project: %w", err)
+		}
+	}
+	tag := strings.TrimLeft(strings.TrimSpace(nextTagFlag), "#")
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("next: load config: %w", err)
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("next: %w", err)
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("next: open index: %w", err)
+	}
+	defer ix.Close()
+	if _, err := ix.Reconcile(); err != nil {
+		return fmt.Errorf("next: reconcile index: %w", err)
+	}
+
+	items, err := listDurableTodos(ix.DB(), false, "")
+	if err != nil {
+		return err
+	}
+	blocked, err := blockedTodos(ix.DB())
+	if err != nil {
+		return err
+	}
+
+	today := journalNow()
+	todayStr := today.Format("2006-01-02")
+	res := nextResult{Items: []nextItem{}}
+	for _, it := range items {
+		if (project != "" && it.Project != project) || (tag != "" && !containsString(it.Tags, tag)) {
+			continue
+		}
+		if blocked[it.ID] || it.Scheduled > todayStr {
+			continue
+		}
+		props, err := loadTodoProps(ix.DB(), it.ID)
+		if err != nil {
+			return err
+		}
+		item := nextItem{
+			ID: it.ID, Path: it.Path, Title: it.Title, Project: it.Project, Tags: it.Tags,
+			Priority: props["priority"], Scheduled: it.Scheduled, Deadline: it.Deadline,
+		}
+		item.Score, item.Reasons = scoreNextTodo(item, today, settings.NextWeights)
+		res.Items = append(res.Items, item)
+	}
+	res.Ready = len(res.Items)
+
+	// ULIDs sort by creation time, so the ID breaks ties oldest first.
+	sort.SliceStable(res.Items, func(i, j int) bool {
+		if res.Items[i].Score != res.Items[j].Score {
+			return res.Items[i].Score > res.Items[j].Score
+		}
+		return res.Items[i].ID < res.Items[j].ID
+	})
+	if len(res.Items) > nextCountFlag {
+		res.Items = res.Items[:nextCountFlag]
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// scoreNextTodo returns it's `rk next` score under w on today, with a
+// reason for each part that scored.
+func scoreNextTodo(it nextItem, today time.Time, w config.NextWeightsSettings) (int, []string) {
+	score := 0
+	reasons := []string{}
+	day, _ := parseSchedDate(today.Format("2006-01-02"))
+
+	overdue := 0
+	for _, date := range []string{it.Scheduled, it.Deadline} {
+		if d, err := parseSchedDate(date); err == nil {
+			overdue = max(overdue, daysBetween(d, day))
+		}
+	}
+	if overdue > 0 && w.Overdue > 0 {
+		score += w.Overdue * overdue
+		reasons = append(reasons, fmt.Sprintf("overdue %dd", overdue))
+	}
+
+	if d, err := parseSchedDate(it.Deadline); err == nil && w.Due > 0 {
+		if until := daysBetween(day, d); until >= 0 && until < nextDueHorizon {
+			score += w.Due * (nextDueHorizon - until)
+			if until == 0 {
+				reasons = append(reasons, "due today")
+			} else {
+				reasons = append(reasons, fmt.Sprintf("due in %dd", until))
+			}
+		}
+	}
+
+	if rank := strings.Index("CBA", it.Priority) + 1; it.Priority != "" && rank > 0 && w.Priority > 0 {
+		score += w.Priority * rank
+		reasons = append(reasons, "priority "+it.Priority)
+	}
+
+	if id, err := ulid.Parse(it.ID); err == nil && w.Age > 0 {
+		created := ulid.Time(id.Time()).UTC()
+		if weeks := int(day.Sub(created).Hours() / 24 / 7); weeks > 0 {
+			score += w.Age * weeks
+			reasons = append(reasons, fmt.Sprintf("%dw old", weeks))
+		}
+	}
+	return score, reasons
+}
+
+// blockedTodos returns the IDs of todos whose depends-on link resolves to
+// a todo still open or in progress. A dependency that resolves to nothing
+// blocks nothing.
+func blockedTodos(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query(`SELECT e.src FROM edges e
+		JOIN node_props p ON p.id = e.dst_key AND p.key = 'state'
+		WHERE e.rel = 'depends-on' AND p.value IN ('open', 'in-progress')`)
+	if err != nil {
+		return nil, fmt.Errorf("next: query dependencies: %w", err)
+	}
+	defer rows.Close()
+	blocked := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("next: scan dependency: %w", err)
+		}
+		blocked[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("next: iterate dependencies: %w", err)
+	}
+	return blocked, nil
+}
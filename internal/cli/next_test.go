@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// runNext runs `rk next <args...>` against vault.
+func runNext(t *testing.T, vault string, args ...string) (stdout string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"next", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), err
+}
+
+// nextTodoID mints a ULID created on day, so a todo's age is fixed.
+func nextTodoID(t *testing.T, day string) string {
+	t.Helper()
+	tm, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		t.Fatalf("parse %q: %v", day, err)
+	}
+	return ulid.MustNew(ulid.Timestamp(tm), rand.Reader).String()
+}
+
+func TestNext(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	overdue := nextTodoID(t, "2026-07-01") // 2d overdue (20) + 1w old (1)
+	urgent := nextTodoID(t, "2026-07-09")  // priority A (24)
+	due := nextTodoID(t, "2026-07-09")     // due in 2d (5 * 12)
+	dep := nextTodoID(t, "2026-07-09")     // waits on blocker
+	blocker := nextTodoID(t, "2026-06-01") // 5w old (5)
+	later := nextTodoID(t, "2026-07-01")   // scheduled next week
+	done := nextTodoID(t, "2026-06-01")
+	work := nextTodoID(t, "2026-07-09") // priority C (8), in project work
+
+	writeTodoFixture(t, vault, overdue, "open", "", "Renew the passport", "deadline: 2026-07-08")
+	writeTodoFixture(t, vault, urgent, "open", "", "Fix the boiler", "priority: A")
+	writeTodoFixture(t, vault, due, "in-progress", "", "File the report", "deadline: 2026-07-12")
+	writeTodoFixture(t, vault, dep, "open", "", "Paint the fence", "depends-on: \"[["+blocker+"]]\"", "priority: A")
+	writeTodoFixture(t, vault, blocker, "open", "", "Buy paint")
+	writeTodoFixture(t, vault, later, "open", "2026-07-20", "Plan the trip", "priority: A")
+	writeTodoFixture(t, vault, done, "done", "", "Old chore", "priority: A")
+	mustWriteFile(t, filepath.Join(vault, "todos", "work", work+".md"),
+		todoFixtureSrc(work, "open", "", "Review the budget", "priority: C", "tags: [finance]"))
+
+	out, err := runNext(t, vault, "--count", "10", "--json")
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	var res nextResult
+	mustDecodeJSON(t, out, &res)
+	var got []string
+	for _, it := range res.Items {
+		got = append(got, it.Title)
+	}
+	if want := "File the report,Fix the boiler,Renew the passport,Review the budget,Buy paint"; strings.Join(got, ",") != want {
+		t.Errorf("suggestions = %q, want %q", strings.Join(got, ","), want)
+	}
+	if res.Ready != 5 {
+		t.Errorf("ready = %d, want 5", res.Ready)
+	}
+	if len(res.Items) == 5 && (res.Items[0].Score != 60 || strings.Join(res.Items[2].Reasons, ", ") != "overdue 2d, 1w old") {
+		t.Errorf("scores = %+v", res.Items)
+	}
+
+	resetCLIFlags()
+	pretty, err := runNext(t, vault)
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if want := "next: File the report (" + due + ")\n  why: due in 2d\nfinished? rk todo done " + due + "\n"; pretty != want {
+		t.Errorf("next output:\n%q\nwant\n%q", pretty, want)
+	}
+
+	resetCLIFlags()
+	if out, err = runNext(t, vault, "--project", "work", "--json"); err != nil {
+		t.Fatalf("next --project: %v", err)
+	}
+	res = nextResult{}
+	mustDecodeJSON(t, out, &res)
+	if len(res.Items) != 1 || res.Items[0].ID != work {
+		t.Errorf("next --project work = %+v", res.Items)
+	}
+
+	// Weights come from the next_weights setting: with priority alone,
+	// the A todo wins.
+	writeVaultSettings(t, vault, "next_weights:\n  overdue: 0\n  due: 0\n  age: 0\n")
+	resetCLIFlags()
+	if out, err = runNext(t, vault, "--json"); err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	res = nextResult{}
+	mustDecodeJSON(t, out, &res)
+	if len(res.Items) != 1 || res.Items[0].ID != urgent || res.Items[0].Score != 24 {
+		t.Errorf("next with priority weight only = %+v", res.Items)
+	}
+
+	resetCLIFlags()
+	if out, err = runNext(t, vault, "--tag", "nothing"); err != nil || !strings.Contains(out, "nothing is ready") {
+		t.Errorf("next --tag nothing = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+var noteContextFlag bool
+
+func init() {
+	noteShowCmd.Flags().BoolVar(&noteContextFlag, "context", false, "Show the sentence around each backlink's [[link]] in the linking note, todo, or log entry")
+}
+
+// noteContextMaxRunes bounds a backlink context; longer sentences are cut
+// around the link and marked with "…".
+const noteContextMaxRunes = 120
+
+// noteContextLinkRe matches any [[...]] wikilink, capturing its inner text.
+var noteContextLinkRe = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+
+// fillBacklinkContexts sets each backlink's Context to the sentence around
+// its first [[link]] in the source's body. Bodies come from the index and
+// are read once per source however many times it links here. A link made
+// in frontmatter (depends-on, ...) has no sentence and is left without one.
+func fillBacklinkContexts(db *sql.DB, links []noteBacklink) error {
+	bodies := map[string]string{}
+	for i, l := range links {
+		body, ok := bodies[l.Src]
+		if !ok {
+			err := db.QueryRow(`SELECT body FROM nodes WHERE id = ?`, l.Src).Scan(&body)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("load body of %q: %w", l.Src, err)
+			}
+			bodies[l.Src] = body
+		}
+		links[i].Context = linkContext(body, l.dst)
+	}
+	return nil
+}
+
+// linkContext returns the sentence of body holding the first [[dst]],
+// [[dst|label]], or [[dst#heading]] link (outside code, as the index reads
+// it: node.CodeMaskedLines), with list
+// and heading markers trimmed and long text cut to noteContextMaxRunes. It
+// returns "" when body has no such link.
+func linkContext(body, dst string) string {
+	lines := strings.Split(body, "\n")
+	for i, masked := range node.CodeMaskedLines(body) {
+		line := lines[i]
+		for _, m := range noteContextLinkRe.FindAllStringSubmatchIndex(masked, -1) {
+			inner := line[m[2]:m[3]]
+			if i := strings.IndexAny(inner, "|#"); i >= 0 {
+				inner = inner[:i]
+			}
+			if strings.TrimSpace(inner) == dst {
+				return clipSentence(line, m[0], m[1])
+			}
+		}
+	}
+	return ""
+}
+
+// clipSentence narrows line to the sentence spanning line[start:end],
+// trims list, task, quote, and heading markers, and cuts what remains
+// around that span to noteContextMaxRunes.
+func clipSentence(line string, start, end int) string {
+	from := 0
+	for i := start - 1; i > 0; i-- {
+		if strings.ContainsRune(".!?", rune(line[i-1])) && line[i] == ' ' {
+			from = i + 1
+			break
+		}
+	}
+	to := len(line)
+	for i := end; i < len(line); i++ {
+		if strings.ContainsRune(".!?", rune(line[i])) && (i+1 == len(line) || line[i+1] == ' ') {
+			to = i + 1
+			break
+		}
+	}
+	s := line[from:to]
+	start -= from
+	if from == 0 {
+		trimmed := strings.TrimLeft(s, " \t")
+		for _, marker := range []string{"- [ ] ", "- [x] ", "- ", "* ", "> ", "# ", "## ", "### "} {
+			if strings.HasPrefix(trimmed, marker) {
+				trimmed = trimmed[len(marker):]
+				break
+			}
+		}
+		start -= len(s) - len(trimmed)
+		s = trimmed
+	}
+	s = strings.TrimRight(s, " \t")
+
+	runes := []rune(s)
+	if len(runes) <= noteContextMaxRunes {
+		return s
+	}
+	// Center the window on the link's start so the link itself survives.
+	at := len([]rune(s[:max(start, 0)]))
+	lo := max(0, at-noteContextMaxRunes/3)
+	hi := min(len(runes), lo+noteContextMaxRunes)
+	lo = max(0, hi-noteContextMaxRunes)
+	out := strings.TrimSpace(string(runes[lo:hi]))
+	if lo > 0 {
+		out = "…" + out
+	}
+	if hi < len(runes) {
+		out += "…"
+	}
+	return out
+}
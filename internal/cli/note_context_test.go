@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNoteShowContext: --context adds the sentence around each backlink's
+// [[link]], trimmed of list markers and cut when long, and only on request;
+// a [[link]] in inline code, which the index ignores, is never the context.
+func TestNoteShowContext(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	long := "This sentence rambles " + strings.Repeat("on and on ", 20) + "before citing [[reading-list]] at last."
+	for _, n := range [][2]string{
+		{"Reading List", "Books to read."},
+		{"Essay Draft", "Intro first. Drafted from [[reading-list|my list]] yesterday! Then edits."},
+		{"Rambling", long},
+		{"Syntax Guide", "Write `[[reading-list]]` to link. The real one: [[reading-list]] here."},
+	} {
+		if _, _, err := runNote(t, vault, "create", n[0], "--body", n[1]); err != nil {
+			t.Fatalf("note create %s: %v", n[0], err)
+		}
+		resetCLIFlags()
+	}
+	if _, stderr, err := runAdd(t, vault, "--", "- picked a book from [[reading-list]]"); err != nil {
+		t.Fatalf("add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+
+	out, _, err := runNote(t, vault, "show", "reading-list", "--links-only", "--context", "--json")
+	if err != nil {
+		t.Fatalf("note show --context: %v", err)
+	}
+	var res noteLinksResult
+	mustDecodeJSON(t, out, &res)
+	contexts := map[string]string{}
+	for _, l := range res.Backlinks {
+		contexts[l.SrcType+" "+l.SrcLabel] = l.Context
+	}
+	if got := contexts["note Essay Draft"]; got != "Drafted from [[reading-list|my list]] yesterday!" {
+		t.Errorf("essay context = %q", got)
+	}
+	if got := contexts["note Syntax Guide"]; got != "The real one: [[reading-list]] here." {
+		t.Errorf("context skipping inline code = %q", got)
+	}
+	if got := contexts["note Rambling"]; !strings.HasPrefix(got, "…") || !strings.Contains(got, "[[reading-list]] at last.") ||
+		len([]rune(got)) > noteContextMaxRunes+1 {
+		t.Errorf("long context = %q", got)
+	}
+	var entry string
+	for k, v := range contexts {
+		if strings.HasPrefix(k, "log-entry ") {
+			entry = v
+		}
+	}
+	if entry != "picked a book from [[reading-list]]" {
+		t.Errorf("log entry context = %q (all: %v)", entry, contexts)
+	}
+
+	resetCLIFlags()
+	pretty, _, err := runNote(t, vault, "show", "reading-list", "--context")
+	if err != nil {
+		t.Fatalf("note show --context: %v", err)
+	}
+	if !strings.Contains(pretty, "backlinks: 4\n") || !strings.Contains(pretty, `<- references Essay Draft: "`) {
+		t.Errorf("note show --context output:\n%s", pretty)
+	}
+
+	resetCLIFlags()
+	if out, _, err = runNote(t, vault, "show", "reading-list", "--json"); err != nil || strings.Contains(out, `"context"`) {
+		t.Errorf("note show without --context = %s, %v", out, err)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runNote(t, vault, "show", "reading-list", "--context", "--content-only"); err == nil {
+		t.Error("--context with --content-only: want an error")
+	}
+}
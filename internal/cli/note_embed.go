@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+var noteExpandFlag bool
+
+func init() {
+	noteShowCmd.Flags().BoolVar(&noteExpandFlag, "expand", false, "Print the note's body with each ![[slug]] embed replaced by that note's body")
+}
+
+// noteEmbedMaxDepth bounds how deeply `rk note show --expand` follows
+// embeds within embeds.
+const noteEmbedMaxDepth = 5
+
+// noteEmbedRe matches an Obsidian embed, ![[slug]], with an optional
+// #heading or |size suffix, which expansion ignores.
+var noteEmbedRe = regexp.MustCompile(`!\[\[([^\]|#]+)(?:[#|][^\]]*)?\]\]`)
+
+// expandNoteEmbeds replaces each ![[slug]] in body (outside code, as the
+// index reads it: node.CodeMaskedLines) with the referenced note's body between "(embed of slug)" and "(end of
+// slug)" markers, recursively to noteEmbedMaxDepth. visited holds the IDs
+// of the notes already being expanded above this body: embedding one of
+// them again would cycle, so it is left marked instead. A ref that matches
+// no note renders as "(missing: slug)".
+func expandNoteEmbeds(db *sql.DB, body string, visited []string) (string, error) {
+	lines := strings.Split(body, "\n")
+	for i, masked := range node.CodeMaskedLines(body) {
+		var b strings.Builder
+		last := 0
+		for _, m := range noteEmbedRe.FindAllStringSubmatchIndex(masked, -1) {
+			out, err := expandNoteEmbed(db, strings.TrimSpace(masked[m[2]:m[3]]), visited)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(lines[i][last:m[0]])
+			b.WriteString(out)
+			last = m[1]
+		}
+		if last > 0 {
+			b.WriteString(lines[i][last:])
+			lines[i] = b.String()
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// expandNoteEmbed renders one embed of ref for expandNoteEmbeds.
+func expandNoteEmbed(db *sql.DB, ref string, visited []string) (string, error) {
+	var id, body string
+	err := db.QueryRow(
+		`SELECT id, body FROM nodes
+		 WHERE (ulid = ? OR EXISTS (SELECT 1 FROM aliases a WHERE a.alias = ? AND a.id = nodes.id))
+		   AND loc LIKE 'notes/%'
+		 LIMIT 1`, ref, ref).Scan(&id, &body)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Sprintf("(missing: %s)", ref), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("look up embed %q: %w", ref, err)
+	}
+	if slices.Contains(visited, id) {
+		return fmt.Sprintf("(embed cycle: %s)", ref), nil
+	}
+	if len(visited) > noteEmbedMaxDepth {
+		return fmt.Sprintf("(embed too deep: %s)", ref), nil
+	}
+	inner, err := expandNoteEmbeds(db, strings.TrimSpace(body), append(visited, id))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(embed of %s)\n%s\n(end of %s)", ref, inner, ref), nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNoteShowExpand: --expand inlines ![[slug]] embeds recursively with
+// markers, breaks cycles, marks missing refs, and leaves code (fenced or
+// inline) and the plain show alone.
+func TestNoteShowExpand(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	for _, n := range [][2]string{
+		{"Trip Plan", "Plan:\n![[packing-list]]\n\n```\n![[packing-list]]\n```\nWrite `![[packing-list]]` to embed.\nMissing ![[no-such-note]]."},
+		{"Packing List", "- boots\n![[first-aid|200]]"},
+		{"First Aid", "- plasters\n![[packing-list]]"},
+	} {
+		if _, _, err := runNote(t, vault, "create", n[0], "--body", n[1]); err != nil {
+			t.Fatalf("note create %s: %v", n[0], err)
+		}
+		resetCLIFlags()
+	}
+
+	out, _, err := runNote(t, vault, "show", "trip-plan", "--expand")
+	if err != nil {
+		t.Fatalf("note show --expand: %v", err)
+	}
+	want := "Plan:\n" +
+		"(embed of packing-list)\n- boots\n" +
+		"(embed of first-aid)\n- plasters\n(embed cycle: packing-list)\n(end of first-aid)\n" +
+		"(end of packing-list)\n\n" +
+		"```\n![[packing-list]]\n```\n" +
+		"Write `![[packing-list]]` to embed.\n" +
+		"Missing (missing: no-such-note)."
+	if got := strings.TrimSpace(out); got != want {
+		t.Errorf("note show --expand:\n%s\nwant\n%s", got, want)
+	}
+
+	resetCLIFlags()
+	out, _, err = runNote(t, vault, "show", "trip-plan", "--content-only")
+	if err != nil {
+		t.Fatalf("note show --content-only: %v", err)
+	}
+	if !strings.Contains(out, "Plan:\n![[packing-list]]") {
+		t.Errorf("note show --content-only expanded embeds:\n%s", out)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runNote(t, vault, "show", "trip-plan", "--expand", "--links-only"); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--expand with --links-only: err = %v, want mutually exclusive", err)
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var noteOrphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List notes with no links in or out",
+	Long: "List the notes under notes/ that have no forward links and no backlinks: ideas not yet " +
+		"connected to anything. This is not the same as a broken link -- a note whose only links are " +
+		"unresolved still links out, so it is not an orphan.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runNoteOrphansE,
+}
+
+// noteOrphansResult is the structured summary of one `rk note orphans` run.
+type noteOrphansResult struct {
+	Notes []noteOrphan `json:"notes"`
+}
+
+// noteOrphan is one unlinked note in a noteOrphansResult.
+type noteOrphan struct {
+	ID    string `json:"id"`
+	Path  string `json:"path"`
+	Title string `json:"title,omitempty"`
+}
+
+func (r noteOrphansResult) Pretty() string {
+	if len(r.Notes) == 0 {
+		return "note: no orphan notes"
+	}
+	lines := make([]string, 0, len(r.Notes))
+	for _, n := range r.Notes {
+		line := n.Path
+		if n.Title != "" {
+			line += "  " + n.Title
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runNoteOrphansE(cmd *cobra.Command, args []string) error {
+	defer resetNoteFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return fmt.Errorf("note orphans: %w", err)
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("note orphans: load config: %w", err)
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("note orphans: open index: %w", err)
+	}
+	defer ix.Close()
+
+	if _, err := ix.Reconcile(); err != nil {
+		return fmt.Errorf("note orphans: reconcile index: %w", err)
+	}
+
+	notes, err := loadOrphanNotes(ix.DB())
+	if err != nil {
+		return err
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(noteOrphansResult{Notes: notes}); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadOrphanNotes returns the notes under notes/ that are no edge's source
+// and no resolved edge's target, ordered by path, in one query rather than
+// a forward-link and backlink lookup per note. Generated index.md catalogs
+// are skipped.
+func loadOrphanNotes(db *sql.DB) ([]noteOrphan, error) {
+	rows, err := db.Query(`SELECT n.id, n.loc, COALESCE(p.value, '') FROM nodes n
+		LEFT JOIN node_props p ON p.id = n.id AND p.key = 'title'
+		WHERE n.type = 'note' AND n.loc LIKE 'notes/%'
+		  AND NOT EXISTS (SELECT 1 FROM edges e WHERE e.src = n.id)
+		  AND NOT EXISTS (SELECT 1 FROM edges e WHERE e.dst_key = n.id)
+		ORDER BY n.loc`)
+	if err != nil {
+		return nil, fmt.Errorf("note orphans: query notes: %w", err)
+	}
+	defer rows.Close()
+	notes := []noteOrphan{}
+	for rows.Next() {
+		var it noteOrphan
+		if err := rows.Scan(&it.ID, &it.Path, &it.Title); err != nil {
+			return nil, fmt.Errorf("note orphans: scan note: %w", err)
+		}
+		it.Path = filepath.ToSlash(it.Path)
+		if filepath.Base(it.Path) == "index.md" {
+			continue // generated catalog file, never a note of its own
+		}
+		notes = append(notes, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("note orphans: iterate notes: %w", err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import "testing"
+
+// TestNoteOrphans: only notes with neither forward links nor backlinks are
+// listed; a link in either direction, even an unresolved one, connects a
+// note.
+func TestNoteOrphans(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	for _, n := range [][]string{
+		{"Hub", "Points at [[spoke]]."},
+		{"Spoke", "Linked from the hub."},
+		{"Dreamer", "Wants [[nowhere]]."},
+		{"Island", "Nobody knows me."},
+	} {
+		resetCLIFlags()
+		if _, stderr, err := runNote(t, vault, "create", n[0], "--body", n[1]); err != nil {
+			t.Fatalf("rk note create %s: %v\nstderr: %s", n[0], err, stderr)
+		}
+	}
+
+	resetCLIFlags()
+	out, stderr, err := runNote(t, vault, "orphans", "--json")
+	if err != nil {
+		t.Fatalf("rk note orphans: %v\nstderr: %s", err, stderr)
+	}
+	var res noteOrphansResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Notes) != 1 || res.Notes[0].Path != "notes/island.md" || res.Notes[0].Title != "Island" {
+		t.Errorf("orphans = %+v, want only notes/island.md", res.Notes)
+	}
+}
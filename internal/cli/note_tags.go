@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	noteUntaggedFlag bool
+	noteCreatedFlag  string
+)
+
+var noteTagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Summarize note tagging: tagged vs untagged, and how often each tag is used",
+	Long: "Count the notes under notes/ that carry tags and those that do not, and how many notes use " +
+		"each tag, most used first. --untagged lists the untagged notes instead, to tag them. --created " +
+		"limits either to notes created in a date range (today, this-week, YYYY-MM-DD, or a range like " +
+		"2026-01-01..2026-01-31 or -30d..today).",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runNoteTagsE,
+}
+
+func init() {
+	f := noteTagsCmd.Flags()
+	f.BoolVar(&noteUntaggedFlag, "untagged", false, "List the notes with no tags instead of the summary")
+	f.StringVar(&noteCreatedFlag, "created", "", "Only count notes created in this date range")
+}
+
+// noteTagStats is `rk note tags`'s summary.
+type noteTagStats struct {
+	Notes    int        `json:"notes"`
+	Tagged   int        `json:"tagged"`
+	Untagged int        `json:"untagged"`
+	Tags     []tagCount `json:"tags"` // most used first, then by name
+}
+
+// tagCount is how many notes carry one tag.
+type tagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+func (s noteTagStats) Pretty() string {
+	if s.Notes == 0 {
+		return "note: no notes"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "note: %d note(s), %d tagged, %d untagged (%d%% tagged)",
+		s.Notes, s.Tagged, s.Untagged, s.Tagged*100/s.Notes)
+	for _, t := range s.Tags {
+		fmt.Fprintf(&b, "\n  %4d  #%s", t.Count, t.Tag)
+	}
+	return b.String()
+}
+
+// noteUntaggedResult is `rk note tags --untagged`'s output.
+type noteUntaggedResult struct {
+	Notes []noteTagsItem `json:"notes"`
+}
+
+// noteTagsItem is one note as `rk note tags` sees it.
+type noteTagsItem struct {
+	ID      string   `json:"id"`
+	Path    string   `json:"path"`
+	Title   string   `json:"title,omitempty"`
+	Created string   `json:"created,omitempty"` // YYYY-MM-DD
+	Tags    []string `json:"-"`
+}
+
+func (r noteUntaggedResult) Pretty() string {
+	if len(r.Notes) == 0 {
+		return "note: no untagged notes"
+	}
+	lines := make([]string, 0, len(r.Notes))
+	for _, n := range r.Notes {
+		line := n.Path
+		if n.Title != "" {
+			line += "  " + n.Title
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runNoteTagsE(cmd *cobra.Command, args []string) error {
+	defer resetNoteFlags(cmd)
+
+	var created *dateRange
+	if noteCreatedFlag != "" {
+		r, err := parseDateFilter(noteCreatedFlag, journalNow())
+		if err != nil {
+			return fmt.Errorf("note tags: --created: %w", err)
+		}
+		created = &r
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return fmt.Errorf("note tags: %w", err)
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("note tags: load config: %w", err)
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("note tags: open index: %w", err)
+	}
+	defer ix.Close()
+
+	if _, err := ix.Reconcile(); err != nil {
+		return fmt.Errorf("note tags: reconcile index: %w", err)
+	}
+
+	notes, err := loadNoteTags(ix.DB())
+	if err != nil {
+		return err
+	}
+	if created != nil {
+		kept := notes[:0]
+		for _, n := range notes {
+			if created.contains(n.Created) {
+				kept = append(kept, n)
+			}
+		}
+		notes = kept
+	}
+
+	var res any
+	if noteUntaggedFlag {
+		untagged := noteUntaggedResult{Notes: []noteTagsItem{}}
+		for _, n := range notes {
+			if len(n.Tags) == 0 {
+				untagged.Notes = append(untagged.Notes, n)
+			}
+		}
+		res = untagged
+	} else {
+		res = summarizeNoteTags(notes)
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// summarizeNoteTags counts notes' tagging into a noteTagStats.
+func summarizeNoteTags(notes []noteTagsItem) noteTagStats {
+	s := noteTagStats{Notes: len(notes), Tags: []tagCount{}}
+	counts := map[string]int{}
+	for _, n := range notes {
+		if len(n.Tags) == 0 {
+			s.Untagged++
+			continue
+		}
+		s.Tagged++
+		for _, t := range n.Tags {
+			counts[t]++
+		}
+	}
+	for tag, n := range counts {
+		s.Tags = append(s.Tags, tagCount{Tag: tag, Count: n})
+	}
+	sort.Slice(s.Tags, func(i, j int) bool {
+		if s.Tags[i].Count != s.Tags[j].Count {
+			return s.Tags[i].Count > s.Tags[j].Count
+		}
+		return s.Tags[i].Tag < s.Tags[j].Tag
+	})
+	return s
+}
+
+// loadNoteTags returns every note under notes/ with its title, creation
+// date, and tags, ordered by path. Generated index.md catalogs are
+// skipped, as in loadOrphanNotes.
+func loadNoteTags(db *sql.DB) ([]noteTagsItem, error) {
+	rows, err := db.Query(`SELECT n.id, n.loc, n.time, COALESCE(t.value, ''), COALESCE(g.value, '') FROM nodes n
+		LEFT JOIN node_props t ON t.id = n.id AND t.key = 'title'
+		LEFT JOIN node_props g ON g.id = n.id AND g.key = 'tags'
+		WHERE n.type = 'note' AND n.loc LIKE 'notes/%'
+		ORDER BY n.loc`)
+	if err != nil {
+		return nil, fmt.Errorf("note tags: query notes: %w", err)
+	}
+	defer rows.Close()
+	notes := []noteTagsItem{}
+	for rows.Next() {
+		var it noteTagsItem
+		var created, tags string
+		if err := rows.Scan(&it.ID, &it.Path, &created, &it.Title, &tags); err != nil {
+			return nil, fmt.Errorf("note tags: scan note: %w", err)
+		}
+		it.Path = filepath.ToSlash(it.Path)
+		if filepath.Base(it.Path) == "index.md" {
+			continue
+		}
+		if len(created) >= len("2006-01-02") {
+			it.Created = created[:len("2006-01-02")]
+		}
+		it.Tags = parseTagList(tags)
+		notes = append(notes, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("note tags: iterate notes: %w", err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestNoteTags: the summary counts tagged and untagged notes and each tag's
+// use, --untagged lists the untagged notes, and --created narrows both.
+func TestNoteTags(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	for _, n := range [][]string{
+		{"notes/alpha.md", "2026-07-01T09:00:00Z", "tags: [go, tools]"},
+		{"notes/beta.md", "2026-07-09T09:00:00Z", "tags: [go]"},
+		{"notes/gamma.md", "2026-07-09T10:00:00Z", ""},
+		{"notes/delta.md", "2026-06-01T10:00:00Z", ""},
+	} {
+		fm := []string{"time: " + n[1]}
+		if n[2] != "" {
+			fm = append(fm, n[2])
+		}
+		writeTestNode(t, vault, n[0], node.Mint(), "note", "Body.", fm...)
+	}
+
+	run := func(v any, args ...string) {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runNote(t, vault, append([]string{"tags", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("rk note tags %v: %v\nstderr: %s", args, err, stderr)
+		}
+		mustDecodeJSON(t, out, v)
+	}
+
+	var stats noteTagStats
+	run(&stats)
+	if stats.Notes != 4 || stats.Tagged != 2 || stats.Untagged != 2 {
+		t.Errorf("stats = %+v, want 4 notes, 2 tagged, 2 untagged", stats)
+	}
+	if len(stats.Tags) != 2 || stats.Tags[0] != (tagCount{Tag: "go", Count: 2}) || stats.Tags[1] != (tagCount{Tag: "tools", Count: 1}) {
+		t.Errorf("tags = %+v, want go 2, tools 1", stats.Tags)
+	}
+
+	var untagged noteUntaggedResult
+	run(&untagged, "--untagged")
+	if len(untagged.Notes) != 2 || untagged.Notes[0].Path != "notes/delta.md" || untagged.Notes[1].Path != "notes/gamma.md" {
+		t.Errorf("--untagged = %+v, want delta and gamma", untagged.Notes)
+	}
+
+	untagged = noteUntaggedResult{}
+	run(&untagged, "--untagged", "--created", "this-week")
+	if len(untagged.Notes) != 1 || untagged.Notes[0].Path != "notes/gamma.md" {
+		t.Errorf("--untagged --created this-week = %+v, want only gamma", untagged.Notes)
+	}
+
+	stats = noteTagStats{}
+	run(&stats, "--created", "2026-07-01..2026-07-31")
+	if stats.Notes != 3 || stats.Untagged != 1 {
+		t.Errorf("--created July = %+v, want 3 notes, 1 untagged", stats)
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var noteTouchCmd = &cobra.Command{
+	Use:   "touch <ref>",
+	Short: "Mark a note as recently revisited without editing its body",
+	Long: "Set the note's updated: frontmatter field to now, leaving its body alone, " +
+		"then reconcile the index so links changed out of band since the last index are picked up.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runNoteTouchE,
+}
+
+// noteTouchResult is the structured summary of one `rk note touch` run.
+type noteTouchResult struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Updated string `json:"updated"` // RFC3339, UTC
+}
+
+func (r noteTouchResult) Pretty() string {
+	return fmt.Sprintf("note: touched %s (updated %s)", r.Path, r.Updated)
+}
+
+func runNoteTouchE(cmd *cobra.Command, args []string) error {
+	defer resetNoteFlags(cmd)
+	ref := args[0]
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return fmt.Errorf("note touch: %w", err)
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("note touch: load config: %w", err)
+	}
+
+	notesDir := filepath.Join(cfg.VaultDir, "notes")
+	if noteMatchFlag {
+		if ref, err = resolveNoteMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("note touch: %w", err)
+		}
+	}
+	n, path, err := findNoteByRefOrAlias(notesDir, ref)
+	if err != nil {
+		return fmt.Errorf("note touch: scan notes dir: %w", err)
+	}
+	if n == nil {
+		return fmt.Errorf("note touch: no note found matching %q (not found)", ref)
+	}
+
+	updated := todoNow().UTC().Format(time.RFC3339)
+	if err := setOrInsertField(n, "updated", updated); err != nil {
+		return fmt.Errorf("note touch: set updated: %w", err)
+	}
+	if err := writeFileAtomic(path, n.Serialize()); err != nil {
+		return fmt.Errorf("note touch: write: %w", err)
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("note touch: open index: %w", err)
+	}
+	defer ix.Close()
+	if _, err := ix.Reconcile(); err != nil {
+		return fmt.Errorf("note touch: reconcile index: %w", err)
+	}
+
+	res := noteTouchResult{ID: n.ULID, Path: relTodoPath(cfg.VaultDir, path), Updated: updated}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
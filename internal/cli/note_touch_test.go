@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNoteTouch_SetsUpdated: touch stamps updated: in the note's frontmatter
+// (resolving --match refs), leaves the body alone, and re-indexes the file
+// so an out-of-band link edit shows up in `note show`.
+func TestNoteTouch_SetsUpdated(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	out, stderr, err := runNote(t, vault, "create", "Reading List", "--body", "Start here.", "--json")
+	if err != nil {
+		t.Fatalf("rk note create: %v\nstderr: %s", err, stderr)
+	}
+	var created noteCreateResult
+	mustDecodeJSON(t, out, &created)
+	resetCLIFlags()
+
+	path := vault + "/" + created.Path
+	src := strings.Replace(mustReadFile(t, path), "Start here.", "Start here. See [[other-note]].", 1)
+	mustWriteFile(t, path, src)
+
+	out, stderr, err = runNote(t, vault, "touch", "reading", "--match", "--json")
+	if err != nil {
+		t.Fatalf("rk note touch --match: %v\nstderr: %s", err, stderr)
+	}
+	var res noteTouchResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != created.ID || res.Updated != "2026-07-10T00:00:00Z" {
+		t.Errorf("touch result = %+v, want %s updated 2026-07-10T00:00:00Z", res, created.ID)
+	}
+	touched := mustReadFile(t, path)
+	if !strings.Contains(touched, "updated: 2026-07-10T00:00:00Z\n") || !strings.Contains(touched, "Start here. See [[other-note]].") {
+		t.Errorf("touched note should gain updated: and keep its body:\n%s", touched)
+	}
+	resetCLIFlags()
+
+	out, _, err = runNote(t, vault, "show", "reading-list", "--json")
+	if err != nil {
+		t.Fatalf("rk note show: %v", err)
+	}
+	var shown noteShowResult
+	mustDecodeJSON(t, out, &shown)
+	if shown.Updated != "2026-07-10T00:00:00Z" || len(shown.ForwardLinks) != 1 {
+		t.Errorf("show after touch = %+v, want updated set and the new link indexed", shown)
+	}
+	resetCLIFlags()
+
+	if _, _, err := runNote(t, vault, "touch", "no-such-note"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("touching a missing note: err = %v, want not found", err)
+	}
+}
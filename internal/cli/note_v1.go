@@ -28,15 +28,21 @@ import (
 // ─────────────────────────────────────────────────────────────────────────────
 
 var (
-	noteDescriptionFlag string
-	noteStageFlag       string
-	noteTagFlag         []string
-	noteAliasFlag       []string
-	noteSlugFlag        string
-	noteDirFlag         string
-	noteBodyFlag        string
-	noteTypeFlag        string
-	noteAuthorFlag      string
+	noteDescriptionFlag    string
+	noteStageFlag          string
+	noteTagFlag            []string
+	noteAliasFlag          []string
+	noteSlugFlag           string
+	noteDirFlag            string
+	noteBodyFlag           string
+	noteTypeFlag           string
+	noteAuthorFlag         string
+	noteStdinFlag          bool
+	noteMatchFlag          bool
+	noteContentOnlyFlag    bool
+	noteLinksOnlyFlag      bool
+	noteStrictMatchFlag    bool
+	noteMatchThresholdFlag int
 )
 
 // resetNoteFlags restores note flag variables to their defaults and clears
@@ -52,7 +58,17 @@ func resetNoteFlags(cmd *cobra.Command) {
 	noteBodyFlag = ""
 	noteTypeFlag = ""
 	noteAuthorFlag = ""
-	for _, name := range []string{"description", "stage", "tag", "alias", "slug", "dir", "body", "type", "author"} {
+	noteStdinFlag = false
+	noteMatchFlag = false
+	noteContentOnlyFlag = false
+	noteLinksOnlyFlag = false
+	noteStrictMatchFlag = false
+	noteMatchThresholdFlag = 0
+	noteUntaggedFlag = false
+	noteCreatedFlag = ""
+	noteExpandFlag = false
+	noteContextFlag = false
+	for _, name := range []string{"description", "stage", "tag", "alias", "slug", "dir", "body", "type", "author", "stdin", "match", "content-only", "links-only", "strict-match", "match-threshold", "untagged", "created", "expand", "context"} {
 		if fl := cmd.Flags().Lookup(name); fl != nil {
 			fl.Changed = false
 		}
@@ -127,10 +143,21 @@ func init() {
 	cf.StringVar(&noteSlugFlag, "slug", "", "Override the self-minted slug (escape hatch for a colliding title)")
 	cf.StringVar(&noteDirFlag, "dir", "", "Subdirectory under notes/ to place the note in")
 	cf.StringVar(&noteBodyFlag, "body", "", "Body text (may contain [[wikilinks]])")
+	cf.BoolVar(&noteStdinFlag, "stdin", false, "Read the body text from stdin (pipe it in; exclusive with --body)")
 	cf.StringVar(&noteTypeFlag, "type", "", "Node type (default: note)")
 	cf.StringVar(&noteAuthorFlag, "author", "", "Author to record (default: $RECKON_AUTHOR, $USER, or \"local\")")
 
-	noteCmd.AddCommand(noteCreateCmd, noteShowCmd, noteRenameCmd, noteIndexCmd)
+	for _, c := range []*cobra.Command{noteShowCmd, noteRenameCmd, noteTouchCmd} {
+		c.Flags().BoolVar(&noteMatchFlag, "match", false, "Treat <ref> as a fuzzy query against note titles")
+		c.Flags().BoolVar(&noteStrictMatchFlag, "strict-match", false, "With --match, fail on any ambiguity instead of picking a clearly best match")
+		c.Flags().IntVar(&noteMatchThresholdFlag, "match-threshold", 0, "With --match, the lowest fuzzy score that counts as a match (default: match_threshold setting)")
+	}
+
+	sf := noteShowCmd.Flags()
+	sf.BoolVar(&noteContentOnlyFlag, "content-only", false, "Print only the note's body, without frontmatter or links")
+	sf.BoolVar(&noteLinksOnlyFlag, "links-only", false, "Print only the note's forward links and backlinks")
+
+	noteCmd.AddCommand(noteCreateCmd, noteShowCmd, noteRenameCmd, noteIndexCmd, noteTouchCmd, noteOrphansCmd, noteTagsCmd)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -157,10 +184,42 @@ type noteForwardLink struct {
 }
 
 // noteBacklink is one incoming edge in a noteShowResult (index-derived only,
-// never stored on the target note's own file).
+// never stored on the target note's own file). SrcType is the linking node's
+// type (note, todo, log-entry, ...) and SrcLabel a human name for it: a
+// note's or todo's title, or a log entry's date and time. Context, filled
+// only by `rk note show --context`, is the sentence around the link.
 type noteBacklink struct {
-	Src string `json:"src"`
-	Rel string `json:"rel"`
+	Src      string `json:"src"`
+	Rel      string `json:"rel"`
+	SrcType  string `json:"src_type,omitempty"`
+	SrcLabel string `json:"src_label,omitempty"`
+	Context  string `json:"context,omitempty"`
+
+	dst string // the link target as written, to find it in the source's body
+}
+
+// describe renders a backlink's source for display: its label, qualified by
+// its type when that is not a note ("todo finish the draft", "log-entry
+// 2026-01-15 09:30"), falling back to the raw source ID.
+func (l noteBacklink) describe() string {
+	label := l.SrcLabel
+	if label == "" {
+		label = l.Src
+	}
+	if l.SrcType == "" || l.SrcType == "note" {
+		return label
+	}
+	return l.SrcType + " " + label
+}
+
+// line renders a backlink as "<- rel source", followed by its context when
+// it has one.
+func (l noteBacklink) line() string {
+	s := fmt.Sprintf("<- %s %s", l.Rel, l.describe())
+	if l.Context != "" {
+		s += fmt.Sprintf(": %q", l.Context)
+	}
+	return s
 }
 
 // noteShowResult is the structured summary of one `rk note show` run.
@@ -170,6 +229,7 @@ type noteShowResult struct {
 	Title        string            `json:"title,omitempty"`
 	Description  string            `json:"description,omitempty"`
 	Stage        string            `json:"stage,omitempty"`
+	Updated      string            `json:"updated,omitempty"` // last `rk note touch`, RFC3339
 	Aliases      []string          `json:"aliases,omitempty"`
 	Path         string            `json:"path"`
 	ForwardLinks []noteForwardLink `json:"forward_links"`
@@ -183,9 +243,45 @@ func (r noteShowResult) Pretty() string {
 		fmt.Fprintf(&b, "\n  title: %s", r.Title)
 	}
 	fmt.Fprintf(&b, "\n  forward_links: %d, backlinks: %d", len(r.ForwardLinks), len(r.Backlinks))
+	for _, l := range r.Backlinks {
+		if l.Context != "" {
+			fmt.Fprintf(&b, "\n  %s", l.line())
+		}
+	}
 	return b.String()
 }
 
+// noteContentResult is `rk note show --content-only`'s output: the note's
+// body with its frontmatter stripped, printed bare in pretty mode.
+type noteContentResult struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+func (r noteContentResult) Pretty() string { return r.Body }
+
+// noteLinksResult is `rk note show --links-only`'s output: the same link
+// lists noteShowResult carries, without the note's fields.
+type noteLinksResult struct {
+	ID           string            `json:"id"`
+	ForwardLinks []noteForwardLink `json:"forward_links"`
+	Backlinks    []noteBacklink    `json:"backlinks"`
+}
+
+func (r noteLinksResult) Pretty() string {
+	var lines []string
+	for _, l := range r.ForwardLinks {
+		lines = append(lines, fmt.Sprintf("-> %s %s", l.Rel, l.Dst))
+	}
+	for _, l := range r.Backlinks {
+		lines = append(lines, l.line())
+	}
+	if len(lines) == 0 {
+		return "note: no links"
+	}
+	return strings.Join(lines, "\n")
+}
+
 // noteRenameResult is the structured summary of one `rk note rename` run.
 type noteRenameResult struct {
 	ID      string `json:"id"`
@@ -254,11 +350,28 @@ func runNoteCreateE(cmd *cobra.Command, args []string) error {
 	description := strings.TrimSpace(noteDescriptionFlag)
 	dir := strings.TrimSpace(noteDirFlag)
 	body := noteBodyFlag
+	if noteStdinFlag {
+		if noteBodyFlag != "" {
+			return fmt.Errorf("note create: --stdin and --body are mutually exclusive")
+		}
+		in := cmd.InOrStdin()
+		if isTerminal(in) {
+			return fmt.Errorf("note create: --stdin needs piped input (e.g. echo \"# Body\" | rk note create <title> --stdin)")
+		}
+		raw, err := readStdinAll(in)
+		if err != nil {
+			return fmt.Errorf("note create: %w", err)
+		}
+		body = string(raw)
+		if strings.TrimSpace(body) == "" {
+			return fmt.Errorf("note create: no body text on stdin")
+		}
+	}
 	if body != "" && !strings.HasSuffix(body, "\n") {
 		body += "\n"
 	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return fmt.Errorf("note create: %w", err)
 	}
@@ -287,7 +400,7 @@ func runNoteCreateE(cmd *cobra.Command, args []string) error {
 	}
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return fmt.Errorf("print result: %w", err)
 		}
 	}
@@ -402,7 +515,17 @@ func runNoteShowE(cmd *cobra.Command, args []string) error {
 	defer resetNoteFlags(cmd)
 	ref := args[0]
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	if noteContentOnlyFlag && noteLinksOnlyFlag {
+		return fmt.Errorf("note show: --content-only and --links-only are mutually exclusive")
+	}
+	if noteExpandFlag && noteLinksOnlyFlag {
+		return fmt.Errorf("note show: --expand and --links-only are mutually exclusive")
+	}
+	if noteContextFlag && (noteContentOnlyFlag || noteExpandFlag) {
+		return fmt.Errorf("note show: --context cannot be combined with --content-only or --expand")
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return fmt.Errorf("note show: %w", err)
 	}
@@ -412,6 +535,12 @@ func runNoteShowE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("note show: load config: %w", err)
 	}
 
+	if noteMatchFlag {
+		if ref, err = resolveNoteMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("note show: %w", err)
+		}
+	}
+
 	ix, err := index.Open(cfg)
 	if err != nil {
 		return fmt.Errorf("note show: open index: %w", err)
@@ -423,19 +552,31 @@ func runNoteShowE(cmd *cobra.Command, args []string) error {
 	}
 
 	db := ix.DB()
-	var id, typ, loc string
+	var id, typ, loc, body string
 	row := db.QueryRow(
-		`SELECT id, type, loc FROM nodes
+		`SELECT id, type, loc, body FROM nodes
 		 WHERE (ulid = ? OR EXISTS (SELECT 1 FROM aliases a WHERE a.alias = ? AND a.id = nodes.id))
 		   AND loc LIKE 'notes/%'
 		 LIMIT 1`, ref, ref)
-	if err := row.Scan(&id, &typ, &loc); err != nil {
+	if err := row.Scan(&id, &typ, &loc, &body); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return fmt.Errorf("note show: no note found matching %q (not found)", ref)
 		}
 		return fmt.Errorf("note show: query node: %w", err)
 	}
 
+	if noteExpandFlag {
+		// The note itself counts as visited, so an embed of it cycles.
+		expanded, err := expandNoteEmbeds(db, strings.TrimSpace(body), []string{id})
+		if err != nil {
+			return fmt.Errorf("note show: %w", err)
+		}
+		return printNoteShow(cmd, mode, noteContentResult{ID: id, Body: expanded})
+	}
+	if noteContentOnlyFlag {
+		return printNoteShow(cmd, mode, noteContentResult{ID: id, Body: strings.TrimSpace(body)})
+	}
+
 	props, err := loadProps(db, id)
 	if err != nil {
 		return fmt.Errorf("note show: %w", err)
@@ -456,6 +597,15 @@ func runNoteShowE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("note show: %w", err)
 	}
+	if noteContextFlag {
+		if err := fillBacklinkContexts(db, backlinks); err != nil {
+			return fmt.Errorf("note show: %w", err)
+		}
+	}
+
+	if noteLinksOnlyFlag {
+		return printNoteShow(cmd, mode, noteLinksResult{ID: id, ForwardLinks: forwardLinks, Backlinks: backlinks})
+	}
 
 	res := noteShowResult{
 		ID:           id,
@@ -463,14 +613,19 @@ func runNoteShowE(cmd *cobra.Command, args []string) error {
 		Title:        props["title"],
 		Description:  props["description"],
 		Stage:        props["stage"],
+		Updated:      props["updated"],
 		Aliases:      aliases,
 		Path:         filepath.ToSlash(loc),
 		ForwardLinks: forwardLinks,
 		Backlinks:    backlinks,
 	}
+	return printNoteShow(cmd, mode, res)
+}
 
+// printNoteShow prints one of show's result shapes, honoring --quiet.
+func printNoteShow(cmd *cobra.Command, mode output.Mode, res any) error {
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return fmt.Errorf("print result: %w", err)
 		}
 	}
@@ -498,19 +653,35 @@ func loadNoteForwardLinks(db *sql.DB, id string) ([]noteForwardLink, error) {
 	return links, nil
 }
 
+// loadNoteBacklinks returns the edges into id, each with its source node's
+// type and label: notes, todos, and log entries all link to notes with
+// [[slug]] in their text.
 func loadNoteBacklinks(db *sql.DB, id string) ([]noteBacklink, error) {
-	rows, err := db.Query("SELECT src, rel FROM edges WHERE dst_key = ?", id)
+	rows, err := db.Query(`SELECT e.src, e.rel, e.dst, COALESCE(n.type, ''), COALESCE(n.title, ''),
+		COALESCE(n.time, ''), COALESCE(p.value, '') FROM edges e
+		LEFT JOIN nodes n ON n.id = e.src
+		LEFT JOIN node_props p ON p.id = e.src AND p.key = 'title'
+		WHERE e.dst_key = ?`, id)
 	if err != nil {
 		return nil, fmt.Errorf("note show: load backlinks for %q: %w", id, err)
 	}
 	defer rows.Close()
 	links := []noteBacklink{}
 	for rows.Next() {
-		var src, rel string
-		if err := rows.Scan(&src, &rel); err != nil {
+		var l noteBacklink
+		var title, ts, propTitle string
+		if err := rows.Scan(&l.Src, &l.Rel, &l.dst, &l.SrcType, &title, &ts, &propTitle); err != nil {
 			return nil, fmt.Errorf("note show: scan backlink for %q: %w", id, err)
 		}
-		links = append(links, noteBacklink{Src: src, Rel: rel})
+		switch {
+		case l.SrcType == "log-entry" && len(ts) >= len("2006-01-02T15:04"):
+			l.SrcLabel = ts[:len("2006-01-02")] + " " + ts[len("2006-01-02T"):len("2006-01-02T15:04")]
+		case propTitle != "":
+			l.SrcLabel = propTitle
+		default:
+			l.SrcLabel = title
+		}
+		links = append(links, l)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("note show: iterate backlinks for %q: %w", id, err)
@@ -532,7 +703,7 @@ func runNoteRenameE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("note rename: %w", err)
 	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return fmt.Errorf("note rename: %w", err)
 	}
@@ -543,6 +714,11 @@ func runNoteRenameE(cmd *cobra.Command, args []string) error {
 	}
 
 	notesDir := filepath.Join(cfg.VaultDir, "notes")
+	if noteMatchFlag {
+		if ref, err = resolveNoteMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("note rename: %w", err)
+		}
+	}
 	n, path, err := findNoteByRefOrAlias(notesDir, ref)
 	if err != nil {
 		return fmt.Errorf("note rename: scan notes dir: %w", err)
@@ -612,7 +788,7 @@ func runNoteRenameE(cmd *cobra.Command, args []string) error {
 	}
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return fmt.Errorf("print result: %w", err)
 		}
 	}
@@ -718,7 +894,7 @@ func slugCollision(notesDir, candidateSlug, excludePath string) (bool, error) {
 func runNoteIndexE(cmd *cobra.Command, args []string) error {
 	defer resetNoteFlags(cmd)
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return fmt.Errorf("note index: %w", err)
 	}
@@ -855,7 +1031,7 @@ func runNoteIndexE(cmd *cobra.Command, args []string) error {
 
 	res := noteIndexResult{Files: written, Skipped: skipped, Removed: removed}
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return fmt.Errorf("print result: %w", err)
 		}
 	}
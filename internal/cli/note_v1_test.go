@@ -915,6 +915,89 @@ func TestNoteShow_ForwardAndBacklinks(t *testing.T) {
 	}
 }
 
+// TestNoteShow_BacklinksFromTodosAndLog: a [[slug]] in a todo's or a log
+// entry's text is a backlink from that todo or entry (not the entry's whole
+// log day), typed and labeled so show can say where the note is used.
+func TestNoteShow_BacklinksFromTodosAndLog(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	if _, stderr, err := runNote(t, vault, "create", "Reading List"); err != nil {
+		t.Fatalf("rk note create: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "add", "finish [[reading-list]]"); err != nil {
+		t.Fatalf("rk todo add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runAdd(t, vault, "--date", "2026-01-15", "--at", "09:30", "read from [[reading-list]]"); err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+
+	out, stderr, err := runNote(t, vault, "show", "reading-list", "--json")
+	if err != nil {
+		t.Fatalf("rk note show: %v\nstderr: %s", err, stderr)
+	}
+	var res noteShowResult
+	mustDecodeJSON(t, out, &res)
+	got := map[string]string{}
+	for _, bl := range res.Backlinks {
+		got[bl.SrcType] = bl.SrcLabel
+	}
+	if len(res.Backlinks) != 2 || got["todo"] != "finish [[reading-list]]" || got["log-entry"] != "2026-01-15 09:30" {
+		t.Errorf("backlinks = %+v, want one from the todo and one from the 09:30 log entry", res.Backlinks)
+	}
+	resetCLIFlags()
+
+	out, stderr, err = runNote(t, vault, "show", "reading-list", "--links-only")
+	if err != nil {
+		t.Fatalf("rk note show --links-only: %v\nstderr: %s", err, stderr)
+	}
+	if !strings.Contains(out, "<- references log-entry 2026-01-15 09:30") {
+		t.Errorf("--links-only output missing the log entry's backlink:\n%s", out)
+	}
+}
+
+// TestNoteShow_ContentOnlyAndLinksOnly: --content-only prints just the body,
+// --links-only just the link lists, and the two cannot be combined.
+func TestNoteShow_ContentOnlyAndLinksOnly(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	if _, stderr, err := runNote(t, vault, "create", "Note B"); err != nil {
+		t.Fatalf("rk note create B: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runNote(t, vault, "create", "Note A", "--body", "See [[note-b]]."); err != nil {
+		t.Fatalf("rk note create A: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+
+	out, stderr, err := runNote(t, vault, "show", "note-a", "--content-only")
+	if err != nil {
+		t.Fatalf("rk note show --content-only: %v\nstderr: %s", err, stderr)
+	}
+	if out != "See [[note-b]].\n" {
+		t.Errorf("--content-only output = %q, want just the body", out)
+	}
+	resetCLIFlags()
+
+	out, _, err = runNote(t, vault, "show", "note-a", "--links-only")
+	if err != nil {
+		t.Fatalf("rk note show --links-only: %v", err)
+	}
+	if out != "-> references note-b\n" {
+		t.Errorf("--links-only output = %q, want just the forward link", out)
+	}
+	resetCLIFlags()
+
+	if _, _, err := runNote(t, vault, "show", "note-a", "--content-only", "--links-only"); err == nil ||
+		!strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("combining --content-only and --links-only: err = %v, want a mutually exclusive error", err)
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // AC-7: create -> index -> query proven end to end (mirrors T4's own
 // capture->index->query precedent).
@@ -1051,3 +1134,47 @@ func TestNoteIndex_PrunesStaleGenerated(t *testing.T) {
 		t.Errorf("removed = %v, want [notes/sub/index.md]", res.Removed)
 	}
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// --stdin: body piped in instead of --body.
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestNoteCreate_StdinBody(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	RootCmd.SetIn(strings.NewReader("# Body\n\nsee [[other]]"))
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+
+	if _, stderr, err := runNote(t, vault, "create", "Piped Note", "--stdin", "--tag", "gen"); err != nil {
+		t.Fatalf("rk note create --stdin: %v\nstderr: %s", err, stderr)
+	}
+	n, err := node.Parse([]byte(mustReadFile(t, filepath.Join(vault, "notes", "piped-note.md"))))
+	if err != nil {
+		t.Fatalf("parse created file: %v", err)
+	}
+	if n.Body != "# Body\n\nsee [[other]]\n" {
+		t.Errorf("Body = %q, want the piped text newline-terminated", n.Body)
+	}
+	if n.Props["tags"] != "[gen]" {
+		t.Errorf("Props[tags] = %q, want [gen]", n.Props["tags"])
+	}
+}
+
+func TestNoteCreate_StdinRejectsBodyAndEmpty(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+
+	RootCmd.SetIn(strings.NewReader("text"))
+	if _, _, err := runNote(t, vault, "create", "A", "--stdin", "--body", "x"); err == nil {
+		t.Error("expected error combining --stdin with --body")
+	}
+	resetCLIFlags()
+	RootCmd.SetIn(strings.NewReader("  \n"))
+	if _, _, err := runNote(t, vault, "create", "B", "--stdin"); err == nil {
+		t.Error("expected error for blank stdin")
+	}
+	if entries, _ := os.ReadDir(filepath.Join(vault, "notes")); len(entries) != 0 {
+		t.Errorf("notes written despite errors: %v", entries)
+	}
+}
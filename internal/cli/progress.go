@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// progressInterval is how often a piped (non-TTY) progressReporter emits a
+// line. A run that finishes inside the first interval prints nothing, so
+// fast commands against small vaults stay as quiet as they always were.
+const progressInterval = 2 * time.Second
+
+// progressReporter is the shared feedback line for long-running commands
+// (`rk index`, `rk migrate legacy`): on a TTY it rewrites a single counter
+// line in place, and when piped it emits one plain line per
+// progressInterval. It writes to stderr so --json/--ndjson stdout stays a
+// clean data stream. A nil *progressReporter is valid and silent, which is
+// what newProgressReporter returns under --quiet.
+type progressReporter struct {
+	w      io.Writer
+	verb   string // e.g. "reindexed"
+	noun   string // e.g. "files"
+	tty    bool
+	now    func() time.Time
+	last   time.Time
+	drawn  bool // a TTY line is currently on screen
+	closed bool
+}
+
+// newProgressReporter returns a reporter writing to w, or nil (a no-op
+// reporter) when --quiet is set. TTY detection only succeeds for a real
+// *os.File character device; test buffers, pipes, and --plain get the
+// periodic mode.
+func newProgressReporter(w io.Writer, verb, noun string) *progressReporter {
+	if quietFlag {
+		return nil
+	}
+	return &progressReporter{
+		w:    w,
+		verb: verb,
+		noun: noun,
+		tty:  isTerminal(w) && !plainFlag,
+		now:  time.Now,
+		last: time.Now(),
+	}
+}
+
+// isTerminal reports whether stream (a command's stdin, stdout, or stderr)
+// is a character device, i.e. an interactive terminal.
+func isTerminal(stream any) bool {
+	f, ok := stream.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth returns the column width of stream's terminal, or 0 when
+// stream is not a terminal or its size is unknown.
+func terminalWidth(stream any) int {
+	if !isTerminal(stream) {
+		return 0
+	}
+	width, _, err := term.GetSize(stream.(*os.File).Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// Update reports done of total units processed. total <= 0 means the total
+// is unknown and only the running count is shown.
+func (p *progressReporter) Update(done, total int) {
+	if p == nil || p.closed {
+		return
+	}
+	line := p.format(done, total)
+	if p.tty {
+		fmt.Fprintf(p.w, "\r\033[K%s", line)
+		p.drawn = true
+		return
+	}
+	if t := p.now(); t.Sub(p.last) >= progressInterval {
+		p.last = t
+		fmt.Fprintln(p.w, line)
+	}
+}
+
+// Done clears the in-place TTY line so the command's own result output
+// starts on a clean line. Piped output needs no cleanup.
+func (p *progressReporter) Done() {
+	if p == nil || p.closed {
+		return
+	}
+	p.closed = true
+	if p.tty && p.drawn {
+		fmt.Fprint(p.w, "\r\033[K")
+	}
+}
+
+func (p *progressReporter) format(done, total int) string {
+	if total > 0 {
+		return fmt.Sprintf("%s %d/%d %s", p.verb, done, total, p.noun)
+	}
+	return fmt.Sprintf("%s %d %s", p.verb, done, p.noun)
+}
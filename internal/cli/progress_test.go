@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestProgressReporterPipedEmitsPeriodically(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgressReporter(&buf, "reindexed", "files")
+	clock := p.last
+	p.now = func() time.Time { return clock }
+
+	p.Update(1, 10)
+	if buf.Len() != 0 {
+		t.Fatalf("emitted before the first interval: %q", buf.String())
+	}
+	clock = clock.Add(progressInterval)
+	p.Update(4, 10)
+	p.Update(5, 10)
+	clock = clock.Add(progressInterval)
+	p.Update(9, 0)
+	p.Done()
+
+	want := "reindexed 4/10 files\nreindexed 9 files\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestProgressReporterTTYRewritesLine(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgressReporter(&buf, "reindexed", "files")
+	p.tty = true
+
+	p.Update(1, 2)
+	p.Update(2, 2)
+	p.Done()
+	p.Update(3, 3) // after Done: ignored
+
+	want := "\r\033[Kreindexed 1/2 files\r\033[Kreindexed 2/2 files\r\033[K"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestProgressReporterQuietIsSilent(t *testing.T) {
+	quietFlag = true
+	t.Cleanup(func() { quietFlag = false })
+
+	var buf bytes.Buffer
+	p := newProgressReporter(&buf, "reindexed", "files")
+	if p != nil {
+		t.Fatalf("newProgressReporter under --quiet = %+v, want nil", p)
+	}
+	p.Update(1, 1)
+	p.Done()
+	if buf.Len() != 0 {
+		t.Errorf("quiet reporter wrote %q", buf.String())
+	}
+}
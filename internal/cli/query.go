@@ -87,14 +87,14 @@ func runQueryE(cmd *cobra.Command, args []string) error {
 
 	// --lang is a seam: only "sql" is implemented.
 	if lang != "" && lang != "sql" {
-		return fmt.Errorf("query: unsupported language: %s", lang)
+		return fmt.Errorf("query: unsupported language: %s%s", lang, config.Suggest(lang, "sql"))
 	}
 
 	if limitSet && limit < 0 {
 		return fmt.Errorf("query: --limit must be >= 0, got %d", limit)
 	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -151,7 +151,7 @@ func runQueryE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("query: iterate: %w", err)
 	}
 
-	w := output.New(cmd.OutOrStdout(), mode)
+	w := newOutput(cmd, mode)
 
 	// Canonical mode reconstructs full node envelopes when the result carries a
 	// node identity ("id" column); otherwise (or with --raw) emit raw rows.
@@ -570,9 +570,10 @@ func (w *fieldWarner) check(fields []string, obj map[string]json.RawMessage) {
 }
 
 // emit writes the objects in the selected mode. NDJSON streams one object per
-// line (stopping cleanly on a broken pipe); JSON writes a single array.
+// line (stopping cleanly on a broken pipe); JSON writes a single array and
+// YAML a single sequence.
 func emit(w *output.Writer, mode output.Mode, objects []any) error {
-	if mode == output.JSON {
+	if mode == output.JSON || mode == output.YAML {
 		if objects == nil {
 			objects = []any{}
 		}
@@ -101,8 +101,15 @@ func resetCLIFlags() {
 	vaultFlag = ""
 	jsonFlag = false
 	ndjsonFlag = false
+	yamlFlag = false
 	quietFlag = false
+	plainFlag = false
+	prettyFlag = false
+	compactFlag = false
+	timingFlag = false
 	dateFlag = ""
+	dayRollover = 0
+	journalLayout = ""
 	RootCmd.SetArgs(nil)
 	RootCmd.SetOut(nil)
 	RootCmd.SetErr(nil)
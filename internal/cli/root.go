@@ -5,7 +5,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/MikeBiancalana/reckon/internal/config"
 	"github.com/MikeBiancalana/reckon/internal/logger"
+	"github.com/MikeBiancalana/reckon/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -28,7 +30,12 @@ var (
 	logLevelFlag string
 	jsonFlag     bool
 	ndjsonFlag   bool
+	yamlFlag     bool
 	vaultFlag    string
+	plainFlag    bool
+	prettyFlag   bool
+	compactFlag  bool
+	timingFlag   bool
 )
 
 // buildLoggerConfig creates a logger configuration from flags and environment variables.
@@ -67,12 +74,13 @@ func buildLoggerConfig(isTUIMode bool) logger.Config {
 	return cfg
 }
 
-// RootCmd is the root command for the CLI. Bare invocation (no subcommand) prints help.
+// RootCmd is the root command for the CLI. Bare invocation (no subcommand)
+// runs the vault's default_command setting: help (git-style) unless set.
 var RootCmd = &cobra.Command{
 	Use:   "rk",
 	Short: "Reckon - CLI Productivity System",
 	Long:  `A terminal-based productivity tool combining daily journaling, task management, and knowledge base.`,
-	// No RunE: bare "rk" prints help (git-style).
+	RunE:  runRootE,
 }
 
 func init() {
@@ -83,17 +91,33 @@ func init() {
 		if jsonFlag && ndjsonFlag {
 			return fmt.Errorf("--json and --ndjson are mutually exclusive")
 		}
+		if yamlFlag && (jsonFlag || ndjsonFlag) {
+			return fmt.Errorf("--yaml and --json/--ndjson are mutually exclusive")
+		}
+		if prettyFlag && compactFlag {
+			return fmt.Errorf("--pretty and --compact are mutually exclusive")
+		}
 
+		var err error
+		if dayRollover, err = loadDayRollover(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v; the journal day turns over at midnight\n", err)
+		}
+		journalLayout = loadJournalLayout()
 		return initLoggerE()
 	}
 
 	// Persistent flags — available to all subcommands
-	RootCmd.PersistentFlags().StringVar(&dateFlag, "date", "", "Date to operate on in YYYY-MM-DD format")
+	RootCmd.PersistentFlags().StringVar(&dateFlag, "date", "", "Date to operate on: YYYY-MM-DD, today, yesterday, tomorrow, or an offset like -1d/+2w")
 	RootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output")
 	RootCmd.PersistentFlags().StringVar(&logFileFlag, "log-file", "", "Path to log file (default: ~/.reckon/logs/reckon.log in TUI mode, stderr otherwise)")
 	RootCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", "", "Log level: DEBUG, INFO, WARN, ERROR (default: INFO)")
 	RootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Output as JSON")
 	RootCmd.PersistentFlags().BoolVar(&ndjsonFlag, "ndjson", false, "Output as newline-delimited JSON")
+	RootCmd.PersistentFlags().BoolVar(&yamlFlag, "yaml", false, "Output as YAML, with the same fields as --json")
+	RootCmd.PersistentFlags().BoolVar(&plainFlag, "plain", false, "Undecorated human output: no color, symbols, or terminal-dependent layout (no effect on --json/--ndjson)")
+	RootCmd.PersistentFlags().BoolVar(&prettyFlag, "pretty", false, "Indent --json output (default when stdout is a terminal)")
+	RootCmd.PersistentFlags().BoolVar(&compactFlag, "compact", false, "Print --json output on one line (default when stdout is piped)")
+	RootCmd.PersistentFlags().BoolVar(&timingFlag, "timing", false, "Print how long the command took to stderr when it finishes")
 	RootCmd.PersistentFlags().StringVar(&vaultFlag, "vault", "", "Override vault directory (default: $RECKON_VAULT or ~/reckon)")
 
 	RootCmd.AddCommand(GetNoteCommand())
@@ -105,6 +129,49 @@ func init() {
 	RootCmd.AddCommand(adoptCmd)
 	RootCmd.AddCommand(migrateCmd)
 	RootCmd.AddCommand(tuiCmd)
+	RootCmd.AddCommand(statsCmd)
+	RootCmd.AddCommand(heatmapCmd)
+	RootCmd.AddCommand(trashCmd)
+	RootCmd.AddCommand(contextCmd)
+	RootCmd.AddCommand(fmtCmd)
+	RootCmd.AddCommand(nextCmd)
+	RootCmd.AddCommand(versionCmd)
+}
+
+// newOutput returns the output.Writer every command prints its result
+// with: cmd's stdout in mode, with --json indented under --pretty, compact
+// under --compact, and otherwise indented only on a terminal.
+func newOutput(cmd *cobra.Command, mode output.Mode) *output.Writer {
+	out := cmd.OutOrStdout()
+	indent := isTerminal(out)
+	switch {
+	case prettyFlag:
+		indent = true
+	case compactFlag:
+		indent = false
+	}
+	return output.New(out, mode).Indent(indent)
+}
+
+// runRootE is bare `rk`: it runs default_command (config.DefaultCommand*).
+// Without a loadable vault config there is no setting to read, so it falls
+// back to help rather than failing.
+func runRootE(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return cmd.Help()
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("rk: %w", err)
+	}
+	switch settings.DefaultCommand {
+	case config.DefaultCommandTUI:
+		return runTUIE(tuiCmd, nil)
+	case config.DefaultCommandToday:
+		return runTodayE(todayCmd, nil)
+	}
+	return cmd.Help()
 }
 
 // initLoggerE initializes the logger with command-line flags.
@@ -114,19 +181,101 @@ func initLoggerE() error {
 	if err := logger.InitializeWithConfig(cfg); err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
-	logger.Info("reckon initialized", "version", "dev", "log_file", logger.GetLogFile(), "log_level", cfg.Level)
+	logger.Info("reckon initialized", "version", version, "log_file", logger.GetLogFile(), "log_level", cfg.Level)
 	return nil
 }
 
-// getEffectiveDate returns the date to operate on, either from --date flag or today.
-func getEffectiveDate() (string, error) {
-	if dateFlag != "" {
-		if _, err := time.Parse("2006-01-02", dateFlag); err != nil {
-			return "", fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", dateFlag)
-		}
-		return dateFlag, nil
+// dayRollover is the vault's day_rollover setting as an offset from
+// midnight, loaded before every command runs.
+var dayRollover time.Duration
+
+// loadDayRollover reads the day_rollover setting. Without a loadable vault
+// it is midnight, and any command that needs one reports why itself; a
+// settings file that fails to load (an invalid day_rollover among other
+// things) is midnight too, but its error is returned so it gets reported
+// rather than silently ignored.
+func loadDayRollover() (time.Duration, error) {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return 0, nil
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := config.DayRolloverOffset(settings.DayRollover)
+	if err != nil {
+		return 0, fmt.Errorf("invalid day_rollover: %w", err)
+	}
+	return offset, nil
+}
+
+// journalLayout is the vault's journal_layout setting, loaded before every
+// command runs; "" reads as flat.
+var journalLayout string
+
+// loadJournalLayout reads the journal_layout setting, best effort like
+// loadDayRollover: without a loadable vault or settings file it is flat.
+func loadJournalLayout() string {
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return config.JournalLayoutFlat
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return config.JournalLayoutFlat
+	}
+	return settings.JournalLayout
+}
+
+// journalNow is todoNow shifted back by the day rollover: its date is the
+// journal day it is now, so an entry logged at 1am with day_rollover 03:00
+// lands on the previous day. Every "today" derives from it; timestamps
+// keep using todoNow.
+func journalNow() time.Time {
+	return todoNow().Add(-dayRollover)
+}
+
+// currentJournalDate is journalNow's date, YYYY-MM-DD.
+func currentJournalDate() string {
+	return journalNow().Format("2006-01-02")
+}
+
+// journalHHMM is the header time for an entry logged at wall-clock hhmm on
+// its journal day. A time before the day rollover belongs to the previous
+// day's file, so it is written past 24:00 -- 01:30 under a 03:00 rollover is
+// 25:30 -- which node.EntryTime places on the next date, after the day's
+// evening entries, rather than 24 hours early.
+func journalHHMM(hhmm string) string {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil || dayRollover == 0 {
+		return hhmm
 	}
-	return time.Now().Format("2006-01-02"), nil
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if clock >= dayRollover {
+		return hhmm
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hour()+24, t.Minute())
+}
+
+// logEntryDaySQL is the journal day of a log entry aliased n in an index
+// query: the date its day file is named for. Day buckets use it rather than
+// n.time's date, which for an entry logged past midnight before the day
+// rollover is already the next date.
+const logEntryDaySQL = "substr(n.loc, -13, 10)"
+
+// resolveDateFlag resolves --date against today: an absolute YYYY-MM-DD or
+// a relative form (yesterday, -1d, +2w) so yesterday's entries can be
+// reached without typing the date. An unset flag is today itself.
+func resolveDateFlag(today time.Time) (string, error) {
+	if dateFlag == "" {
+		return today.Format("2006-01-02"), nil
+	}
+	d, err := resolveDateEndpoint(dateFlag, today)
+	if err != nil {
+		return "", fmt.Errorf("invalid --date: %w", err)
+	}
+	return d, nil
 }
 
 // Execute runs the root command with git-style external dispatch.
@@ -144,5 +293,17 @@ func Execute() error {
 		return err
 	}
 
-	return RootCmd.Execute()
+	return executeTimed()
+}
+
+// executeTimed runs RootCmd and, under --timing, reports the command's
+// wall-clock duration on stderr, where it cannot mix with --json output.
+// A failed command is timed too; --quiet suppresses the report.
+func executeTimed() error {
+	start := time.Now()
+	cmd, err := RootCmd.ExecuteC()
+	if timingFlag && !quietFlag {
+		fmt.Fprintf(cmd.ErrOrStderr(), "timing: %s took %s\n", cmd.CommandPath(), time.Since(start).Round(time.Millisecond))
+	}
+	return err
 }
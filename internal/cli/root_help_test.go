@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/MikeBiancalana/reckon/internal/node"
 	"github.com/spf13/cobra"
 )
 
@@ -57,6 +58,12 @@ func TestAddHelp(t *testing.T) {
 		RootCmd.SetArgs(nil)
 		RootCmd.SetOut(nil)
 		RootCmd.SetErr(nil)
+		// cobra never clears a parsed --help; left set, every later
+		// `rk add` in this binary would print usage instead of running.
+		if fl := addCmd.Flags().Lookup("help"); fl != nil {
+			fl.Value.Set("false")
+			fl.Changed = false
+		}
 	})
 
 	err := RootCmd.Execute()
@@ -107,7 +114,7 @@ func TestRootCommandSurface(t *testing.T) {
 		names[cmd.Name()] = true
 	}
 
-	survivors := []string{"add", "adopt", "migrate", "index", "note", "query", "today", "todo", "tui"}
+	survivors := []string{"add", "adopt", "migrate", "index", "note", "query", "today", "todo", "tui", "stats", "heatmap", "version"}
 	for _, verb := range survivors {
 		if !names[verb] {
 			t.Errorf("expected verb %q to be registered", verb)
@@ -148,7 +155,7 @@ func TestNoteSubcommandSurface(t *testing.T) {
 		names[cmd.Name()] = true
 	}
 
-	survivors := []string{"create", "show", "rename", "index"}
+	survivors := []string{"create", "show", "rename", "index", "touch", "orphans", "tags"}
 	for _, verb := range survivors {
 		if !names[verb] {
 			t.Errorf("expected note subcommand %q to be registered", verb)
@@ -162,3 +169,72 @@ func TestNoteSubcommandSurface(t *testing.T) {
 		}
 	}
 }
+
+// TestRootBare_DefaultCommand: bare `rk` prints help until the vault's
+// default_command setting picks another verb.
+func TestRootBare_DefaultCommand(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-10", "Morning task.")
+
+	run := func() string {
+		t.Helper()
+		// An earlier test's `rk --help` leaves RootCmd's help flag set.
+		if fl := RootCmd.Flags().Lookup("help"); fl != nil {
+			fl.Value.Set("false")
+			fl.Changed = false
+		}
+		var buf bytes.Buffer
+		RootCmd.SetOut(&buf)
+		RootCmd.SetErr(&buf)
+		RootCmd.SetArgs([]string{"--vault", vault})
+		if err := RootCmd.Execute(); err != nil {
+			t.Fatalf("bare rk: %v\noutput:\n%s", err, buf.String())
+		}
+		resetCLIFlags()
+		return buf.String()
+	}
+
+	if out := run(); !strings.Contains(out, "Usage:") {
+		t.Errorf("bare rk without default_command should print help:\n%s", out)
+	}
+	writeVaultSettings(t, vault, "default_command: today\n")
+	if out := run(); !strings.Contains(out, "Morning task.") || strings.Contains(out, "Usage:") {
+		t.Errorf("bare rk with default_command: today should print the agenda:\n%s", out)
+	}
+}
+
+// TestTiming: --timing reports the command's duration on stderr, keeping
+// --json stdout clean, and --quiet suppresses it.
+func TestTiming(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	run := func(args ...string) (stdout, stderr string) {
+		t.Helper()
+		var outBuf, errBuf bytes.Buffer
+		RootCmd.SetOut(&outBuf)
+		RootCmd.SetErr(&errBuf)
+		RootCmd.SetArgs(append([]string{"todo", "list", "--vault", vault}, args...))
+		if err := executeTimed(); err != nil {
+			t.Fatalf("rk todo list %v: %v", args, err)
+		}
+		resetCLIFlags()
+		return outBuf.String(), errBuf.String()
+	}
+
+	stdout, stderr := run("--timing", "--json")
+	if !strings.HasPrefix(stderr, "timing: rk todo list took ") {
+		t.Errorf("--timing stderr = %q", stderr)
+	}
+	var res todoListResult
+	mustDecodeJSON(t, stdout, &res)
+
+	if _, stderr := run("--timing", "--quiet"); stderr != "" {
+		t.Errorf("--timing --quiet stderr = %q, want nothing", stderr)
+	}
+	if _, stderr := run(); stderr != "" {
+		t.Errorf("stderr without --timing = %q, want nothing", stderr)
+	}
+}
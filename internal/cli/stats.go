@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// stats flag variables, reset (with their pflag Changed state) by
+// resetStatsFlags after every run.
+var (
+	statsFromFlag string
+	statsToFlag   string
+)
+
+func resetStatsFlags(cmd *cobra.Command) {
+	statsFromFlag = ""
+	statsToFlag = ""
+	for _, name := range []string{"from", "to"} {
+		if fl := cmd.Flags().Lookup(name); fl != nil {
+			fl.Changed = false
+		}
+	}
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show a one-screen overview of the vault",
+	Long: "Summarize the vault from its index: the logging streak, open, overdue, and done todo counts, " +
+		"notes and unresolved links, and this week's wins. Read-only. With --from and/or --to " +
+		"(YYYY-MM-DD, today, yesterday, or an offset like -90d) the log figures cover that range instead: " +
+		"its entries, days logged, wins, and todos completed, and the streak ending on its last day. " +
+		"Todo and note counts are always as of now.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runStatsE,
+}
+
+func init() {
+	f := statsCmd.Flags()
+	f.StringVar(&statsFromFlag, "from", "", "First day of the range to report on (default: the first day logged)")
+	f.StringVar(&statsToFlag, "to", "", "Last day of the range to report on (default: today)")
+}
+
+// statsResult is `rk stats`'s overview. Dates are UTC, like the rest of
+// the log and todo arithmetic (see todoNow).
+type statsResult struct {
+	Date string `json:"date"`
+	// Streak is how many consecutive days, ending today, have a log
+	// entry; a day not yet logged does not break it until it is over.
+	Streak          int `json:"streak"`
+	LogEntries      int `json:"log_entries"`
+	TodosOpen       int `json:"todos_open"`    // open or in-progress
+	TodosOverdue    int `json:"todos_overdue"` // open ones whose deadline has passed
+	TodosDone       int `json:"todos_done"`
+	Notes           int `json:"notes"`
+	UnresolvedLinks int `json:"unresolved_links"`
+	WinsThisWeek    int `json:"wins_this_week"` // Monday through today
+
+	// Range is set under --from/--to: LogEntries and Streak then count
+	// within it, and the fields below report on it.
+	Range          *dateRange `json:"range,omitempty"`
+	DaysLogged     int        `json:"days_logged,omitempty"`
+	Wins           int        `json:"wins,omitempty"`
+	TodosCompleted int        `json:"todos_completed,omitempty"` // distinct todos a did:: entry completed
+}
+
+func (r statsResult) Pretty() string {
+	var b strings.Builder
+	if r.Range != nil {
+		fmt.Fprintf(&b, "stats: %s..%s", r.Range.From, r.Range.To)
+		fmt.Fprintf(&b, "\n  log:   %d entries on %d day(s), %d-day streak to %s", r.LogEntries, r.DaysLogged, r.Streak, r.Range.To)
+		fmt.Fprintf(&b, "\n  done:  %d todo(s) completed", r.TodosCompleted)
+		fmt.Fprintf(&b, "\n  wins:  %d", r.Wins)
+		fmt.Fprintf(&b, "\n  now:   %d open todos, %d overdue, %d done; %d notes, %d unresolved link(s)",
+			r.TodosOpen, r.TodosOverdue, r.TodosDone, r.Notes, r.UnresolvedLinks)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "stats: %s", r.Date)
+	fmt.Fprintf(&b, "\n  log:   %d-day streak, %d entries", r.Streak, r.LogEntries)
+	fmt.Fprintf(&b, "\n  todos: %d open, %d overdue, %d done", r.TodosOpen, r.TodosOverdue, r.TodosDone)
+	fmt.Fprintf(&b, "\n  notes: %d, %d unresolved link(s)", r.Notes, r.UnresolvedLinks)
+	fmt.Fprintf(&b, "\n  wins:  %d this week", r.WinsThisWeek)
+	return b.String()
+}
+
+func runStatsE(cmd *cobra.Command, args []string) error {
+	defer resetStatsFlags(cmd)
+
+	today := currentJournalDate()
+	var span *dateRange
+	if statsFromFlag != "" || statsToFlag != "" {
+		r := dateRange{To: today}
+		var err error
+		if statsFromFlag != "" {
+			if r.From, err = resolveDateEndpoint(statsFromFlag, journalNow()); err != nil {
+				return fmt.Errorf("stats: --from: %w", err)
+			}
+		}
+		if statsToFlag != "" {
+			if r.To, err = resolveDateEndpoint(statsToFlag, journalNow()); err != nil {
+				return fmt.Errorf("stats: --to: %w", err)
+			}
+		}
+		if r.From != "" && r.From > r.To {
+			return fmt.Errorf("stats: --from %s is after --to %s", r.From, r.To)
+		}
+		span = &r
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("stats: load config: %w", err)
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("stats: open index: %w", err)
+	}
+	defer ix.Close()
+
+	st, err := ix.Reconcile()
+	if err != nil {
+		return fmt.Errorf("stats: reconcile index: %w", err)
+	}
+	warnSkippedJournals(cmd, "stats", st.Warnings)
+
+	res, err := buildStats(ix.DB(), today)
+	if err != nil {
+		return err
+	}
+	if span != nil {
+		if err := applyStatsRange(ix.DB(), &res, *span); err != nil {
+			return err
+		}
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// warnSkippedJournals reports, on stderr, each journal day file the index
+// pass could not parse: its entries are left out of verb's figures rather
+// than failing the command, and the warning says which days that was.
+func warnSkippedJournals(cmd *cobra.Command, verb string, warnings []index.Warning) {
+	if quietFlag {
+		return
+	}
+	for _, w := range warnings {
+		if w.Kind != "unparsable" || !strings.HasPrefix(w.Files[0], "log/") {
+			continue
+		}
+		day := strings.TrimSuffix(path.Base(w.Files[0]), ".md")
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s: warning: skipped journal %s (%s): %s\n", verb, day, w.Files[0], w.Error)
+	}
+}
+
+// applyStatsRange replaces res's log figures with ones for r: entries,
+// days logged, wins, and todos completed within it, and the streak of
+// logged days ending on r.To (on today, a day not yet logged does not
+// break it, as in buildStats) and never reaching back past r.From. An open
+// From becomes the first day logged.
+func applyStatsRange(db *sql.DB, res *statsResult, r dateRange) error {
+	rows, err := db.Query(`SELECT `+logEntryDaySQL+`, COALESCE(p.value, '') FROM nodes n
+		LEFT JOIN node_props p ON p.id = n.id AND p.key = 'kind'
+		WHERE n.type = 'log-entry' AND `+logEntryDaySQL+` <= ?`, r.To)
+	if err != nil {
+		return fmt.Errorf("stats: query log entries: %w", err)
+	}
+	defer rows.Close()
+	res.LogEntries, res.Wins = 0, 0
+	logged := map[string]bool{}
+	first := ""
+	for rows.Next() {
+		var day, kind string
+		if err := rows.Scan(&day, &kind); err != nil {
+			return fmt.Errorf("stats: scan log entry: %w", err)
+		}
+		if first == "" || day < first {
+			first = day
+		}
+		if !r.contains(day) {
+			continue
+		}
+		res.LogEntries++
+		logged[day] = true
+		if kind == "win" {
+			res.Wins++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("stats: iterate log entries: %w", err)
+	}
+	if r.From == "" {
+		r.From = first
+		if r.From == "" || r.From > r.To {
+			r.From = r.To
+		}
+	}
+	res.Range = &r
+	res.DaysLogged = len(logged)
+
+	end, err := parseSchedDate(r.To)
+	if err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+	if r.To == res.Date && !logged[r.To] {
+		end = end.AddDate(0, 0, -1)
+	}
+	res.Streak = 0
+	for day := end.Format("2006-01-02"); logged[day]; day = end.Format("2006-01-02") {
+		res.Streak++
+		end = end.AddDate(0, 0, -1)
+	}
+
+	done, err := todosDoneByDay(db, r.From, r.To)
+	if err != nil {
+		return fmt.Errorf("stats: %w", err)
+	}
+	completed := map[string]bool{}
+	for _, todos := range done {
+		for _, todo := range todos {
+			completed[todo] = true
+		}
+	}
+	res.TodosCompleted = len(completed)
+	return nil
+}
+
+// buildStats computes the overview as of today (YYYY-MM-DD) from the index.
+func buildStats(db *sql.DB, today string) (statsResult, error) {
+	res := statsResult{Date: today}
+	todayT, err := parseSchedDate(today)
+	if err != nil {
+		return statsResult{}, fmt.Errorf("stats: %w", err)
+	}
+	week, err := parseDateFilter("this-week", todayT)
+	if err != nil {
+		return statsResult{}, fmt.Errorf("stats: %w", err)
+	}
+
+	rows, err := db.Query(`SELECT ` + logEntryDaySQL + `, COALESCE(p.value, '') FROM nodes n
+		LEFT JOIN node_props p ON p.id = n.id AND p.key = 'kind'
+		WHERE n.type = 'log-entry'`)
+	if err != nil {
+		return statsResult{}, fmt.Errorf("stats: query log entries: %w", err)
+	}
+	logged := map[string]bool{}
+	for rows.Next() {
+		var day, kind string
+		if err := rows.Scan(&day, &kind); err != nil {
+			rows.Close()
+			return statsResult{}, fmt.Errorf("stats: scan log entry: %w", err)
+		}
+		res.LogEntries++
+		logged[day] = true
+		if kind == "win" && week.From <= day && day <= today {
+			res.WinsThisWeek++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return statsResult{}, fmt.Errorf("stats: iterate log entries: %w", err)
+	}
+	rows.Close()
+
+	day := todayT
+	if !logged[today] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for logged[day.Format("2006-01-02")] {
+		res.Streak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	rows, err = db.Query(`SELECT COALESCE(s.value, ''), COALESCE(d.value, '') FROM nodes n
+		LEFT JOIN node_props s ON s.id = n.id AND s.key = 'state'
+		LEFT JOIN node_props d ON d.id = n.id AND d.key = 'deadline'
+		WHERE n.type = 'todo'`)
+	if err != nil {
+		return statsResult{}, fmt.Errorf("stats: query todos: %w", err)
+	}
+	for rows.Next() {
+		var state, deadline string
+		if err := rows.Scan(&state, &deadline); err != nil {
+			rows.Close()
+			return statsResult{}, fmt.Errorf("stats: scan todo: %w", err)
+		}
+		switch state {
+		case "open", "in-progress":
+			res.TodosOpen++
+			if deadline != "" && deadline < today {
+				res.TodosOverdue++
+			}
+		case "done":
+			res.TodosDone++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return statsResult{}, fmt.Errorf("stats: iterate todos: %w", err)
+	}
+	rows.Close()
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM nodes WHERE type = 'note'").Scan(&res.Notes); err != nil {
+		return statsResult{}, fmt.Errorf("stats: count notes: %w", err)
+	}
+	if res.UnresolvedLinks, err = countUnresolvedLinks(db); err != nil {
+		return statsResult{}, fmt.Errorf("stats: %w", err)
+	}
+	return res, nil
+}
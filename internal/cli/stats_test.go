@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// runStats invokes `rk stats --vault <vault> <args...>`.
+func runStats(t *testing.T, vault string, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"stats", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), errBuf.String(), err
+}
+
+// TestStats: the overview counts the log streak (today not yet logged
+// keeps it alive), todo states and overdue deadlines, notes and their
+// unresolved links, and wins since Monday.
+func TestStats(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10") // a Friday
+
+	for _, e := range []struct{ date, kind string }{
+		{"2026-07-09", "win"}, {"2026-07-08", ""}, {"2026-07-07", ""}, {"2026-07-05", "win"},
+	} {
+		resetCLIFlags()
+		args := []string{"entry", "--date", e.date, "--at", "09:00"}
+		if e.kind != "" {
+			args = append(args, "--kind", e.kind)
+		}
+		if _, stderr, err := runAdd(t, vault, args...); err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", args, err, stderr)
+		}
+	}
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Late.", "deadline: 2026-07-01")
+	writeTodoFixture(t, vault, node.Mint(), "in-progress", "", "Going.")
+	writeTodoFixture(t, vault, node.Mint(), "done", "", "Finished.")
+	writeTestNode(t, vault, "notes/ideas.md", node.Mint(), "note", "See [[nowhere]].")
+
+	resetCLIFlags()
+	out, stderr, err := runStats(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk stats: %v\nstderr: %s", err, stderr)
+	}
+	var got statsResult
+	mustDecodeJSON(t, out, &got)
+	want := statsResult{
+		Date: "2026-07-10", Streak: 3, LogEntries: 4,
+		TodosOpen: 2, TodosOverdue: 1, TodosDone: 1,
+		Notes: 1, UnresolvedLinks: 1, WinsThisWeek: 1,
+	}
+	if got != want {
+		t.Errorf("stats = %+v\nwant    %+v", got, want)
+	}
+
+	resetCLIFlags()
+	pretty, _, err := runStats(t, vault)
+	if err != nil || !strings.Contains(pretty, "3-day streak") || !strings.Contains(pretty, "1 overdue") {
+		t.Errorf("pretty stats = %q (err %v), want the streak and overdue count", pretty, err)
+	}
+
+	resetCLIFlags()
+	if quiet, _, err := runStats(t, vault, "--quiet"); err != nil || quiet != "" {
+		t.Errorf("stats --quiet = %q, %v; want no output", quiet, err)
+	}
+}
+
+// TestStats_Range: --from/--to counts log entries, days, wins, and todos
+// completed inside the range, with the streak ending on its last day and
+// cut off at its first; an inverted range is an error.
+func TestStats_Range(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	for _, e := range []struct{ date, kind string }{
+		{"2026-03-30", ""}, {"2026-03-31", ""}, {"2026-04-01", ""}, {"2026-04-02", "win"},
+		{"2026-05-10", ""}, {"2026-06-29", "win"}, {"2026-06-30", ""}, {"2026-07-09", ""},
+	} {
+		resetCLIFlags()
+		args := []string{"entry", "--date", e.date, "--at", "09:00"}
+		if e.kind != "" {
+			args = append(args, "--kind", e.kind)
+		}
+		if _, stderr, err := runAdd(t, vault, args...); err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", args, err, stderr)
+		}
+	}
+	id := node.Mint()
+	writeTodoFixture(t, vault, id, "done", "", "Quarterly task.")
+	if _, err := appendDidLogEntry(filepath.Join(vault, "log"), "2026-05-10", "10:00", "local", "completed todo "+id, id); err != nil {
+		t.Fatalf("log did entry: %v", err)
+	}
+
+	resetCLIFlags()
+	out, stderr, err := runStats(t, vault, "--from", "2026-04-01", "--to", "2026-06-30", "--json")
+	if err != nil {
+		t.Fatalf("rk stats --from --to: %v\nstderr: %s", err, stderr)
+	}
+	var got statsResult
+	mustDecodeJSON(t, out, &got)
+	if got.Range == nil || *got.Range != (dateRange{From: "2026-04-01", To: "2026-06-30"}) {
+		t.Fatalf("range = %+v, want 2026-04-01..2026-06-30", got.Range)
+	}
+	if got.LogEntries != 6 || got.DaysLogged != 5 || got.Wins != 2 || got.Streak != 2 || got.TodosCompleted != 1 {
+		t.Errorf("ranged stats = %+v, want 6 entries on 5 days, 2 wins, a 2-day streak, 1 todo completed", got)
+	}
+
+	resetCLIFlags()
+	out, _, err = runStats(t, vault, "--from", "2026-04-01", "--to", "2026-04-02", "--json")
+	if err != nil {
+		t.Fatalf("rk stats (short range): %v", err)
+	}
+	mustDecodeJSON(t, out, &got)
+	if got.Streak != 2 {
+		t.Errorf("streak in 2026-04-01..02 = %d, want 2 (cut off at --from)", got.Streak)
+	}
+
+	resetCLIFlags()
+	out, _, err = runStats(t, vault, "--from", "-30d")
+	if err != nil || !strings.Contains(out, "stats: 2026-06-10..2026-07-10") || !strings.Contains(out, "1-day streak to 2026-07-10") {
+		t.Errorf("relative --from output = %q (err %v), want the resolved range and today's grace streak", out, err)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runStats(t, vault, "--from", "2026-07-01", "--to", "2026-06-01"); err == nil || !strings.Contains(err.Error(), "is after --to") {
+		t.Errorf("inverted range err = %v, want is after --to", err)
+	}
+}
+
+// TestStats_SkipsCorruptJournal: one day file that does not parse, among
+// good ones, is left out of the figures and named in a warning instead of
+// failing the command.
+func TestStats_SkipsCorruptJournal(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	for _, date := range []string{"2026-07-08", "2026-07-10"} {
+		resetCLIFlags()
+		if _, stderr, err := runAdd(t, vault, "entry", "--date", date, "--at", "09:00"); err != nil {
+			t.Fatalf("rk add --date %s: %v\nstderr: %s", date, err, stderr)
+		}
+	}
+	mustWriteFile(t, filepath.Join(vault, "log", "2026-07-09.md"),
+		"---\ntype: log-day\n---\n<<<<<<< HEAD\n## 09:00 · mike\nmine\n=======\n## 09:05 · mike\ntheirs\n>>>>>>> other\n")
+
+	resetCLIFlags()
+	out, stderr, err := runStats(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk stats with a corrupt journal: %v\nstderr: %s", err, stderr)
+	}
+	var got statsResult
+	mustDecodeJSON(t, out, &got)
+	if got.LogEntries != 2 {
+		t.Errorf("log_entries = %d, want the 2 entries of the readable days", got.LogEntries)
+	}
+	if !strings.Contains(stderr, "skipped journal 2026-07-09 (log/2026-07-09.md)") {
+		t.Errorf("stderr = %q, want the skipped day named", stderr)
+	}
+}
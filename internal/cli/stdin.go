@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxStdinSize caps how much a --stdin flag will read (1 MiB), so an
+// accidental `rk ... --stdin < /dev/urandom` fails fast instead of filling
+// memory or the vault.
+const maxStdinSize = 1 << 20
+
+// readStdinAll reads all of r, refusing input larger than maxStdinSize.
+func readStdinAll(r io.Reader) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, maxStdinSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+	if len(raw) > maxStdinSize {
+		return nil, fmt.Errorf("stdin exceeds %d bytes", maxStdinSize)
+	}
+	return raw, nil
+}
+
+// readStdinCapped reads all of r like readStdinAll, but input larger than
+// maxStdinSize is cut to it (at a line break where one falls in the last
+// quarter, and never mid-rune) rather than refused; truncated reports the
+// cut. The rest of r is drained, so the command piping into rk still
+// finishes rather than dying of a closed pipe.
+func readStdinCapped(r io.Reader) (raw []byte, truncated bool, err error) {
+	raw, err = io.ReadAll(io.LimitReader(r, maxStdinSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("read stdin: %w", err)
+	}
+	if len(raw) <= maxStdinSize {
+		return raw, false, nil
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return nil, false, fmt.Errorf("read stdin: %w", err)
+	}
+	raw = raw[:maxStdinSize]
+	if nl := bytes.LastIndexByte(raw, '\n'); nl >= maxStdinSize*3/4 {
+		raw = raw[:nl+1]
+	}
+	for i := len(raw) - 1; i >= 0 && i >= len(raw)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(raw[i]) {
+			if !utf8.FullRune(raw[i:]) {
+				raw = raw[:i]
+			}
+			break
+		}
+	}
+	return raw, true, nil
+}
+
+// readStdinLines reads r (bounded by maxStdinSize) and returns its
+// non-blank lines, each trimmed of surrounding whitespace, in input order.
+// It backs every one-item-per-line --stdin mode.
+func readStdinLines(r io.Reader) ([]string, error) {
+	raw, err := readStdinAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	sc := bufio.NewScanner(strings.NewReader(string(raw)))
+	sc.Buffer(make([]byte, 0, 64*1024), maxStdinSize)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+	return lines, nil
+}
+
+// stdinBlock is the text a --stdin capture of piped output writes (`rk add`'s
+// entry body, `rk todo note`'s note): message (which may be "") as its first
+// line, then raw with CRLFs normalized, each line indented four spaces. The
+// indent keeps the input's own line structure intact as a markdown code
+// block and keeps any of its lines from starting a "## " log header or an
+// id:: marker. A truncated input ends with a marker saying so. Input that
+// is only whitespace adds nothing.
+func stdinBlock(message string, raw []byte, truncated bool) string {
+	text := strings.TrimRight(strings.ReplaceAll(string(raw), "\r\n", "\n"), " \t\r\n")
+	text = strings.TrimLeft(text, "\r\n")
+	if text == "" {
+		return message
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = "    " + strings.TrimRight(line, " \t\r")
+		}
+	}
+	block := strings.Join(lines, "\n")
+	if truncated {
+		block += fmt.Sprintf("\n\n[stdin truncated to %d bytes]", maxStdinSize)
+	}
+	if message == "" {
+		return block
+	}
+	return message + "\n\n" + block
+}
+
+// confirm asks question on prompt and reads one line of in: y or yes (in
+// any case) is consent; anything else, or no input at all, declines.
+func confirm(in io.Reader, prompt io.Writer, question string) (bool, error) {
+	fmt.Fprintf(prompt, "%s [y/N] ", question)
+	sc := bufio.NewScanner(in)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return false, fmt.Errorf("read answer: %w", err)
+		}
+		return false, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(sc.Text())) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
@@ -16,7 +16,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -31,14 +30,27 @@ import (
 // Flag variables
 // ─────────────────────────────────────────────────────────────────────────────
 
-var todayNoLogFlag bool
+var (
+	todayNoLogFlag       bool
+	todayStrictFlag      bool
+	todayColorFlag       bool
+	todaySummaryFlag     bool
+	todayNoAutoCarryFlag bool
+)
 
 // resetTodayFlags restores today flag variables to their defaults and clears
 // the pflag Changed state on whichever of these flags are registered on cmd.
 // Mirrors todo.go's resetTodoFlags / query.go's resetQueryFlags.
 func resetTodayFlags(cmd *cobra.Command) {
 	todayNoLogFlag = false
-	for _, name := range []string{"no-log"} {
+	todayStrictFlag = false
+	todayColorFlag = false
+	todaySummaryFlag = false
+	todayNoAutoCarryFlag = false
+	todayGapsMinutesFlag = 0
+	todayCopyMergeFlag = false
+	todayCopyForceFlag = false
+	for _, name := range []string{"no-log", "strict", "color", "summary", "no-auto-carry", "minutes", "merge", "force"} {
 		if fl := cmd.Flags().Lookup(name); fl != nil {
 			fl.Changed = false
 		}
@@ -79,6 +91,10 @@ var todayOpenCmd = &cobra.Command{
 
 func init() {
 	todayActCmd.Flags().BoolVar(&todayNoLogFlag, "no-log", false, "Suppress the did-entry log write when completing (x/done)")
+	todayActCmd.Flags().BoolVar(&todayStrictFlag, "strict", false, "Fail (instead of warning) when d/D would leave scheduled after deadline")
+	todayCmd.Flags().BoolVar(&todayColorFlag, "color", false, "Dim carried-over (scheduled before today) rows with ANSI styling")
+	todayCmd.Flags().BoolVar(&todaySummaryFlag, "summary", false, "Print the day's wrap-up (todos done, wins, log entries, active hours) instead of the agenda")
+	todayCmd.Flags().BoolVar(&todayNoAutoCarryFlag, "no-auto-carry", false, "Leave out rows that are on the agenda only because their scheduled date has passed")
 	todayCmd.AddCommand(todayActCmd, todayOpenCmd)
 }
 
@@ -104,14 +120,34 @@ type agendaItem struct {
 	ReadOnly  bool   `json:"read_only,omitempty"`  // true for external/work-ticket rows
 	Body      string `json:"body,omitempty"`
 	Title     string `json:"title,omitempty"` // derived first non-empty body line
+	// Carried marks a native row on today's agenda only because its
+	// scheduled date has passed: it was carried over from CarriedFrom.
+	Carried     bool   `json:"carried,omitempty"`
+	CarriedFrom string `json:"carried_from,omitempty"`
+	Estimate    string `json:"estimate,omitempty"` // native only: effort estimate, e.g. "2h"
 }
 
 // agendaResult wraps `rk today`'s items so --json emits a single object
 // ({"items": []} on empty), mirroring todoListResult.
 type agendaResult struct {
 	Items []agendaItem `json:"items"`
+	// Load sums the native rows' estimates (agendaLoadFor); nil when the
+	// agenda has no native rows.
+	Load *agendaLoad `json:"load,omitempty"`
+
+	color bool // --color: dim carried rows in pretty output
+	plain bool // --plain: spell out the carried marker
 }
 
+// ansiDim/ansiReset wrap a carried row under `rk today --color`;
+// ansiRed/ansiGreen mark the before/after of a --preview change.
+const (
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
 func (r agendaResult) Pretty() string {
 	if len(r.Items) == 0 {
 		return "today: nothing due"
@@ -123,7 +159,20 @@ func (r agendaResult) Pretty() string {
 		if it.ReadOnly {
 			marker = " [read-only]"
 		}
-		fmt.Fprintf(&b, "\n  %s [%s]%s %s", it.ID, it.State, marker, it.Title)
+		line := fmt.Sprintf("%s [%s]%s %s", it.ID, it.State, marker, it.Title)
+		if it.Carried && r.plain {
+			line += " (carried from " + it.CarriedFrom + ")"
+		} else if it.Carried {
+			line += " ↻ " + it.CarriedFrom
+			if r.color {
+				line = ansiDim + line + ansiReset
+			}
+		}
+		b.WriteString("\n  " + line)
+	}
+	// The load footer stays out of the way until estimates are in use.
+	if r.Load != nil && (r.Load.EstimateMinutes > 0 || r.Load.CapacityMinutes > 0) {
+		b.WriteString("\n" + r.Load.line())
 	}
 	return b.String()
 }
@@ -146,6 +195,7 @@ type todayActResult struct {
 	Repeat       string `json:"repeat,omitempty"`
 	DidEntryID   string `json:"did_entry_id,omitempty"`
 	DidEntryPath string `json:"did_entry_path,omitempty"`
+	Warning      string `json:"warning,omitempty"` // d/D only: scheduled ended up after deadline
 }
 
 func (r todayActResult) Pretty() string {
@@ -173,7 +223,7 @@ func (r todayOpenResult) Pretty() string { return r.SourceURL }
 func runTodayE(cmd *cobra.Command, args []string) error {
 	defer resetTodayFlags(cmd)
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -193,11 +243,34 @@ func runTodayE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("today: reconcile index: %w", err)
 	}
 
-	todayStr := todoNow().Format("2006-01-02")
+	todayStr := currentJournalDate()
+	if todaySummaryFlag {
+		sum, err := buildDaySummary(ix.DB(), todayStr)
+		if err != nil {
+			return err
+		}
+		if !(mode == output.Pretty && quietFlag) {
+			return newOutput(cmd, mode).Print(sum)
+		}
+		return nil
+	}
+
 	items, warnings, err := buildAgenda(ix.DB(), todayStr)
 	if err != nil {
 		return err
 	}
+	if todayNoAutoCarryFlag {
+		items = dropCarried(items, todayStr)
+	}
+
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("today: %w", err)
+	}
+	load := agendaLoadFor(items, settings.DailyCapacity)
+	if load != nil {
+		warnings = append(warnings, load.warnings...)
+	}
 
 	if !quietFlag {
 		for _, w := range warnings {
@@ -205,9 +278,9 @@ func runTodayE(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	res := agendaResult{Items: items}
+	res := agendaResult{Items: items, Load: load, color: todayColorFlag && !plainFlag, plain: plainFlag}
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
@@ -288,7 +361,7 @@ func buildAgenda(db *sql.DB, todayStr string) ([]agendaItem, []string, error) {
 			continue
 		}
 
-		items = append(items, agendaItem{
+		item := agendaItem{
 			ID:        c.id,
 			Type:      c.typ,
 			Path:      c.loc,
@@ -302,11 +375,52 @@ func buildAgenda(db *sql.DB, todayStr string) ([]agendaItem, []string, error) {
 			ReadOnly:  c.typ == "work-ticket",
 			Body:      strings.TrimSpace(c.body),
 			Title:     c.title,
-		})
+		}
+		if c.typ == "todo" {
+			item.Estimate = props["estimate"]
+		}
+		if sched := props["scheduled"]; c.typ == "todo" && sched != "" {
+			if d, perr := parseSchedDate(sched); perr == nil && d.Before(todayDate) {
+				item.Carried = true
+				item.CarriedFrom = sched
+			}
+		}
+		items = append(items, item)
 	}
 	return items, warnings, nil
 }
 
+// dropCarried filters buildAgenda's items down to a clean day for
+// --no-auto-carry: a Carried row is left out unless its deadline or pin also
+// puts it on todayStr's agenda. Nothing is written -- the row's scheduled
+// date is untouched, so it carries again on the next plain `rk today`.
+func dropCarried(items []agendaItem, todayStr string) []agendaItem {
+	todayDate, err := parseSchedDate(todayStr)
+	if err != nil {
+		return items
+	}
+	out := []agendaItem{}
+	for _, it := range items {
+		if it.Carried && !agendaDue(it, todayDate) {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// agendaDue reports whether it matches the agenda predicate on its deadline
+// (on or before today) or pin (today) alone, ignoring its scheduled date.
+func agendaDue(it agendaItem, todayDate time.Time) bool {
+	if d, err := parseSchedDate(it.Deadline); it.Deadline != "" && err == nil && !d.After(todayDate) {
+		return true
+	}
+	if d, err := parseSchedDate(it.Pinned); it.Pinned != "" && err == nil && d.Equal(todayDate) {
+		return true
+	}
+	return false
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // act (split actuator)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -321,8 +435,9 @@ func runTodayActE(cmd *cobra.Command, args []string) error {
 		arg = args[2]
 	}
 	noLog := todayNoLogFlag
+	strict := todayStrictFlag
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -353,13 +468,16 @@ func runTodayActE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("today act: %q is read-only (external work ticket); use `rk today open` instead", ref)
 	}
 
-	res, err := dispatchTodayAct(cfg.VaultDir, ref, key, arg, noLog)
+	res, err := dispatchTodayAct(cfg.VaultDir, ref, key, arg, noLog, strict)
 	if err != nil {
 		return err
 	}
+	if res.Warning != "" && !quietFlag {
+		fmt.Fprintf(cmd.ErrOrStderr(), "today act: warning: %s\n", res.Warning)
+	}
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
@@ -404,7 +522,7 @@ func normalizeActKey(key string) (string, error) {
 
 // dispatchTodayAct resolves ref to a native durable todo file (via the same
 // todos/ walk rk todo done uses) and applies the actuation named by key.
-func dispatchTodayAct(vaultDir, ref, key, arg string, noLog bool) (todayActResult, error) {
+func dispatchTodayAct(vaultDir, ref, key, arg string, noLog, strict bool) (todayActResult, error) {
 	normKey, err := normalizeActKey(key)
 	if err != nil {
 		return todayActResult{}, err
@@ -419,9 +537,9 @@ func dispatchTodayAct(vaultDir, ref, key, arg string, noLog bool) (todayActResul
 	case "t":
 		return actPin(vaultDir, n, foundPath, ref)
 	case "d":
-		return actDefer(vaultDir, n, foundPath, ref, arg)
+		return actDefer(vaultDir, n, foundPath, ref, arg, strict)
 	case "D":
-		return actDeadline(vaultDir, n, foundPath, ref, arg)
+		return actDeadline(vaultDir, n, foundPath, ref, arg, strict)
 	case "p":
 		return actPriority(vaultDir, n, foundPath, ref, arg)
 	case "x":
@@ -435,30 +553,11 @@ func dispatchTodayAct(vaultDir, ref, key, arg string, noLog bool) (todayActResul
 }
 
 // loadNativeTodoForEdit resolves ref to a durable todo file (ULID fast-path,
-// else a walk over todos/*.md matching ULID or alias) -- identical
-// resolution to doneDurableTodo (todo.go), reused verbatim so `rk today act`
+// else a walk over todos/*.md matching ULID or alias, else a unique ULID
+// prefix) -- it is resolveDurableTodo (todo.go), shared so `rk today act`
 // and `rk todo done` never disagree on which file a ref names.
 func loadNativeTodoForEdit(vaultDir, ref string) (*node.Node, string, error) {
-	todosDir := filepath.Join(vaultDir, "todos")
-
-	fastPath := filepath.Join(todosDir, ref+".md")
-	n, foundPath, err := loadDurableTodoAt(fastPath)
-	if err != nil {
-		return nil, "", err
-	}
-	if n != nil && n.Type != "todo" {
-		n, foundPath = nil, ""
-	}
-	if n == nil {
-		n, foundPath, err = findDurableTodoByRefOrAlias(todosDir, ref)
-		if err != nil {
-			return nil, "", err
-		}
-	}
-	if n == nil {
-		return nil, "", fmt.Errorf("today act: no todo found matching %q (not found)", ref)
-	}
-	return n, foundPath, nil
+	return resolveDurableTodo(vaultDir, ref, "today act")
 }
 
 // setOrInsertField applies the HasField trichotomy (SetField if the scalar
@@ -473,7 +572,7 @@ func setOrInsertField(n *node.Node, key, value string) error {
 
 // actPin implements the "t"/"pin" key: pinned <- today's date.
 func actPin(vaultDir string, n *node.Node, foundPath, ref string) (todayActResult, error) {
-	today := todoNow().Format("2006-01-02")
+	today := currentJournalDate()
 	if err := setOrInsertField(n, "pinned", today); err != nil {
 		return todayActResult{}, fmt.Errorf("today act: set pinned: %w", err)
 	}
@@ -503,15 +602,21 @@ func resolveDeferDate(arg string, now time.Time) (string, error) {
 	}
 }
 
-// actDefer implements the "d"/"defer" key: scheduled <- resolved date.
-func actDefer(vaultDir string, n *node.Node, foundPath, ref, arg string) (todayActResult, error) {
+// actDefer implements the "d"/"defer" key: scheduled <- resolved date. A
+// date past the todo's deadline is reported via Warning, or refused before
+// any write when strict.
+func actDefer(vaultDir string, n *node.Node, foundPath, ref, arg string, strict bool) (todayActResult, error) {
 	if strings.TrimSpace(arg) == "" {
 		return todayActResult{}, fmt.Errorf("today act: d/defer requires an argument (tomorrow|next-week|YYYY-MM-DD)")
 	}
-	date, err := resolveDeferDate(arg, todoNow())
+	date, err := resolveDeferDate(arg, journalNow())
 	if err != nil {
 		return todayActResult{}, err
 	}
+	warning := scheduleDeadlineWarning(date, n.Props["deadline"])
+	if warning != "" && strict {
+		return todayActResult{}, fmt.Errorf("today act: %s (--strict)", warning)
+	}
 	if err := setOrInsertField(n, "scheduled", date); err != nil {
 		return todayActResult{}, fmt.Errorf("today act: set scheduled: %w", err)
 	}
@@ -520,12 +625,13 @@ func actDefer(vaultDir string, n *node.Node, foundPath, ref, arg string) (todayA
 	}
 	return todayActResult{
 		Ref: ref, Key: "d", ID: n.ULID, Path: relTodoPath(vaultDir, foundPath),
-		State: n.Props["state"], Scheduled: date,
+		State: n.Props["state"], Scheduled: date, Warning: warning,
 	}, nil
 }
 
 // actDeadline implements the "D"/"deadline" key: deadline <- literal date.
-func actDeadline(vaultDir string, n *node.Node, foundPath, ref, arg string) (todayActResult, error) {
+// A deadline before the todo's scheduled date is handled as in actDefer.
+func actDeadline(vaultDir string, n *node.Node, foundPath, ref, arg string, strict bool) (todayActResult, error) {
 	if strings.TrimSpace(arg) == "" {
 		return todayActResult{}, fmt.Errorf("today act: D/deadline requires a YYYY-MM-DD argument")
 	}
@@ -534,6 +640,10 @@ func actDeadline(vaultDir string, n *node.Node, foundPath, ref, arg string) (tod
 		return todayActResult{}, fmt.Errorf("today act: deadline: %w", err)
 	}
 	date := d.Format("2006-01-02")
+	warning := scheduleDeadlineWarning(n.Props["scheduled"], date)
+	if warning != "" && strict {
+		return todayActResult{}, fmt.Errorf("today act: %s (--strict)", warning)
+	}
 	if err := setOrInsertField(n, "deadline", date); err != nil {
 		return todayActResult{}, fmt.Errorf("today act: set deadline: %w", err)
 	}
@@ -542,7 +652,7 @@ func actDeadline(vaultDir string, n *node.Node, foundPath, ref, arg string) (tod
 	}
 	return todayActResult{
 		Ref: ref, Key: "D", ID: n.ULID, Path: relTodoPath(vaultDir, foundPath),
-		State: n.Props["state"], Deadline: date,
+		State: n.Props["state"], Deadline: date, Warning: warning,
 	}, nil
 }
 
@@ -620,7 +730,7 @@ func runTodayOpenE(cmd *cobra.Command, args []string) error {
 	defer resetTodayFlags(cmd)
 	ref := args[0]
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -661,7 +771,7 @@ func runTodayOpenE(cmd *cobra.Command, args []string) error {
 
 	res := todayOpenResult{Ref: ref, SourceURL: url}
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
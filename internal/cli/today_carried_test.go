@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestToday_MarksCarriedRows: a row on the agenda because its scheduled date
+// passed is flagged carried (with the date it came from) in JSON and marked
+// with ↻ in pretty output; a row scheduled today is neither.
+func TestToday_MarksCarriedRows(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	carried := node.Mint()
+	writeTodoFixture(t, vault, carried, "open", "2026-07-07", "Carried task.")
+	fresh := node.Mint()
+	writeTodoFixture(t, vault, fresh, "open", "2026-07-10", "Fresh task.")
+
+	stdout, stderr, err := runToday(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk today --json: %v\nstderr: %s", err, stderr)
+	}
+	var res agendaResult
+	mustDecodeJSON(t, stdout, &res)
+	for _, it := range res.Items {
+		switch it.ID {
+		case carried:
+			if !it.Carried || it.CarriedFrom != "2026-07-07" {
+				t.Errorf("carried row = %+v, want carried from 2026-07-07", it)
+			}
+		case fresh:
+			if it.Carried || it.CarriedFrom != "" {
+				t.Errorf("row scheduled today = %+v, want it not carried", it)
+			}
+		}
+	}
+
+	resetCLIFlags()
+	stdout, _, err = runToday(t, vault)
+	if err != nil {
+		t.Fatalf("rk today: %v", err)
+	}
+	if !strings.Contains(stdout, "Carried task. ↻ 2026-07-07") {
+		t.Errorf("pretty output missing the carried marker:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "Fresh task. ↻") || strings.Contains(stdout, ansiDim) {
+		t.Errorf("pretty output without --color marked or styled the wrong rows:\n%s", stdout)
+	}
+
+	resetCLIFlags()
+	stdout, _, err = runToday(t, vault, "--color")
+	if err != nil {
+		t.Fatalf("rk today --color: %v", err)
+	}
+	if !strings.Contains(stdout, ansiDim+carried) {
+		t.Errorf("--color output should dim the carried row:\n%q", stdout)
+	}
+	if strings.Contains(stdout, ansiDim+fresh) {
+		t.Errorf("--color output dimmed the fresh row:\n%q", stdout)
+	}
+
+	resetCLIFlags()
+	stdout, _, err = runToday(t, vault, "--color", "--plain")
+	if err != nil {
+		t.Fatalf("rk today --color --plain: %v", err)
+	}
+	if !strings.Contains(stdout, "Carried task. (carried from 2026-07-07)") || strings.Contains(stdout, "↻") || strings.Contains(stdout, "\x1b[") {
+		t.Errorf("--plain output should spell out the marker and drop --color's styling:\n%q", stdout)
+	}
+}
+
+// TestToday_NoAutoCarryDropsCarriedRows: --no-auto-carry leaves out a row on
+// the agenda only because its scheduled date passed, keeps a carried row
+// whose deadline also falls due, and leaves the dropped row's file alone.
+func TestToday_NoAutoCarryDropsCarriedRows(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	carried := node.Mint()
+	carriedPath, carriedSrc := writeTodoFixture(t, vault, carried, "open", "2026-07-07", "Carried task.")
+	due := node.Mint()
+	writeTodoFixture(t, vault, due, "open", "2026-07-07", "Due task.", "deadline: 2026-07-10")
+	fresh := node.Mint()
+	writeTodoFixture(t, vault, fresh, "open", "2026-07-10", "Fresh task.")
+
+	stdout, stderr, err := runToday(t, vault, "--json", "--no-auto-carry")
+	if err != nil {
+		t.Fatalf("rk today --no-auto-carry: %v\nstderr: %s", err, stderr)
+	}
+	var res agendaResult
+	mustDecodeJSON(t, stdout, &res)
+	got := map[string]bool{}
+	for _, it := range res.Items {
+		got[it.ID] = true
+	}
+	if got[carried] || !got[due] || !got[fresh] {
+		t.Errorf("--no-auto-carry items = %+v, want the due and fresh rows only", res.Items)
+	}
+	if src := mustReadFile(t, carriedPath); src != carriedSrc {
+		t.Errorf("--no-auto-carry rewrote the carried todo:\n%s", src)
+	}
+
+	resetCLIFlags()
+	stdout, _, err = runToday(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk today: %v", err)
+	}
+	res = agendaResult{}
+	mustDecodeJSON(t, stdout, &res)
+	if len(res.Items) != 3 {
+		t.Errorf("plain rk today after --no-auto-carry = %d items, want 3 (flag must not stick)", len(res.Items))
+	}
+}
+
+// TestToday_CarryIsIdempotent: carrying is derived from the scheduled date
+// at read time, so asking for the agenda again never carries a row twice or
+// touches its file, and a carried todo completed today is not carried into
+// tomorrow.
+func TestToday_CarryIsIdempotent(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	carried := node.Mint()
+	carriedPath, carriedSrc := writeTodoFixture(t, vault, carried, "open", "2026-07-07", "Carried task.")
+	agenda := func() []agendaItem {
+		t.Helper()
+		resetCLIFlags()
+		stdout, stderr, err := runToday(t, vault, "--json")
+		if err != nil {
+			t.Fatalf("rk today: %v\nstderr: %s", err, stderr)
+		}
+		var res agendaResult
+		mustDecodeJSON(t, stdout, &res)
+		return res.Items
+	}
+	for i := range 2 {
+		rows := 0
+		for _, it := range agenda() {
+			if it.ID == carried {
+				rows++
+			}
+		}
+		if rows != 1 {
+			t.Errorf("rk today run %d: carried todo on the agenda %d times, want once", i+1, rows)
+		}
+	}
+	if src := mustReadFile(t, carriedPath); src != carriedSrc {
+		t.Errorf("rk today rewrote the carried todo:\n%s", src)
+	}
+
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "done", carried); err != nil {
+		t.Fatalf("rk todo done: %v\nstderr: %s", err, stderr)
+	}
+	pinTodoNow(t, "2026-07-11")
+	for _, it := range agenda() {
+		if it.ID == carried {
+			t.Errorf("a carried todo completed on 2026-07-10 was carried into 2026-07-11: %+v", it)
+		}
+	}
+}
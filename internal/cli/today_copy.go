@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todayCopyMergeFlag bool
+	todayCopyForceFlag bool
+)
+
+var todayCopyCmd = &cobra.Command{
+	Use:   "copy <from-date> <to-date>",
+	Short: "Copy a day's intentions and schedule into another day, as a template",
+	Long: "Copy the log entries of kind intention and schedule (`rk add --kind`) from one day to " +
+		"another, with fresh IDs and their own times, leaving every other entry behind. A target " +
+		"day that already has entries is refused unless --merge (add the copies, skipping any the " +
+		"target already has) or --force (replace the target's intention and schedule entries with " +
+		"the copies; its other entries stay). The target may not be later than today.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(2),
+	RunE:         runTodayCopyE,
+}
+
+func init() {
+	f := todayCopyCmd.Flags()
+	f.BoolVar(&todayCopyMergeFlag, "merge", false, "Add the copies to a target day that already has entries")
+	f.BoolVar(&todayCopyForceFlag, "force", false, "Replace a target day's intention and schedule entries with the copies")
+	todayCmd.AddCommand(todayCopyCmd)
+}
+
+// todayCopyKinds are the entry kinds `rk today copy` carries over: the
+// day's plan, not its record.
+var todayCopyKinds = []string{"intention", "schedule"}
+
+// todayCopyResult is `rk today copy`'s output.
+type todayCopyResult struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Path     string   `json:"path"`
+	Copied   []string `json:"copied"`   // the new entries' IDs
+	Skipped  int      `json:"skipped"`  // under --merge, copies the target already had
+	Replaced bool     `json:"replaced"` // --force replaced the target's planning entries
+}
+
+func (r todayCopyResult) Pretty() string {
+	s := fmt.Sprintf("today copy: %d entr(ies) from %s to %s", len(r.Copied), r.From, r.To)
+	if r.Skipped > 0 {
+		s += fmt.Sprintf(" (%d already there)", r.Skipped)
+	}
+	if r.Replaced {
+		s += " (replaced its intentions and schedule)"
+	}
+	return s
+}
+
+func runTodayCopyE(cmd *cobra.Command, args []string) error {
+	defer resetTodayFlags(cmd)
+
+	if todayCopyMergeFlag && todayCopyForceFlag {
+		return fmt.Errorf("today copy: --merge and --force are mutually exclusive")
+	}
+	now := journalNow()
+	today := now.Format("2006-01-02")
+	from, err := resolveDateEndpoint(args[0], now)
+	if err != nil {
+		return fmt.Errorf("today copy: from: %w", err)
+	}
+	to, err := resolveDateEndpoint(args[1], now)
+	if err != nil {
+		return fmt.Errorf("today copy: to: %w", err)
+	}
+	if from == to {
+		return fmt.Errorf("today copy: from and to are both %s", from)
+	}
+	if to > today {
+		return fmt.Errorf("today copy: to %s is after today (%s)", to, today)
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("today copy: load config: %w", err)
+	}
+	logDir := filepath.Join(cfg.VaultDir, "log")
+
+	src, err := readCopyDay(logDir, from)
+	if err != nil {
+		return err
+	}
+	var plan []*node.Node
+	for _, e := range src.entries {
+		if e.Time != "" && slices.Contains(todayCopyKinds, e.Props["kind"]) {
+			plan = append(plan, e)
+		}
+	}
+	if len(plan) == 0 {
+		return fmt.Errorf("today copy: no intention or schedule entries on %s (not found)", from)
+	}
+
+	dst, err := readCopyDay(logDir, to)
+	if err != nil {
+		return err
+	}
+	res := todayCopyResult{From: from, To: to, Path: dst.relPath, Copied: []string{}}
+	var drop []node.Span
+	switch {
+	case len(dst.entries) == 0:
+	case todayCopyForceFlag:
+		// Replace only the planning entries; the rest of the day stays.
+		for i, e := range dst.entries {
+			if slices.Contains(todayCopyKinds, e.Props["kind"]) {
+				drop = append(drop, dst.spans[i])
+			}
+		}
+		res.Replaced = true
+	case todayCopyMergeFlag:
+		have := map[string]bool{}
+		for _, e := range dst.entries {
+			have[e.Props["kind"]+"\x00"+e.Body] = true
+		}
+		kept := plan[:0]
+		for _, e := range plan {
+			if have[e.Props["kind"]+"\x00"+e.Body] {
+				res.Skipped++
+				continue
+			}
+			kept = append(kept, e)
+		}
+		plan = kept
+	default:
+		return fmt.Errorf("today copy: %s already has %d entr(ies); pass --merge to add to them or --force to replace its intentions and schedule", to, len(dst.entries))
+	}
+
+	var blocks []string
+	for _, e := range plan {
+		id := node.Mint()
+		header := journalHHMM(e.Time[len("2006-01-02T"):len("2006-01-02T15:04")]) + " " + e.Props["kind"]
+		blocks = append(blocks, node.RenderLogEntry(header, e.Author, id, e.Body))
+		res.Copied = append(res.Copied, id)
+	}
+	if err := dst.rewrite(to, drop, blocks); err != nil {
+		return err
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// copyDay is a day file as `rk today copy` reads it: its raw bytes (nil
+// when the day has no file), its log entries in file order, and each
+// entry's byte span in raw.
+type copyDay struct {
+	path, relPath string
+	raw           []byte
+	entries       []*node.Node
+	spans         []node.Span
+}
+
+// readCopyDay reads day's file under logDir; a day with no file has no
+// entries.
+func readCopyDay(logDir, day string) (copyDay, error) {
+	path, relPath := logDayFile(logDir, day)
+	d := copyDay{path: path, relPath: relPath}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return d, nil
+	}
+	if err != nil {
+		return d, fmt.Errorf("today copy: read %s: %w", relPath, err)
+	}
+	if bytes.Contains(raw, []byte("\r\n")) {
+		return d, fmt.Errorf("today copy: CRLF line endings are not supported (reckon-vj55): %s", relPath)
+	}
+	nodes, err := node.LogParser{}.Parse(raw, node.Loc{File: relPath})
+	if err != nil {
+		return d, fmt.Errorf("today copy: parse %s: %w", relPath, err)
+	}
+	d.raw = raw
+	if len(nodes) == 0 || nodes[0].Type != "log-day" {
+		return d, nil
+	}
+	// LogParser builds one entry per SplitEntries block, in order.
+	d.entries = nodes[1:]
+	for _, e := range nodes[0].SplitEntries() {
+		d.spans = append(d.spans, e.Span)
+	}
+	return d, nil
+}
+
+// rewrite writes d back with the entries at drop cut out and blocks
+// appended, in one atomic write (creating the file, as `rk add` does, when
+// the day has none), so a failure leaves the day as it was.
+func (d copyDay) rewrite(day string, drop []node.Span, blocks []string) error {
+	if len(blocks) == 0 && len(drop) == 0 {
+		return nil
+	}
+	var out []byte
+	if d.raw == nil {
+		n := node.NewNode("log-day", "", "# "+day+"\n\n"+strings.Join(blocks, "\n"))
+		n.Aliases = []string{day}
+		out = []byte(n.Render())
+	} else {
+		prev := 0
+		for _, sp := range drop {
+			out = append(out, d.raw[prev:sp.Start]...)
+			prev = sp.End
+		}
+		out = append(out, d.raw[prev:]...)
+		for _, b := range blocks {
+			out = append(out, "\n"+b...)
+		}
+	}
+	parsed, err := node.Parse(out)
+	if err != nil {
+		return fmt.Errorf("today copy: parse rewritten %s: %w", d.relPath, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o755); err != nil {
+		return fmt.Errorf("today copy: create %s: %w", filepath.Dir(d.relPath), err)
+	}
+	if err := writeFileAtomic(d.path, parsed.Serialize()); err != nil {
+		return fmt.Errorf("today copy: write: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodayCopy: only intention and schedule entries are copied, at their
+// own times with fresh IDs; a target with entries needs --merge (which
+// skips copies already there) or --force (which replaces its entries); and
+// both dates are validated, the target no later than today.
+func TestTodayCopy(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-12")
+
+	for _, e := range [][3]string{
+		{"09:00", "intention", "Ship the release notes"},
+		{"09:05", "schedule", "14:00 design review"},
+		{"11:30", "", "Fixed the flaky test"},
+		{"17:00", "win", "Release out"},
+	} {
+		resetCLIFlags()
+		args := []string{e[2], "--date", "2026-07-10", "--at", e[0]}
+		if e[1] != "" {
+			args = append(args, "--kind", e[1])
+		}
+		if _, stderr, err := runAdd(t, vault, args...); err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", e, err, stderr)
+		}
+	}
+
+	copyDay := func(args ...string) (todayCopyResult, error) {
+		t.Helper()
+		resetCLIFlags()
+		out, _, err := runToday(t, vault, append([]string{"copy", "--json"}, args...)...)
+		var res todayCopyResult
+		if err == nil {
+			mustDecodeJSON(t, out, &res)
+		}
+		return res, err
+	}
+	entries := func(day string) []*node.Node {
+		t.Helper()
+		got, err := readCopyDay(filepath.Join(vault, "log"), day)
+		if err != nil {
+			t.Fatalf("readCopyDay(%s): %v", day, err)
+		}
+		return got.entries
+	}
+
+	res, err := copyDay("2026-07-10", "2026-07-11")
+	if err != nil {
+		t.Fatalf("today copy: %v", err)
+	}
+	got := entries("2026-07-11")
+	if len(res.Copied) != 2 || len(got) != 2 {
+		t.Fatalf("copied %v, target has %d entries; want the 2 planning entries", res.Copied, len(got))
+	}
+	if got[0].Time != "2026-07-11T09:00:00Z" || got[0].Props["kind"] != "intention" || got[0].Body != "Ship the release notes" {
+		t.Errorf("first copy = %s %v %q", got[0].Time, got[0].Props, got[0].Body)
+	}
+	if src := entries("2026-07-10"); got[1].ULID == src[1].ULID || got[1].Body != "14:00 design review" {
+		t.Errorf("second copy = %s %q, want a fresh ID and the schedule text", got[1].ULID, got[1].Body)
+	}
+
+	if _, err := copyDay("2026-07-10", "2026-07-11"); err == nil || !strings.Contains(err.Error(), "--merge") {
+		t.Errorf("copy onto a day with entries: err = %v", err)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runAdd(t, vault, "Reviewed PRs", "--date", "2026-07-11", "--at", "10:00"); err != nil {
+		t.Fatalf("rk add: %v\nstderr: %s", err, stderr)
+	}
+	if res, err := copyDay("2026-07-10", "2026-07-11", "--merge"); err != nil || len(res.Copied) != 0 || res.Skipped != 2 {
+		t.Errorf("--merge = %+v, %v; want both copies skipped as already there", res, err)
+	}
+	dayPath, _ := logDayFile(filepath.Join(vault, "log"), "2026-07-11")
+	before, err := node.Parse([]byte(mustReadFile(t, dayPath)))
+	if err != nil {
+		t.Fatalf("parse target: %v", err)
+	}
+	res, err = copyDay("2026-07-10", "2026-07-11", "--force")
+	if err != nil || !res.Replaced || len(res.Copied) != 2 {
+		t.Fatalf("--force = %+v, %v; want 2 fresh copies", res, err)
+	}
+	got = entries("2026-07-11")
+	var bodies []string
+	for _, e := range got {
+		bodies = append(bodies, e.Body)
+	}
+	if len(got) != 3 || got[0].Body != "Reviewed PRs" || got[1].ULID != res.Copied[0] || got[2].ULID != res.Copied[1] {
+		t.Errorf("after --force the target has %q; want its log entry kept and the old copies replaced by %v", bodies, res.Copied)
+	}
+	if after, err := node.Parse([]byte(mustReadFile(t, dayPath))); err != nil || after.ULID != before.ULID {
+		t.Errorf("--force should keep the day's ID %s, got %v (%v)", before.ULID, after, err)
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"2026-07-10", "2026-07-13"}, "after today"},
+		{[]string{"2026-07-10", "2026-07-10"}, "both 2026-07-10"},
+		{[]string{"2026-07-10", "2026-02-30"}, "invalid date"},
+		{[]string{"2026-07-09", "2026-07-12"}, "not found"},
+		{[]string{"2026-07-10", "today", "--merge", "--force"}, "mutually exclusive"},
+	} {
+		if _, err := copyDay(tc.args...); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("today copy %v: err = %v, want %q", tc.args, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todayGapsMinutesFlag int
+
+var todayGapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "List long stretches between the day's log entries, to backfill",
+	Long: "Report every span between two consecutive log entries of today (or --date) at least " +
+		"--minutes long (default: the log_gap_minutes setting), with its start and end times. " +
+		"A review aid only: it never writes an entry.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTodayGapsE,
+}
+
+func init() {
+	todayGapsCmd.Flags().IntVar(&todayGapsMinutesFlag, "minutes", 0, "Shortest gap to report, in minutes (default: log_gap_minutes setting)")
+	todayCmd.AddCommand(todayGapsCmd)
+}
+
+// todayGapsResult is `rk today gaps`'s output.
+type todayGapsResult struct {
+	Date    string   `json:"date"`
+	Minutes int      `json:"minutes"` // the threshold gaps were measured against
+	Gaps    []logGap `json:"gaps"`
+}
+
+// logGap is one span with no log entries, between the entry From and the
+// one after it, To.
+type logGap struct {
+	Start   string `json:"start"` // HH:MM (UTC) of the entry before the gap
+	End     string `json:"end"`   // HH:MM (UTC) of the entry after it
+	Minutes int    `json:"minutes"`
+	From    string `json:"from"` // the entry before the gap's ID
+	To      string `json:"to"`   // the entry after the gap's ID
+}
+
+func (r todayGapsResult) Pretty() string {
+	if len(r.Gaps) == 0 {
+		return fmt.Sprintf("%s: no gaps of %s or more", r.Date, formatMinutes(r.Minutes))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d gap(s) of %s or more", r.Date, len(r.Gaps), formatMinutes(r.Minutes))
+	for _, g := range r.Gaps {
+		fmt.Fprintf(&b, "\n  %s-%s (%s)", g.Start, g.End, formatMinutes(g.Minutes))
+	}
+	return b.String()
+}
+
+func runTodayGapsE(cmd *cobra.Command, args []string) error {
+	defer resetTodayFlags(cmd)
+
+	if todayGapsMinutesFlag < 0 {
+		return fmt.Errorf("today gaps: --minutes must be positive, got %d", todayGapsMinutesFlag)
+	}
+	day, err := resolveDateFlag(journalNow())
+	if err != nil {
+		return fmt.Errorf("today gaps: %w", err)
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("today gaps: load config: %w", err)
+	}
+	minutes := todayGapsMinutesFlag
+	if minutes == 0 {
+		settings, err := config.LoadSettings(cfg.VaultDir)
+		if err != nil {
+			return fmt.Errorf("today gaps: %w", err)
+		}
+		minutes = settings.LogGapMinutes
+	}
+	if minutes == 0 {
+		return fmt.Errorf("today gaps: no gap length: pass --minutes or set log_gap_minutes")
+	}
+
+	ix, err := index.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("today gaps: open index: %w", err)
+	}
+	defer ix.Close()
+
+	if _, err := ix.Reconcile(); err != nil {
+		return fmt.Errorf("today gaps: reconcile index: %w", err)
+	}
+
+	gaps, err := findLogGaps(ix.DB(), day, time.Duration(minutes)*time.Minute)
+	if err != nil {
+		return err
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(todayGapsResult{Date: day, Minutes: minutes, Gaps: gaps}); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// findLogGaps returns the spans of at least threshold between consecutive
+// log entries of day (YYYY-MM-DD), in time order. Time before the first entry
+// and after the last is not a gap: the day's edges are unknown.
+func findLogGaps(db *sql.DB, day string, threshold time.Duration) ([]logGap, error) {
+	rows, err := db.Query(`SELECT n.id, n.time FROM nodes n
+		WHERE n.type = 'log-entry' AND `+logEntryDaySQL+` = ? ORDER BY n.time`, day)
+	if err != nil {
+		return nil, fmt.Errorf("today gaps: query log entries: %w", err)
+	}
+	defer rows.Close()
+
+	gaps := []logGap{}
+	var prevID string
+	var prev time.Time
+	for rows.Next() {
+		var id, ts string
+		if err := rows.Scan(&id, &ts); err != nil {
+			return nil, fmt.Errorf("today gaps: scan log entry: %w", err)
+		}
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			continue
+		}
+		if !prev.IsZero() && t.Sub(prev) >= threshold {
+			gaps = append(gaps, logGap{
+				Start:   prev.UTC().Format("15:04"),
+				End:     t.UTC().Format("15:04"),
+				Minutes: int(t.Sub(prev).Minutes()),
+				From:    prevID,
+				To:      id,
+			})
+		}
+		prevID, prev = id, t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("today gaps: iterate log entries: %w", err)
+	}
+	return gaps, nil
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTodayGaps: spans between consecutive entries of at least the
+// threshold are reported with their times; --minutes overrides the
+// log_gap_minutes default of 90, and other days' entries never count.
+func TestTodayGaps(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-05")
+
+	for _, e := range [][2]string{
+		{"2026-07-05", "09:00"}, {"2026-07-05", "09:30"}, {"2026-07-05", "12:00"},
+		{"2026-07-05", "12:20"}, {"2026-07-05", "15:00"}, {"2026-07-04", "18:00"},
+	} {
+		resetCLIFlags()
+		if _, stderr, err := runAdd(t, vault, "worked", "--date", e[0], "--at", e[1]); err != nil {
+			t.Fatalf("rk add %v: %v\nstderr: %s", e, err, stderr)
+		}
+	}
+
+	gaps := func(args ...string) todayGapsResult {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runToday(t, vault, append([]string{"gaps", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("rk today gaps %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res todayGapsResult
+		mustDecodeJSON(t, out, &res)
+		return res
+	}
+
+	res := gaps()
+	if res.Minutes != 90 || len(res.Gaps) != 2 {
+		t.Fatalf("gaps = %+v, want 2 gaps of 90m or more", res)
+	}
+	if g := res.Gaps[0]; g.Start != "09:30" || g.End != "12:00" || g.Minutes != 150 {
+		t.Errorf("first gap = %+v, want 09:30-12:00 (150m)", g)
+	}
+	if res := gaps("--minutes", "155"); len(res.Gaps) != 1 || res.Gaps[0].Start != "12:20" {
+		t.Errorf("--minutes 155 = %+v, want only 12:20-15:00", res.Gaps)
+	}
+	if res := gaps("--date", "2026-07-04"); len(res.Gaps) != 0 {
+		t.Errorf("a day with one entry has gaps %+v", res.Gaps)
+	}
+
+	resetCLIFlags()
+	out, _, err := runToday(t, vault, "gaps")
+	if err != nil {
+		t.Fatalf("rk today gaps: %v", err)
+	}
+	if !strings.Contains(out, "09:30-12:00 (2h30m)") {
+		t.Errorf("pretty gaps missing 09:30-12:00:\n%s", out)
+	}
+}
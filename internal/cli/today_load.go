@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+)
+
+// agendaLoad is the agenda's total estimated effort: the sum of its native
+// rows' estimate props, checked against the daily_capacity setting. A row
+// without a (valid) estimate counts as zero and is tallied in Unestimated.
+type agendaLoad struct {
+	EstimateMinutes int  `json:"estimate_minutes"`
+	Unestimated     int  `json:"unestimated"`
+	CapacityMinutes int  `json:"capacity_minutes,omitempty"` // 0 = no daily_capacity set
+	OverCapacity    bool `json:"over_capacity,omitempty"`
+
+	warnings []string // malformed estimates and the over-capacity warning
+}
+
+// agendaLoadFor sums items' estimates against capacity (a ParseEstimate
+// string, "" for none). Returns nil when items has no native rows, since
+// external rows carry no estimate to sum.
+func agendaLoadFor(items []agendaItem, capacity string) *agendaLoad {
+	var l agendaLoad
+	native := 0
+	for _, it := range items {
+		if it.ReadOnly {
+			continue
+		}
+		native++
+		if it.Estimate == "" {
+			l.Unestimated++
+			continue
+		}
+		d, err := config.ParseEstimate(it.Estimate)
+		if err != nil {
+			l.Unestimated++
+			l.warnings = append(l.warnings, fmt.Sprintf("today: %s: counting malformed estimate as zero: %v", it.ID, err))
+			continue
+		}
+		l.EstimateMinutes += int(d.Minutes())
+	}
+	if native == 0 {
+		return nil
+	}
+	// LoadSettings has already validated capacity.
+	if c, err := config.ParseEstimate(capacity); capacity != "" && err == nil {
+		l.CapacityMinutes = int(c.Minutes())
+		if l.EstimateMinutes > l.CapacityMinutes {
+			l.OverCapacity = true
+			l.warnings = append(l.warnings, fmt.Sprintf("today: warning: estimated load %s exceeds daily_capacity %s",
+				formatMinutes(l.EstimateMinutes), formatMinutes(l.CapacityMinutes)))
+		}
+	}
+	return &l
+}
+
+// line renders the load as the agenda's pretty footer, e.g.
+// "load: 7h00m of 6h00m capacity (over), 1 todo(s) unestimated, counted as 0".
+func (l agendaLoad) line() string {
+	s := "load: " + formatMinutes(l.EstimateMinutes)
+	if l.CapacityMinutes > 0 {
+		s += " of " + formatMinutes(l.CapacityMinutes) + " capacity"
+		if l.OverCapacity {
+			s += " (over)"
+		}
+	} else {
+		s += " estimated"
+	}
+	if l.Unestimated > 0 {
+		s += fmt.Sprintf(", %d todo(s) unestimated, counted as 0", l.Unestimated)
+	}
+	return s
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestToday_EstimatedLoad: `rk today` sums the agenda's estimates (an
+// unestimated todo counts as zero) and warns once they exceed
+// daily_capacity; todo add --estimate records the estimate.
+func TestToday_EstimatedLoad(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+	writeVaultSettings(t, vault, "daily_capacity: 6h\n")
+
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "add", "Write the report", "--scheduled", "2026-07-10", "--estimate", "1d", "--json")
+	if err != nil {
+		t.Fatalf("todo add --estimate: %v\nstderr: %s", err, stderr)
+	}
+	var added todoAddResult
+	mustDecodeJSON(t, out, &added)
+	if src := mustReadFile(t, vault+"/"+added.Path); !strings.Contains(src, "estimate: 1d\n") {
+		t.Errorf("todo file should record the estimate:\n%s", src)
+	}
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-10", "Quick call.", "estimate: 30m")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-10", "Unsized task.")
+
+	resetCLIFlags()
+	out, stderr, err = runToday(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk today --json: %v\nstderr: %s", err, stderr)
+	}
+	var res agendaResult
+	mustDecodeJSON(t, out, &res)
+	if res.Load == nil || res.Load.EstimateMinutes != 510 || res.Load.Unestimated != 1 ||
+		res.Load.CapacityMinutes != 360 || !res.Load.OverCapacity {
+		t.Errorf("load = %+v, want 510m of 360m, over, 1 unestimated", res.Load)
+	}
+	if !strings.Contains(stderr, "estimated load 8h30m exceeds daily_capacity 6h00m") {
+		t.Errorf("stderr should warn about the over-capacity load:\n%s", stderr)
+	}
+
+	resetCLIFlags()
+	out, _, err = runToday(t, vault)
+	if err != nil {
+		t.Fatalf("rk today: %v", err)
+	}
+	if !strings.Contains(out, "load: 8h30m of 6h00m capacity (over), 1 todo(s) unestimated, counted as 0") {
+		t.Errorf("pretty agenda missing the load footer:\n%s", out)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "add", "Bad", "--estimate", "soon"); err == nil {
+		t.Error("todo add with a malformed --estimate should fail")
+	}
+}
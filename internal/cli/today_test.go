@@ -56,12 +56,16 @@
 //	    ReadOnly  bool   `json:"read_only,omitempty"`   // true for external/work-ticket rows
 //	    Body      string `json:"body,omitempty"`
 //	    Title     string `json:"title,omitempty"`       // derived first non-empty body line (reckon-fnqs.3)
+//	    Carried     bool   `json:"carried,omitempty"`      // native row whose scheduled date has passed
+//	    CarriedFrom string `json:"carried_from,omitempty"` // that scheduled date
+//	    Estimate    string `json:"estimate,omitempty"`     // native only: effort estimate, e.g. "2h"
 //	}
 //
 //	// agendaResult wraps `rk today`'s items so --json emits a single object
 //	// ({"items": []} on empty), mirroring todoListResult.
 //	type agendaResult struct {
 //	    Items []agendaItem `json:"items"`
+//	    Load  *agendaLoad  `json:"load,omitempty"` // estimate_minutes, unestimated, capacity_minutes, over_capacity
 //	}
 //
 //	// todayActResult is the structured summary of one `rk today act` run,
@@ -760,7 +764,7 @@ func TestTodayAct_DoneFlipsStateSpanLocal(t *testing.T) {
 		t.Fatalf("rk today act x --no-log: %v\nstderr: %s", err, stderr)
 	}
 
-	want := strings.Replace(src, "state: open", "state: done", 1)
+	want := markedDone(src)
 	got := mustReadFile(t, path)
 	if got != want {
 		t.Fatalf("done not span-local\n--- want ---\n%q\n--- got ---\n%q", want, got)
@@ -968,7 +972,7 @@ func TestTodayAct_DoneNoLogSuppressesEntry(t *testing.T) {
 		t.Fatalf("rk today act x --no-log: %v\nstderr: %s", err, stderr)
 	}
 
-	want := strings.Replace(src, "state: open", "state: done", 1)
+	want := markedDone(src)
 	got := mustReadFile(t, path)
 	if got != want {
 		t.Fatalf("state flip not span-local under --no-log\n--- want ---\n%q\n--- got ---\n%q", want, got)
@@ -1109,7 +1113,7 @@ func TestTodayAct_NativeRowUnaffectedBySplit(t *testing.T) {
 		t.Fatalf("rk today act x (native, amid mixed agenda): %v\nstderr: %s", err, stderr)
 	}
 
-	want := strings.Replace(nativeSrc, "state: open", "state: done", 1)
+	want := markedDone(nativeSrc)
 	got := mustReadFile(t, nativePath)
 	if got != want {
 		t.Fatalf("native row not actuated span-locally amid a mixed agenda\n--- want ---\n%q\n--- got ---\n%q", want, got)
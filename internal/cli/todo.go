@@ -5,9 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -38,17 +41,51 @@ var todoNow = func() time.Time { return time.Now().UTC() }
 // ─────────────────────────────────────────────────────────────────────────────
 
 var (
-	todoEphemeralFlag     bool
-	todoScheduledFlag     string
-	todoDeadlineFlag      string
-	todoDependsFlag       string
-	todoRepeatFlag        string
-	todoAuthorFlag        string
-	todoListAllFlag       bool
-	todoListStateFlag     string
-	todoListDurableFlag   bool
-	todoListEphemeralFlag bool
-	todoDoneEphemeralFlag bool
+	todoEphemeralFlag      bool
+	todoScheduledFlag      string
+	todoDeadlineFlag       string
+	todoDependsFlag        string
+	todoRepeatFlag         string
+	todoAuthorFlag         string
+	todoListAllFlag        bool
+	todoListStateFlag      string
+	todoListDurableFlag    bool
+	todoListEphemeralFlag  bool
+	todoDoneEphemeralFlag  bool
+	todoAddStdinFlag       bool
+	todoNoteStdinFlag      bool
+	todoStrictFlag         bool
+	todoListSchedFlag      string
+	todoMatchFlag          bool
+	todoListFullIDFlag     bool
+	todoListIDWidthFlag    int
+	todoReschedSchedFlag   bool
+	todoDryRunFlag         bool
+	todoSplitIntoFlag      []string
+	todoSplitParentFlag    bool
+	todoParentFlag         string
+	todoListTreeFlag       bool
+	todoAssigneeFlag       string
+	todoListMineFlag       bool
+	todoListUnassignedFlag bool
+	todoEstimateFlag       string
+	todoListColumnsFlag    string
+	todoListCountFlag      bool
+	todoStrictMatchFlag    bool
+	todoTagsFlag           string
+	todoListTagFlag        string
+	todoListNotTagFlag     []string
+	todoListOverdueFlag    bool
+	todoListDoneOnFlag     string
+	todoListDoneTodayFlag  bool
+	todoEditFlag           bool
+	todoPreviewFlag        bool
+	todoYesFlag            bool
+	todoGCDaysFlag         int
+	todoMatchThresholdFlag int
+	todoEditTitleFlag      string
+	todoClearSchedFlag     bool
+	todoClearDeadlineFlag  bool
 )
 
 // resetTodoFlags restores todo flag variables to their defaults and clears the
@@ -67,7 +104,46 @@ func resetTodoFlags(cmd *cobra.Command) {
 	todoListDurableFlag = false
 	todoListEphemeralFlag = false
 	todoDoneEphemeralFlag = false
-	for _, name := range []string{"ephemeral", "scheduled", "deadline", "depends", "repeat", "author", "all", "state", "durable"} {
+	todoAddStdinFlag = false
+	todoNoteStdinFlag = false
+	todoDeleteStdinFlag = false
+	todoShowFormatFlag = ""
+	todoStrictFlag = false
+	todoListSchedFlag = ""
+	todoMatchFlag = false
+	todoListFullIDFlag = false
+	todoListIDWidthFlag = 0
+	todoReschedSchedFlag = false
+	todoDryRunFlag = false
+	todoSplitIntoFlag = nil
+	todoSplitParentFlag = false
+	todoParentFlag = ""
+	todoListTreeFlag = false
+	todoAssigneeFlag = ""
+	todoListMineFlag = false
+	todoListUnassignedFlag = false
+	todoEstimateFlag = ""
+	todoListColumnsFlag = todoColumnsAuto
+	todoListCountFlag = false
+	todoStrictMatchFlag = false
+	todoTagsFlag = ""
+	todoListTagFlag = ""
+	todoListNotTagFlag = nil
+	todoListOverdueFlag = false
+	todoListDoneOnFlag = ""
+	todoListDoneTodayFlag = false
+	todoListWatchFlag = false
+	todoListBellFlag = false
+	todoEditFlag = false
+	todoPreviewFlag = false
+	todoYesFlag = false
+	todoGCDaysFlag = 0
+	todoMatchThresholdFlag = 0
+	todoEditTitleFlag = ""
+	todoClearSchedFlag = false
+	todoClearDeadlineFlag = false
+	todoProjectFlag = ""
+	for _, name := range []string{"ephemeral", "scheduled", "deadline", "depends", "repeat", "author", "all", "state", "durable", "stdin", "strict", "match", "full-id", "id-width", "schedule", "dry-run", "into", "complete-parent", "parent", "tree", "assignee", "mine", "include-unassigned", "estimate", "columns", "count", "strict-match", "tags", "tag", "edit", "preview", "yes", "days", "match-threshold", "project", "include-overdue", "format", "done-on", "done-today", "title", "clear-scheduled", "clear-deadline", "watch", "bell", "not-tag"} {
 		if fl := cmd.Flags().Lookup(name); fl != nil {
 			fl.Changed = false
 		}
@@ -82,14 +158,17 @@ func resetTodoFlags(cmd *cobra.Command) {
 var todoCmd = &cobra.Command{
 	Use:   "todo",
 	Short: "Manage todo items (durable and ephemeral)",
-	Long:  "Create, list, and complete todo items. Durable todos live one-per-file under todos/<ULID>.md; ephemeral todos are checkbox lines in a shared todos/inbox.md container.",
+	Long: "Create, list, and complete todo items. Durable todos live one-per-file under todos/<ULID>.md, or " +
+		"todos/<project>/<ULID>.md in a project; ephemeral todos are checkbox lines in a shared todos/inbox.md container. " +
+		"--project scopes any todo command to one project: add files into it, list shows only it, and refs resolve only within it.",
 }
 
 var todoAddCmd = &cobra.Command{
 	Use:          "add <text...>",
 	Short:        "Create a new todo (durable by default, or --ephemeral)",
+	Long:         "Create a new todo from the argument text, or with --stdin one todo per non-blank stdin line (every other flag applies to each).",
 	SilenceUsage: true,
-	Args:         cobra.MinimumNArgs(1),
+	Args:         cobra.ArbitraryArgs, // arity depends on --stdin; checked in runTodoAddE
 	RunE:         runTodoAddE,
 }
 
@@ -103,13 +182,26 @@ var todoListCmd = &cobra.Command{
 
 var todoDoneCmd = &cobra.Command{
 	Use:          "done <ref>",
+	Aliases:      []string{"complete"},
 	Short:        "Mark a todo done (durable ref/alias, or --ephemeral <index>)",
+	Long:         "Mark a todo done. Idempotent: an already-done todo is reported as skipped, never reopened (use `rk todo reopen` for that).",
 	SilenceUsage: true,
 	Args:         cobra.ExactArgs(1),
 	RunE:         runTodoDoneE,
 }
 
+var todoReopenCmd = &cobra.Command{
+	Use:          "reopen <ref>",
+	Short:        "Set a done/cancelled todo back to open (durable ref/alias, or --ephemeral <index>)",
+	Long:         "Set a todo's state back to open. Idempotent: an already-open todo is reported as skipped.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runTodoReopenE,
+}
+
 func init() {
+	todoCmd.PersistentFlags().StringVar(&todoProjectFlag, "project", "", "Scope to one project, a subdirectory of todos/ (add default: todo_project setting)")
+
 	af := todoAddCmd.Flags()
 	af.BoolVar(&todoEphemeralFlag, "ephemeral", false, "Create an ephemeral inbox item instead of a durable todo")
 	af.StringVar(&todoScheduledFlag, "scheduled", "", "Scheduled date (durable only)")
@@ -117,18 +209,74 @@ func init() {
 	af.StringVar(&todoDependsFlag, "depends", "", "ULID/alias this todo depends on (durable only)")
 	af.StringVar(&todoRepeatFlag, "repeat", "", "Org-style repeater cookie (+Nd, ++Nd, .+Nd; durable only, requires --scheduled)")
 	af.StringVar(&todoAuthorFlag, "author", "", "Author to record (default: $RECKON_AUTHOR, $USER, or \"local\")")
+	af.BoolVar(&todoAddStdinFlag, "stdin", false, "Read todo text from stdin, one todo per line")
+	af.BoolVar(&todoStrictFlag, "strict", false, "Fail (instead of warning) when --scheduled is after --deadline")
+	af.StringVar(&todoParentFlag, "parent", "", "ULID/alias of the todo this one is a subtask of (durable only)")
+	af.StringVar(&todoAssigneeFlag, "assignee", "", "Who the todo is assigned to (durable only; default: the default_assignee setting, else unassigned; \"\" for unassigned)")
+	af.StringVar(&todoEstimateFlag, "estimate", "", "Effort estimate, e.g. 30m, 2h, or 1d (a day is 8h; durable only)")
+	af.StringVar(&todoTagsFlag, "tags", "", "Comma-separated tags (durable only; default: the inbox_tag when auto_inbox is on)")
 
 	lf := todoListCmd.Flags()
 	lf.BoolVar(&todoListAllFlag, "all", false, "Include done/checked items")
 	lf.StringVar(&todoListStateFlag, "state", "", "Filter durable todos by exact state")
 	lf.BoolVar(&todoListDurableFlag, "durable", false, "Show only durable todos")
 	lf.BoolVar(&todoListEphemeralFlag, "ephemeral", false, "Show only ephemeral todos")
+	lf.StringVar(&todoListSchedFlag, "scheduled", "", "Filter by scheduled date: today, this-week, past, YYYY-MM-DD, or a range like 2026-01-01..2026-01-07 or -7d..today")
+	lf.BoolVar(&todoListFullIDFlag, "full-id", false, "Print full durable todo IDs, ignoring todo_id_width")
+	lf.BoolVar(&todoListTreeFlag, "tree", false, "List subtasks indented under their parent todo")
+	lf.StringVar(&todoAssigneeFlag, "assignee", "", "Show only todos assigned to this name")
+	lf.BoolVar(&todoListMineFlag, "mine", false, "Show only todos assigned to you (the default_assignee setting, else $RECKON_AUTHOR, $USER, or \"local\")")
+	lf.BoolVar(&todoListUnassignedFlag, "include-unassigned", false, "With --mine/--assignee, also show unassigned items")
+	lf.StringVar(&todoListColumnsFlag, "columns", todoColumnsAuto, "Which details pretty rows show: compact, normal, wide, or auto (by terminal width)")
+	lf.BoolVar(&todoListCountFlag, "count", false, "Print only the number of matching items")
+	lf.StringVar(&todoListTagFlag, "tag", "", "Show only durable todos carrying this tag")
+	lf.StringArrayVar(&todoListNotTagFlag, "not-tag", nil, "Leave out todos carrying this tag, ignoring case, after --tag has filtered (repeatable, or comma-separated)")
+	lf.BoolVar(&todoListOverdueFlag, "include-overdue", false, "With --scheduled, also show open todos scheduled or due before today")
+	lf.StringVar(&todoListDoneOnFlag, "done-on", "", "Show only todos completed on this date or range (today, -1d, YYYY-MM-DD, or a range like -7d..today)")
+	lf.BoolVar(&todoListDoneTodayFlag, "done-today", false, "Show only todos completed today (same as --done-on today)")
+	lf.IntVar(&todoListIDWidthFlag, "id-width", 0, "Print durable todo IDs truncated to N characters, widened where needed to stay unique (default: todo_id_width setting, 0 = full)")
 
 	df := todoDoneCmd.Flags()
 	df.BoolVar(&todoDoneEphemeralFlag, "ephemeral", false, "Target the ephemeral inbox: <ref> is a 1-based line index")
 	df.StringVar(&todoAuthorFlag, "author", "", "Author to record on a recurring rule's did:: audit entry (default: $RECKON_AUTHOR, $USER, or \"local\")")
 
-	todoCmd.AddCommand(todoAddCmd, todoListCmd, todoDoneCmd)
+	todoNoteCmd.Flags().BoolVar(&todoEditFlag, "edit", false, "Write the note in $VISUAL/$EDITOR")
+	todoNoteCmd.Flags().BoolVar(&todoNoteStdinFlag, "stdin", false, "Attach piped stdin (e.g. command output) verbatim under the note text")
+
+	rf := todoReopenCmd.Flags()
+	rf.BoolVar(&todoDoneEphemeralFlag, "ephemeral", false, "Target the ephemeral inbox: <ref> is a 1-based line index")
+
+	ef := todoEditCmd.Flags()
+	ef.StringVar(&todoEditTitleFlag, "title", "", "New title (the body's first line)")
+	ef.StringVar(&todoTagsFlag, "tags", "", "Comma-separated tags, replacing the current ones (\"\" removes them all)")
+	ef.StringVar(&todoScheduledFlag, "scheduled", "", "New scheduled date: YYYY-MM-DD, today, tomorrow, or an offset like +3d")
+	ef.StringVar(&todoDeadlineFlag, "deadline", "", "New deadline: YYYY-MM-DD, today, tomorrow, or an offset like +3d")
+	ef.BoolVar(&todoClearSchedFlag, "clear-scheduled", false, "Remove the scheduled date")
+	ef.BoolVar(&todoClearDeadlineFlag, "clear-deadline", false, "Remove the deadline")
+	ef.BoolVar(&todoStrictFlag, "strict", false, "Fail (instead of warning) when the scheduled date ends up after the deadline")
+
+	for _, c := range []*cobra.Command{todoDoneCmd, todoReopenCmd, todoShowCmd, todoNoteCmd, todoCheckCmd, todoEditCmd} {
+		c.Flags().BoolVar(&todoMatchFlag, "match", false, "Treat <ref> as a fuzzy query against durable todo titles")
+		c.Flags().BoolVar(&todoStrictMatchFlag, "strict-match", false, "With --match, fail on any ambiguity instead of picking a clearly best match")
+		c.Flags().IntVar(&todoMatchThresholdFlag, "match-threshold", 0, "With --match, the lowest fuzzy score that counts as a match (default: match_threshold setting)")
+	}
+
+	xf := todoRescheduleOverdueCmd.Flags()
+	xf.BoolVar(&todoReschedSchedFlag, "schedule", false, "Sweep todos with a past scheduled date instead of a past deadline")
+	xf.BoolVar(&todoDryRunFlag, "dry-run", false, "Report what would change without writing")
+	xf.BoolVar(&todoPreviewFlag, "preview", false, "Show each change's before and after on stderr and ask before writing")
+	xf.BoolVar(&todoYesFlag, "yes", false, "With --preview, apply without asking")
+
+	gf := todoGCCmd.Flags()
+	gf.IntVar(&todoGCDaysFlag, "days", 0, "Archive todos done more than N days ago (default: archive_after_days setting)")
+	gf.BoolVar(&todoDryRunFlag, "dry-run", false, "Report what would be archived without writing")
+
+	sf := todoSplitCmd.Flags()
+	sf.StringArrayVar(&todoSplitIntoFlag, "into", nil, "Text of one subtask (repeat for each piece)")
+	sf.BoolVar(&todoSplitParentFlag, "complete-parent", false, "Mark the original todo done once the subtasks exist")
+	sf.StringVar(&todoAuthorFlag, "author", "", "Author to record on the subtasks (default: $RECKON_AUTHOR, $USER, or \"local\")")
+
+	todoCmd.AddCommand(todoAddCmd, todoListCmd, todoShowCmd, todoEditCmd, todoNoteCmd, todoCheckCmd, todoDoneCmd, todoReopenCmd, todoRescheduleOverdueCmd, todoSplitCmd, todoParentCmd, todoTriageCmd, todoPlanCmd, todoGCCmd)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -139,8 +287,9 @@ func init() {
 // todoAddResult is the structured summary of one `rk todo add` run.
 type todoAddResult struct {
 	Kind  string `json:"kind"`            // "durable" | "ephemeral"
-	Path  string `json:"path"`            // vault-relative: "todos/<ULID>.md" or "todos/inbox.md"
+	Path  string `json:"path"`            // vault-relative: "todos/[<project>/]<ULID>.md" or "todos/inbox.md"
 	ID    string `json:"id,omitempty"`    // durable only: the new node's ULID
+	Alias string `json:"alias,omitempty"` // durable only: task_id_style alias, if any
 	Line  int    `json:"line,omitempty"`  // ephemeral only: 1-based index of the appended item
 	State string `json:"state,omitempty"` // durable only: "open" on create
 }
@@ -149,38 +298,194 @@ func (r todoAddResult) Pretty() string {
 	if r.Kind == "ephemeral" {
 		return fmt.Sprintf("todo: added ephemeral item to %s (line %d)", r.Path, r.Line)
 	}
+	if r.Alias != "" {
+		return fmt.Sprintf("todo: added %s (id %s, alias %s, state %s)", r.Path, r.ID, r.Alias, r.State)
+	}
 	return fmt.Sprintf("todo: added %s (id %s, state %s)", r.Path, r.ID, r.State)
 }
 
+// todoAddBulkResult is the structured summary of one `rk todo add --stdin`
+// run: every created todo plus one error entry per line that failed.
+type todoAddBulkResult struct {
+	Created []todoAddResult    `json:"created"`
+	Errors  []todoAddBulkError `json:"errors"`
+}
+
+// todoAddBulkError is one stdin line `rk todo add --stdin` could not create.
+type todoAddBulkError struct {
+	Line  int    `json:"line"` // 1-based index among the non-blank input lines
+	Text  string `json:"text"`
+	Error string `json:"error"`
+}
+
+func (r todoAddBulkResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: added %d todo(s)", len(r.Created))
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(&b, ", %d failed", len(r.Errors))
+	}
+	for _, c := range r.Created {
+		b.WriteString("\n  " + c.Pretty())
+	}
+	for _, e := range r.Errors {
+		fmt.Fprintf(&b, "\n  error line %d (%q): %s", e.Line, e.Text, e.Error)
+	}
+	return b.String()
+}
+
+// todoStates are the states rk itself moves a durable todo through. A
+// vault may use others (`waiting`), which `rk todo list --state` filters on
+// as exactly as these; a filter matching nothing gets the nearest of these
+// suggested, in case it was a typo.
+var todoStates = []string{"open", "in-progress", "done", "cancelled", "archived"}
+
 // todoListItem is one row of `rk todo list` output, durable or ephemeral.
 type todoListItem struct {
-	Kind      string `json:"kind"`                // "durable" | "ephemeral"
-	ID        string `json:"id,omitempty"`        // durable only: ULID
-	Path      string `json:"path,omitempty"`      // durable only: vault-relative file path
-	Container string `json:"container,omitempty"` // ephemeral only: vault-relative container path
-	Line      int    `json:"line,omitempty"`      // ephemeral only: stable 1-based index in file order
-	State     string `json:"state,omitempty"`     // durable only: "open" | "done"
-	Checked   bool   `json:"checked"`             // ephemeral only (meaningful false, so no omitempty)
-	Scheduled string `json:"scheduled,omitempty"` // durable only
-	Deadline  string `json:"deadline,omitempty"`  // durable only
-	Depends   string `json:"depends,omitempty"`   // durable only
-	Repeat    string `json:"repeat,omitempty"`    // durable only: repeater cookie, sourced from props["repeat"]
-	Body      string `json:"body"`                // node body (durable) / checkbox text (ephemeral)
-	Title     string `json:"title,omitempty"`     // durable only: derived first non-empty body line
+	Kind      string   `json:"kind"`                // "durable" | "ephemeral"
+	ID        string   `json:"id,omitempty"`        // durable only: ULID
+	Path      string   `json:"path,omitempty"`      // durable only: vault-relative file path
+	Project   string   `json:"project,omitempty"`   // durable only: the todos/ subdirectory it is in, "" for none
+	Container string   `json:"container,omitempty"` // ephemeral only: vault-relative container path
+	Line      int      `json:"line,omitempty"`      // ephemeral only: stable 1-based index in file order
+	State     string   `json:"state,omitempty"`     // durable only: "open" | "done"
+	Checked   bool     `json:"checked"`             // ephemeral only (meaningful false, so no omitempty)
+	Scheduled string   `json:"scheduled,omitempty"` // durable only
+	Deadline  string   `json:"deadline,omitempty"`  // durable only
+	Depends   string   `json:"depends,omitempty"`   // durable only
+	Parent    string   `json:"parent,omitempty"`    // durable only: the parent todo's ULID, for subtasks
+	Repeat    string   `json:"repeat,omitempty"`    // durable only: repeater cookie, sourced from props["repeat"]
+	Tags      []string `json:"tags,omitempty"`      // durable only: the tags prop's list
+	Assignee  string   `json:"assignee,omitempty"`  // durable only: "" = unassigned
+	Estimate  string   `json:"estimate,omitempty"`  // durable only: effort estimate, e.g. "2h"
+	Pinned    bool     `json:"pinned,omitempty"`    // durable only: listed first (`rk todo pin`)
+	Done      string   `json:"done,omitempty"`      // durable only: the day it was marked done (see markTodoDone)
+	Body      string   `json:"body"`                // node body (durable) / checkbox text (ephemeral)
+	Title     string   `json:"title,omitempty"`     // durable only: derived first non-empty body line
+	Overdue   bool     `json:"overdue,omitempty"`   // durable only, under --include-overdue: see todoOverdue
+	Completed string   `json:"completed,omitempty"` // durable only, under --done-on: the completion day in range (see todoCompletedOn)
+}
+
+// todoOverdue reports whether the durable todo it is still open (or in
+// progress) with its scheduled date or deadline before today, as the agenda
+// carries it over.
+func todoOverdue(it todoListItem, today string) bool {
+	if it.Kind != "durable" || (it.State != "open" && it.State != "in-progress") {
+		return false
+	}
+	for _, date := range []string{it.Scheduled, it.Deadline} {
+		if _, err := parseSchedDate(date); err == nil && date < today {
+			return true
+		}
+	}
+	return false
 }
 
 // todoListResult wraps `rk todo list`'s items so --json emits a single object
 // ({"items": []} on empty), not a bare top-level array.
 type todoListResult struct {
 	Items []todoListItem `json:"items"`
+	// Problems lists the files under todos/ the listing could not read,
+	// so a broken hand edit hides only its own todo, visibly.
+	Problems []todoFileProblem `json:"problems,omitempty"`
+	// Undated counts archived todos a --done-on listing left out because
+	// their completion day is unknown (see todoCompletedOn).
+	Undated int `json:"undated,omitempty"`
+
+	// shortIDs maps a durable item's ID to its abbreviated display form
+	// (abbreviateIDs); pretty output only, --json always carries full IDs.
+	shortIDs map[string]string
+	// depth is each listed item's nesting level under --tree (subtasks 1);
+	// pretty output only.
+	depth map[string]int
+	// columns is the resolved --columns preset (never auto); pretty output
+	// only, "" meaning normal.
+	columns string
+	// scoped is set under --project, whose rows all share one project, so
+	// pretty output leaves the project column off.
+	scoped bool
+	// context is the active context tag (`rk context`), shown in the
+	// pretty header.
+	context string
+}
+
+// todoFileProblem is a file under todos/ that yields no todo, and why.
+type todoFileProblem struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// todoCountResult is `rk todo list --count`'s output: how many items the
+// listing would show, printed bare in pretty mode.
+type todoCountResult struct {
+	Count int `json:"count"`
+}
+
+func (r todoCountResult) Pretty() string { return strconv.Itoa(r.Count) }
+
+// `rk todo list --columns` presets: which details a pretty durable row
+// shows after its ID, state, and title.
+const (
+	todoColumnsAuto    = "auto"    // compact, normal, or wide by terminal width
+	todoColumnsCompact = "compact" // the deadline only
+	todoColumnsNormal  = "normal"  // dates, dependency, estimate, assignee
+	todoColumnsWide    = "wide"    // normal plus tags, repeater, and parent
+)
+
+// Terminal widths --columns auto switches preset at: narrower than
+// todoColumnsNarrowWidth is compact, todoColumnsWideWidth or wider is wide.
+const (
+	todoColumnsNarrowWidth = 80
+	todoColumnsWideWidth   = 160
+)
+
+// resolveTodoColumns maps a --columns value to a concrete preset; auto
+// picks by the width of out's terminal, and is normal when out is not one.
+func resolveTodoColumns(flag string, out io.Writer) (string, error) {
+	switch flag {
+	case todoColumnsCompact, todoColumnsNormal, todoColumnsWide:
+		return flag, nil
+	case todoColumnsAuto:
+	default:
+		return "", fmt.Errorf("todo list: --columns must be %s, %s, %s, or %s, got %q%s",
+			todoColumnsCompact, todoColumnsNormal, todoColumnsWide, todoColumnsAuto, flag,
+			config.Suggest(flag, todoColumnsCompact, todoColumnsNormal, todoColumnsWide, todoColumnsAuto))
+	}
+	width := terminalWidth(out)
+	switch {
+	case width <= 0:
+		return todoColumnsNormal, nil
+	case width < todoColumnsNarrowWidth:
+		return todoColumnsCompact, nil
+	case width >= todoColumnsWideWidth:
+		return todoColumnsWide, nil
+	}
+	return todoColumnsNormal, nil
 }
 
 func (r todoListResult) Pretty() string {
+	header := "todo: no items"
+	if len(r.Items) > 0 {
+		header = fmt.Sprintf("todo: %d item(s)", len(r.Items))
+	}
+	if r.context != "" {
+		header += " (context #" + r.context + ")"
+	}
+	if r.Undated > 0 {
+		header += fmt.Sprintf(" (%d archived todo(s) with no completion date left out)", r.Undated)
+	}
 	if len(r.Items) == 0 {
-		return "todo: no items"
+		return header
+	}
+	// The assignee column only appears once some listed todo is assigned,
+	// so a solo vault's listing stays unchanged.
+	// Likewise the project column, once some listed todo is in a project.
+	shared, projects := false, false
+	for _, it := range r.Items {
+		shared = shared || it.Assignee != ""
+		projects = projects || (it.Project != "" && !r.scoped)
 	}
 	var b strings.Builder
-	fmt.Fprintf(&b, "todo: %d item(s)", len(r.Items))
+	b.WriteString(header)
 	for _, it := range r.Items {
 		if it.Kind == "ephemeral" {
 			mark := " "
@@ -190,7 +495,30 @@ func (r todoListResult) Pretty() string {
 			fmt.Fprintf(&b, "\n  [%s] %d. %s", mark, it.Line, it.Body)
 			continue
 		}
-		fmt.Fprintf(&b, "\n  %s [%s] %s", it.ID, it.State, it.Title)
+		id := it.ID
+		if short, ok := r.shortIDs[id]; ok {
+			id = short
+		}
+		indent := strings.Repeat("  ", r.depth[it.ID])
+		fmt.Fprintf(&b, "\n  %s%s [%s] %s", indent, id, it.State, it.Title)
+		if it.Pinned {
+			b.WriteString(" (pinned)")
+		}
+		if it.Overdue {
+			b.WriteString(" (overdue)")
+		}
+		if it.Completed != "" {
+			fmt.Fprintf(&b, " (done %s)", it.Completed)
+		}
+		if projects && it.Project != "" {
+			fmt.Fprintf(&b, " (project %s)", it.Project)
+		}
+		if r.columns == todoColumnsCompact {
+			if it.Deadline != "" {
+				fmt.Fprintf(&b, " (deadline %s)", it.Deadline)
+			}
+			continue
+		}
 		if it.Scheduled != "" {
 			fmt.Fprintf(&b, " (scheduled %s)", it.Scheduled)
 		}
@@ -200,6 +528,31 @@ func (r todoListResult) Pretty() string {
 		if it.Depends != "" {
 			fmt.Fprintf(&b, " (blocked on %s)", it.Depends)
 		}
+		if it.Estimate != "" {
+			fmt.Fprintf(&b, " (estimate %s)", it.Estimate)
+		}
+		if shared {
+			if it.Assignee == "" {
+				b.WriteString(" (unassigned)")
+			} else {
+				fmt.Fprintf(&b, " (assignee %s)", it.Assignee)
+			}
+		}
+		if r.columns == todoColumnsWide {
+			if it.Repeat != "" {
+				fmt.Fprintf(&b, " (repeat %s)", it.Repeat)
+			}
+			if it.Parent != "" {
+				parent := it.Parent
+				if short, ok := r.shortIDs[parent]; ok {
+					parent = short
+				}
+				fmt.Fprintf(&b, " (parent %s)", parent)
+			}
+			for _, tag := range it.Tags {
+				b.WriteString(" #" + tag)
+			}
+		}
 	}
 	return b.String()
 }
@@ -227,6 +580,8 @@ type todoDoneResult struct {
 	DidEntryPath string `json:"did_entry_path,omitempty"`
 	Missed       int    `json:"missed,omitempty"`       // count of fully-elapsed intervals since the old cursor
 	Materialized string `json:"materialized,omitempty"` // "todos/inbox.md#<line>" iff Missed > 0
+
+	ParentCompleted string `json:"parent_completed,omitempty"` // parent ULID auto-completed along with its last subtask
 }
 
 func (r todoDoneResult) Pretty() string {
@@ -240,9 +595,51 @@ func (r todoDoneResult) Pretty() string {
 		}
 		return s
 	}
+	if r.ParentCompleted != "" {
+		return fmt.Sprintf("todo: %s marked done; parent %s done too", r.Ref, r.ParentCompleted)
+	}
 	return fmt.Sprintf("todo: %s marked done", r.Ref)
 }
 
+// todoReopenResult is the structured summary of one `rk todo reopen` run.
+type todoReopenResult struct {
+	Kind    string `json:"kind"`            // "durable" | "ephemeral"
+	Ref     string `json:"ref"`             // the ref/index the caller passed
+	Path    string `json:"path,omitempty"`  // vault-relative: the file mutated
+	ID      string `json:"id,omitempty"`    // durable only: resolved ULID
+	State   string `json:"state,omitempty"` // durable only: always "open"
+	Skipped bool   `json:"skipped"`         // true = idempotent no-op (already open/unchecked)
+}
+
+func (r todoReopenResult) Pretty() string {
+	if r.Skipped {
+		return fmt.Sprintf("todo: %s already open (skipped)", r.Ref)
+	}
+	return fmt.Sprintf("todo: %s reopened", r.Ref)
+}
+
+// scheduleDeadlineWarning returns a human-readable problem description when
+// both dates are set, parse as YYYY-MM-DD, and scheduled falls after
+// deadline; otherwise "". Unparsable dates are left to the callers' own
+// validation. Callers warn by default and fail under --strict.
+func scheduleDeadlineWarning(scheduled, deadline string) string {
+	if scheduled == "" || deadline == "" {
+		return ""
+	}
+	s, err := parseSchedDate(scheduled)
+	if err != nil {
+		return ""
+	}
+	d, err := parseSchedDate(deadline)
+	if err != nil {
+		return ""
+	}
+	if s.After(d) {
+		return fmt.Sprintf("scheduled date %s is after deadline %s", scheduled, deadline)
+	}
+	return ""
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // resolveAuthor (plan.md D8)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -274,14 +671,31 @@ func runTodoAddE(cmd *cobra.Command, args []string) error {
 	deadline := todoDeadlineFlag
 	depends := todoDependsFlag
 	repeat := todoRepeatFlag
+	assignee := strings.TrimSpace(todoAssigneeFlag)
+	estimate := strings.TrimSpace(todoEstimateFlag)
+	tags := parseTagInput(todoTagsFlag)
 	author := resolveAuthor(todoAuthorFlag)
-	body := strings.TrimSpace(strings.Join(args, " "))
-	if body == "" {
-		return fmt.Errorf("todo add: empty body text")
+	var body string
+	switch {
+	case todoAddStdinFlag && len(args) > 0:
+		return fmt.Errorf("todo add: --stdin takes no text arguments")
+	case !todoAddStdinFlag && len(args) == 0:
+		return fmt.Errorf("todo add: missing todo text (pass it as arguments, or use --stdin)")
+	case !todoAddStdinFlag:
+		body = strings.TrimSpace(strings.Join(args, " "))
+		if body == "" {
+			return fmt.Errorf("todo add: empty body text")
+		}
 	}
 
-	if ephemeral && (scheduled != "" || deadline != "" || depends != "" || repeat != "") {
-		return fmt.Errorf("todo add: --ephemeral does not support --scheduled/--deadline/--depends/--repeat (durable-only)")
+	parentRef := strings.TrimSpace(todoParentFlag)
+	if ephemeral && (scheduled != "" || deadline != "" || depends != "" || repeat != "" || parentRef != "" || assignee != "" || estimate != "" || len(tags) > 0 || todoProjectFlag != "") {
+		return fmt.Errorf("todo add: --ephemeral does not support --scheduled/--deadline/--depends/--repeat/--parent/--assignee/--estimate/--tags/--project (durable-only)")
+	}
+	if estimate != "" {
+		if _, err := config.ParseEstimate(estimate); err != nil {
+			return fmt.Errorf("todo add: --estimate: %w", err)
+		}
 	}
 	if repeat != "" {
 		if scheduled == "" {
@@ -295,7 +709,16 @@ func runTodoAddE(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	if w := scheduleDeadlineWarning(scheduled, deadline); w != "" {
+		if todoStrictFlag {
+			return fmt.Errorf("todo add: %s (--strict)", w)
+		}
+		if !quietFlag {
+			fmt.Fprintf(cmd.ErrOrStderr(), "todo add: warning: %s\n", w)
+		}
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -310,43 +733,141 @@ func runTodoAddE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("todo add: create todos dir: %w", err)
 	}
 
-	var res todoAddResult
-	if ephemeral {
-		res, err = addEphemeralTodo(todosDir, author, body)
-	} else {
-		res, err = addDurableTodo(todosDir, author, body, scheduled, deadline, depends, repeat)
+	var parentID string
+	if parentRef != "" {
+		parent, _, err := resolveTodoParent(cfg.VaultDir, "", parentRef, "todo add")
+		if err != nil {
+			return err
+		}
+		parentID = parent.ULID
 	}
+
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("todo add: %w", err)
+	}
+	if !ephemeral && !cmd.Flags().Changed("assignee") {
+		assignee = settings.DefaultAssignee
+	}
+	if len(tags) == 0 && !ephemeral && settings.AutoInbox {
+		tags = []string{settings.InboxTag}
+	}
+	if !ephemeral {
+		if tags, err = newTodoTags(cfg.VaultDir, settings, tags); err != nil {
+			return fmt.Errorf("todo add: %w", err)
+		}
+	}
+	project := todoProjectFlag
+	if project == "" {
+		project = settings.TodoProject
+	} else if err := config.ValidateProjectName(project); err != nil {
+		return fmt.Errorf("todo add: --project: %w", err)
+	}
+
+	add := func(body string) (todoAddResult, error) {
+		if ephemeral {
+			return addEphemeralTodo(todosDir, author, body)
+		}
+		props, links := durableTodoFields(scheduled, deadline, depends, repeat)
+		if assignee != "" {
+			props["assignee"] = assignee
+		}
+		if estimate != "" {
+			props["estimate"] = estimate
+		}
+		if len(tags) > 0 {
+			props["tags"] = "[" + strings.Join(tags, ", ") + "]"
+		}
+		if parentID != "" {
+			links = append(links, node.Link{Rel: "parent", To: parentID})
+		}
+		return createDurableTodo(todosDir, project, "", author, body, props, links)
+	}
+
+	if todoAddStdinFlag {
+		return addTodosFromStdin(cmd, mode, add)
+	}
+
+	res, err := add(body)
 	if err != nil {
 		return err
 	}
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addTodosFromStdin is `rk todo add --stdin`'s bulk path: it creates one
+// todo per non-blank stdin line via add, continuing past a failed line so a
+// single bad entry does not abort the batch. The summary is printed before
+// the error that reports how many lines failed.
+func addTodosFromStdin(cmd *cobra.Command, mode output.Mode, add func(string) (todoAddResult, error)) error {
+	lines, err := readStdinLines(cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("todo add: %w", err)
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("todo add: no todo text on stdin")
+	}
+
+	res := todoAddBulkResult{Created: []todoAddResult{}, Errors: []todoAddBulkError{}}
+	for i, line := range lines {
+		r, err := add(line)
+		if err != nil {
+			res.Errors = append(res.Errors, todoAddBulkError{Line: i + 1, Text: line, Error: err.Error()})
+			continue
+		}
+		res.Created = append(res.Created, r)
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("todo add: %d of %d line(s) failed", len(res.Errors), len(lines))
+	}
 	return nil
 }
 
 // addDurableTodo creates todos/<ULID>.md via the NewNode -> set fields ->
-// Render -> Parse -> writeFileAtomic recipe (plan.md D1/D9). The ULID is
-// minted via the mintTodoULID seam so tests can force a collision. repeat
+// Render -> Parse -> writeFileAtomic recipe (plan.md D1/D9). The ULID is id,
+// or when id is "" minted via the mintTodoULID seam so tests can force a
+// collision. repeat
 // (v1-T6) is the raw repeater cookie; caller (runTodoAddE) has already
 // validated it via parseRepeat and required --scheduled to be set alongside
-// it.
-func addDurableTodo(todosDir, author, body, scheduled, deadline, depends, repeat string) (todoAddResult, error) {
-	id := mintTodoULID()
-	path := filepath.Join(todosDir, id+".md")
-
-	if _, err := os.Stat(path); err == nil {
-		return todoAddResult{}, fmt.Errorf("todo add: refusing to overwrite existing file at %s", path)
-	} else if !os.IsNotExist(err) {
-		return todoAddResult{}, fmt.Errorf("todo add: stat %s: %w", path, err)
+// it. The vault's task_id_style setting (the vault is todosDir's parent)
+// may add a memorable alias alongside the ULID (mintTodoAlias), its
+// default_todo_tags and context tag the todo (newTodoTags), and its
+// default_assignee is assigned it.
+func addDurableTodo(todosDir, id, author, body, scheduled, deadline, depends, repeat string) (todoAddResult, error) {
+	props, links := durableTodoFields(scheduled, deadline, depends, repeat)
+	vaultDir := filepath.Dir(todosDir)
+	settings, err := config.LoadSettings(vaultDir)
+	if err != nil {
+		return todoAddResult{}, fmt.Errorf("todo add: %w", err)
+	}
+	tags, err := newTodoTags(vaultDir, settings, nil)
+	if err != nil {
+		return todoAddResult{}, fmt.Errorf("todo add: %w", err)
 	}
+	if len(tags) > 0 {
+		props["tags"] = "[" + strings.Join(tags, ", ") + "]"
+	}
+	if settings.DefaultAssignee != "" {
+		props["assignee"] = settings.DefaultAssignee
+	}
+	return createDurableTodo(todosDir, "", id, author, body, props, links)
+}
 
-	n := node.NewNode("todo", author, body+"\n")
-	n.ULID = id
-	n.Time = time.Now().UTC().Format(time.RFC3339)
+// durableTodoFields maps `rk todo add`'s field flags onto a new todo's
+// props and typed links; empty values are left out.
+func durableTodoFields(scheduled, deadline, depends, repeat string) (map[string]string, []node.Link) {
 	props := map[string]string{"state": "open"}
 	if scheduled != "" {
 		props["scheduled"] = scheduled
@@ -357,10 +878,52 @@ func addDurableTodo(todosDir, author, body, scheduled, deadline, depends, repeat
 	if repeat != "" {
 		props["repeat"] = repeat
 	}
-	n.Props = props
+	var links []node.Link
 	if depends != "" {
-		n.Links = []node.Link{{Rel: "depends-on", To: depends}}
+		links = []node.Link{{Rel: "depends-on", To: depends}}
+	}
+	return props, links
+}
+
+// createDurableTodo is addDurableTodo's write half, shared with
+// splitDurableTodo (todo_split.go): it mints the ULID (unless given one in
+// id) and any alias and writes a new open todo carrying props (which must
+// include state) and typed links, in project's subdirectory of todosDir
+// ("" for none).
+func createDurableTodo(todosDir, project, id, author, body string, props map[string]string, links []node.Link) (todoAddResult, error) {
+	settings, err := config.LoadSettings(filepath.Dir(todosDir))
+	if err != nil {
+		return todoAddResult{}, fmt.Errorf("todo add: %w", err)
+	}
+
+	dir := todoProjectDir(todosDir, project)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return todoAddResult{}, fmt.Errorf("todo add: create project dir: %w", err)
+	}
+	if id == "" {
+		id = mintTodoULID()
 	}
+	path := filepath.Join(dir, id+".md")
+
+	if _, err := os.Stat(path); err == nil {
+		return todoAddResult{}, fmt.Errorf("todo add: refusing to overwrite existing file at %s", path)
+	} else if !os.IsNotExist(err) {
+		return todoAddResult{}, fmt.Errorf("todo add: stat %s: %w", path, err)
+	}
+
+	alias, err := mintTodoAlias(settings.TaskIDStyle, todosDir, body)
+	if err != nil {
+		return todoAddResult{}, err
+	}
+
+	n := node.NewNode("todo", author, body+"\n")
+	n.ULID = id
+	if alias != "" {
+		n.Aliases = []string{alias}
+	}
+	n.Time = time.Now().UTC().Format(time.RFC3339)
+	n.Props = props
+	n.Links = links
 
 	rendered := n.Render()
 	parsed, err := node.Parse(rendered)
@@ -374,12 +937,81 @@ func addDurableTodo(todosDir, author, body, scheduled, deadline, depends, repeat
 
 	return todoAddResult{
 		Kind:  "durable",
-		Path:  "todos/" + id + ".md",
+		Path:  relTodoPath(filepath.Dir(todosDir), path),
 		ID:    id,
-		State: "open",
+		Alias: alias,
+		State: props["state"],
 	}, nil
 }
 
+// mintTodoAlias returns the memorable alias a new durable todo gets under
+// task_id_style (config.Settings), or "" for the default ULID-only style:
+//
+//	daily  <created UTC date>-NN, numbered per day from 01
+//	slug   slugify(first body line), suffixed -2, -3, ... on collision
+//
+// Both loop until the candidate collides with no existing todo's ULID or
+// alias. A body that slugifies to nothing gets no alias.
+func mintTodoAlias(style, todosDir, body string) (string, error) {
+	if style == "" || style == config.TaskIDStyleULID {
+		return "", nil
+	}
+	taken, err := todoRefSet(todosDir)
+	if err != nil {
+		return "", err
+	}
+
+	var candidate func(n int) string
+	switch style {
+	case config.TaskIDStyleDaily:
+		day := currentJournalDate()
+		candidate = func(n int) string { return fmt.Sprintf("%s-%02d", day, n) }
+	case config.TaskIDStyleSlug:
+		base := slugify(firstBodyLine(body))
+		if base == "" {
+			return "", nil
+		}
+		candidate = func(n int) string {
+			if n == 1 {
+				return base
+			}
+			return fmt.Sprintf("%s-%d", base, n)
+		}
+	default:
+		return "", fmt.Errorf("todo add: unknown task_id_style %q", style)
+	}
+
+	for n := 1; ; n++ {
+		if c := candidate(n); !taken[c] {
+			return c, nil
+		}
+	}
+}
+
+// todoRefSet collects every ULID and alias in use by a durable todo under
+// todosDir, in any project. Unparsable/CRLF files are skipped, matching
+// findDurableTodoByRefOrAlias.
+func todoRefSet(todosDir string) (map[string]bool, error) {
+	files, err := todoFiles(todosDir, "")
+	if err != nil {
+		return nil, fmt.Errorf("todo add: %w", err)
+	}
+	taken := map[string]bool{}
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" {
+			continue
+		}
+		if n.ULID != "" {
+			taken[n.ULID] = true
+		}
+		for _, a := range n.Aliases {
+			taken[a] = true
+		}
+	}
+	return taken, nil
+}
+
 // addEphemeralTodo creates todos/inbox.md on first use, or appends a checkbox
 // line at EOF on subsequent calls (plan.md D2).
 //
@@ -446,8 +1078,61 @@ func runTodoListE(cmd *cobra.Command, args []string) error {
 	if durableOnly && ephemeralOnly {
 		return fmt.Errorf("todo list: --durable and --ephemeral are mutually exclusive")
 	}
+	if todoListFullIDFlag && todoListIDWidthFlag != 0 {
+		return fmt.Errorf("todo list: --full-id and --id-width are mutually exclusive")
+	}
+	if todoListIDWidthFlag != 0 && todoListIDWidthFlag < config.MinTodoIDWidth {
+		return fmt.Errorf("todo list: --id-width must be 0 or at least %d, got %d", config.MinTodoIDWidth, todoListIDWidthFlag)
+	}
+	assignee := strings.TrimSpace(todoAssigneeFlag)
+	if todoListMineFlag && assignee != "" {
+		return fmt.Errorf("todo list: --mine and --assignee are mutually exclusive")
+	}
+	if todoListMineFlag {
+		assignee = resolveAuthor("")
+	}
+	if todoListUnassignedFlag && assignee == "" {
+		return fmt.Errorf("todo list: --include-unassigned requires --mine or --assignee")
+	}
+	if todoListOverdueFlag && todoListSchedFlag == "" {
+		return fmt.Errorf("todo list: --include-overdue requires --scheduled")
+	}
+	if todoListBellFlag && !todoListWatchFlag {
+		return fmt.Errorf("todo list: --bell requires --watch")
+	}
+	project := todoProjectFlag
+	if project != "" {
+		if err := config.ValidateProjectName(project); err != nil {
+			return fmt.Errorf("todo list: --project: %w", err)
+		}
+	}
+	// --plain output never depends on the terminal, so auto sees none.
+	var columnsOut io.Writer = cmd.OutOrStdout()
+	if plainFlag {
+		columnsOut = nil
+	}
+	columns, err := resolveTodoColumns(todoListColumnsFlag, columnsOut)
+	if err != nil {
+		return err
+	}
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	if _, err := resolveTodoListRange(todoListSchedFlag); err != nil {
+		return fmt.Errorf("todo list: --scheduled: %w", err)
+	}
+	if todoListDoneTodayFlag {
+		if todoListDoneOnFlag != "" {
+			return fmt.Errorf("todo list: --done-today and --done-on are mutually exclusive")
+		}
+		todoListDoneOnFlag = "today"
+	}
+	if todoListDoneOnFlag != "" {
+		if _, err := resolveTodoListRange(todoListDoneOnFlag); err != nil {
+			return fmt.Errorf("todo list: --done-on: %w", err)
+		}
+		all = true // completed todos are what it lists
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -456,6 +1141,15 @@ func runTodoListE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("todo list: load config: %w", err)
 	}
+	if todoListMineFlag {
+		settings, err := config.LoadSettings(cfg.VaultDir)
+		if err != nil {
+			return fmt.Errorf("todo list: %w", err)
+		}
+		if settings.DefaultAssignee != "" {
+			assignee = settings.DefaultAssignee
+		}
+	}
 
 	ix, err := index.Open(cfg)
 	if err != nil {
@@ -463,43 +1157,321 @@ func runTodoListE(cmd *cobra.Command, args []string) error {
 	}
 	defer ix.Close()
 
-	if _, err := ix.Reconcile(); err != nil {
-		return fmt.Errorf("todo list: reconcile index: %w", err)
+	// list builds the listing from a fresh reconcile; --watch calls it on
+	// every refresh, so the date filters resolve against each refresh's
+	// today.
+	list := func() (todoListResult, error) {
+		st, err := ix.Reconcile()
+		if err != nil {
+			return todoListResult{}, fmt.Errorf("todo list: reconcile index: %w", err)
+		}
+
+		res := todoListResult{Items: []todoListItem{}, columns: columns, scoped: project != ""}
+		if res.Problems, err = todoProblemFiles(ix.DB(), st.Warnings, project); err != nil {
+			return todoListResult{}, err
+		}
+		if !quietFlag && !todoListCountFlag {
+			for _, p := range res.Problems {
+				fmt.Fprintf(cmd.ErrOrStderr(), "todo list: warning: skipped %s: %s\n", p.Path, p.Reason)
+			}
+		}
+		if res.context, err = readTodoContext(cfg.VaultDir); err != nil {
+			return todoListResult{}, fmt.Errorf("todo list: %w", err)
+		}
+
+		if !ephemeralOnly {
+			durItems, err := listDurableTodos(ix.DB(), all, stateFilter)
+			if err != nil {
+				return todoListResult{}, err
+			}
+			res.Items = append(res.Items, durItems...)
+		}
+		// The shared inbox is in no project, so --project lists durable todos
+		// only.
+		if !durableOnly && project == "" {
+			ephItems, err := listEphemeralTodos(ix.DB(), all)
+			if err != nil {
+				return todoListResult{}, err
+			}
+			res.Items = append(res.Items, ephItems...)
+		}
+
+		if project != "" {
+			kept := res.Items[:0]
+			for _, it := range res.Items {
+				if it.Project == project {
+					kept = append(kept, it)
+				}
+			}
+			res.Items = kept
+		}
+
+		if schedRange, err := resolveTodoListRange(todoListSchedFlag); err != nil {
+			return todoListResult{}, err
+		} else if schedRange != nil {
+			// Ephemeral items carry no scheduled date, so a --scheduled filter
+			// keeps durable todos only. --include-overdue adds the overdue ones
+			// in one pass, so a todo both in range and overdue is listed once.
+			// --watch implies it here: the range widens to every overdue
+			// todo, not just the ones that fall overdue while watching.
+			today := currentJournalDate()
+			kept := res.Items[:0]
+			for _, it := range res.Items {
+				if (todoListOverdueFlag || todoListWatchFlag) && todoOverdue(it, today) {
+					it.Overdue = true
+				}
+				if schedRange.contains(it.Scheduled) || it.Overdue {
+					kept = append(kept, it)
+				}
+			}
+			res.Items = kept
+		}
+
+		if todoListWatchFlag {
+			// --watch marks every overdue todo, whatever the filters.
+			today := currentJournalDate()
+			for i := range res.Items {
+				res.Items[i].Overdue = todoOverdue(res.Items[i], today)
+			}
+		}
+
+		if assignee != "" {
+			// Ephemeral items carry no assignee, so they count as unassigned.
+			kept := res.Items[:0]
+			for _, it := range res.Items {
+				if it.Assignee == assignee || (todoListUnassignedFlag && it.Assignee == "") {
+					kept = append(kept, it)
+				}
+			}
+			res.Items = kept
+		}
+
+		if tag := strings.TrimLeft(strings.TrimSpace(todoListTagFlag), "#"); tag != "" {
+			// Ephemeral items carry no tags, so a --tag filter keeps durable
+			// todos only.
+			kept := res.Items[:0]
+			for _, it := range res.Items {
+				if containsString(it.Tags, tag) {
+					kept = append(kept, it)
+				}
+			}
+			res.Items = kept
+		}
+
+		if excluded := parseTagInput(strings.Join(todoListNotTagFlag, ",")); len(excluded) > 0 {
+			// --not-tag applies after --tag: a todo is listed when it
+			// carries the --tag tag and none of these. Untagged items,
+			// inbox ones included, carry none.
+			kept := res.Items[:0]
+			for _, it := range res.Items {
+				if !todoHasAnyTag(it.Tags, excluded) {
+					kept = append(kept, it)
+				}
+			}
+			res.Items = kept
+		}
+
+		if doneRange, err := resolveTodoListRange(todoListDoneOnFlag); err != nil {
+			return todoListResult{}, err
+		} else if doneRange != nil {
+			// Ephemeral items carry no completion date, so a --done-on filter
+			// keeps durable todos only.
+			days, err := todoCompletionDays(ix.DB())
+			if err != nil {
+				return todoListResult{}, err
+			}
+			kept := res.Items[:0]
+			for _, it := range res.Items {
+				if it.Kind != "durable" {
+					continue
+				}
+				completed, known := todoCompletedOn(cfg.VaultDir, it, days[it.ID], *doneRange)
+				if !known {
+					res.Undated++
+				}
+				if completed != "" {
+					it.Completed = completed
+					kept = append(kept, it)
+				}
+			}
+			res.Items = kept
+		}
+
+		// Pinned todos lead the list, each keeping its place among them.
+		sort.SliceStable(res.Items, func(i, j int) bool { return res.Items[i].Pinned && !res.Items[j].Pinned })
+
+		if todoListTreeFlag {
+			res.Items, res.depth = todoTreeOrder(res.Items)
+		}
+
+		if mode == output.Pretty && !todoListFullIDFlag {
+			width := todoListIDWidthFlag
+			if width == 0 {
+				settings, err := config.LoadSettings(cfg.VaultDir)
+				if err != nil {
+					return todoListResult{}, fmt.Errorf("todo list: %w", err)
+				}
+				width = settings.TodoIDWidth
+			}
+			if width > 0 {
+				ids, err := durableTodoIDs(ix.DB())
+				if err != nil {
+					return todoListResult{}, err
+				}
+				res.shortIDs = abbreviateIDs(ids, width)
+			}
+		}
+		return res, nil
 	}
 
-	res := todoListResult{Items: []todoListItem{}}
+	if todoListWatchFlag {
+		return watchTodoList(cmd, mode, list)
+	}
+	res, err := list()
+	if err != nil {
+		return err
+	}
+	if stateFilter != "" && !quietFlag && !todoListCountFlag && !slices.ContainsFunc(res.Items, func(it todoListItem) bool { return it.Kind == "durable" }) {
+		if hint := config.Suggest(stateFilter, todoStates...); hint != "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "todo list: no todos in state %q%s\n", stateFilter, hint)
+		}
+	}
+	return printTodoList(cmd, mode, res)
+}
 
-	if !ephemeralOnly {
-		durItems, err := listDurableTodos(ix.DB(), all, stateFilter)
-		if err != nil {
-			return err
+// printTodoList prints one listing, or only its size under --count.
+func printTodoList(cmd *cobra.Command, mode output.Mode, res todoListResult) error {
+	if todoListCountFlag {
+		return newOutput(cmd, mode).Print(todoCountResult{Count: len(res.Items)})
+	}
+	return newOutput(cmd, mode).Print(res)
+}
+
+// todoCompletedOn returns the latest day in r that the durable todo it was
+// completed, or "". A recurring todo, which stays open, was completed on
+// each day a did:: log entry records. A done or archived one was completed
+// on its done: date (markTodoDone); one marked done before those were
+// recorded falls back to its did:: entries, then, while still done, to the
+// file's mtime as `rk todo gc` reads it. An archived todo's mtime is when
+// it was archived, so without either its day is unknown: known is false.
+func todoCompletedOn(vaultDir string, it todoListItem, didDays []string, r dateRange) (day string, known bool) {
+	if it.Repeat == "" && (it.State == "done" || it.State == "archived") {
+		if _, err := parseSchedDate(it.Done); err == nil {
+			if r.contains(it.Done) {
+				return it.Done, true
+			}
+			return "", true
 		}
-		res.Items = append(res.Items, durItems...)
 	}
-	if !durableOnly {
-		ephItems, err := listEphemeralTodos(ix.DB(), all)
+	switch {
+	case len(didDays) > 0 && (it.Repeat != "" || it.State == "done" || it.State == "archived"):
+		for _, d := range didDays {
+			if r.contains(d) {
+				day = d
+			}
+		}
+		return day, true
+	case it.State == "done":
+		info, err := os.Stat(filepath.Join(vaultDir, filepath.FromSlash(it.Path)))
 		if err != nil {
-			return err
+			return "", false
+		}
+		if d := info.ModTime().UTC().Format("2006-01-02"); r.contains(d) {
+			return d, true
 		}
-		res.Items = append(res.Items, ephItems...)
+		return "", true
+	case it.State == "archived":
+		return "", false
 	}
+	return "", true // not completed (or reopened)
+}
 
-	return output.New(cmd.OutOrStdout(), mode).Print(res)
+// todoCompletionDays maps each todo a did:: log entry completed to the
+// days (YYYY-MM-DD, ascending) of those entries.
+func todoCompletionDays(db *sql.DB) (map[string][]string, error) {
+	rows, err := db.Query(`SELECT DISTINCT e.dst_key, ` + logEntryDaySQL + ` FROM edges e JOIN nodes n ON n.id = e.src
+		WHERE e.rel = 'did' AND n.type = 'log-entry' AND e.dst_key IS NOT NULL ORDER BY 2`)
+	if err != nil {
+		return nil, fmt.Errorf("todo list: query completions: %w", err)
+	}
+	defer rows.Close()
+	days := map[string][]string{}
+	for rows.Next() {
+		var id, day string
+		if err := rows.Scan(&id, &day); err != nil {
+			return nil, fmt.Errorf("todo list: scan completion: %w", err)
+		}
+		days[id] = append(days[id], day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("todo list: iterate completions: %w", err)
+	}
+	return days, nil
+}
+
+// durableTodoIDs returns every durable todo ID in the index, done or not,
+// so an abbreviation is unique against todos the current listing hides.
+func durableTodoIDs(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("SELECT id FROM nodes WHERE type = 'todo'")
+	if err != nil {
+		return nil, fmt.Errorf("todo list: query durable ids: %w", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("todo list: scan durable id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("todo list: iterate durable ids: %w", err)
+	}
+	return ids, nil
+}
+
+// abbreviateIDs maps each of ids to its first width characters, widened
+// git-style to the shortest prefix no other ID shares, so an abbreviated ID
+// is never ambiguous. In sorted order an ID's longest common prefix with
+// any other ID is with one of its two neighbours.
+func abbreviateIDs(ids []string, width int) map[string]string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	commonPrefix := func(a, b string) int {
+		n := 0
+		for n < len(a) && n < len(b) && a[n] == b[n] {
+			n++
+		}
+		return n
+	}
+	out := make(map[string]string, len(sorted))
+	for i, id := range sorted {
+		n := width
+		if i > 0 {
+			n = max(n, commonPrefix(id, sorted[i-1])+1)
+		}
+		if i+1 < len(sorted) {
+			n = max(n, commonPrefix(id, sorted[i+1])+1)
+		}
+		out[id] = id[:min(n, len(id))]
+	}
+	return out
 }
 
 // listDurableTodos closes rows manually (not deferred) before issuing the
 // per-row loadTodoProps queries below -- a defer would hold this cursor open
 // across those nested queries on the same *sql.DB.
 func listDurableTodos(db *sql.DB, all bool, stateFilter string) ([]todoListItem, error) {
-	rows, err := db.Query("SELECT id, body, title FROM nodes WHERE type = 'todo'")
+	rows, err := db.Query("SELECT id, body, title, loc FROM nodes WHERE type = 'todo'")
 	if err != nil {
 		return nil, fmt.Errorf("todo list: query durable nodes: %w", err)
 	}
-	type row struct{ id, body, title string }
+	type row struct{ id, body, title, loc string }
 	var candidates []row
 	for rows.Next() {
 		var r row
-		if err := rows.Scan(&r.id, &r.body, &r.title); err != nil {
+		if err := rows.Scan(&r.id, &r.body, &r.title, &r.loc); err != nil {
 			rows.Close()
 			return nil, fmt.Errorf("todo list: scan durable node: %w", err)
 		}
@@ -529,15 +1501,27 @@ func listDurableTodos(db *sql.DB, all bool, stateFilter string) ([]todoListItem,
 		if err != nil {
 			return nil, err
 		}
+		parent, err := loadTodoParent(db, r.id)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.ToSlash(r.loc)
 		items = append(items, todoListItem{
 			Kind:      "durable",
 			ID:        r.id,
-			Path:      "todos/" + r.id + ".md",
+			Path:      path,
+			Project:   todoProjectOf(path),
 			State:     state,
 			Scheduled: props["scheduled"],
 			Deadline:  props["deadline"],
 			Depends:   depends,
+			Parent:    parent,
 			Repeat:    props["repeat"],
+			Tags:      parseTagList(props["tags"]),
+			Assignee:  props["assignee"],
+			Estimate:  props["estimate"],
+			Pinned:    props["list_pinned"] == "true",
+			Done:      props["done"],
 			Body:      strings.TrimSpace(r.body),
 			Title:     r.title,
 		})
@@ -577,6 +1561,53 @@ func loadDependsOn(db *sql.DB, id string) (string, error) {
 	return dst, nil
 }
 
+// loadTodoParent returns the ULID id's parent: link resolves to, or the raw
+// ref when it resolves to nothing.
+func loadTodoParent(db *sql.DB, id string) (string, error) {
+	var dst string
+	err := db.QueryRow("SELECT COALESCE(dst_key, dst) FROM edges WHERE src = ? AND rel = 'parent' LIMIT 1", id).Scan(&dst)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("todo list: load parent for %q: %w", id, err)
+	}
+	return dst, nil
+}
+
+// todoProblemFiles returns the files under todos/ (or one project's
+// subdirectory) that yield no todo: those the reconcile pass could not
+// parse (warnings), and those that parsed without frontmatter, as when a
+// hand edit leaves the opening --- unterminated.
+func todoProblemFiles(db *sql.DB, warnings []index.Warning, project string) ([]todoFileProblem, error) {
+	prefix := "todos/"
+	if project != "" {
+		prefix += project + "/"
+	}
+	var problems []todoFileProblem
+	for _, w := range warnings {
+		if w.Kind == "unparsable" && strings.HasPrefix(w.Files[0], prefix) {
+			problems = append(problems, todoFileProblem{Path: w.Files[0], Reason: w.Error})
+		}
+	}
+	rows, err := db.Query("SELECT DISTINCT loc FROM nodes WHERE type = '' AND substr(loc, 1, ?) = ? ORDER BY loc", len(prefix), prefix)
+	if err != nil {
+		return nil, fmt.Errorf("todo list: query typeless files: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var loc string
+		if err := rows.Scan(&loc); err != nil {
+			return nil, fmt.Errorf("todo list: scan typeless file: %w", err)
+		}
+		problems = append(problems, todoFileProblem{Path: loc, Reason: "no frontmatter type (is the opening --- unterminated?)"})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("todo list: iterate typeless files: %w", err)
+	}
+	return problems, nil
+}
+
 func listEphemeralTodos(db *sql.DB, all bool) ([]todoListItem, error) {
 	var id, body string
 	err := db.QueryRow("SELECT id, body FROM nodes WHERE type = 'todo-ephemeral' LIMIT 1").Scan(&id, &body)
@@ -613,7 +1644,7 @@ func runTodoDoneE(cmd *cobra.Command, args []string) error {
 	ref := args[0]
 	ephemeral := todoDoneEphemeralFlag
 
-	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag)
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
 	if err != nil {
 		return err
 	}
@@ -623,6 +1654,15 @@ func runTodoDoneE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("todo done: load config: %w", err)
 	}
 
+	if todoMatchFlag {
+		if ephemeral {
+			return fmt.Errorf("todo done: --match and --ephemeral are mutually exclusive")
+		}
+		if ref, err = resolveTodoMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("todo done: %w", err)
+		}
+	}
+
 	var res todoDoneResult
 	if ephemeral {
 		res, err = doneEphemeralTodo(cfg.VaultDir, ref)
@@ -634,7 +1674,7 @@ func runTodoDoneE(cmd *cobra.Command, args []string) error {
 	}
 
 	if !(mode == output.Pretty && quietFlag) {
-		if err := output.New(cmd.OutOrStdout(), mode).Print(res); err != nil {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
 			return err
 		}
 	}
@@ -645,12 +1685,29 @@ func runTodoDoneE(cmd *cobra.Command, args []string) error {
 // walk over todos/*.md matching ULID or alias), then flips state->done via a
 // span-local SetField, or reports an idempotent skip if already done.
 func doneDurableTodo(vaultDir, ref string) (todoDoneResult, error) {
+	n, foundPath, err := resolveDurableTodo(vaultDir, ref, "todo done")
+	if err != nil {
+		return todoDoneResult{}, err
+	}
+	return completeDurableTodoNode(vaultDir, n, foundPath, ref, false, true)
+}
+
+// resolveDurableTodo is the ref lookup shared by `rk todo done` and
+// `rk todo reopen`: the todos/<ref>.md ULID fast-path, else a walk over
+// todos/*.md matching ULID or alias. A miss is a "(not found)" error
+// prefixed with verb. The walk covers every project, or under --project
+// (todoProjectFlag) only that one.
+func resolveDurableTodo(vaultDir, ref, verb string) (*node.Node, string, error) {
 	todosDir := filepath.Join(vaultDir, "todos")
+	files, err := todoFiles(todosDir, todoProjectFlag)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", verb, err)
+	}
 
-	fastPath := filepath.Join(todosDir, ref+".md")
+	fastPath := filepath.Join(todoProjectDir(todosDir, todoProjectFlag), ref+".md")
 	n, foundPath, err := loadDurableTodoAt(fastPath)
 	if err != nil {
-		return todoDoneResult{}, err
+		return nil, "", err
 	}
 	if n != nil && n.Type != "todo" {
 		// e.g. ref == "inbox" resolving to the ephemeral container: not a
@@ -658,16 +1715,66 @@ func doneDurableTodo(vaultDir, ref string) (todoDoneResult, error) {
 		n, foundPath = nil, ""
 	}
 	if n == nil {
-		n, foundPath, err = findDurableTodoByRefOrAlias(todosDir, ref)
+		n, foundPath = findDurableTodoByRefOrAlias(files, ref)
+	}
+	if n == nil {
+		n, foundPath, err = findDurableTodoByPrefix(files, ref, verb)
 		if err != nil {
-			return todoDoneResult{}, err
+			return nil, "", err
 		}
 	}
 	if n == nil {
-		return todoDoneResult{}, fmt.Errorf("todo done: no todo found matching %q (not found)", ref)
+		return nil, "", fmt.Errorf("%s: no todo found matching %q (not found)", verb, ref)
 	}
+	return n, foundPath, nil
+}
 
-	return completeDurableTodoNode(vaultDir, n, foundPath, ref, false, true)
+// minTodoPrefixLen is the shortest ULID prefix findDurableTodoByPrefix will
+// try, so a one- or two-character typo never silently resolves. It is also
+// the narrowest --id-width, so a printed ID always resolves.
+const minTodoPrefixLen = config.MinTodoIDWidth
+
+// findDurableTodoByPrefix resolves ref as an abbreviated ULID (git
+// short-hash style, e.g. as printed by `rk todo list --id-width`): exactly
+// one durable todo whose ULID starts with ref (case-insensitively) is a
+// match; several are an error listing them. A ref shorter than
+// minTodoPrefixLen, or matching nothing, returns (nil, "", nil). Only
+// consulted after exact ULID/alias lookup fails, so an alias that happens to
+// prefix a ULID still means the alias. Numeric refs need no special case:
+// the 1-based ephemeral index only applies under --ephemeral, which never
+// reaches this resolver.
+func findDurableTodoByPrefix(files []string, ref, verb string) (*node.Node, string, error) {
+	if len(ref) < minTodoPrefixLen {
+		return nil, "", nil
+	}
+	prefix := strings.ToUpper(ref)
+	var hits []*node.Node
+	var hitPaths []string
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || !strings.HasPrefix(n.ULID, prefix) {
+			continue
+		}
+		hits = append(hits, n)
+		hitPaths = append(hitPaths, path)
+	}
+	switch len(hits) {
+	case 0:
+		return nil, "", nil
+	case 1:
+		return hits[0], hitPaths[0], nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d todos match prefix %q; type more characters:", verb, len(hits), ref)
+	for i, n := range hits {
+		if i == maxListedCandidates {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(hits)-maxListedCandidates)
+			break
+		}
+		fmt.Fprintf(&b, "\n  %s  %s", n.ULID, firstBodyLine(n.Body))
+	}
+	return nil, "", errors.New(b.String())
 }
 
 // completeDurableTodoNode is doneDurableTodo's shared completion body, also
@@ -691,6 +1798,19 @@ func doneDurableTodo(vaultDir, ref string) (todoDoneResult, error) {
 // stays "open" so it remains visible in the default list), so the
 // idempotent-skip check just below never trips for it — that check, and the
 // state flip, are the non-recurring path only.
+// markTodoDone sets n's state to done and records the journal day it was
+// done on as done: YYYY-MM-DD, the completion date `rk todo gc` and
+// `rk todo list --done-on` read.
+func markTodoDone(n *node.Node) error {
+	if err := n.SetField("state", "done"); err != nil {
+		return fmt.Errorf("set state: %w", err)
+	}
+	if err := setOrInsertField(n, "done", currentJournalDate()); err != nil {
+		return fmt.Errorf("set done date: %w", err)
+	}
+	return nil
+}
+
 func completeDurableTodoNode(vaultDir string, n *node.Node, foundPath, ref string, logDid, recurLogDid bool) (todoDoneResult, error) {
 	relPath := relTodoPath(vaultDir, foundPath)
 	id := n.ULID
@@ -709,8 +1829,8 @@ func completeDurableTodoNode(vaultDir string, n *node.Node, foundPath, ref strin
 		}, nil
 	}
 
-	if err := n.SetField("state", "done"); err != nil {
-		return todoDoneResult{}, fmt.Errorf("todo done: set state: %w", err)
+	if err := markTodoDone(n); err != nil {
+		return todoDoneResult{}, fmt.Errorf("todo done: %w", err)
 	}
 	if err := writeFileAtomic(foundPath, n.Serialize()); err != nil {
 		return todoDoneResult{}, fmt.Errorf("todo done: write: %w", err)
@@ -720,6 +1840,12 @@ func completeDurableTodoNode(vaultDir string, n *node.Node, foundPath, ref strin
 		Kind: "durable", Ref: ref, Path: relPath, ID: id, State: "done", Skipped: false,
 	}
 
+	parentDone, err := autoCompleteParent(vaultDir, n)
+	if err != nil {
+		return res, err
+	}
+	res.ParentCompleted = parentDone
+
 	if !logDid {
 		return res, nil
 	}
@@ -730,8 +1856,8 @@ func completeDurableTodoNode(vaultDir string, n *node.Node, foundPath, ref strin
 	}
 
 	now := todoNow()
-	dayStr := now.Format("2006-01-02")
-	hhmm := now.Format("15:04")
+	dayStr := currentJournalDate()
+	hhmm := journalHHMM(now.Format("15:04"))
 	body := fmt.Sprintf("completed todo %s", id)
 
 	logDir := filepath.Join(vaultDir, "log")
@@ -789,7 +1915,7 @@ func doneRecurringTodo(vaultDir string, n *node.Node, foundPath, ref, repeatCook
 	}
 
 	now := todoNow()
-	dayStr := now.Format("2006-01-02")
+	dayStr := currentJournalDate()
 	today, err := parseSchedDate(dayStr)
 	if err != nil {
 		return todoDoneResult{}, fmt.Errorf("todo done: internal: reparse today %q: %w", dayStr, err)
@@ -820,7 +1946,7 @@ func doneRecurringTodo(vaultDir string, n *node.Node, foundPath, ref, repeatCook
 	}
 
 	if logDid {
-		hhmm := now.Format("15:04")
+		hhmm := journalHHMM(now.Format("15:04"))
 		body := fmt.Sprintf("completed recurring todo %s (repeat %s); advanced scheduled %s → %s", id, repeatCookie, schedStr, nextStr)
 
 		logDir := filepath.Join(vaultDir, "log")
@@ -873,15 +1999,11 @@ func loadDurableTodoAt(path string) (*node.Node, string, error) {
 	return n, path, nil
 }
 
-// findDurableTodoByRefOrAlias walks todos/*.md looking for a durable todo
-// (type "todo") whose ULID or alias matches ref. Unparsable/CRLF files are
-// skipped rather than aborting the whole search.
-func findDurableTodoByRefOrAlias(todosDir, ref string) (*node.Node, string, error) {
-	matches, err := filepath.Glob(filepath.Join(todosDir, "*.md"))
-	if err != nil {
-		return nil, "", fmt.Errorf("todo done: glob todos dir: %w", err)
-	}
-	for _, path := range matches {
+// findDurableTodoByRefOrAlias walks files (todoFiles) looking for a durable
+// todo (type "todo") whose ULID or alias matches ref. Unparsable/CRLF files
+// are skipped rather than aborting the whole search.
+func findDurableTodoByRefOrAlias(files []string, ref string) (*node.Node, string) {
+	for _, path := range files {
 		raw, err := os.ReadFile(path)
 		if err != nil || bytes.Contains(raw, []byte("\r\n")) {
 			continue
@@ -891,10 +2013,10 @@ func findDurableTodoByRefOrAlias(todosDir, ref string) (*node.Node, string, erro
 			continue
 		}
 		if n.ULID == ref || containsString(n.Aliases, ref) {
-			return n, path, nil
+			return n, path
 		}
 	}
-	return nil, "", nil
+	return nil, ""
 }
 
 // doneEphemeralTodo flips the ref'th (1-based, file order) checkbox line in
@@ -931,6 +2053,116 @@ func doneEphemeralTodo(vaultDir, ref string) (todoDoneResult, error) {
 	return todoDoneResult{Kind: "ephemeral", Ref: ref, Path: "todos/inbox.md", Skipped: false}, nil
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// reopen
+// ─────────────────────────────────────────────────────────────────────────────
+
+func runTodoReopenE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	ref := args[0]
+	ephemeral := todoDoneEphemeralFlag
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo reopen: load config: %w", err)
+	}
+
+	if todoMatchFlag {
+		if ephemeral {
+			return fmt.Errorf("todo reopen: --match and --ephemeral are mutually exclusive")
+		}
+		if ref, err = resolveTodoMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("todo reopen: %w", err)
+		}
+	}
+
+	var res todoReopenResult
+	if ephemeral {
+		res, err = reopenEphemeralTodo(cfg.VaultDir, ref)
+	} else {
+		res, err = reopenDurableTodo(cfg.VaultDir, ref)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reopenDurableTodo sets a durable todo's state to open (from done,
+// cancelled, or in-progress), or reports an idempotent skip if it is
+// already open. A recurring rule is always open, so it always skips.
+func reopenDurableTodo(vaultDir, ref string) (todoReopenResult, error) {
+	n, foundPath, err := resolveDurableTodo(vaultDir, ref, "todo reopen")
+	if err != nil {
+		return todoReopenResult{}, err
+	}
+	res := todoReopenResult{
+		Kind: "durable", Ref: ref, Path: relTodoPath(vaultDir, foundPath), ID: n.ULID, State: "open",
+	}
+	if n.Props["state"] == "open" {
+		res.Skipped = true
+		return res, nil
+	}
+	if err := setOrInsertField(n, "state", "open"); err != nil {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: set state: %w", err)
+	}
+	if n.HasField("done") {
+		if err := n.RemoveField("done"); err != nil {
+			return todoReopenResult{}, fmt.Errorf("todo reopen: clear done date: %w", err)
+		}
+	}
+	if err := writeFileAtomic(foundPath, n.Serialize()); err != nil {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: write: %w", err)
+	}
+	return res, nil
+}
+
+// reopenEphemeralTodo unchecks the idx'th checkbox line of todos/inbox.md,
+// the inverse of doneEphemeralTodo.
+func reopenEphemeralTodo(vaultDir, ref string) (todoReopenResult, error) {
+	idx, err := strconv.Atoi(ref)
+	if err != nil || idx < 1 {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: --ephemeral requires a positive 1-based index, got %q", ref)
+	}
+
+	path := filepath.Join(vaultDir, "todos", "inbox.md")
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: ephemeral container not found (not found): %s", path)
+	}
+	if err != nil {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: read %s: %w", path, err)
+	}
+	if bytes.Contains(raw, []byte("\r\n")) {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: CRLF line endings are not supported (reckon-vj55): %s", path)
+	}
+
+	newRaw, changed, found := setChecklistLine(raw, idx, false)
+	if !found {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: index %d out of range (not found)", idx)
+	}
+	res := todoReopenResult{Kind: "ephemeral", Ref: ref, Path: "todos/inbox.md", Skipped: !changed}
+	if !changed {
+		return res, nil
+	}
+	if err := writeFileAtomic(path, newRaw); err != nil {
+		return todoReopenResult{}, fmt.Errorf("todo reopen: write %s: %w", path, err)
+	}
+	return res, nil
+}
+
 // relTodoPath converts an absolute file path to a vault-relative,
 // forward-slash-separated display path.
 func relTodoPath(vaultDir, path string) string {
@@ -941,6 +2173,18 @@ func relTodoPath(vaultDir, path string) string {
 	return filepath.ToSlash(rel)
 }
 
+// todoHasAnyTag reports whether tags holds any of want, ignoring case.
+func todoHasAnyTag(tags, want []string) bool {
+	for _, t := range tags {
+		for _, w := range want {
+			if strings.EqualFold(t, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func containsString(ss []string, target string) bool {
 	for _, s := range ss {
 		if s == target {
@@ -992,18 +2236,31 @@ func splitChecklistLines(body string) []checklistLineItem {
 // raw from unchecked to checked, touching only that single byte. Returns
 // found=false if idx is out of range.
 func flipChecklistLine(raw []byte, idx int) (newRaw []byte, alreadyChecked bool, found bool) {
+	newRaw, changed, found := setChecklistLine(raw, idx, true)
+	return newRaw, found && !changed, found
+}
+
+// setChecklistLine sets the idx'th (1-based) checklist line's mark to
+// checked ('x') or unchecked (' '), touching only that one byte. changed is
+// false when the line already had the requested mark (raw is returned
+// as-is); found is false when idx is out of range.
+func setChecklistLine(raw []byte, idx int, checked bool) (newRaw []byte, changed bool, found bool) {
 	matches := checklistMarkRe.FindAllSubmatchIndex(raw, -1)
 	if idx < 1 || idx > len(matches) {
 		return nil, false, false
 	}
-	m := matches[idx-1]
-	markStart := m[2]
+	markStart := matches[idx-1][2]
 	mark := raw[markStart]
-	if mark == 'x' || mark == 'X' {
-		return raw, true, true
+	isChecked := mark == 'x' || mark == 'X'
+	if isChecked == checked {
+		return raw, false, true
 	}
 	out := make([]byte, len(raw))
 	copy(out, raw)
-	out[markStart] = 'x'
-	return out, false, true
+	if checked {
+		out[markStart] = 'x'
+	} else {
+		out[markStart] = ' '
+	}
+	return out, true, true
 }
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// writeVaultSettings writes body as vault's .reckon/config.yaml.
+func writeVaultSettings(t *testing.T, vault, body string) {
+	t.Helper()
+	path := filepath.Join(vault, filepath.FromSlash(config.SettingsFile))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir settings dir: %v", err)
+	}
+	mustWriteFile(t, path, body)
+}
+
+// addTodoJSON runs `rk todo add <text> --json` and decodes the result.
+func addTodoJSON(t *testing.T, vault, text string) todoAddResult {
+	t.Helper()
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "add", text, "--json")
+	if err != nil {
+		t.Fatalf("todo add %q: %v\nstderr: %s", text, err, stderr)
+	}
+	var res todoAddResult
+	mustDecodeJSON(t, out, &res)
+	return res
+}
+
+func TestTodoAdd_DefaultStyleHasNoAlias(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	res := addTodoJSON(t, vault, "buy milk")
+	if res.Alias != "" {
+		t.Errorf("alias = %q, want none under the default ulid style", res.Alias)
+	}
+}
+
+func TestTodoAdd_DailyStyleNumbersPerDay(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-01-15")
+	writeVaultSettings(t, vault, "task_id_style: daily\n")
+
+	first := addTodoJSON(t, vault, "buy milk")
+	second := addTodoJSON(t, vault, "buy eggs")
+	if first.Alias != "2026-01-15-01" || second.Alias != "2026-01-15-02" {
+		t.Fatalf("aliases = %q, %q; want 2026-01-15-01, 2026-01-15-02", first.Alias, second.Alias)
+	}
+	if !isValidULID(first.ID) {
+		t.Errorf("id = %q, want the ULID to stay the canonical id", first.ID)
+	}
+
+	n, err := node.Parse([]byte(mustReadFile(t, filepath.Join(vault, first.Path))))
+	if err != nil {
+		t.Fatalf("parse new todo: %v", err)
+	}
+	if !containsString(n.Aliases, "2026-01-15-01") {
+		t.Errorf("aliases on disk = %v, want 2026-01-15-01", n.Aliases)
+	}
+
+	// The alias resolves wherever a ref is accepted.
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "done", "2026-01-15-02"); err != nil {
+		t.Fatalf("todo done by alias: %v\nstderr: %s", err, stderr)
+	}
+}
+
+func TestTodoAdd_SlugStyleAvoidsCollisions(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "task_id_style: slug\n")
+
+	first := addTodoJSON(t, vault, "Buy milk!")
+	second := addTodoJSON(t, vault, "buy milk")
+	if first.Alias != "buy-milk" || second.Alias != "buy-milk-2" {
+		t.Errorf("aliases = %q, %q; want buy-milk, buy-milk-2", first.Alias, second.Alias)
+	}
+	if none := addTodoJSON(t, vault, "!!!"); none.Alias != "" {
+		t.Errorf("alias for an unsluggable title = %q, want none", none.Alias)
+	}
+}
+
+func TestTodoAdd_InvalidIDStyleFails(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "task_id_style: sequential\n")
+
+	_, _, err := runTodo(t, vault, "add", "buy milk")
+	if err == nil || !strings.Contains(err.Error(), "task_id_style") {
+		t.Fatalf("err = %v, want an invalid task_id_style error", err)
+	}
+}
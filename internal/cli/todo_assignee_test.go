@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoAssignee_AddAndFilter: --assignee persists to frontmatter, --mine
+// and --assignee filter on it (unassigned todos only with
+// --include-unassigned), and the pretty listing gains an assignee column.
+func TestTodoAssignee_AddAndFilter(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Setenv("RECKON_AUTHOR", "sam")
+
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "add", "Review the design", "--assignee", "sam", "--json")
+	if err != nil {
+		t.Fatalf("todo add --assignee: %v\nstderr: %s", err, stderr)
+	}
+	var mine todoAddResult
+	mustDecodeJSON(t, out, &mine)
+	if src := mustReadFile(t, vault+"/"+mine.Path); !strings.Contains(src, "assignee: sam\n") {
+		t.Errorf("todo file should record the assignee:\n%s", src)
+	}
+
+	theirs := node.Mint()
+	writeTodoFixture(t, vault, theirs, "open", "", "Fix the build.", "assignee: alex")
+	nobody := node.Mint()
+	writeTodoFixture(t, vault, nobody, "open", "", "Triage the inbox.")
+
+	list := func(args ...string) []string {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"list", "--durable", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo list %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res todoListResult
+		mustDecodeJSON(t, out, &res)
+		var ids []string
+		for _, it := range res.Items {
+			ids = append(ids, it.ID)
+		}
+		return ids
+	}
+	if got := list("--mine"); len(got) != 1 || got[0] != mine.ID {
+		t.Errorf("--mine = %v, want [%s]", got, mine.ID)
+	}
+	if got := list("--assignee", "alex"); len(got) != 1 || got[0] != theirs {
+		t.Errorf("--assignee alex = %v, want [%s]", got, theirs)
+	}
+	if got := list("--mine", "--include-unassigned"); len(got) != 2 {
+		t.Errorf("--mine --include-unassigned = %v, want the sam and unassigned todos", got)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--durable")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	for _, want := range []string{"Review the design (assignee sam)", "Fix the build. (assignee alex)", "Triage the inbox. (unassigned)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("pretty list missing %q:\n%s", want, out)
+		}
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--mine", "--assignee", "alex"); err == nil {
+		t.Error("--mine with --assignee should be rejected")
+	}
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--include-unassigned"); err == nil {
+		t.Error("--include-unassigned alone should be rejected")
+	}
+}
+
+// TestTodoAssignee_DefaultAssignee: default_assignee assigns new durable
+// todos that get no --assignee (--assignee "" opts out) and is who --mine
+// means.
+func TestTodoAssignee_DefaultAssignee(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Setenv("RECKON_AUTHOR", "sam")
+	writeVaultSettings(t, vault, "default_assignee: Sam Lee\n")
+
+	add := func(args ...string) todoAddResult {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"add", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo add %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res todoAddResult
+		mustDecodeJSON(t, out, &res)
+		return res
+	}
+	def := add("Review the design")
+	if src := mustReadFile(t, vault+"/"+def.Path); !strings.Contains(src, "assignee: Sam Lee\n") {
+		t.Errorf("todo without --assignee should get default_assignee:\n%s", src)
+	}
+	other := add("Fix the build", "--assignee", "alex")
+	if src := mustReadFile(t, vault+"/"+other.Path); !strings.Contains(src, "assignee: alex\n") {
+		t.Errorf("--assignee should override default_assignee:\n%s", src)
+	}
+	none := add("Triage the inbox", "--assignee", "")
+	if src := mustReadFile(t, vault+"/"+none.Path); strings.Contains(src, "assignee:") {
+		t.Errorf(`--assignee "" should leave the todo unassigned:\n%s`, src)
+	}
+
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "list", "--durable", "--mine", "--json")
+	if err != nil {
+		t.Fatalf("todo list --mine: %v", err)
+	}
+	var res todoListResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Items) != 1 || res.Items[0].ID != def.ID {
+		t.Errorf("--mine = %+v, want only %s (default_assignee, not $RECKON_AUTHOR)", res.Items, def.ID)
+	}
+}
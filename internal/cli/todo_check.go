@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoCheckCmd = &cobra.Command{
+	Use:   "check <ref> <note>",
+	Short: "Toggle a checklist step in a durable todo's notes",
+	Long: "Toggle the checkbox of a step note (a \"- [ ] text\" body line) of a durable todo. " +
+		"<note> is the note's position as `rk todo show` numbers it; other notes are not steps.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(2),
+	RunE:         runTodoCheckE,
+}
+
+// todoCheckResult is the structured summary of one `rk todo check` run.
+type todoCheckResult struct {
+	ID        string `json:"id"`
+	Path      string `json:"path"`
+	Note      int    `json:"note"`
+	Text      string `json:"text"`
+	Checked   bool   `json:"checked"` // the step's mark after the toggle
+	Steps     int    `json:"steps"`
+	StepsDone int    `json:"steps_done"`
+}
+
+func (r todoCheckResult) Pretty() string {
+	verb := "unchecked"
+	if r.Checked {
+		verb = "checked"
+	}
+	return fmt.Sprintf("todo: %s step %d (%s); %d/%d steps done", verb, r.Note, r.Text, r.StepsDone, r.Steps)
+}
+
+func runTodoCheckE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+	ref := args[0]
+	pos, err := strconv.Atoi(args[1])
+	if err != nil || pos < 1 {
+		return fmt.Errorf("todo check: <note> must be a note position (1, 2, ...), got %q", args[1])
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo check: load config: %w", err)
+	}
+
+	if todoMatchFlag {
+		if ref, err = resolveTodoMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("todo check: %w", err)
+		}
+	}
+	n, path, err := resolveDurableTodo(cfg.VaultDir, ref, "todo check")
+	if err != nil {
+		return err
+	}
+	rel := relTodoPath(cfg.VaultDir, path)
+
+	notes := todoNotes(n.Body)
+	if pos > len(notes) {
+		return fmt.Errorf("todo check: %s has %d note(s), no note %d (not found)", rel, len(notes), pos)
+	}
+	note := notes[pos-1]
+	if !note.Step {
+		return fmt.Errorf("todo check: note %d of %s is not a \"- [ ]\" step", pos, rel)
+	}
+
+	// Every checklist line after the title is a step note, so the
+	// target's index among the file's checklist lines counts back from
+	// the last one.
+	after := 0
+	for _, later := range notes[pos:] {
+		if later.Step {
+			after++
+		}
+	}
+	raw := n.Serialize()
+	idx := len(checklistMarkRe.FindAllIndex(raw, -1)) - after
+	newRaw, _, found := setChecklistLine(raw, idx, !note.Checked)
+	if !found {
+		return fmt.Errorf("todo check: locate step %d in %s", pos, rel)
+	}
+	if err := writeFileAtomic(path, newRaw); err != nil {
+		return fmt.Errorf("todo check: write: %w", err)
+	}
+
+	res := todoCheckResult{ID: n.ULID, Path: rel, Note: pos, Text: note.Text, Checked: !note.Checked}
+	notes[pos-1].Checked = !note.Checked
+	res.Steps, res.StepsDone = countSteps(notes)
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
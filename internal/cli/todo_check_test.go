@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoCheck: "- [ ]" notes are steps; check toggles one by its note
+// position, show reports the done count, and plain notes are refused.
+func TestTodoCheck(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	path, _ := writeTodoFixture(t, vault, id, "open", "", "Release 2.0.\n\nCut from main.\n- [ ] tag the build\n- [x] write notes\n- [ ] announce")
+
+	out, stderr, err := runTodo(t, vault, "check", id, "4", "--json")
+	if err != nil {
+		t.Fatalf("todo check: %v\nstderr: %s", err, stderr)
+	}
+	var res todoCheckResult
+	mustDecodeJSON(t, out, &res)
+	if !res.Checked || res.Text != "announce" || res.StepsDone != 2 || res.Steps != 3 {
+		t.Errorf("check = %+v, want announce checked, 2/3 done", res)
+	}
+	if src := mustReadFile(t, path); !strings.Contains(src, "- [ ] tag the build\n- [x] write notes\n- [x] announce\n") {
+		t.Errorf("only the targeted step should flip:\n%s", src)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "check", id, "3"); err != nil {
+		t.Fatalf("todo check (uncheck): %v", err)
+	}
+	resetCLIFlags()
+	show, _, err := runTodo(t, vault, "show", id)
+	if err != nil || !strings.Contains(show, "(1/3 steps done)") || !strings.Contains(show, "3. [ ] write notes") {
+		t.Errorf("show = %q (err %v), want 1/3 steps done with step 3 unchecked", show, err)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "check", id, "1"); err == nil || !strings.Contains(err.Error(), "not a") {
+		t.Errorf("check of a plain note err = %v, want a not-a-step error", err)
+	}
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "check", id, "9"); err == nil || !strings.Contains(err.Error(), "(not found)") {
+		t.Errorf("check past the last note err = %v, want a (not found) error", err)
+	}
+}
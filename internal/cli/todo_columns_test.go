@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoListColumns: --columns compact keeps only the deadline after the
+// title, wide adds tags, repeater, and parent, and auto is normal when
+// stdout is not a terminal.
+func TestTodoListColumns(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	writeTodoFixture(t, vault, id, "open", "2026-05-01", "Ship it.",
+		"deadline: 2026-05-03", "estimate: 2h", "repeat: +1w", "tags: [work, urgent]")
+
+	list := func(args ...string) string {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"list", "--durable"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo list %v: %v\nstderr: %s", args, err, stderr)
+		}
+		return out
+	}
+
+	compact := list("--columns", "compact")
+	if !strings.Contains(compact, "Ship it. (deadline 2026-05-03)") || strings.Contains(compact, "scheduled") || strings.Contains(compact, "estimate") {
+		t.Errorf("compact row should carry the deadline only:\n%s", compact)
+	}
+	normal := list()
+	if !strings.Contains(normal, "(scheduled 2026-05-01) (deadline 2026-05-03)") || strings.Contains(normal, "#work") {
+		t.Errorf("auto off a terminal should be the normal row:\n%s", normal)
+	}
+	if explicit := list("--columns", "normal"); explicit != normal {
+		t.Errorf("--columns normal = %q, want the default %q", explicit, normal)
+	}
+	wide := list("--columns", "wide")
+	if !strings.Contains(wide, "(estimate 2h) (repeat +1w) #work #urgent") {
+		t.Errorf("wide row should add the repeater and tags:\n%s", wide)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--columns", "huge"); err == nil || !strings.Contains(err.Error(), "--columns") {
+		t.Errorf("--columns huge err = %v, want a --columns error", err)
+	}
+	if got, _ := resolveTodoColumns(todoColumnsAuto, &bytes.Buffer{}); got != todoColumnsNormal {
+		t.Errorf("auto off a terminal = %q, want normal", got)
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoListCount: --count applies the listing's filters and prints only
+// the number of matches (or {"count": N} under --json), even with --quiet.
+// A broken todo file is left out of the count without a warning on stderr,
+// so the output stays a bare number for scripts.
+func TestTodoListCount(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-08", "Overdue one.")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-09", "Overdue two.")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-20", "Later.")
+	writeTodoFixture(t, vault, node.Mint(), "done", "2026-07-01", "Finished.")
+	broken := node.Mint()
+	mustWriteFile(t, filepath.Join(vault, "todos", broken+".md"),
+		"---\nid: "+broken+"\ntype: todo\nstate: open\nNo closing fence.\n")
+
+	count := func(args ...string) string {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"list", "--durable", "--count"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo list --count %v: %v\nstderr: %s", args, err, stderr)
+		}
+		if stderr != "" {
+			t.Errorf("todo list --count %v wrote to stderr: %q", args, stderr)
+		}
+		return strings.TrimSpace(out)
+	}
+	if got := count(); got != "3" {
+		t.Errorf("--count = %q, want 3 open todos", got)
+	}
+	if got := count("--scheduled", "past", "--quiet"); got != "2" {
+		t.Errorf("--count --scheduled past --quiet = %q, want 2", got)
+	}
+
+	var res todoCountResult
+	mustDecodeJSON(t, count("--all", "--json"), &res)
+	if res.Count != 4 {
+		t.Errorf("--count --all --json = %+v, want count 4", res)
+	}
+}
+
+// TestTodoListStateTypo: --state filters on any state exactly, a vault's
+// own as well as the built-in ones; a filter matching nothing gets the
+// nearest built-in state suggested when it looks like a typo, except under
+// --count, whose output is only the number.
+func TestTodoListStateTypo(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeTodoFixture(t, vault, node.Mint(), "in-progress", "", "Started.")
+	writeTodoFixture(t, vault, node.Mint(), "waiting", "", "Blocked on review.")
+
+	resetCLIFlags()
+	_, stderr, err := runTodo(t, vault, "list", "--state", "inprogress")
+	if err != nil || !strings.Contains(stderr, `no todos in state "inprogress" (did you mean "in-progress"?)`) {
+		t.Errorf("--state inprogress: stderr %q, %v; want a suggestion of in-progress", stderr, err)
+	}
+
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "list", "--state", "inprogress", "--count")
+	if err != nil || strings.TrimSpace(out) != "0" || stderr != "" {
+		t.Errorf("--state inprogress --count = %q, stderr %q, %v; want 0 with no hint", out, stderr, err)
+	}
+
+	resetCLIFlags()
+	out, stderr, err = runTodo(t, vault, "list", "--state", "waiting", "--count")
+	if err != nil || strings.TrimSpace(out) != "1" || stderr != "" {
+		t.Errorf("--state waiting = %q, stderr %q, %v; want 1 with no hint", out, stderr, err)
+	}
+
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "list", "--state", "someday"); err != nil || stderr != "" {
+		t.Errorf("--state someday: stderr %q, %v; want no error and no suggestion", stderr, err)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--state", "in-progress", "--count")
+	if err != nil || strings.TrimSpace(out) != "1" {
+		t.Errorf("--state in-progress --count = %q, %v; want 1", out, err)
+	}
+}
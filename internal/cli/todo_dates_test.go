@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+func TestScheduleDeadlineWarning(t *testing.T) {
+	cases := []struct {
+		scheduled, deadline string
+		warn                bool
+	}{
+		{"2026-08-02", "2026-08-01", true},
+		{"2026-08-01", "2026-08-01", false},
+		{"2026-07-30", "2026-08-01", false},
+		{"", "2026-08-01", false},
+		{"2026-08-02", "", false},
+		{"garbage", "2026-08-01", false},
+	}
+	for _, tc := range cases {
+		got := scheduleDeadlineWarning(tc.scheduled, tc.deadline)
+		if (got != "") != tc.warn {
+			t.Errorf("scheduleDeadlineWarning(%q, %q) = %q, want warn=%v", tc.scheduled, tc.deadline, got, tc.warn)
+		}
+	}
+}
+
+func TestTodoAdd_ScheduledAfterDeadlineWarns(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	_, stderr, err := runTodo(t, vault, "add", "late", "--scheduled", "2026-08-05", "--deadline", "2026-08-01")
+	if err != nil {
+		t.Fatalf("todo add: %v", err)
+	}
+	if !strings.Contains(stderr, "warning: scheduled date 2026-08-05 is after deadline 2026-08-01") {
+		t.Errorf("stderr = %q, want the schedule/deadline warning", stderr)
+	}
+}
+
+func TestTodoAdd_ScheduledAfterDeadlineStrictFails(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	_, _, err := runTodo(t, vault, "add", "late", "--scheduled", "2026-08-05", "--deadline", "2026-08-01", "--strict")
+	if err == nil || !strings.Contains(err.Error(), "--strict") {
+		t.Fatalf("err = %v, want a --strict refusal", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(vault, "todos")); len(entries) != 0 {
+		t.Errorf("todo written despite --strict refusal: %v", entries)
+	}
+}
+
+func TestTodayActDeadline_BeforeScheduled(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	out, _, err := runTodo(t, vault, "add", "x", "--scheduled", "2026-08-05", "--json")
+	if err != nil {
+		t.Fatalf("todo add: %v", err)
+	}
+	var added todoAddResult
+	mustDecodeJSON(t, out, &added)
+	before := mustReadFile(t, filepath.Join(vault, added.Path))
+
+	resetCLIFlags()
+	if _, _, err := runToday(t, vault, "act", added.ID, "D", "2026-08-01", "--strict"); err == nil {
+		t.Fatal("expected --strict refusal for a deadline before scheduled")
+	}
+	if got := mustReadFile(t, filepath.Join(vault, added.Path)); got != before {
+		t.Errorf("file changed despite --strict refusal:\n%s", got)
+	}
+
+	resetCLIFlags()
+	out, _, err = runToday(t, vault, "act", added.ID, "D", "2026-08-01", "--json")
+	if err != nil {
+		t.Fatalf("today act D: %v", err)
+	}
+	var res todayActResult
+	mustDecodeJSON(t, out, &res)
+	if res.Warning == "" || res.Deadline != "2026-08-01" {
+		t.Errorf("result = %+v, want deadline set with a warning", res)
+	}
+}
+
+func TestTodoList_ScheduledFilter(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-08")
+
+	for _, sched := range []string{"2026-07-01", "2026-07-08", "2026-07-20"} {
+		resetCLIFlags()
+		if _, _, err := runTodo(t, vault, "add", "due "+sched, "--scheduled", sched); err != nil {
+			t.Fatalf("todo add: %v", err)
+		}
+	}
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "add", "inbox item", "--ephemeral"); err != nil {
+		t.Fatalf("todo add --ephemeral: %v", err)
+	}
+
+	cases := map[string][]string{
+		"past":                   {"2026-07-01"},
+		"today":                  {"2026-07-08"},
+		"-7d..today":             {"2026-07-01", "2026-07-08"},
+		"2026-07-09..2026-12-31": {"2026-07-20"},
+	}
+	for expr, want := range cases {
+		resetCLIFlags()
+		out, _, err := runTodo(t, vault, "list", "--scheduled", expr, "--json")
+		if err != nil {
+			t.Fatalf("todo list --scheduled %s: %v", expr, err)
+		}
+		var res todoListResult
+		mustDecodeJSON(t, out, &res)
+		var got []string
+		for _, it := range res.Items {
+			got = append(got, it.Scheduled)
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("--scheduled %s = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestTodoList_IncludeOverdue(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-08")
+
+	today := node.Mint()
+	late := node.Mint()
+	dueLate := node.Mint()
+	writeTodoFixture(t, vault, today, "open", "2026-07-08", "Today.")
+	writeTodoFixture(t, vault, late, "in-progress", "2026-07-01", "Late.")
+	writeTodoFixture(t, vault, dueLate, "open", "2026-07-08", "Due late.", "deadline: 2026-07-05")
+	writeTodoFixture(t, vault, node.Mint(), "done", "2026-07-01", "Done late.")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-20", "Later.")
+
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "list", "--scheduled", "today", "--include-overdue", "--json")
+	if err != nil {
+		t.Fatalf("todo list --include-overdue: %v", err)
+	}
+	var res todoListResult
+	mustDecodeJSON(t, out, &res)
+	overdue := map[string]bool{}
+	for _, it := range res.Items {
+		if _, dup := overdue[it.ID]; dup {
+			t.Errorf("%s listed twice", it.ID)
+		}
+		overdue[it.ID] = it.Overdue
+	}
+	want := map[string]bool{today: false, late: true, dueLate: true}
+	if len(overdue) != len(want) {
+		t.Fatalf("items = %v, want %v", overdue, want)
+	}
+	for id, o := range want {
+		if got, ok := overdue[id]; !ok || got != o {
+			t.Errorf("%s: listed=%v overdue=%v, want overdue=%v", id, ok, got, o)
+		}
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--scheduled", "today", "--include-overdue")
+	if err != nil {
+		t.Fatalf("todo list --include-overdue (pretty): %v", err)
+	}
+	if !strings.Contains(out, "Late. (overdue)") || strings.Contains(out, "Today. (overdue)") {
+		t.Errorf("pretty output should mark only overdue todos:\n%s", out)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--include-overdue"); err == nil ||
+		!strings.Contains(err.Error(), "--include-overdue requires --scheduled") {
+		t.Errorf("--include-overdue without --scheduled: err = %v", err)
+	}
+}
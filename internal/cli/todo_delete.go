@@ -0,0 +1,431 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoDeleteStdinFlag bool
+
+// renameIntoTrash moves a todo file into a trash batch; a var so tests can
+// make a move fail partway through a delete.
+var renameIntoTrash = os.Rename
+
+// todoTrashDir is where `rk todo delete` moves todo files, relative to the
+// vault: one subdirectory per run (a batch), named for when it ran, holding
+// the files at their vault-relative paths. The index skips it.
+const todoTrashDir = ".trash"
+
+// todoTrashBatchLayout names a batch directory; it sorts in time order.
+const todoTrashBatchLayout = "20060102T150405.000000000Z"
+
+var todoDeleteCmd = &cobra.Command{
+	Use:   "delete [<ref>...]",
+	Short: "Delete durable todos, restorable for a short while with undelete",
+	Long: "Delete the durable todos named by each <ref> (ULID, alias, or ID prefix), or with --stdin by each " +
+		"line of stdin. Nothing is deleted unless every ref resolves. The files move to the vault's .trash/ " +
+		"directory as one batch, which `rk todo undelete` restores; batches older than undelete_seconds (a " +
+		"vault setting, default 60) are purged the next time either command runs.",
+	SilenceUsage: true,
+	Args:         cobra.ArbitraryArgs,
+	RunE:         runTodoDeleteE,
+}
+
+var todoUndeleteCmd = &cobra.Command{
+	Use:   "undelete [<ref>]",
+	Short: "Restore the todos the last `rk todo delete` removed, or one deleted todo",
+	Long: "Move the most recent `rk todo delete` batch back from .trash/ to where its todos were, or with " +
+		"<ref> (ULID, alias, or ID prefix) just that todo, from whichever batch holds it. Fails, restoring " +
+		"nothing, if a todo's path has been reused since, or if its batch is older than undelete_seconds " +
+		"and so already purged. `rk trash list` shows what can still be restored.",
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runTodoUndeleteE,
+}
+
+func init() {
+	todoDeleteCmd.Flags().BoolVar(&todoDeleteStdinFlag, "stdin", false, "Read refs from stdin, one per line")
+	todoCmd.AddCommand(todoDeleteCmd, todoUndeleteCmd)
+}
+
+// todoDeleteResult is the structured summary of one `rk todo delete` run.
+type todoDeleteResult struct {
+	Batch   string           `json:"batch"` // vault-relative batch directory
+	Deleted []todoDeleteItem `json:"deleted"`
+	// Expires is when the batch is purged (RFC 3339, UTC) and undelete
+	// stops working.
+	Expires string `json:"expires"`
+
+	seconds int // undelete_seconds, for the pretty message
+}
+
+// todoDeleteItem is one todo a delete moved to the trash, or an undelete
+// restored.
+type todoDeleteItem struct {
+	ID    string `json:"id"`
+	Path  string `json:"path"` // where the todo lives (or lived)
+	Title string `json:"title"`
+
+	aliases []string // for matching an undelete ref
+}
+
+func (r todoDeleteResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: deleted %d todo(s); run `rk todo undelete` within %ds to restore", len(r.Deleted), r.seconds)
+	for _, it := range r.Deleted {
+		fmt.Fprintf(&b, "\n  %s  %s", it.ID, it.Title)
+	}
+	return b.String()
+}
+
+// todoUndeleteResult is the structured summary of one `rk todo undelete` run.
+type todoUndeleteResult struct {
+	Batch    string           `json:"batch"`
+	Restored []todoDeleteItem `json:"restored"`
+}
+
+func (r todoUndeleteResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: restored %d todo(s)", len(r.Restored))
+	for _, it := range r.Restored {
+		fmt.Fprintf(&b, "\n  %s  %s", it.ID, it.Title)
+	}
+	return b.String()
+}
+
+func runTodoDeleteE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	refs := args
+	if todoDeleteStdinFlag {
+		lines, err := readStdinLines(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("todo delete: %w", err)
+		}
+		refs = append(append([]string{}, args...), lines...)
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("todo delete: no todos to delete (pass refs as arguments, or use --stdin)")
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo delete: load config: %w", err)
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("todo delete: %w", err)
+	}
+	window := time.Duration(settings.UndeleteSeconds) * time.Second
+	if err := purgeTodoTrash(cfg.VaultDir, window); err != nil {
+		return fmt.Errorf("todo delete: %w", err)
+	}
+
+	// Resolve every ref before moving anything, so a typo in the middle
+	// of a piped list deletes nothing.
+	var paths []string
+	var items []todoDeleteItem
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		n, path, err := resolveDurableTodo(cfg.VaultDir, ref, "todo delete")
+		if err != nil {
+			return err
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+		items = append(items, todoDeleteItem{ID: n.ULID, Path: relTodoPath(cfg.VaultDir, path), Title: firstBodyLine(n.Body)})
+	}
+
+	now := todoNow().UTC()
+	batch, err := newTodoTrashBatch(cfg.VaultDir, now)
+	if err != nil {
+		return fmt.Errorf("todo delete: %w", err)
+	}
+	for i, path := range paths {
+		dst := filepath.Join(batch, filepath.FromSlash(items[i].Path))
+		err := os.MkdirAll(filepath.Dir(dst), 0o755)
+		if err == nil {
+			err = renameIntoTrash(path, dst)
+		}
+		if err != nil {
+			err = fmt.Errorf("todo delete: move %s to the trash: %w", items[i].Path, err)
+			if rbErr := unmoveTodoTrash(batch, paths[:i], items[:i]); rbErr != nil {
+				return fmt.Errorf("%w; %d todo(s) already moved stay in %s (`rk todo undelete` restores them): %v",
+					err, i, relTodoPath(cfg.VaultDir, batch), rbErr)
+			}
+			return fmt.Errorf("%w; nothing was deleted", err)
+		}
+	}
+
+	res := todoDeleteResult{
+		Batch:   relTodoPath(cfg.VaultDir, batch),
+		Deleted: items,
+		Expires: now.Add(window).Format(time.RFC3339),
+		seconds: settings.UndeleteSeconds,
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+func runTodoUndeleteE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo undelete: load config: %w", err)
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("todo undelete: %w", err)
+	}
+	if err := purgeTodoTrash(cfg.VaultDir, time.Duration(settings.UndeleteSeconds)*time.Second); err != nil {
+		return fmt.Errorf("todo undelete: %w", err)
+	}
+
+	batches, err := loadTodoTrash(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("todo undelete: %w", err)
+	}
+	if len(batches) == 0 {
+		return fmt.Errorf("todo undelete: nothing to undelete (deleted todos are kept for %ds)", settings.UndeleteSeconds)
+	}
+	batch := batches[len(batches)-1]
+	items := batch.Todos
+	if len(args) == 1 {
+		var it todoDeleteItem
+		if batch, it, err = findTrashedTodo(batches, args[0]); err != nil {
+			return fmt.Errorf("todo undelete: %w", err)
+		}
+		items = []todoDeleteItem{it}
+	}
+	if err := restoreTodoTrash(cfg.VaultDir, batch, items); err != nil {
+		return fmt.Errorf("todo undelete: %w", err)
+	}
+
+	res := todoUndeleteResult{Batch: batch.Batch, Restored: items}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// newTodoTrashBatch creates and returns the batch directory for a delete
+// run at now, suffixed -2, -3, ... when one by that name already exists.
+func newTodoTrashBatch(vaultDir string, now time.Time) (string, error) {
+	base := filepath.Join(vaultDir, todoTrashDir, now.Format(todoTrashBatchLayout))
+	if err := os.MkdirAll(filepath.Dir(base), 0o755); err != nil {
+		return "", fmt.Errorf("create trash dir: %w", err)
+	}
+	dir := base
+	for i := 2; ; i++ {
+		err := os.Mkdir(dir, 0o755)
+		if err == nil {
+			return dir, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", fmt.Errorf("create trash batch: %w", err)
+		}
+		dir = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// todoTrashBatches returns the names of the batch directories in the
+// vault's trash, oldest first. A missing trash has none.
+func todoTrashBatches(vaultDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(vaultDir, todoTrashDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read trash: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if _, ok := todoTrashBatchTime(e.Name()); ok && e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ti, _ := todoTrashBatchTime(names[i])
+		tj, _ := todoTrashBatchTime(names[j])
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return len(names[i]) < len(names[j]) || len(names[i]) == len(names[j]) && names[i] < names[j]
+	})
+	return names, nil
+}
+
+// todoTrashBatchTime parses the time a batch directory name records,
+// ignoring any -N suffix.
+func todoTrashBatchTime(name string) (time.Time, bool) {
+	stamp, _, _ := strings.Cut(name, "-")
+	t, err := time.Parse(todoTrashBatchLayout, stamp)
+	return t, err == nil
+}
+
+// purgeTodoTrash permanently removes the trash batches made more than
+// window before todoNow.
+func purgeTodoTrash(vaultDir string, window time.Duration) error {
+	batches, err := todoTrashBatches(vaultDir)
+	if err != nil {
+		return err
+	}
+	cutoff := todoNow().UTC().Add(-window)
+	for _, name := range batches {
+		if t, _ := todoTrashBatchTime(name); t.Before(cutoff) {
+			if err := os.RemoveAll(filepath.Join(vaultDir, todoTrashDir, name)); err != nil {
+				return fmt.Errorf("purge trash batch %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// todoTrashBatch is one `rk todo delete` run's batch in the trash.
+type todoTrashBatch struct {
+	Batch   string           `json:"batch"`   // vault-relative batch directory
+	Deleted string           `json:"deleted"` // when (RFC 3339, UTC)
+	Expires string           `json:"expires,omitempty"`
+	Todos   []todoDeleteItem `json:"todos"`
+
+	name string // the batch directory's name
+}
+
+// loadTodoTrash returns every batch in the vault's trash with the todos it
+// holds, oldest first.
+func loadTodoTrash(vaultDir string) ([]todoTrashBatch, error) {
+	names, err := todoTrashBatches(vaultDir)
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]todoTrashBatch, 0, len(names))
+	for _, name := range names {
+		dir := filepath.Join(vaultDir, todoTrashDir, name)
+		deleted, _ := todoTrashBatchTime(name)
+		b := todoTrashBatch{Batch: relTodoPath(vaultDir, dir), Deleted: deleted.Format(time.RFC3339), Todos: []todoDeleteItem{}, name: name}
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			it := todoDeleteItem{Path: filepath.ToSlash(rel)}
+			if n, ok := parseCandidateFile(path); ok {
+				it.ID, it.Title, it.aliases = n.ULID, firstBodyLine(n.Body), n.Aliases
+			}
+			b.Todos = append(b.Todos, it)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read trash batch %s: %w", name, err)
+		}
+		batches = append(batches, b)
+	}
+	return batches, nil
+}
+
+// findTrashedTodo returns the newest trashed todo whose ULID or alias is
+// ref, else the one todo whose ULID starts with ref, and its batch.
+func findTrashedTodo(batches []todoTrashBatch, ref string) (todoTrashBatch, todoDeleteItem, error) {
+	for i := len(batches) - 1; i >= 0; i-- {
+		for _, it := range batches[i].Todos {
+			if it.ID == ref || containsString(it.aliases, ref) {
+				return batches[i], it, nil
+			}
+		}
+	}
+	var found []todoDeleteItem
+	var foundIn todoTrashBatch
+	seen := map[string]bool{}
+	for i := len(batches) - 1; i >= 0; i-- {
+		for _, it := range batches[i].Todos {
+			if it.ID != "" && strings.HasPrefix(it.ID, ref) && !seen[it.ID] {
+				seen[it.ID] = true
+				if len(found) == 0 {
+					foundIn = batches[i]
+				}
+				found = append(found, it)
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return todoTrashBatch{}, todoDeleteItem{}, fmt.Errorf("no deleted todo matching %q in the trash (not found)", ref)
+	case 1:
+		return foundIn, found[0], nil
+	default:
+		return todoTrashBatch{}, todoDeleteItem{}, fmt.Errorf("%q matches %d deleted todos; use more of the ID", ref, len(found))
+	}
+}
+
+// unmoveTodoTrash rolls back a delete that failed partway: it moves the
+// todos already in batch back to paths and removes the batch.
+func unmoveTodoTrash(batch string, paths []string, items []todoDeleteItem) error {
+	for i, path := range paths {
+		if err := os.Rename(filepath.Join(batch, filepath.FromSlash(items[i].Path)), path); err != nil {
+			return fmt.Errorf("move %s back: %w", items[i].Path, err)
+		}
+	}
+	return os.RemoveAll(batch)
+}
+
+// restoreTodoTrash moves items from batch back to their vault-relative
+// paths, removing the batch once it is empty. It checks every destination
+// first, restoring nothing if any is taken. A restored todo keeps its
+// filename, and so its place in ULID (creation) order.
+func restoreTodoTrash(vaultDir string, batch todoTrashBatch, items []todoDeleteItem) error {
+	dir := filepath.Join(vaultDir, todoTrashDir, batch.name)
+	for _, it := range items {
+		if _, err := os.Stat(filepath.Join(vaultDir, filepath.FromSlash(it.Path))); err == nil {
+			return fmt.Errorf("%s exists again; move it aside to restore it", it.Path)
+		}
+	}
+	for _, it := range items {
+		src, dst := filepath.Join(dir, filepath.FromSlash(it.Path)), filepath.Join(vaultDir, filepath.FromSlash(it.Path))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", filepath.Dir(it.Path), err)
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return fmt.Errorf("restore %s: %w", it.Path, err)
+		}
+	}
+	if len(items) < len(batch.Todos) {
+		return nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove trash batch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+func TestTodoDeleteUndelete(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	a, b, keep := node.Mint(), node.Mint(), node.Mint()
+	pathA, srcA := writeTodoFixture(t, vault, a, "open", "", "Call the plumber.")
+	pathB, srcB := writeTodoFixture(t, vault, b, "done", "", "File the taxes.")
+	pathKeep, _ := writeTodoFixture(t, vault, keep, "open", "", "Water the plants.")
+
+	// One unknown ref deletes nothing.
+	if _, err := runTodoStdin(t, vault, a+"\nno-such-todo\n", "delete", "--stdin"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("delete with an unknown ref: err = %v, want not found", err)
+	}
+	if _, err := os.Stat(pathA); err != nil {
+		t.Fatalf("a failed delete removed %s: %v", pathA, err)
+	}
+
+	out, err := runTodoStdin(t, vault, a+"\n\n"+b+"\n"+a+"\n", "delete", "--stdin", "--json")
+	if err != nil {
+		t.Fatalf("todo delete --stdin: %v", err)
+	}
+	var res todoDeleteResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Deleted) != 2 || res.Deleted[0].ID != a || res.Deleted[1].ID != b {
+		t.Fatalf("deleted = %+v, want %s then %s once each", res.Deleted, a, b)
+	}
+	if res.Expires != "2026-07-10T00:01:00Z" {
+		t.Errorf("expires = %q, want 60s after the delete", res.Expires)
+	}
+	for _, p := range []string{pathA, pathB} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after delete (err %v)", p, err)
+		}
+	}
+	resetCLIFlags()
+	if _, err := os.Stat(filepath.Join(vault, res.Batch, "todos", a+".md")); err != nil {
+		t.Errorf("deleted todo not in the trash batch: %v", err)
+	}
+	listOut, _, err := runTodo(t, vault, "list", "--all")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	if strings.Contains(listOut, "plumber") || !strings.Contains(listOut, "Water the plants") {
+		t.Errorf("todo list after delete:\n%s", listOut)
+	}
+
+	pretty, _, err := runTodo(t, vault, "undelete")
+	if err != nil {
+		t.Fatalf("todo undelete: %v", err)
+	}
+	if !strings.Contains(pretty, "restored 2 todo(s)") {
+		t.Errorf("undelete output = %q", pretty)
+	}
+	if got := mustReadFile(t, pathA); got != srcA {
+		t.Errorf("restored %s differs:\n%q\nwant\n%q", pathA, got, srcA)
+	}
+	if got := mustReadFile(t, pathB); got != srcB {
+		t.Errorf("restored %s differs:\n%q\nwant\n%q", pathB, got, srcB)
+	}
+	if _, err := os.Stat(filepath.Join(vault, res.Batch)); !os.IsNotExist(err) {
+		t.Errorf("trash batch left behind after undelete (err %v)", err)
+	}
+	if _, _, err := runTodo(t, vault, "undelete"); err == nil || !strings.Contains(err.Error(), "nothing to undelete") {
+		t.Errorf("second undelete: err = %v, want nothing to undelete", err)
+	}
+
+	// A batch older than undelete_seconds is purged on the next run.
+	if _, _, err := runTodo(t, vault, "delete", keep); err != nil {
+		t.Fatalf("todo delete %s: %v", keep, err)
+	}
+	pinTodoNow(t, "2026-07-11")
+	if _, _, err := runTodo(t, vault, "undelete"); err == nil || !strings.Contains(err.Error(), "nothing to undelete") {
+		t.Errorf("undelete after the window: err = %v, want nothing to undelete", err)
+	}
+	if _, err := os.Stat(pathKeep); !os.IsNotExist(err) {
+		t.Errorf("%s restored after the window (err %v)", pathKeep, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(vault, todoTrashDir)); len(entries) != 0 {
+		t.Errorf("expired batch not purged: %d left in the trash", len(entries))
+	}
+}
+
+// TestTodoDeleteRollsBack: when a move into the trash fails partway, the
+// todos already moved go back and no batch is left behind.
+func TestTodoDeleteRollsBack(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	a, b := node.Mint(), node.Mint()
+	pathA, srcA := writeTodoFixture(t, vault, a, "open", "", "Call the plumber.")
+	pathB, _ := writeTodoFixture(t, vault, b, "open", "", "File the taxes.")
+
+	prev := renameIntoTrash
+	renameIntoTrash = func(src, dst string) error {
+		if src == pathB {
+			return errors.New("disk full")
+		}
+		return prev(src, dst)
+	}
+	t.Cleanup(func() { renameIntoTrash = prev })
+
+	_, err := runTodoStdin(t, vault, a+"\n"+b+"\n", "delete", "--stdin")
+	if err == nil || !strings.Contains(err.Error(), "disk full") || !strings.Contains(err.Error(), "nothing was deleted") {
+		t.Fatalf("delete with a failing move: err = %v, want disk full and nothing was deleted", err)
+	}
+	if got := mustReadFile(t, pathA); got != srcA {
+		t.Errorf("%s after the rollback = %q, want it back unchanged", pathA, got)
+	}
+	if _, err := os.Stat(pathB); err != nil {
+		t.Errorf("%s after the failed delete: %v", pathB, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(vault, todoTrashDir)); len(entries) != 0 {
+		t.Errorf("trash after the rollback holds %d batch(es), want none", len(entries))
+	}
+}
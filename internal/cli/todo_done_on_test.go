@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoListDoneOn: --done-today/--done-on list the todos completed on
+// those days (done todos by file mtime, recurring ones by their did::
+// entries), with --tag narrowing further; archived todos, whose completion
+// day is lost, are left out and counted.
+func TestTodoListDoneOn(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	a, b, open, rec, arch := node.Mint(), node.Mint(), node.Mint(), node.Mint(), node.Mint()
+	pathA, _ := writeTodoFixture(t, vault, a, "done", "", "Call the plumber.", "tags: [home]")
+	pathB, _ := writeTodoFixture(t, vault, b, "done", "", "Ship the release.", "tags: [work]")
+	writeTodoFixture(t, vault, open, "open", "", "Still going.", "tags: [work]")
+	writeTodoFixture(t, vault, arch, "archived", "", "Long ago.")
+	mustWriteFile(t, filepath.Join(vault, "todos", rec+".md"), recurringTodoSrc(rec, "2026-07-10", "+1d", "Water the plants."))
+	for path, day := range map[string]string{pathA: "2026-07-09", pathB: "2026-07-10"} {
+		mtime, _ := time.Parse("2006-01-02 15:04", day+" 12:00")
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := runTodo(t, vault, "done", rec); err != nil {
+		t.Fatalf("todo done %s: %v", rec, err)
+	}
+
+	list := func(args ...string) todoListResult {
+		t.Helper()
+		resetCLIFlags()
+		out, _, err := runTodo(t, vault, append([]string{"list", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo list %v: %v", args, err)
+		}
+		var res todoListResult
+		mustDecodeJSON(t, out, &res)
+		return res
+	}
+	ids := func(res todoListResult) map[string]string {
+		out := map[string]string{}
+		for _, it := range res.Items {
+			out[it.ID] = it.Completed
+		}
+		return out
+	}
+
+	res := list("--done-today")
+	if got := ids(res); len(got) != 2 || got[b] != "2026-07-10" || got[rec] != "2026-07-10" {
+		t.Errorf("--done-today = %v, want %s and the recurring %s on 2026-07-10", got, b, rec)
+	}
+	if res.Undated != 1 {
+		t.Errorf("undated = %d, want 1 (the archived todo)", res.Undated)
+	}
+	if got := ids(list("--done-on", "yesterday")); len(got) != 1 || got[a] != "2026-07-09" {
+		t.Errorf("--done-on yesterday = %v, want %s on 2026-07-09", got, a)
+	}
+	if got := ids(list("--done-on", "-1d..today")); len(got) != 3 {
+		t.Errorf("--done-on -1d..today = %v, want %s, %s and %s", got, a, b, rec)
+	}
+	if got := ids(list("--done-on", "-7d..", "--tag", "work")); len(got) != 1 || got[b] == "" {
+		t.Errorf("--done-on -7d.. --tag work = %v, want %s only", got, b)
+	}
+
+	resetCLIFlags()
+	pretty, _, err := runTodo(t, vault, "list", "--done-today")
+	if err != nil {
+		t.Fatalf("todo list --done-today: %v", err)
+	}
+	if !strings.Contains(pretty, "1 archived todo(s) with no completion date left out") || !strings.Contains(pretty, "(done 2026-07-10)") {
+		t.Errorf("pretty --done-today:\n%s", pretty)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--done-today", "--done-on", "today"); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--done-today with --done-on: err = %v, want mutually exclusive", err)
+	}
+}
+
+// TestTodoListDoneOn_DoneField: a todo's done: date decides its completion
+// day over a later mtime, and keeps an archived todo dated.
+func TestTodoListDoneOn_DoneField(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	pinTodoNow(t, "2026-07-08")
+	done := node.Mint()
+	path, _ := writeTodoFixture(t, vault, done, "open", "", "Ship the release.")
+	if _, stderr, err := runTodo(t, vault, "done", done); err != nil {
+		t.Fatalf("todo done: %v\nstderr: %s", err, stderr)
+	}
+	pinTodoNow(t, "2026-07-10")
+	touched := time.Date(2026, 7, 10, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, touched, touched); err != nil {
+		t.Fatal(err)
+	}
+	arch := node.Mint()
+	writeTodoFixture(t, vault, arch, "archived", "", "Long ago.", "done: 2026-07-01")
+
+	list := func(args ...string) todoListResult {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"list", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo list %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res todoListResult
+		mustDecodeJSON(t, out, &res)
+		return res
+	}
+	if res := list("--done-today"); len(res.Items) != 0 || res.Undated != 0 {
+		t.Errorf("--done-today = %+v, want nothing: the fresh mtime is not a completion", res)
+	}
+	if res := list("--done-on", "2026-07-08"); len(res.Items) != 1 || res.Items[0].ID != done || res.Items[0].Completed != "2026-07-08" {
+		t.Errorf("--done-on 2026-07-08 = %+v, want %s by its done: date", res.Items, done)
+	}
+	if res := list("--done-on", "2026-07-01"); len(res.Items) != 1 || res.Items[0].ID != arch {
+		t.Errorf("--done-on 2026-07-01 = %+v, want the archived %s by its done: date", res.Items, arch)
+	}
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoEditCmd = &cobra.Command{
+	Use:   "edit <ref>",
+	Short: "Change a durable todo's title, tags, and dates in one write",
+	Long: "Apply every given change to a durable todo at once: --title replaces the body's first line, " +
+		"--tags replaces the tag list, --scheduled/--deadline set the dates (YYYY-MM-DD, today, tomorrow, " +
+		"or an offset like +3d), and --clear-scheduled/--clear-deadline remove them. Every change is " +
+		"checked before the file is written, so a bad flag leaves the todo as it was. A recurring todo " +
+		"keeps its scheduled date: its repeater needs one to advance.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runTodoEditE,
+}
+
+// todoEditResult is the structured summary of one `rk todo edit` run.
+type todoEditResult struct {
+	ID        string   `json:"id"`
+	Path      string   `json:"path"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags"`
+	Scheduled string   `json:"scheduled,omitempty"`
+	Deadline  string   `json:"deadline,omitempty"`
+	Changed   []string `json:"changed"` // the fields edited, in flag order
+	Warning   string   `json:"warning,omitempty"`
+}
+
+func (r todoEditResult) Pretty() string {
+	msg := fmt.Sprintf("todo: edited %s (%s)", r.ID, strings.Join(r.Changed, ", "))
+	if r.Warning != "" {
+		msg += "\n  warning: " + r.Warning
+	}
+	return msg
+}
+
+func runTodoEditE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+	ref := args[0]
+
+	title := strings.TrimSpace(todoEditTitleFlag)
+	setTitle := cmd.Flags().Changed("title")
+	setTags := cmd.Flags().Changed("tags")
+	tags := parseTagInput(todoTagsFlag)
+	if setTitle && (title == "" || strings.Contains(title, "\n")) {
+		return fmt.Errorf("todo edit: --title must be one non-empty line")
+	}
+	if todoScheduledFlag != "" && todoClearSchedFlag {
+		return fmt.Errorf("todo edit: --scheduled and --clear-scheduled are mutually exclusive")
+	}
+	if todoDeadlineFlag != "" && todoClearDeadlineFlag {
+		return fmt.Errorf("todo edit: --deadline and --clear-deadline are mutually exclusive")
+	}
+	if !setTitle && !setTags && todoScheduledFlag == "" && todoDeadlineFlag == "" && !todoClearSchedFlag && !todoClearDeadlineFlag {
+		return fmt.Errorf("todo edit: nothing to change (give --title, --tags, --scheduled, --deadline, --clear-scheduled, or --clear-deadline)")
+	}
+
+	today := journalNow()
+	var scheduled, deadline string
+	var err error
+	if todoScheduledFlag != "" {
+		if scheduled, err = resolveDateEndpoint(todoScheduledFlag, today); err != nil {
+			return fmt.Errorf("todo edit: --scheduled: %w", err)
+		}
+	}
+	if todoDeadlineFlag != "" {
+		if deadline, err = resolveDateEndpoint(todoDeadlineFlag, today); err != nil {
+			return fmt.Errorf("todo edit: --deadline: %w", err)
+		}
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo edit: load config: %w", err)
+	}
+	if todoMatchFlag {
+		if ref, err = resolveTodoMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("todo edit: %w", err)
+		}
+	}
+	n, path, err := resolveDurableTodo(cfg.VaultDir, ref, "todo edit")
+	if err != nil {
+		return err
+	}
+	if todoClearSchedFlag && n.Props["repeat"] != "" {
+		return fmt.Errorf("todo edit: %s repeats (%s); its scheduled date cannot be cleared", ref, n.Props["repeat"])
+	}
+
+	// Every edit lands on the in-memory node first; the file is written
+	// once, after all of them succeed.
+	var changed []string
+	if setTitle {
+		if err := setTodoTitle(n, title); err != nil {
+			return fmt.Errorf("todo edit: set title: %w", err)
+		}
+		changed = append(changed, "title")
+	}
+	if setTags {
+		if len(tags) == 0 {
+			err = n.RemoveField("tags")
+		} else {
+			err = setOrInsertField(n, "tags", "["+strings.Join(tags, ", ")+"]")
+		}
+		if err != nil {
+			return fmt.Errorf("todo edit: set tags: %w", err)
+		}
+		changed = append(changed, "tags")
+	}
+	for _, f := range []struct {
+		key, value string
+		clear      bool
+	}{
+		{"scheduled", scheduled, todoClearSchedFlag},
+		{"deadline", deadline, todoClearDeadlineFlag},
+	} {
+		switch {
+		case f.clear:
+			err = n.RemoveField(f.key)
+		case f.value != "":
+			err = setOrInsertField(n, f.key, f.value)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("todo edit: set %s: %w", f.key, err)
+		}
+		changed = append(changed, f.key)
+	}
+
+	warning := scheduleDeadlineWarning(n.Props["scheduled"], n.Props["deadline"])
+	if warning != "" && todoStrictFlag {
+		return fmt.Errorf("todo edit: %s (--strict)", warning)
+	}
+	if err := writeFileAtomic(path, n.Serialize()); err != nil {
+		return fmt.Errorf("todo edit: write: %w", err)
+	}
+
+	res := todoEditResult{
+		ID: n.ULID, Path: relTodoPath(cfg.VaultDir, path), Title: firstBodyLine(n.Body),
+		Tags: parseTagList(n.Props["tags"]), Scheduled: n.Props["scheduled"], Deadline: n.Props["deadline"],
+		Changed: changed, Warning: warning,
+	}
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setTodoTitle replaces n's title, the first non-blank line of its body,
+// with title; a todo with an empty body gets title as its body. The
+// frontmatter and the rest of the body are untouched.
+func setTodoTitle(n *node.Node, title string) error {
+	bodyStart := len(n.Raw) - len(n.Body)
+	raw := string(n.Raw)
+	var out string
+	pos := bodyStart
+	for pos < len(raw) {
+		end := strings.IndexByte(raw[pos:], '\n')
+		if end < 0 {
+			end = len(raw)
+		} else {
+			end += pos
+		}
+		if strings.TrimSpace(raw[pos:end]) != "" {
+			out = raw[:pos] + title + raw[end:]
+			break
+		}
+		pos = end + 1
+	}
+	if out == "" {
+		if raw != "" && !strings.HasSuffix(raw, "\n") {
+			raw += "\n"
+		}
+		out = raw + title + "\n"
+	}
+	reparsed, err := node.ParseAt([]byte(out), n.Loc)
+	if err != nil {
+		return err
+	}
+	*n = *reparsed
+	return nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+func TestTodoEdit(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	id := node.Mint()
+	path, src := writeTodoFixture(t, vault, id, "open", "2026-07-01", "Call the plumber\n\nAbout the leak.", "tags: [home]")
+
+	out, _, err := runTodo(t, vault, "edit", id, "--title", "Call the electrician", "--tags", "house,#urgent",
+		"--clear-scheduled", "--deadline", "+3d", "--json")
+	if err != nil {
+		t.Fatalf("todo edit: %v", err)
+	}
+	var res todoEditResult
+	mustDecodeJSON(t, out, &res)
+	if res.Title != "Call the electrician" || strings.Join(res.Tags, ",") != "house,urgent" ||
+		res.Scheduled != "" || res.Deadline != "2026-07-13" {
+		t.Errorf("result = %+v", res)
+	}
+	if got := strings.Join(res.Changed, ","); got != "title,tags,scheduled,deadline" {
+		t.Errorf("changed = %q", got)
+	}
+	want := "---\ndeadline: 2026-07-13\nid: " + id + "\ntype: todo\nstate: open\ntags: [house, urgent]\n---\nCall the electrician\n\nAbout the leak.\n"
+	if got := mustReadFile(t, path); got != want {
+		t.Errorf("edited file:\n%q\nwant\n%q", got, want)
+	}
+
+	// A rejected combination, or a bad date, writes nothing.
+	mustWriteFile(t, path, src)
+	for _, args := range [][]string{
+		{"--scheduled", "today", "--clear-scheduled"},
+		{"--deadline", "tomorrow", "--clear-deadline"},
+		{"--title", "New", "--deadline", "friday"},
+		{"--scheduled", "2026-08-01", "--deadline", "2026-07-20", "--strict"},
+		{"--title", " "},
+		{},
+	} {
+		resetCLIFlags()
+		if _, _, err := runTodo(t, vault, append([]string{"edit", id}, args...)...); err == nil {
+			t.Errorf("todo edit %v: want an error", args)
+		}
+		if got := mustReadFile(t, path); got != src {
+			t.Errorf("todo edit %v changed the file:\n%s", args, got)
+		}
+	}
+
+	// Without --strict a scheduled date after the deadline only warns, and
+	// --tags "" drops every tag.
+	resetCLIFlags()
+	pretty, _, err := runTodo(t, vault, "edit", id, "--scheduled", "2026-08-01", "--deadline", "2026-07-20", "--tags", "")
+	if err != nil {
+		t.Fatalf("todo edit: %v", err)
+	}
+	if !strings.Contains(pretty, "edited "+id+" (tags, scheduled, deadline)") || !strings.Contains(pretty, "warning: scheduled date 2026-08-01 is after deadline 2026-07-20") {
+		t.Errorf("edit output = %q", pretty)
+	}
+	if got := mustReadFile(t, path); strings.Contains(got, "tags:") || !strings.Contains(got, "scheduled: 2026-08-01") {
+		t.Errorf("edited file:\n%s", got)
+	}
+
+	// A recurring todo keeps the scheduled date its repeater advances.
+	rec := node.Mint()
+	writeTodoFixture(t, vault, rec, "open", "2026-07-01", "Water the plants", "repeat: +1w")
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "edit", rec, "--clear-scheduled"); err == nil || !strings.Contains(err.Error(), "cannot be cleared") {
+		t.Errorf("clearing a repeating todo's date: err = %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Archive durable todos that have been done for a while",
+	Long: "Set every durable todo that was marked done more than archive_after_days days ago (a vault setting, " +
+		"or --days) to state archived. Archived todos stay in todos/ and keep their IDs, so links and " +
+		"`rk todo reopen` still work, but `rk todo list` hides them unless asked with --state archived or --all. " +
+		"Open, in-progress, and cancelled todos are never touched. A todo's completion date is its done: " +
+		"field, set by `rk todo done`; for a todo done before that was recorded, its file's modification time.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTodoGCE,
+}
+
+// todoGCResult is the structured summary of one `rk todo gc` run.
+type todoGCResult struct {
+	Days int `json:"days"`
+	// Cutoff is the first completion date (UTC) too recent to archive.
+	Cutoff   string       `json:"cutoff"`
+	DryRun   bool         `json:"dry_run"`
+	Archived []todoGCItem `json:"archived"`
+	Kept     int          `json:"kept"` // done todos too recent to archive
+}
+
+// todoGCItem is one done todo a gc run archived (or would, under --dry-run).
+type todoGCItem struct {
+	ID    string `json:"id"`
+	Path  string `json:"path"`
+	Title string `json:"title"`
+	Done  string `json:"done"` // completion date: the done: field, else the file's mtime
+}
+
+func (r todoGCResult) Pretty() string {
+	var b strings.Builder
+	verb := "archived"
+	if r.DryRun {
+		verb = "would archive"
+	}
+	fmt.Fprintf(&b, "todo: %s %d done todo(s) older than %d day(s), kept %d", verb, len(r.Archived), r.Days, r.Kept)
+	for _, it := range r.Archived {
+		fmt.Fprintf(&b, "\n  %s  %s (done %s)", it.ID, it.Title, it.Done)
+	}
+	return b.String()
+}
+
+func runTodoGCE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo gc: load config: %w", err)
+	}
+
+	days := todoGCDaysFlag
+	if !cmd.Flags().Changed("days") {
+		settings, err := config.LoadSettings(cfg.VaultDir)
+		if err != nil {
+			return fmt.Errorf("todo gc: %w", err)
+		}
+		days = settings.ArchiveAfterDays
+	}
+	if days <= 0 {
+		return fmt.Errorf("todo gc: no retention period: set archive_after_days in %s or pass --days", config.SettingsFile)
+	}
+
+	res, err := archiveDoneTodos(cfg.VaultDir, days, todoDryRunFlag)
+	if err != nil {
+		return err
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// todoDoneDate is the day the done todo n, read from path, was done: its
+// done: field, or for a legacy file without a valid one, its mtime's date,
+// marking done normally being a todo's last write.
+func todoDoneDate(n *node.Node, path string) (string, error) {
+	if d := n.Props["done"]; d != "" {
+		if _, err := parseSchedDate(d); err == nil {
+			return d, nil
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return info.ModTime().UTC().Format("2006-01-02"), nil
+}
+
+// archiveDoneTodos sets state archived on every durable todo in state done
+// that was done more than days days before journalNow's date: on its done:
+// date, or for a todo done before those were recorded, on the day its file
+// was last modified (see todoDoneDate).
+func archiveDoneTodos(vaultDir string, days int, dryRun bool) (todoGCResult, error) {
+	cutoff := journalNow().AddDate(0, 0, -days).Format("2006-01-02")
+	res := todoGCResult{Days: days, Cutoff: cutoff, DryRun: dryRun, Archived: []todoGCItem{}}
+
+	files, err := todoFiles(filepath.Join(vaultDir, "todos"), todoProjectFlag)
+	if err != nil {
+		return todoGCResult{}, fmt.Errorf("todo gc: %w", err)
+	}
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || n.ULID == "" || n.Props["state"] != "done" {
+			continue
+		}
+		rel := relTodoPath(vaultDir, path)
+		done, err := todoDoneDate(n, path)
+		if err != nil {
+			return todoGCResult{}, fmt.Errorf("todo gc: stat %s: %w", rel, err)
+		}
+		if done >= cutoff {
+			res.Kept++
+			continue
+		}
+		if !dryRun {
+			if err := setOrInsertField(n, "state", "archived"); err != nil {
+				return todoGCResult{}, fmt.Errorf("todo gc: set state on %s: %w", rel, err)
+			}
+			if err := writeFileAtomic(path, n.Serialize()); err != nil {
+				return todoGCResult{}, fmt.Errorf("todo gc: write %s: %w", rel, err)
+			}
+		}
+		res.Archived = append(res.Archived, todoGCItem{ID: n.ULID, Path: rel, Title: firstBodyLine(n.Body), Done: done})
+	}
+	return res, nil
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoGC: gc archives only done todos whose file is older than the
+// retention period, --dry-run writes nothing, and without a period it
+// refuses to run.
+func TestTodoGC(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	age := func(path, date string) {
+		t.Helper()
+		tm, _ := time.Parse("2006-01-02", date)
+		if err := os.Chtimes(path, tm, tm); err != nil {
+			t.Fatalf("chtimes %s: %v", path, err)
+		}
+	}
+	oldDone, _ := writeTodoFixture(t, vault, node.Mint(), "done", "", "Shipped long ago.")
+	age(oldDone, "2026-06-01")
+	recentDone, recentSrc := writeTodoFixture(t, vault, node.Mint(), "done", "", "Shipped this week.")
+	age(recentDone, "2026-07-08")
+	oldOpen, openSrc := writeTodoFixture(t, vault, node.Mint(), "open", "", "Still open.")
+	age(oldOpen, "2026-01-01")
+
+	if _, _, err := runTodo(t, vault, "gc"); err == nil || !strings.Contains(err.Error(), "archive_after_days in "+config.SettingsFile) {
+		t.Fatalf("gc with no period: err = %v, want an archive_after_days hint naming %s", err, config.SettingsFile)
+	}
+
+	writeVaultSettings(t, vault, "archive_after_days: 30\n")
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "gc", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("gc --dry-run: %v\nstderr: %s", err, stderr)
+	}
+	var res todoGCResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Archived) != 1 || res.Archived[0].Title != "Shipped long ago." || res.Kept != 1 || !res.DryRun {
+		t.Fatalf("gc --dry-run = %+v, want the old done todo and 1 kept", res)
+	}
+	if src := mustReadFile(t, oldDone); !strings.Contains(src, "state: done") {
+		t.Errorf("--dry-run wrote the todo:\n%s", src)
+	}
+
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "gc"); err != nil {
+		t.Fatalf("gc: %v\nstderr: %s", err, stderr)
+	}
+	if src := mustReadFile(t, oldDone); !strings.Contains(src, "state: archived") {
+		t.Errorf("old done todo not archived:\n%s", src)
+	}
+	if src := mustReadFile(t, recentDone); src != recentSrc {
+		t.Errorf("recent done todo changed:\n%s", src)
+	}
+	if src := mustReadFile(t, oldOpen); src != openSrc {
+		t.Errorf("open todo changed:\n%s", src)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "gc", "--days", "1", "--json")
+	if err != nil {
+		t.Fatalf("gc --days 1: %v", err)
+	}
+	res = todoGCResult{}
+	mustDecodeJSON(t, out, &res)
+	if len(res.Archived) != 1 || res.Archived[0].Title != "Shipped this week." {
+		t.Errorf("gc --days 1 = %+v, want the recent done todo", res)
+	}
+}
+
+// TestTodoGC_DoneDate: `rk todo done` records the day as done:, which gc
+// goes by even when the file was written again later; reopening clears it.
+func TestTodoGC_DoneDate(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	pinTodoNow(t, "2026-06-01")
+	id := node.Mint()
+	path, _ := writeTodoFixture(t, vault, id, "open", "", "Shipped long ago.")
+	if _, stderr, err := runTodo(t, vault, "done", id); err != nil {
+		t.Fatalf("todo done: %v\nstderr: %s", err, stderr)
+	}
+	if src := mustReadFile(t, path); !strings.Contains(src, "done: 2026-06-01\n") {
+		t.Fatalf("todo done did not record the done date:\n%s", src)
+	}
+
+	pinTodoNow(t, "2026-07-10")
+	now := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, now, now); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "gc", "--days", "30", "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("gc: %v\nstderr: %s", err, stderr)
+	}
+	var res todoGCResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Archived) != 1 || res.Archived[0].Done != "2026-06-01" {
+		t.Errorf("gc = %+v, want the todo archived as done on 2026-06-01 despite its fresh mtime", res)
+	}
+
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "reopen", id); err != nil {
+		t.Fatalf("todo reopen: %v\nstderr: %s", err, stderr)
+	}
+	if src := mustReadFile(t, path); strings.Contains(src, "done:") {
+		t.Errorf("reopen kept the done date:\n%s", src)
+	}
+}
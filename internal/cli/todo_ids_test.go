@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAbbreviateIDs_WidensSharedPrefixes(t *testing.T) {
+	ids := []string{"01AAAAAA11", "01AAAAAA22", "01BBBBBB33", "01C"}
+	got := abbreviateIDs(ids, 4)
+	want := map[string]string{
+		"01AAAAAA11": "01AAAAAA1",
+		"01AAAAAA22": "01AAAAAA2",
+		"01BBBBBB33": "01BB",
+		"01C":        "01C",
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("abbreviateIDs[%s] = %q, want %q", id, got[id], w)
+		}
+	}
+}
+
+func TestTodoList_IDWidth(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	added := addTodoJSON(t, vault, "buy milk")
+	short := added.ID[:6]
+
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "list", "--id-width", "6")
+	if err != nil {
+		t.Fatalf("todo list --id-width: %v", err)
+	}
+	if !strings.Contains(out, "  "+short+" [open] buy milk") || strings.Contains(out, added.ID) {
+		t.Errorf("--id-width 6 output:\n%s\nwant the 6-character id %s", out, short)
+	}
+
+	writeVaultSettings(t, vault, "todo_id_width: 6\n")
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	if strings.Contains(out, added.ID) || !strings.Contains(out, short) {
+		t.Errorf("todo_id_width 6 output:\n%s\nwant the 6-character id %s", out, short)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--full-id")
+	if err != nil {
+		t.Fatalf("todo list --full-id: %v", err)
+	}
+	if !strings.Contains(out, added.ID) {
+		t.Errorf("--full-id output:\n%s\nwant the full id %s", out, added.ID)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--json")
+	if err != nil {
+		t.Fatalf("todo list --json: %v", err)
+	}
+	var res todoListResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Items) != 1 || res.Items[0].ID != added.ID {
+		t.Errorf("--json items = %+v, want the full id %s despite todo_id_width", res.Items, added.ID)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--full-id", "--id-width", "4"); err == nil {
+		t.Error("--full-id with --id-width should be rejected")
+	}
+
+	for _, w := range []string{"-1", "2"} {
+		resetCLIFlags()
+		if _, _, err := runTodo(t, vault, "list", "--id-width", w); err == nil || !strings.Contains(err.Error(), "at least 4") {
+			t.Errorf("--id-width %s: err = %v, want it rejected below 4", w, err)
+		}
+	}
+	writeVaultSettings(t, vault, "todo_id_width: 3\n")
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list"); err == nil || !strings.Contains(err.Error(), "todo_id_width") {
+		t.Errorf("todo_id_width 3: err = %v, want it rejected", err)
+	}
+}
+
+// writePrefixFixture writes two durable todos whose ULIDs share the prefix
+// 01HZZZZ and differ after it, returning their ULIDs.
+func writePrefixFixture(t *testing.T, vault string) (a, b string) {
+	t.Helper()
+	a, b = "01HZZZZA00000000000000000A", "01HZZZZB00000000000000000B"
+	writeTodoFixture(t, vault, a, "open", "", "alpha task")
+	writeTodoFixture(t, vault, b, "open", "", "beta task")
+	return a, b
+}
+
+func TestTodoDone_UniquePrefixResolves(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	a, _ := writePrefixFixture(t, vault)
+
+	out, stderr, err := runTodo(t, vault, "done", "01hzzzza", "--json")
+	if err != nil {
+		t.Fatalf("todo done <unique prefix>: %v\nstderr: %s", err, stderr)
+	}
+	var res todoDoneResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != a {
+		t.Errorf("done resolved to %s, want %s", res.ID, a)
+	}
+}
+
+func TestTodoDone_AmbiguousPrefixListsCandidates(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	a, b := writePrefixFixture(t, vault)
+
+	_, _, err := runTodo(t, vault, "done", "01HZZZZ")
+	if err == nil {
+		t.Fatal("ambiguous prefix resolved, want an error")
+	}
+	for _, want := range []string{"2 todos match prefix", a, b, "alpha task"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestTodoDone_PrefixNoMatchOrTooShort(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writePrefixFixture(t, vault)
+
+	for _, ref := range []string{"01HY", "01H"} {
+		resetCLIFlags()
+		_, _, err := runTodo(t, vault, "done", ref)
+		if err == nil || !strings.Contains(err.Error(), "(not found)") {
+			t.Errorf("done %q: err = %v, want a (not found) error", ref, err)
+		}
+	}
+}
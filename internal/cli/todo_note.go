@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoNoteCmd = &cobra.Command{
+	Use:   "note <ref> [text...]",
+	Short: "Append a note to a durable todo's body",
+	Long: "Append the argument text to a durable todo's body, below its title and earlier notes. " +
+		"With --edit the note is written in $VISUAL/$EDITOR instead (any text given is the starting content); " +
+		"saving an empty file cancels. With --stdin the note carries piped input, such as a command's output, " +
+		"as an indented block under any text given; input over 1 MiB is truncated with a marker.",
+	SilenceUsage: true,
+	Args:         cobra.MinimumNArgs(1),
+	RunE:         runTodoNoteE,
+}
+
+// todoNoteResult is the structured summary of one `rk todo note` run.
+type todoNoteResult struct {
+	ID    string `json:"id"`
+	Path  string `json:"path"`
+	Lines int    `json:"lines"` // how many lines the note added
+	// Truncated is set when --stdin's input exceeded maxStdinSize and was
+	// cut short.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func (r todoNoteResult) Pretty() string {
+	msg := fmt.Sprintf("todo: added a %d-line note to %s", r.Lines, r.Path)
+	if r.Truncated {
+		msg += fmt.Sprintf("; stdin truncated to %d bytes", maxStdinSize)
+	}
+	return msg
+}
+
+func runTodoNoteE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+	ref := args[0]
+	text := strings.TrimSpace(strings.Join(args[1:], " "))
+	edit := todoEditFlag
+	stdin := todoNoteStdinFlag
+
+	if edit && stdin {
+		return fmt.Errorf("todo note: --edit and --stdin are mutually exclusive")
+	}
+	if text == "" && !edit && !stdin {
+		return fmt.Errorf("todo note: missing note text (pass it as arguments, or use --edit or --stdin)")
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo note: load config: %w", err)
+	}
+
+	if todoMatchFlag {
+		if ref, err = resolveTodoMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("todo note: %w", err)
+		}
+	}
+	// Resolve before opening the editor, so a bad ref never costs the
+	// user a written note.
+	n, path, err := resolveDurableTodo(cfg.VaultDir, ref, "todo note")
+	if err != nil {
+		return err
+	}
+
+	truncated := false
+	if stdin {
+		raw, cut, err := readStdinCapped(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("todo note: %w", err)
+		}
+		text, truncated = stdinBlock(text, raw, cut), cut
+		if text == "" {
+			return fmt.Errorf("todo note: empty note: nothing on stdin")
+		}
+	}
+	if edit {
+		if text, err = editText(text); err != nil {
+			return fmt.Errorf("todo note: %w", err)
+		}
+		if text == "" {
+			fmt.Fprintln(cmd.ErrOrStderr(), "todo note: empty note; nothing added")
+			return nil
+		}
+	}
+
+	// The note goes after a blank line at the end of the body; the
+	// frontmatter is untouched.
+	raw := bytes.TrimRight(n.Serialize(), "\n")
+	raw = append(raw, "\n\n"+text+"\n"...)
+	if err := writeFileAtomic(path, raw); err != nil {
+		return fmt.Errorf("todo note: write: %w", err)
+	}
+
+	res := todoNoteResult{ID: n.ULID, Path: relTodoPath(cfg.VaultDir, path), Lines: strings.Count(text, "\n") + 1, Truncated: truncated}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
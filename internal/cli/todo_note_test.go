@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// stubEditor makes --edit "save" content without a terminal, recording
+// the starting content the editor was opened with.
+func stubEditor(t *testing.T, content string, opened *string) {
+	t.Helper()
+	t.Setenv("VISUAL", "true")
+	orig := runEditor
+	t.Cleanup(func() { runEditor = orig })
+	runEditor = func(_ []string, path string) error {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		*opened = string(raw)
+		return os.WriteFile(path, []byte(content), 0o644)
+	}
+}
+
+// TestTodoNote: note appends argument text below the body; --edit takes
+// the note from the editor (prefilled with any text), with --match, and an
+// empty save adds nothing.
+func TestTodoNote(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	path, _ := writeTodoFixture(t, vault, id, "open", "", "Migrate the billing service.")
+
+	if _, stderr, err := runTodo(t, vault, "note", id, "cut", "over", "on", "Friday"); err != nil {
+		t.Fatalf("todo note: %v\nstderr: %s", err, stderr)
+	}
+
+	var opened string
+	stubEditor(t, "Status:\n\nshadow traffic is clean\n", &opened)
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "note", "billing", "--match", "--edit", "Status:"); err != nil {
+		t.Fatalf("todo note --edit: %v\nstderr: %s", err, stderr)
+	}
+	if opened != "Status:" {
+		t.Errorf("editor opened with %q, want the argument text", opened)
+	}
+	want := "Migrate the billing service.\n\ncut over on Friday\n\nStatus:\n\nshadow traffic is clean\n"
+	if src := mustReadFile(t, path); !strings.HasSuffix(src, "---\n"+want) {
+		t.Errorf("todo file body = %q, want it to end %q", src, want)
+	}
+
+	stubEditor(t, "  \n", &opened)
+	resetCLIFlags()
+	before := mustReadFile(t, path)
+	_, stderr, err := runTodo(t, vault, "note", "billing", "--match", "--edit")
+	if err != nil || !strings.Contains(stderr, "nothing added") {
+		t.Errorf("empty --edit = %v (stderr %q), want a cancelled no-op", err, stderr)
+	}
+	if mustReadFile(t, path) != before {
+		t.Error("an empty editor save should leave the todo untouched")
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "note", "billing", "--match"); err == nil || !strings.Contains(err.Error(), "--edit") {
+		t.Errorf("note without text err = %v, want a hint at --edit", err)
+	}
+}
+
+// TestTodoNoteStdin: --stdin attaches piped output, line for line, as an
+// indented block under the note text, with --match; the todo still parses
+// and keeps the output's newlines in its body.
+func TestTodoNoteStdin(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	path, _ := writeTodoFixture(t, vault, id, "open", "", "Debug the crashing pod.")
+
+	out, err := runTodoStdin(t, vault, "E0101 boot failed\r\n---\n\n  retrying in 5s\n",
+		"note", "crashing", "--match", "--stdin", "pod logs:", "--json")
+	if err != nil {
+		t.Fatalf("todo note --stdin: %v", err)
+	}
+	var res todoNoteResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != id || res.Lines != 6 || res.Truncated {
+		t.Errorf("result = %+v, want a 6-line note on %s", res, id)
+	}
+	want := "Debug the crashing pod.\n\npod logs:\n\n    E0101 boot failed\n    ---\n\n      retrying in 5s\n"
+	src := mustReadFile(t, path)
+	if !strings.HasSuffix(src, "---\n"+want) {
+		t.Errorf("todo file = %q, want it to end %q", src, want)
+	}
+	n, err := node.Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("parse noted todo: %v", err)
+	}
+	if n.ULID != id || !strings.Contains(n.Body, "    E0101 boot failed\n    ---\n") {
+		t.Errorf("parsed todo %s body = %q, want the block's lines intact", n.ULID, n.Body)
+	}
+
+	resetCLIFlags()
+	if _, err := runTodoStdin(t, vault, "x\n", "note", id, "--stdin", "--edit"); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--stdin --edit err = %v, want mutually exclusive", err)
+	}
+	resetCLIFlags()
+	if _, err := runTodoStdin(t, vault, " \n", "note", id, "--stdin"); err == nil || !strings.Contains(err.Error(), "nothing on stdin") {
+		t.Errorf("empty --stdin err = %v, want nothing on stdin", err)
+	}
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Subtasks are durable todos carrying a `parent: "[[<ULID>]]"` link, as
+// written by `rk todo split`, `rk todo add --parent`, and `rk todo parent`.
+// The hierarchy is one level deep: a subtask cannot itself have subtasks,
+// which also rules out cycles.
+
+var todoParentCmd = &cobra.Command{
+	Use:          "parent <ref> <parent-ref>",
+	Short:        "Make a durable todo a subtask of another",
+	Long:         "Set <ref>'s parent: link to <parent-ref>, replacing any existing parent. Subtasks nest one level: the parent cannot itself be a subtask, and <ref> cannot already have subtasks.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(2),
+	RunE:         runTodoParentE,
+}
+
+// todoParentResult is the structured summary of one `rk todo parent` run.
+type todoParentResult struct {
+	ID     string `json:"id"`
+	Path   string `json:"path"`
+	Parent string `json:"parent"` // the parent's ULID
+}
+
+func (r todoParentResult) Pretty() string {
+	return fmt.Sprintf("todo: %s is now a subtask of %s", r.ID, r.Parent)
+}
+
+func runTodoParentE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo parent: load config: %w", err)
+	}
+
+	child, childPath, err := resolveDurableTodo(cfg.VaultDir, args[0], "todo parent")
+	if err != nil {
+		return err
+	}
+	parent, _, err := resolveTodoParent(cfg.VaultDir, child.ULID, args[1], "todo parent")
+	if err != nil {
+		return err
+	}
+	kids, _, err := todoChildren(cfg.VaultDir, child)
+	if err != nil {
+		return fmt.Errorf("todo parent: %w", err)
+	}
+	if len(kids) > 0 {
+		return fmt.Errorf("todo parent: %s has %d subtask(s) of its own; subtasks nest one level only", args[0], len(kids))
+	}
+
+	if err := setOrInsertField(child, "parent", `"[[`+parent.ULID+`]]"`); err != nil {
+		return fmt.Errorf("todo parent: set parent: %w", err)
+	}
+	if err := writeFileAtomic(childPath, child.Serialize()); err != nil {
+		return fmt.Errorf("todo parent: write: %w", err)
+	}
+
+	res := todoParentResult{ID: child.ULID, Path: relTodoPath(cfg.VaultDir, childPath), Parent: parent.ULID}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveTodoParent resolves ref as the parent for the todo childID ("" for
+// one not yet created), refusing the child itself and any todo that is
+// already a subtask.
+func resolveTodoParent(vaultDir, childID, ref, verb string) (*node.Node, string, error) {
+	parent, path, err := resolveDurableTodo(vaultDir, ref, verb)
+	if err != nil {
+		return nil, "", err
+	}
+	if parent.ULID == "" {
+		return nil, "", fmt.Errorf("%s: parent %s has no id", verb, relTodoPath(vaultDir, path))
+	}
+	if parent.ULID == childID {
+		return nil, "", fmt.Errorf("%s: a todo cannot be its own parent", verb)
+	}
+	if todoParentRef(parent) != "" {
+		return nil, "", fmt.Errorf("%s: %s is itself a subtask; subtasks nest one level only", verb, ref)
+	}
+	return parent, path, nil
+}
+
+// todoParentRef returns n's parent: link target (a ULID or alias), or "".
+func todoParentRef(n *node.Node) string {
+	for _, l := range n.Links {
+		if l.Rel == "parent" {
+			return l.To
+		}
+	}
+	return ""
+}
+
+// todoChildren returns every durable todo whose parent: link names parent
+// by ULID or alias, with their paths, in filename order.
+func todoChildren(vaultDir string, parent *node.Node) ([]*node.Node, []string, error) {
+	files, err := todoFiles(filepath.Join(vaultDir, "todos"), "")
+	if err != nil {
+		return nil, nil, err
+	}
+	var kids []*node.Node
+	var paths []string
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" {
+			continue
+		}
+		if ref := todoParentRef(n); ref != "" && (ref == parent.ULID || containsString(parent.Aliases, ref)) {
+			kids = append(kids, n)
+			paths = append(paths, path)
+		}
+	}
+	return kids, paths, nil
+}
+
+// autoCompleteParent marks done's parent done when the vault's
+// auto_complete_parent setting is on and every one of the parent's
+// subtasks is now done or cancelled. It returns the parent's ULID if it
+// was completed, else "". A recurring or already-closed parent is left
+// alone.
+func autoCompleteParent(vaultDir string, done *node.Node) (string, error) {
+	ref := todoParentRef(done)
+	if ref == "" {
+		return "", nil
+	}
+	settings, err := config.LoadSettings(vaultDir)
+	if err != nil {
+		return "", fmt.Errorf("todo done: %w", err)
+	}
+	if !settings.AutoCompleteParent {
+		return "", nil
+	}
+	parent, parentPath, err := resolveDurableTodo(vaultDir, ref, "todo done")
+	if err != nil {
+		return "", fmt.Errorf("todo done: auto-complete parent: %w", err)
+	}
+	if st := parent.Props["state"]; (st != "open" && st != "in-progress") || parent.HasField("repeat") {
+		return "", nil
+	}
+	kids, _, err := todoChildren(vaultDir, parent)
+	if err != nil {
+		return "", fmt.Errorf("todo done: auto-complete parent: %w", err)
+	}
+	for _, k := range kids {
+		if st := k.Props["state"]; st != "done" && st != "cancelled" {
+			return "", nil
+		}
+	}
+	if err := markTodoDone(parent); err != nil {
+		return "", fmt.Errorf("todo done: auto-complete parent: %w", err)
+	}
+	if err := writeFileAtomic(parentPath, parent.Serialize()); err != nil {
+		return "", fmt.Errorf("todo done: auto-complete parent: write: %w", err)
+	}
+	return parent.ULID, nil
+}
+
+// todoTreeOrder reorders list items for `rk todo list --tree`: each listed
+// subtask moves to just after its parent, keeping relative order otherwise.
+// A subtask whose parent is not listed (or, in a hand-edited vault, is
+// itself nested) stays at the top level. The returned depth map marks the
+// nested subtasks.
+func todoTreeOrder(items []todoListItem) ([]todoListItem, map[string]int) {
+	parentOf := map[string]string{}
+	for _, it := range items {
+		if it.Kind == "durable" {
+			parentOf[it.ID] = it.Parent
+		}
+	}
+	nested := func(it todoListItem) bool {
+		grand, listed := parentOf[it.Parent]
+		return it.Kind == "durable" && it.Parent != "" && it.Parent != it.ID && listed && grand == ""
+	}
+	children := map[string][]todoListItem{}
+	var top []todoListItem
+	for _, it := range items {
+		if nested(it) {
+			children[it.Parent] = append(children[it.Parent], it)
+			continue
+		}
+		top = append(top, it)
+	}
+	out := make([]todoListItem, 0, len(items))
+	depth := map[string]int{}
+	for _, it := range top {
+		out = append(out, it)
+		if it.Kind != "durable" {
+			continue
+		}
+		for _, c := range children[it.ID] {
+			out = append(out, c)
+			depth[c.ID] = 1
+		}
+	}
+	return out, depth
+}
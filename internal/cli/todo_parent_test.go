@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoParent_TreeListing: --parent and `rk todo parent` link subtasks,
+// and `todo list --tree` indents them under their parent.
+func TestTodoParent_TreeListing(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	parent := node.Mint()
+	writeTodoFixture(t, vault, parent, "open", "", "Plan the offsite.")
+	other := node.Mint()
+	writeTodoFixture(t, vault, other, "open", "", "Book the venue.")
+
+	resetCLIFlags()
+	out, stderr, err := runTodo(t, vault, "add", "Draft the agenda", "--parent", parent, "--json")
+	if err != nil {
+		t.Fatalf("todo add --parent: %v\nstderr: %s", err, stderr)
+	}
+	var sub todoAddResult
+	mustDecodeJSON(t, out, &sub)
+
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "parent", other, parent); err != nil {
+		t.Fatalf("todo parent: %v\nstderr: %s", err, stderr)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--durable", "--tree", "--json")
+	if err != nil {
+		t.Fatalf("todo list --tree --json: %v", err)
+	}
+	var res todoListResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Items) != 3 || res.Items[0].ID != parent {
+		t.Fatalf("tree items = %+v, want the parent first", res.Items)
+	}
+	for _, it := range res.Items[1:] {
+		if it.Parent != parent {
+			t.Errorf("item %s parent = %q, want %s", it.ID, it.Parent, parent)
+		}
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--durable", "--tree")
+	if err != nil {
+		t.Fatalf("todo list --tree: %v", err)
+	}
+	if !strings.Contains(out, "\n  "+parent+" [open] Plan the offsite.") || !strings.Contains(out, "\n    "+other+" [open] Book the venue.") {
+		t.Errorf("pretty tree should indent subtasks under the parent:\n%s", out)
+	}
+}
+
+// TestTodoParent_Guards: a todo cannot be its own parent, nor nest under a
+// subtask, nor become a subtask while it has subtasks.
+func TestTodoParent_Guards(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	parent := node.Mint()
+	writeTodoFixture(t, vault, parent, "open", "", "Parent.")
+	child := node.Mint()
+	writeTodoFixture(t, vault, child, "open", "", "Child.", `parent: "[[`+parent+`]]"`)
+	loose := node.Mint()
+	loosePath, looseSrc := writeTodoFixture(t, vault, loose, "open", "", "Loose.")
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"parent", loose, loose}, "its own parent"},
+		{[]string{"parent", loose, child}, "itself a subtask"},
+		{[]string{"parent", parent, loose}, "subtask(s) of its own"},
+		{[]string{"add", "Deeper", "--parent", child}, "itself a subtask"},
+	} {
+		resetCLIFlags()
+		if _, _, err := runTodo(t, vault, tc.args...); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("todo %v: err = %v, want %q", tc.args, err, tc.want)
+		}
+	}
+	if got := mustReadFile(t, loosePath); got != looseSrc {
+		t.Errorf("refused re-parent changed %s:\n%s", loose, got)
+	}
+}
+
+// TestTodoDone_AutoCompleteParent: with auto_complete_parent on, closing
+// the last open subtask completes the parent; without it, nothing happens.
+func TestTodoDone_AutoCompleteParent(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	parent := node.Mint()
+	parentPath, _ := writeTodoFixture(t, vault, parent, "open", "", "Parent.")
+	first := node.Mint()
+	writeTodoFixture(t, vault, first, "open", "", "First.", `parent: "[[`+parent+`]]"`)
+	second := node.Mint()
+	writeTodoFixture(t, vault, second, "cancelled", "", "Second.", `parent: "[[`+parent+`]]"`)
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "done", first); err != nil {
+		t.Fatalf("todo done: %v", err)
+	}
+	if got := mustReadFile(t, parentPath); !strings.Contains(got, "state: open\n") {
+		t.Errorf("parent completed without auto_complete_parent:\n%s", got)
+	}
+
+	writeVaultSettings(t, vault, "auto_complete_parent: true\n")
+	third := node.Mint()
+	writeTodoFixture(t, vault, third, "open", "", "Third.", `parent: "[[`+parent+`]]"`)
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "done", third, "--json")
+	if err != nil {
+		t.Fatalf("todo done: %v", err)
+	}
+	var res todoDoneResult
+	mustDecodeJSON(t, out, &res)
+	if res.ParentCompleted != parent {
+		t.Errorf("parent_completed = %q, want %s", res.ParentCompleted, parent)
+	}
+	if got := mustReadFile(t, parentPath); !strings.Contains(got, "state: done\n") {
+		t.Errorf("parent not auto-completed:\n%s", got)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// A pinned todo carries `list_pinned: true` in its frontmatter (`pinned:`
+// is the agenda pin's date, set by `rk today act <ref> t`). `rk todo list`
+// and the TUI's todos pane list pinned todos first, whatever the sort.
+
+var todoPinCmd = &cobra.Command{
+	Use:          "pin <ref>",
+	Short:        "Pin a durable todo to the top of todo lists",
+	Long:         "Set list_pinned: true on a durable todo, so `rk todo list` and the TUI list it first. Idempotent: an already-pinned todo is reported as skipped.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         func(cmd *cobra.Command, args []string) error { return runTodoPinE(cmd, args, true) },
+}
+
+var todoUnpinCmd = &cobra.Command{
+	Use:          "unpin <ref>",
+	Short:        "Unpin a durable todo",
+	Long:         "Remove a durable todo's list_pinned: field, returning it to its usual place in todo lists. Idempotent: a todo that is not pinned is reported as skipped.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         func(cmd *cobra.Command, args []string) error { return runTodoPinE(cmd, args, false) },
+}
+
+func init() {
+	todoCmd.AddCommand(todoPinCmd, todoUnpinCmd)
+}
+
+// todoPinResult is the structured summary of one `rk todo pin` or `rk todo
+// unpin` run.
+type todoPinResult struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Pinned  bool   `json:"pinned"`
+	Skipped bool   `json:"skipped"` // true = idempotent no-op (already pinned/unpinned)
+}
+
+func (r todoPinResult) Pretty() string {
+	verb := "pinned"
+	if !r.Pinned {
+		verb = "unpinned"
+	}
+	if r.Skipped {
+		return fmt.Sprintf("todo: %s already %s (skipped)", r.ID, verb)
+	}
+	return fmt.Sprintf("todo: %s %s", verb, r.ID)
+}
+
+func runTodoPinE(cmd *cobra.Command, args []string, pin bool) error {
+	defer resetTodoFlags(cmd)
+	verb := "todo pin"
+	if !pin {
+		verb = "todo unpin"
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("%s: load config: %w", verb, err)
+	}
+
+	n, path, err := resolveDurableTodo(cfg.VaultDir, args[0], verb)
+	if err != nil {
+		return err
+	}
+	res := todoPinResult{ID: n.ULID, Path: relTodoPath(cfg.VaultDir, path), Pinned: pin}
+	if res.Skipped = (n.Props["list_pinned"] == "true") == pin; !res.Skipped {
+		if pin {
+			err = setOrInsertField(n, "list_pinned", "true")
+		} else {
+			err = n.RemoveField("list_pinned")
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", verb, err)
+		}
+		if err := writeFileAtomic(path, n.Serialize()); err != nil {
+			return fmt.Errorf("%s: write: %w", verb, err)
+		}
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoPin: pin writes list_pinned: true and floats the todo to the top of
+// `rk todo list`, marked; pinning twice is skipped; unpin restores the file.
+func TestTodoPin(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	first := node.Mint()
+	second := node.Mint()
+	writeTodoFixture(t, vault, first, "open", "", "Water the plants")
+	path, src := writeTodoFixture(t, vault, second, "open", "", "Renew the lease")
+
+	out, _, err := runTodo(t, vault, "pin", second, "--json")
+	if err != nil {
+		t.Fatalf("todo pin: %v", err)
+	}
+	var res todoPinResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != second || !res.Pinned || res.Skipped {
+		t.Errorf("pin result = %+v", res)
+	}
+	if got := mustReadFile(t, path); !strings.Contains(got, "list_pinned: true\n") {
+		t.Errorf("pinned file:\n%s", got)
+	}
+
+	resetCLIFlags()
+	list, _, err := runTodo(t, vault, "list", "--durable", "--full-id")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	if want := "\n  " + second + " [open] Renew the lease (pinned)\n  " + first + " [open] Water the plants"; !strings.Contains(list, want) {
+		t.Errorf("todo list:\n%s\nwant pinned todo first:%s", list, want)
+	}
+
+	resetCLIFlags()
+	if out, _, err = runTodo(t, vault, "pin", second); err != nil || !strings.Contains(out, "already pinned (skipped)") {
+		t.Errorf("pin again = %q, %v", out, err)
+	}
+
+	resetCLIFlags()
+	if out, _, err = runTodo(t, vault, "unpin", second); err != nil || !strings.Contains(out, "todo: unpinned "+second) {
+		t.Errorf("unpin = %q, %v", out, err)
+	}
+	if got := mustReadFile(t, path); got != src {
+		t.Errorf("unpinned file:\n%q\nwant\n%q", got, src)
+	}
+}
+
+// TestTodoPinAgendaPin: the list pin and the agenda pin (`pinned:`, set by
+// `rk today act <ref> t`) are separate fields: pinning or unpinning one
+// leaves the other alone, and `rk today` reads a list-pinned todo cleanly.
+func TestTodoPinAgendaPin(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+	today := currentJournalDate()
+
+	id := node.Mint()
+	path, _ := writeTodoFixture(t, vault, id, "open", "", "Renew the lease", "pinned: "+today)
+
+	if _, _, err := runTodo(t, vault, "pin", id); err != nil {
+		t.Fatalf("todo pin: %v", err)
+	}
+	got := mustReadFile(t, path)
+	if !strings.Contains(got, "pinned: "+today+"\n") || !strings.Contains(got, "list_pinned: true\n") {
+		t.Errorf("pin should keep the agenda pin:\n%s", got)
+	}
+
+	resetCLIFlags()
+	out, stderr, err := runToday(t, vault)
+	if err != nil {
+		t.Fatalf("rk today: %v\nstderr: %s", err, stderr)
+	}
+	if strings.Contains(stderr, "malformed") || !strings.Contains(out, "Renew the lease") {
+		t.Errorf("rk today should list the agenda-pinned todo without warnings:\n%s\nstderr: %s", out, stderr)
+	}
+
+	other := node.Mint()
+	otherPath, _ := writeTodoFixture(t, vault, other, "open", "", "Water the plants")
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "pin", other); err != nil {
+		t.Fatalf("todo pin: %v", err)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runToday(t, vault, "act", other, "t"); err != nil {
+		t.Fatalf("today act t: %v\nstderr: %s", err, stderr)
+	}
+	if got := mustReadFile(t, otherPath); !strings.Contains(got, "list_pinned: true\n") || !strings.Contains(got, "pinned: "+today+"\n") {
+		t.Errorf("agenda pin should keep the list pin:\n%s", got)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "unpin", id); err != nil {
+		t.Fatalf("todo unpin: %v", err)
+	}
+	if got := mustReadFile(t, path); strings.Contains(got, "list_pinned") || !strings.Contains(got, "pinned: "+today+"\n") {
+		t.Errorf("unpin should leave the agenda pin:\n%s", got)
+	}
+}
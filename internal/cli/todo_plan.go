@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// writePlannedTodo writes a planned todo back; a var so tests can make a
+// write fail partway through a plan run.
+var writePlannedTodo = writeFileAtomic
+
+var todoPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Schedule unscheduled todos one at a time",
+	Long: "Walk every open or in-progress durable todo with no scheduled date, oldest first, prompting on " +
+		"stderr for each. Answer with a date (YYYY-MM-DD, today, tomorrow, +3d, +1w) to schedule it; a " +
+		"blank answer skips it and q stops. Each answer is written as it is given, so todos planned before " +
+		"stopping stay planned.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTodoPlanE,
+}
+
+// todoPlanResult is the structured summary of one `rk todo plan` run.
+type todoPlanResult struct {
+	Planned []todoPlannedItem `json:"planned"`
+	Skipped int               `json:"skipped"`
+	// Remaining counts unscheduled todos never reached because planning
+	// stopped.
+	Remaining int `json:"remaining"`
+}
+
+// todoPlannedItem is one todo a plan run scheduled.
+type todoPlannedItem struct {
+	ID        string `json:"id"`
+	Path      string `json:"path"`
+	Title     string `json:"title"`
+	Scheduled string `json:"scheduled"`
+}
+
+func (r todoPlanResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: planned %d todo(s), skipped %d", len(r.Planned), r.Skipped)
+	if r.Remaining > 0 {
+		fmt.Fprintf(&b, ", %d left", r.Remaining)
+	}
+	for _, it := range r.Planned {
+		fmt.Fprintf(&b, "\n  %s  %s (scheduled %s)", it.ID, it.Title, it.Scheduled)
+	}
+	return b.String()
+}
+
+func runTodoPlanE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo plan: load config: %w", err)
+	}
+
+	// A run that fails partway still reports the todos it already planned,
+	// since each answer is written as it is given.
+	res, err := planUnscheduled(cfg.VaultDir, cmd.InOrStdin(), cmd.ErrOrStderr())
+	if err != nil && len(res.Planned) == 0 {
+		return err
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if perr := newOutput(cmd, mode).Print(res); perr != nil {
+			return fmt.Errorf("print result: %w", perr)
+		}
+	}
+	return err
+}
+
+// parsePlanAnswer reads a plan reply: blank skips, q quits, and anything
+// else must be a date no earlier than today.
+func parsePlanAnswer(line string) (date string, skip, quit bool, err error) {
+	line = strings.TrimSpace(line)
+	switch strings.ToLower(line) {
+	case "":
+		return "", true, false, nil
+	case "q", "quit":
+		return "", false, true, nil
+	}
+	today := journalNow()
+	if date, err = resolveDateEndpoint(line, today); err != nil {
+		return "", false, false, err
+	}
+	if date < today.Format("2006-01-02") {
+		return "", false, false, fmt.Errorf("%s is in the past", date)
+	}
+	return date, false, false, nil
+}
+
+// planUnscheduled prompts on prompt for each open durable todo with no
+// scheduled date, in filename (ULID, i.e. creation) order, reading one
+// answer per todo from in. An invalid answer re-prompts; running out of
+// input stops like q. On a failed write it returns the todos planned so
+// far alongside the error.
+func planUnscheduled(vaultDir string, in io.Reader, prompt io.Writer) (todoPlanResult, error) {
+	res := todoPlanResult{Planned: []todoPlannedItem{}}
+
+	files, err := todoFiles(filepath.Join(vaultDir, "todos"), todoProjectFlag)
+	if err != nil {
+		return todoPlanResult{}, fmt.Errorf("todo plan: %w", err)
+	}
+	type planTodo struct {
+		n    *node.Node
+		path string
+	}
+	var queue []planTodo
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || n.ULID == "" {
+			continue
+		}
+		if st := n.Props["state"]; (st == "open" || st == "in-progress") && n.Props["scheduled"] == "" {
+			queue = append(queue, planTodo{n, path})
+		}
+	}
+	if len(queue) == 0 {
+		fmt.Fprintln(prompt, "todo plan: no unscheduled todos")
+		return res, nil
+	}
+
+	sc := bufio.NewScanner(in)
+	for i, t := range queue {
+		title := firstBodyLine(t.n.Body)
+		if d := t.n.Props["deadline"]; d != "" {
+			title += " (deadline " + d + ")"
+		}
+		var date string
+		var skip, quit bool
+		for {
+			fmt.Fprintf(prompt, "[%d/%d, %d left] %s\n  date, blank to skip, q to quit: ", i+1, len(queue), len(queue)-i, title)
+			if !sc.Scan() {
+				quit = true
+				break
+			}
+			if date, skip, quit, err = parsePlanAnswer(sc.Text()); err == nil {
+				break
+			}
+			fmt.Fprintf(prompt, "todo plan: %v\n", err)
+		}
+		if quit {
+			res.Remaining = len(queue) - i
+			break
+		}
+		if skip {
+			res.Skipped++
+			continue
+		}
+
+		rel := relTodoPath(vaultDir, t.path)
+		err := setOrInsertField(t.n, "scheduled", date)
+		if err != nil {
+			err = fmt.Errorf("set scheduled on %s: %w", rel, err)
+		} else if err = writePlannedTodo(t.path, t.n.Serialize()); err != nil {
+			err = fmt.Errorf("write %s: %w", rel, err)
+		}
+		if err != nil {
+			res.Remaining = len(queue) - i
+			return res, fmt.Errorf("todo plan: %w (%d todo(s) already planned)", err, len(res.Planned))
+		}
+		if w := scheduleDeadlineWarning(date, t.n.Props["deadline"]); w != "" {
+			fmt.Fprintf(prompt, "todo plan: warning: %s\n", w)
+		}
+		res.Planned = append(res.Planned, todoPlannedItem{ID: t.n.ULID, Path: rel, Title: firstBodyLine(t.n.Body), Scheduled: date})
+	}
+	if err := sc.Err(); err != nil {
+		return res, fmt.Errorf("todo plan: read answers: %w (%d todo(s) already planned)", err, len(res.Planned))
+	}
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoPlan: plan walks the unscheduled open todos oldest first,
+// re-prompting on a bad or past date, and a q keeps what was planned.
+func TestTodoPlan(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+	pinTodoNow(t, "2026-07-10")
+
+	first, _ := writeTodoFixture(t, vault, node.Mint(), "open", "", "Call the bank.", "deadline: 2026-07-12")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-07-15", "Already planned.")
+	writeTodoFixture(t, vault, node.Mint(), "done", "", "Finished.")
+	writeTodoFixture(t, vault, node.Mint(), "in-progress", "", "Paint the shed.")
+	lastPath, lastSrc := writeTodoFixture(t, vault, node.Mint(), "open", "", "Read later.")
+
+	RootCmd.SetIn(strings.NewReader("someday\n-1d\n+1w\n\nq\n"))
+	out, stderr, err := runTodo(t, vault, "plan", "--json")
+	if err != nil {
+		t.Fatalf("todo plan: %v\nstderr: %s", err, stderr)
+	}
+	for _, want := range []string{
+		"[1/3, 3 left] Call the bank. (deadline 2026-07-12)",
+		"todo plan: invalid date",
+		"todo plan: 2026-07-09 is in the past",
+		"todo plan: warning: scheduled date 2026-07-17 is after deadline 2026-07-12",
+		"[3/3, 1 left] Read later.",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("prompts missing %q:\n%s", want, stderr)
+		}
+	}
+	var res todoPlanResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Planned) != 1 || res.Planned[0].Scheduled != "2026-07-17" || res.Skipped != 1 || res.Remaining != 1 {
+		t.Fatalf("plan = %+v, want 1 planned, 1 skipped, 1 left", res)
+	}
+	if got := mustReadFile(t, first); !strings.Contains(got, "scheduled: 2026-07-17") {
+		t.Errorf("planned todo not scheduled:\n%s", got)
+	}
+	if got := mustReadFile(t, lastPath); got != lastSrc {
+		t.Errorf("todo after q changed:\n%s", got)
+	}
+
+	resetCLIFlags()
+	RootCmd.SetIn(strings.NewReader("tomorrow\n"))
+	pretty, _, err := runTodo(t, vault, "plan")
+	if err != nil {
+		t.Fatalf("todo plan: %v", err)
+	}
+	if !strings.Contains(pretty, "todo: planned 1 todo(s), skipped 0, 1 left") || !strings.Contains(pretty, "Paint the shed. (scheduled 2026-07-11)") {
+		t.Errorf("plan output = %q", pretty)
+	}
+}
+
+// TestTodoPlan_PartialFailure: a write failing partway through the run
+// still reports the todos already planned.
+func TestTodoPlan_PartialFailure(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+	pinTodoNow(t, "2026-07-10")
+
+	first := node.Mint()
+	writeTodoFixture(t, vault, first, "open", "", "Call the bank.")
+	secondPath, secondSrc := writeTodoFixture(t, vault, node.Mint(), "open", "", "Paint the shed.")
+
+	prev := writePlannedTodo
+	writePlannedTodo = func(path string, data []byte) error {
+		if path == secondPath {
+			return errors.New("disk full")
+		}
+		return prev(path, data)
+	}
+	t.Cleanup(func() { writePlannedTodo = prev })
+
+	RootCmd.SetIn(strings.NewReader("tomorrow\ntomorrow\n"))
+	out, _, err := runTodo(t, vault, "plan", "--json")
+	if err == nil || !strings.Contains(err.Error(), "disk full") || !strings.Contains(err.Error(), "1 todo(s) already planned") {
+		t.Fatalf("todo plan with a failing write: err = %v, want disk full after 1 planned", err)
+	}
+	var res todoPlanResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Planned) != 1 || res.Planned[0].ID != first || res.Remaining != 1 {
+		t.Errorf("partial result = %+v, want only %s planned and 1 left", res, first)
+	}
+	if got := mustReadFile(t, secondPath); got != secondSrc {
+		t.Errorf("the todo whose write failed changed:\n%s", got)
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoListProblemFiles: a todo file with merge-conflict markers and one
+// with unterminated frontmatter are skipped and reported, on stderr and in
+// --json's problems, while every good todo is still listed.
+func TestTodoListProblemFiles(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	good := []string{node.Mint(), node.Mint(), node.Mint()}
+	for i, id := range good {
+		writeTodoFixture(t, vault, id, "open", "", []string{"Call the bank.", "Water the plants.", "File the taxes."}[i])
+	}
+	conflict, open := node.Mint(), node.Mint()
+	mustWriteFile(t, filepath.Join(vault, "todos", conflict+".md"),
+		"---\nid: "+conflict+"\ntype: todo\n<<<<<<< HEAD\nstate: open\n=======\nstate: done\n>>>>>>> other\n---\nMerged badly.\n")
+	mustWriteFile(t, filepath.Join(vault, "todos", open+".md"),
+		"---\nid: "+open+"\ntype: todo\nstate: open\nNo closing fence.\n")
+
+	out, stderr, err := runTodo(t, vault, "list", "--json")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	var res todoListResult
+	mustDecodeJSON(t, out, &res)
+	for _, id := range good {
+		if !containsID(res.Items, id) {
+			t.Errorf("good todo %s missing from the list", id)
+		}
+	}
+	if len(res.Problems) != 2 {
+		t.Fatalf("problems = %+v, want the two broken files", res.Problems)
+	}
+	for _, p := range res.Problems {
+		if p.Path != "todos/"+conflict+".md" && p.Path != "todos/"+open+".md" {
+			t.Errorf("unexpected problem %+v", p)
+		}
+		if !strings.Contains(stderr, "todo list: warning: skipped "+p.Path+": ") {
+			t.Errorf("stderr does not warn about %s:\n%s", p.Path, stderr)
+		}
+	}
+
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "list", "--quiet"); err != nil || strings.Contains(stderr, "warning") {
+		t.Errorf("todo list --quiet: err %v, stderr %q", err, stderr)
+	}
+}
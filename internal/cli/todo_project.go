@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+)
+
+// todoProjectFlag is the todo family's --project, registered once on
+// todoCmd so every subcommand inherits it, and reset with the other todo
+// flags by resetTodoFlags.
+var todoProjectFlag string
+
+// A project is a subdirectory of todos/: todos/work/<ULID>.md is a durable
+// todo in project "work", todos/<ULID>.md one in no project. The ephemeral
+// inbox (todos/inbox.md) is shared and belongs to no project.
+
+// todoProjectDir returns the directory project's durable todos live in
+// under todosDir; "" is todosDir itself.
+func todoProjectDir(todosDir, project string) string {
+	if project == "" {
+		return todosDir
+	}
+	return filepath.Join(todosDir, project)
+}
+
+// todoProjectOf returns the project a vault-relative durable todo path puts
+// it in, "" for one directly under todos/.
+func todoProjectOf(relPath string) string {
+	dir := filepath.ToSlash(filepath.Dir(filepath.FromSlash(relPath)))
+	if project, ok := strings.CutPrefix(dir, "todos/"); ok && !strings.Contains(project, "/") {
+		return project
+	}
+	return ""
+}
+
+// todoFiles lists the *.md files durable todo walks read: those in
+// project's directory, or with project "" those directly under todosDir
+// and in each project subdirectory of it. Files are in filename (ULID,
+// i.e. creation) order whatever project they are in.
+func todoFiles(todosDir, project string) ([]string, error) {
+	if project != "" {
+		if err := config.ValidateProjectName(project); err != nil {
+			return nil, fmt.Errorf("--project: %w", err)
+		}
+		files, err := filepath.Glob(filepath.Join(todosDir, project, "*.md"))
+		if err != nil {
+			return nil, fmt.Errorf("glob todos dir: %w", err)
+		}
+		return files, nil
+	}
+	var files []string
+	for _, pattern := range []string{"*.md", filepath.Join("*", "*.md")} {
+		matches, err := filepath.Glob(filepath.Join(todosDir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("glob todos dir: %w", err)
+		}
+		files = append(files, matches...)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		bi, bj := filepath.Base(files[i]), filepath.Base(files[j])
+		if bi != bj {
+			return bi < bj
+		}
+		return files[i] < files[j]
+	})
+	return files, nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTodoProjects: --project files a todo under todos/<project>/, list
+// aggregates every project (with the project column) unless scoped, refs
+// resolve only within --project, and todo_project is add's default.
+func TestTodoProjects(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	add := func(args ...string) todoAddResult {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"add", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo add %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res todoAddResult
+		mustDecodeJSON(t, out, &res)
+		return res
+	}
+	work := add("--project", "work", "Write the report.")
+	home := add("--project", "personal", "Water the plants.")
+	loose := add("Call the bank.")
+	if work.Path != "todos/work/"+work.ID+".md" || loose.Path != "todos/"+loose.ID+".md" {
+		t.Fatalf("paths = %q, %q; want todos/work/<id>.md and todos/<id>.md", work.Path, loose.Path)
+	}
+	if _, err := os.Stat(filepath.Join(vault, filepath.FromSlash(work.Path))); err != nil {
+		t.Fatalf("work todo not written: %v", err)
+	}
+
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "list", "--json")
+	if err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	var all todoListResult
+	mustDecodeJSON(t, out, &all)
+	projects := map[string]string{}
+	for _, it := range all.Items {
+		projects[it.ID] = it.Project
+	}
+	if len(all.Items) != 3 || projects[work.ID] != "work" || projects[home.ID] != "personal" || projects[loose.ID] != "" {
+		t.Errorf("list = %+v, want all three todos with their projects", all.Items)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list")
+	if err != nil {
+		t.Fatalf("todo list (pretty): %v", err)
+	}
+	if !strings.Contains(out, "Write the report. (project work)") {
+		t.Errorf("pretty list has no project column:\n%s", out)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--project", "work", "--json")
+	if err != nil {
+		t.Fatalf("todo list --project work: %v", err)
+	}
+	var scoped todoListResult
+	mustDecodeJSON(t, out, &scoped)
+	if len(scoped.Items) != 1 || scoped.Items[0].ID != work.ID {
+		t.Errorf("list --project work = %+v, want only the work todo", scoped.Items)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "done", "--project", "work", home.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("done --project work on a personal todo: err = %v, want not found", err)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "done", home.ID); err != nil {
+		t.Fatalf("done across projects: %v\nstderr: %s", err, stderr)
+	}
+	if src := mustReadFile(t, filepath.Join(vault, filepath.FromSlash(home.Path))); !strings.Contains(src, "state: done") {
+		t.Errorf("personal todo not done:\n%s", src)
+	}
+
+	writeVaultSettings(t, vault, "todo_project: work\n")
+	if res := add("Plan the offsite."); !strings.HasPrefix(res.Path, "todos/work/") {
+		t.Errorf("add with todo_project: path = %q, want todos/work/", res.Path)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "add", "--project", "../escape", "Nope."); err == nil {
+		t.Error("add --project ../escape: want an error")
+	}
+}
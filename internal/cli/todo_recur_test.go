@@ -672,7 +672,7 @@ func TestTodoDone_NonRecurring_Unchanged(t *testing.T) {
 		t.Errorf("recurrence fields leaked on a non-recurring done: %+v", res)
 	}
 
-	want := strings.Replace(src, "state: open", "state: done", 1)
+	want := markedDone(src)
 	if got := mustReadFile(t, path); got != want {
 		t.Fatalf("non-recurring done disturbed bytes beyond the state field\n--- want ---\n%q\n--- got ---\n%q", want, got)
 	}
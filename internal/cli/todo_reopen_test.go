@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+func TestTodoReopen_DurableIsIdempotent(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	out, _, err := runTodo(t, vault, "add", "ship it", "--json")
+	if err != nil {
+		t.Fatalf("todo add: %v", err)
+	}
+	var added todoAddResult
+	mustDecodeJSON(t, out, &added)
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "complete", added.ID); err != nil {
+		t.Fatalf("todo complete: %v", err)
+	}
+
+	for i, wantSkipped := range []bool{false, true} {
+		resetCLIFlags()
+		out, _, err := runTodo(t, vault, "reopen", added.ID, "--json")
+		if err != nil {
+			t.Fatalf("todo reopen #%d: %v", i+1, err)
+		}
+		var res todoReopenResult
+		mustDecodeJSON(t, out, &res)
+		if res.Skipped != wantSkipped || res.State != "open" {
+			t.Errorf("reopen #%d = %+v, want skipped=%v state=open", i+1, res, wantSkipped)
+		}
+	}
+
+	n, err := node.Parse([]byte(mustReadFile(t, filepath.Join(vault, added.Path))))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if n.Props["state"] != "open" {
+		t.Errorf("state = %q, want open", n.Props["state"])
+	}
+}
+
+func TestTodoReopen_Ephemeral(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	path := writeEphemeralContainer(t, vault, node.Mint(), checklistLine(true, "a"), checklistLine(false, "b"))
+
+	if _, _, err := runTodo(t, vault, "reopen", "--ephemeral", "1"); err != nil {
+		t.Fatalf("todo reopen --ephemeral 1: %v", err)
+	}
+	resetCLIFlags()
+	out, _, err := runTodo(t, vault, "reopen", "--ephemeral", "2", "--json")
+	if err != nil {
+		t.Fatalf("todo reopen --ephemeral 2: %v", err)
+	}
+	var res todoReopenResult
+	mustDecodeJSON(t, out, &res)
+	if !res.Skipped {
+		t.Errorf("reopening an unchecked line: skipped = false, want true")
+	}
+
+	raw := mustReadFile(t, path)
+	if !strings.Contains(raw, checklistLine(false, "a")) || strings.Contains(raw, "[x]") {
+		t.Errorf("inbox after reopen:\n%s", raw)
+	}
+}
+
+func TestTodoReopen_NotFound(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	_, _, err := runTodo(t, vault, "reopen", "nope")
+	if err == nil || !strings.Contains(err.Error(), "(not found)") {
+		t.Fatalf("err = %v, want a (not found) error", err)
+	}
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// writeRescheduledTodo writes a rescheduled todo back; a var so tests can
+// make a write fail partway through a sweep.
+var writeRescheduledTodo = writeFileAtomic
+
+var todoRescheduleOverdueCmd = &cobra.Command{
+	Use:   "reschedule-overdue <date>",
+	Short: "Move every overdue open todo to a new date",
+	Long: "Set every open or in-progress durable todo whose deadline is before today (or, with --schedule, " +
+		"whose scheduled date is) to <date>. <date> is YYYY-MM-DD, today, tomorrow, or an offset like +3d/+1w. " +
+		"Done and cancelled todos are never touched.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runTodoRescheduleOverdueE,
+}
+
+// todoRescheduleResult is the structured summary of one
+// `rk todo reschedule-overdue` run.
+type todoRescheduleResult struct {
+	Field   string                `json:"field"` // "deadline" | "scheduled"
+	Date    string                `json:"date"`  // the resolved target date
+	DryRun  bool                  `json:"dry_run"`
+	Count   int                   `json:"count"`
+	Changed []todoRescheduledItem `json:"changed"`
+}
+
+// todoRescheduledItem is one todo a reschedule-overdue run moved (or, under
+// --dry-run, would move).
+type todoRescheduledItem struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Title   string `json:"title"`
+	From    string `json:"from"`              // the overdue date being replaced
+	Warning string `json:"warning,omitempty"` // scheduled/deadline ordering problem, if any
+}
+
+func (r todoRescheduleResult) Pretty() string {
+	verb := "moved"
+	if r.DryRun {
+		verb = "would move"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: %s %d overdue todo(s) to %s %s", verb, r.Count, r.Field, r.Date)
+	for _, it := range r.Changed {
+		fmt.Fprintf(&b, "\n  %s  %s (was %s)", it.ID, it.Title, it.From)
+		if it.Warning != "" {
+			fmt.Fprintf(&b, " [warning: %s]", it.Warning)
+		}
+	}
+	return b.String()
+}
+
+func runTodoRescheduleOverdueE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	field := "deadline"
+	if todoReschedSchedFlag {
+		field = "scheduled"
+	}
+	dryRun := todoDryRunFlag
+	preview := todoPreviewFlag
+	if preview && dryRun {
+		return fmt.Errorf("todo reschedule-overdue: --preview and --dry-run are mutually exclusive")
+	}
+	if todoYesFlag && !preview {
+		return fmt.Errorf("todo reschedule-overdue: --yes requires --preview")
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	today := journalNow()
+	date, err := resolveDateEndpoint(args[0], today)
+	if err != nil {
+		return fmt.Errorf("todo reschedule-overdue: %w", err)
+	}
+	if date < today.Format("2006-01-02") {
+		return fmt.Errorf("todo reschedule-overdue: %s is in the past; the todos would stay overdue", date)
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo reschedule-overdue: load config: %w", err)
+	}
+
+	// A sweep that fails partway still reports the todos it already moved,
+	// so the run is never silently half-applied.
+	partial := func(res todoRescheduleResult, err error) error {
+		if res.Count > 0 && !(mode == output.Pretty && quietFlag) {
+			if perr := newOutput(cmd, mode).Print(res); perr != nil {
+				return perr
+			}
+		}
+		return err
+	}
+
+	res, err := rescheduleOverdueTodos(cfg.VaultDir, field, date, dryRun || preview, cmd.ErrOrStderr())
+	if err != nil {
+		return partial(res, err)
+	}
+	if preview && res.Count > 0 {
+		printReschedulePreview(cmd.ErrOrStderr(), res, isTerminal(cmd.ErrOrStderr()) && !plainFlag, plainFlag)
+		apply := todoYesFlag
+		if !apply {
+			if apply, err = confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), fmt.Sprintf("apply %d change(s)?", res.Count)); err != nil {
+				return fmt.Errorf("todo reschedule-overdue: %w", err)
+			}
+		}
+		if apply {
+			if res, err = rescheduleOverdueTodos(cfg.VaultDir, field, date, false, io.Discard); err != nil {
+				return partial(res, err)
+			}
+		} else {
+			fmt.Fprintln(cmd.ErrOrStderr(), "todo reschedule-overdue: cancelled; nothing written")
+		}
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printReschedulePreview writes one before → after line per change to w,
+// the old date red and the new one green when color is set; plain spells
+// the arrow "->".
+func printReschedulePreview(w io.Writer, res todoRescheduleResult, color, plain bool) {
+	from, to, reset, arrow := "", "", "", "→"
+	if color {
+		from, to, reset = ansiRed, ansiGreen, ansiReset
+	}
+	if plain {
+		arrow = "->"
+	}
+	for _, it := range res.Changed {
+		fmt.Fprintf(w, "todo %s: %s %s%s%s %s %s%s%s  %s\n",
+			it.ID, res.Field, from, it.From, reset, arrow, to, res.Date, reset, it.Title)
+	}
+}
+
+// rescheduleOverdueTodos sets field to date on every open/in-progress
+// durable todo whose field is before today, in filename (ULID, i.e.
+// creation) order. A todo whose field is malformed is warned about on
+// stderr and left alone rather than failing the whole sweep. Under dryRun
+// nothing is written. On a failed write the error comes back with the
+// todos already written in res.
+func rescheduleOverdueTodos(vaultDir, field, date string, dryRun bool, stderr io.Writer) (todoRescheduleResult, error) {
+	res := todoRescheduleResult{Field: field, Date: date, DryRun: dryRun, Changed: []todoRescheduledItem{}}
+	today := currentJournalDate()
+
+	files, err := todoFiles(filepath.Join(vaultDir, "todos"), todoProjectFlag)
+	if err != nil {
+		return todoRescheduleResult{}, fmt.Errorf("todo reschedule-overdue: %w", err)
+	}
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || n.ULID == "" {
+			continue
+		}
+		if st := n.Props["state"]; st != "open" && st != "in-progress" {
+			continue
+		}
+		from := n.Props[field]
+		if from == "" {
+			continue
+		}
+		if _, err := parseSchedDate(from); err != nil {
+			fmt.Fprintf(stderr, "todo reschedule-overdue: warning: %s: invalid %s %q; skipped\n",
+				relTodoPath(vaultDir, path), field, from)
+			continue
+		}
+		if from >= today {
+			continue
+		}
+
+		item := todoRescheduledItem{
+			ID: n.ULID, Path: relTodoPath(vaultDir, path), Title: firstBodyLine(n.Body), From: from,
+		}
+		if field == "scheduled" {
+			item.Warning = scheduleDeadlineWarning(date, n.Props["deadline"])
+		} else {
+			item.Warning = scheduleDeadlineWarning(n.Props["scheduled"], date)
+		}
+		if !dryRun {
+			err := setOrInsertField(n, field, date)
+			if err != nil {
+				err = fmt.Errorf("set %s on %s: %w", field, item.Path, err)
+			} else if err = writeRescheduledTodo(path, n.Serialize()); err != nil {
+				err = fmt.Errorf("write %s: %w", item.Path, err)
+			}
+			if err != nil {
+				res.Count = len(res.Changed)
+				return res, fmt.Errorf("todo reschedule-overdue: %w (%d todo(s) already moved)", err, res.Count)
+			}
+		}
+		res.Changed = append(res.Changed, item)
+	}
+	res.Count = len(res.Changed)
+	return res, nil
+}
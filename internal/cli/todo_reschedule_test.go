@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoRescheduleOverdue_Deadline: only open/in-progress todos whose
+// deadline already passed are moved; done todos, future deadlines, and
+// todos without one are left byte-identical.
+func TestTodoRescheduleOverdue_Deadline(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	overdue := node.Mint()
+	overduePath, _ := writeTodoFixture(t, vault, overdue, "open", "", "Late task.", "deadline: 2026-07-01")
+	wip := node.Mint()
+	writeTodoFixture(t, vault, wip, "in-progress", "", "Started task.", "deadline: 2026-07-09")
+	done := node.Mint()
+	donePath, doneSrc := writeTodoFixture(t, vault, done, "done", "", "Finished task.", "deadline: 2026-07-01")
+	future := node.Mint()
+	futurePath, futureSrc := writeTodoFixture(t, vault, future, "open", "", "Future task.", "deadline: 2026-07-20")
+	plain := node.Mint()
+	plainPath, plainSrc := writeTodoFixture(t, vault, plain, "open", "2026-07-01", "Scheduled-only task.")
+
+	stdout, stderr, err := runTodo(t, vault, "reschedule-overdue", "+3d", "--json")
+	if err != nil {
+		t.Fatalf("reschedule-overdue: %v\nstderr: %s", err, stderr)
+	}
+	var res todoRescheduleResult
+	mustDecodeJSON(t, stdout, &res)
+	if res.Field != "deadline" || res.Date != "2026-07-13" || res.Count != 2 {
+		t.Fatalf("result = %+v, want 2 deadlines moved to 2026-07-13", res)
+	}
+	if res.Changed[0].ID != overdue || res.Changed[0].From != "2026-07-01" || res.Changed[1].ID != wip {
+		t.Errorf("changed = %+v, want %s then %s", res.Changed, overdue, wip)
+	}
+
+	if got := mustReadFile(t, overduePath); !strings.Contains(got, "deadline: 2026-07-13\n") {
+		t.Errorf("overdue todo not rescheduled:\n%s", got)
+	}
+	for path, src := range map[string]string{donePath: doneSrc, futurePath: futureSrc, plainPath: plainSrc} {
+		if got := mustReadFile(t, path); got != src {
+			t.Errorf("%s changed:\n%s\nwant:\n%s", path, got, src)
+		}
+	}
+}
+
+// TestTodoRescheduleOverdue_ScheduleDryRun: --schedule sweeps past
+// scheduled dates instead, and --dry-run reports without writing.
+func TestTodoRescheduleOverdue_ScheduleDryRun(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	id := node.Mint()
+	path, src := writeTodoFixture(t, vault, id, "open", "2026-07-07", "Carried task.")
+
+	stdout, _, err := runTodo(t, vault, "reschedule-overdue", "tomorrow", "--schedule", "--dry-run")
+	if err != nil {
+		t.Fatalf("reschedule-overdue --dry-run: %v", err)
+	}
+	if !strings.Contains(stdout, "would move 1 overdue todo(s) to scheduled 2026-07-11") {
+		t.Errorf("dry-run output:\n%s", stdout)
+	}
+	if got := mustReadFile(t, path); got != src {
+		t.Errorf("--dry-run wrote the file:\n%s", got)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "reschedule-overdue", "tomorrow", "--schedule"); err != nil {
+		t.Fatalf("reschedule-overdue --schedule: %v", err)
+	}
+	if got := mustReadFile(t, path); !strings.Contains(got, "scheduled: 2026-07-11\n") {
+		t.Errorf("scheduled not moved:\n%s", got)
+	}
+}
+
+func TestTodoRescheduleOverdue_RejectsPastDate(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	_, _, err := runTodo(t, vault, "reschedule-overdue", "yesterday")
+	if err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Errorf("err = %v, want a past-date rejection", err)
+	}
+}
+
+// TestTodoRescheduleOverdue_Preview: --preview prints each change's
+// before → after and writes only once the prompt is answered yes (or
+// --yes is given).
+func TestTodoRescheduleOverdue_Preview(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+	pinTodoNow(t, "2026-07-10")
+
+	id := node.Mint()
+	path, src := writeTodoFixture(t, vault, id, "open", "", "Late task.", "deadline: 2026-07-01")
+
+	RootCmd.SetIn(strings.NewReader("n\n"))
+	_, stderr, err := runTodo(t, vault, "reschedule-overdue", "+3d", "--preview")
+	if err != nil {
+		t.Fatalf("--preview (declined): %v\nstderr: %s", err, stderr)
+	}
+	if !strings.Contains(stderr, "todo "+id+": deadline 2026-07-01 → 2026-07-13  Late task.") || !strings.Contains(stderr, "[y/N]") {
+		t.Errorf("preview should show the change and ask:\n%s", stderr)
+	}
+	if got := mustReadFile(t, path); got != src {
+		t.Errorf("declined preview wrote the todo:\n%s", got)
+	}
+
+	resetCLIFlags()
+	RootCmd.SetIn(strings.NewReader("yes\n"))
+	if _, stderr, err := runTodo(t, vault, "reschedule-overdue", "+3d", "--preview"); err != nil {
+		t.Fatalf("--preview (accepted): %v\nstderr: %s", err, stderr)
+	}
+	if got := mustReadFile(t, path); !strings.Contains(got, "deadline: 2026-07-13\n") {
+		t.Errorf("accepted preview should reschedule:\n%s", got)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "reschedule-overdue", "+3d", "--yes"); err == nil || !strings.Contains(err.Error(), "--preview") {
+		t.Errorf("--yes alone err = %v, want a --preview error", err)
+	}
+}
+
+// TestTodoRescheduleOverdue_PartialFailure: a write failing partway through
+// the sweep still reports the todos already moved.
+func TestTodoRescheduleOverdue_PartialFailure(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	first := node.Mint()
+	writeTodoFixture(t, vault, first, "open", "", "Late task.", "deadline: 2026-07-01")
+	second := node.Mint()
+	secondPath, secondSrc := writeTodoFixture(t, vault, second, "open", "", "Later task.", "deadline: 2026-07-02")
+
+	prev := writeRescheduledTodo
+	writeRescheduledTodo = func(path string, data []byte) error {
+		if path == secondPath {
+			return errors.New("disk full")
+		}
+		return prev(path, data)
+	}
+	t.Cleanup(func() { writeRescheduledTodo = prev })
+
+	stdout, _, err := runTodo(t, vault, "reschedule-overdue", "today", "--json")
+	if err == nil || !strings.Contains(err.Error(), "disk full") || !strings.Contains(err.Error(), "1 todo(s) already moved") {
+		t.Fatalf("reschedule-overdue with a failing write: err = %v, want disk full after 1 moved", err)
+	}
+	var res todoRescheduleResult
+	mustDecodeJSON(t, stdout, &res)
+	if res.Count != 1 || res.Changed[0].ID != first {
+		t.Errorf("partial result = %+v, want only %s", res, first)
+	}
+	if got := mustReadFile(t, secondPath); got != secondSrc {
+		t.Errorf("the todo whose write failed changed:\n%s", got)
+	}
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// todoShowFormatFlag is `rk todo show --format`: "" for the usual output,
+// or todoShowFormatMarkdown.
+var todoShowFormatFlag string
+
+// todoShowFormatMarkdown renders the todo as a markdown card to paste into
+// a chat or ticket.
+const todoShowFormatMarkdown = "markdown"
+
+var todoShowCmd = &cobra.Command{
+	Use:   "show <ref>",
+	Short: "Show one durable todo with its dates, tags, and notes",
+	Long: "Show a durable todo's fields and its notes: every non-blank body line after the title, numbered by position. " +
+		"With --json the whole todo is one object, for scripts that would otherwise parse `rk todo list`. " +
+		"With --format markdown it is a markdown card to paste into a chat or ticket instead: a checkbox " +
+		"with the title, a line of dates and tags, and the notes as a list. " +
+		"A ref that matches no todo is a (not found) error.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runTodoShowE,
+}
+
+func init() {
+	todoShowCmd.Flags().StringVar(&todoShowFormatFlag, "format", "", "Print the todo as a markdown card (markdown)")
+}
+
+// todoShowResult is `rk todo show`'s output: the todo's list row plus the
+// detail a listing leaves out.
+type todoShowResult struct {
+	todoListItem
+	Aliases []string   `json:"aliases"`
+	Author  string     `json:"author,omitempty"`
+	Created string     `json:"created,omitempty"` // the node's time field
+	Notes   []todoNote `json:"notes"`
+	// Steps/StepsDone count the checkbox notes, and how many are checked.
+	Steps     int `json:"steps"`
+	StepsDone int `json:"steps_done"`
+}
+
+// todoNote is one note line of a durable todo's body.
+type todoNote struct {
+	Position int    `json:"position"` // 1-based, in body order
+	Text     string `json:"text"`     // the line, less any "- " bullet or checkbox
+	// Step marks a "- [ ] text" note, a checkable step `rk todo check`
+	// toggles; Checked is its mark.
+	Step    bool `json:"step"`
+	Checked bool `json:"checked"`
+}
+
+func (r todoShowResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: %s [%s] %s\n  path: %s", r.ID, r.State, r.Title, r.Path)
+	for _, f := range []struct{ name, value string }{
+		{"aliases", strings.Join(r.Aliases, ", ")},
+		{"created", r.Created},
+		{"author", r.Author},
+		{"scheduled", r.Scheduled},
+		{"deadline", r.Deadline},
+		{"repeat", r.Repeat},
+		{"estimate", r.Estimate},
+		{"assignee", r.Assignee},
+		{"depends on", r.Depends},
+		{"parent", r.Parent},
+	} {
+		if f.value != "" {
+			fmt.Fprintf(&b, "\n  %s: %s", f.name, f.value)
+		}
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(&b, "\n  tags: #%s", strings.Join(r.Tags, " #"))
+	}
+	if len(r.Notes) > 0 {
+		b.WriteString("\n  notes:")
+		if r.Steps > 0 {
+			fmt.Fprintf(&b, " (%d/%d steps done)", r.StepsDone, r.Steps)
+		}
+		for _, note := range r.Notes {
+			mark := ""
+			if note.Step {
+				mark = "[ ] "
+				if note.Checked {
+					mark = "[x] "
+				}
+			}
+			fmt.Fprintf(&b, "\n    %d. %s%s", note.Position, mark, note.Text)
+		}
+	}
+	return b.String()
+}
+
+func runTodoShowE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+	ref := args[0]
+
+	format := todoShowFormatFlag
+	if format != "" && format != todoShowFormatMarkdown {
+		return fmt.Errorf("todo show: --format must be %s, got %q%s", todoShowFormatMarkdown, format,
+			config.Suggest(format, todoShowFormatMarkdown))
+	}
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+	if format != "" && mode != output.Pretty {
+		return fmt.Errorf("todo show: --format and --json/--ndjson/--yaml are mutually exclusive")
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo show: load config: %w", err)
+	}
+
+	if todoMatchFlag {
+		if ref, err = resolveTodoMatch(cmd, cfg.VaultDir, ref); err != nil {
+			return fmt.Errorf("todo show: %w", err)
+		}
+	}
+
+	n, path, err := resolveDurableTodo(cfg.VaultDir, ref, "todo show")
+	if err != nil {
+		return err
+	}
+	res := todoShowFromNode(relTodoPath(cfg.VaultDir, path), n)
+	if format == todoShowFormatMarkdown {
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), res.Markdown())
+		return err
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+// markdownEscaper backslash-escapes the characters that would make todo
+// text render as markup: emphasis, code, links, HTML, tables, strikethrough,
+// and headings.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "|", `\|`, "~", `\~`, "#", `\#`,
+)
+
+// markdownListMarkerRe matches text that would start a nested list if it
+// opened a list item's line.
+var markdownListMarkerRe = regexp.MustCompile(`^([-+]|\d+[.)])(\s|$)`)
+
+// markdownText escapes s for a markdown list item's line.
+func markdownText(s string) string {
+	s = markdownEscaper.Replace(s)
+	if m := markdownListMarkerRe.FindStringSubmatchIndex(s); m != nil {
+		s = s[:m[3]-1] + `\` + s[m[3]-1:]
+	}
+	return s
+}
+
+// Markdown renders the todo as a markdown card: a task-list item with the
+// title (checked once done or archived), then, indented under it, a line
+// of its state, dates, and tags, and its notes as a nested list.
+func (r todoShowResult) Markdown() string {
+	var b strings.Builder
+	mark := " "
+	if r.State == "done" || r.State == "archived" {
+		mark = "x"
+	}
+	fmt.Fprintf(&b, "- [%s] %s", mark, markdownText(r.Title))
+
+	var meta []string
+	if r.State != "open" && r.State != "done" {
+		meta = append(meta, markdownEscaper.Replace(r.State))
+	}
+	for _, f := range []struct{ name, value string }{
+		{"scheduled", r.Scheduled},
+		{"deadline", r.Deadline},
+		{"estimate", r.Estimate},
+		{"assignee", r.Assignee},
+	} {
+		if f.value != "" {
+			meta = append(meta, f.name+" "+markdownEscaper.Replace(f.value))
+		}
+	}
+	if len(r.Tags) > 0 {
+		tags := make([]string, len(r.Tags))
+		for i, t := range r.Tags {
+			tags[i] = markdownEscaper.Replace("#" + t)
+		}
+		meta = append(meta, strings.Join(tags, " "))
+	}
+	if len(meta) > 0 {
+		fmt.Fprintf(&b, "\n\n  *%s*", strings.Join(meta, " · "))
+	}
+
+	if len(r.Notes) > 0 {
+		b.WriteString("\n")
+		for _, note := range r.Notes {
+			b.WriteString("\n  - ")
+			if note.Step {
+				if note.Checked {
+					b.WriteString("[x] ")
+				} else {
+					b.WriteString("[ ] ")
+				}
+			}
+			b.WriteString(markdownText(note.Text))
+		}
+	}
+	return b.String()
+}
+
+// todoShowFromNode builds the show result straight from the todo's file, so
+// it is current even when the index has not caught up.
+func todoShowFromNode(path string, n *node.Node) todoShowResult {
+	title, notes := firstBodyLine(n.Body), todoNotes(n.Body)
+	res := todoShowResult{
+		todoListItem: todoListItem{
+			Kind:      "durable",
+			ID:        n.ULID,
+			Path:      path,
+			State:     n.Props["state"],
+			Scheduled: n.Props["scheduled"],
+			Deadline:  n.Props["deadline"],
+			Repeat:    n.Props["repeat"],
+			Tags:      parseTagList(n.Props["tags"]),
+			Assignee:  n.Props["assignee"],
+			Estimate:  n.Props["estimate"],
+			Body:      strings.TrimSpace(n.Body),
+			Title:     title,
+		},
+		Aliases: append([]string{}, n.Aliases...),
+		Author:  n.Author,
+		Created: n.Time,
+		Notes:   notes,
+	}
+	res.Steps, res.StepsDone = countSteps(notes)
+	sort.Strings(res.Aliases)
+	for _, l := range n.Links {
+		switch l.Rel {
+		case "depends-on":
+			res.Depends = l.To
+		case "parent":
+			res.Parent = l.To
+		}
+	}
+	return res
+}
+
+// todoNotes splits body into its notes: every non-blank line after the
+// title. A line in task-list form ("- [ ] text", matched unindented as the
+// checklist helpers do) is a step.
+func todoNotes(body string) []todoNote {
+	notes := []todoNote{}
+	titleSeen := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !titleSeen {
+			titleSeen = true
+			continue
+		}
+		note := todoNote{Position: len(notes) + 1}
+		if m := checklistItemRe.FindStringSubmatch(line); m != nil {
+			note.Step, note.Checked, note.Text = true, m[1] == "x" || m[1] == "X", m[2]
+		} else {
+			note.Text = strings.TrimPrefix(strings.TrimSpace(line), "- ")
+		}
+		notes = append(notes, note)
+	}
+	return notes
+}
+
+// countSteps returns how many of notes are steps, and how many are checked.
+func countSteps(notes []todoNote) (steps, done int) {
+	for _, note := range notes {
+		if note.Step {
+			steps++
+			if note.Checked {
+				done++
+			}
+		}
+	}
+	return steps, done
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoShow: show emits one todo with its dates, tags, and numbered
+// notes (the body lines after the title), and a miss is a not-found error.
+func TestTodoShow(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	writeTodoFixture(t, vault, id, "open", "2026-05-01", "Plan the offsite.\n\n- book the venue\nask about catering",
+		"deadline: 2026-05-20", "tags: [work, q3]", "aliases: [offsite]")
+
+	out, stderr, err := runTodo(t, vault, "show", "offsite", "--json")
+	if err != nil {
+		t.Fatalf("todo show: %v\nstderr: %s", err, stderr)
+	}
+	var res todoShowResult
+	mustDecodeJSON(t, out, &res)
+	if res.ID != id || res.Title != "Plan the offsite." || res.Scheduled != "2026-05-01" || res.Deadline != "2026-05-20" {
+		t.Errorf("show = %+v, want the todo's id, title, and dates", res)
+	}
+	if strings.Join(res.Tags, ",") != "work,q3" || strings.Join(res.Aliases, ",") != "offsite" {
+		t.Errorf("tags/aliases = %v/%v, want work,q3 / offsite", res.Tags, res.Aliases)
+	}
+	want := []todoNote{{Position: 1, Text: "book the venue"}, {Position: 2, Text: "ask about catering"}}
+	if len(res.Notes) != 2 || res.Notes[0] != want[0] || res.Notes[1] != want[1] {
+		t.Errorf("notes = %+v, want %+v", res.Notes, want)
+	}
+
+	resetCLIFlags()
+	pretty, _, err := runTodo(t, vault, "show", "offsite")
+	if err != nil {
+		t.Fatalf("todo show (pretty): %v", err)
+	}
+	if !strings.Contains(pretty, "tags: #work #q3") || !strings.Contains(pretty, "2. ask about catering") {
+		t.Errorf("pretty show should list tags and numbered notes:\n%s", pretty)
+	}
+
+	resetCLIFlags()
+	if quiet, _, err := runTodo(t, vault, "show", "offsite", "--quiet"); err != nil || quiet != "" {
+		t.Errorf("show --quiet = %q, %v; want no output", quiet, err)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "show", "nope"); err == nil || !strings.Contains(err.Error(), "(not found)") {
+		t.Errorf("show of a missing ref err = %v, want a (not found) error", err)
+	}
+}
+
+// TestTodoShowMarkdown: --format markdown prints a checkbox card with a
+// metadata line and the notes as a nested list, markdown in the todo's own
+// text escaped.
+func TestTodoShowMarkdown(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	writeTodoFixture(t, vault, id, "in-progress", "2026-05-01", "Fix *all* the [[login]] bugs\n\n- [x] repro on staging\n1. check the_session cookie",
+		"deadline: 2026-05-20", "tags: [work, q3]")
+
+	out, _, err := runTodo(t, vault, "show", id, "--format", "markdown")
+	if err != nil {
+		t.Fatalf("todo show --format markdown: %v", err)
+	}
+	want := "- [ ] Fix \\*all\\* the \\[\\[login\\]\\] bugs\n\n" +
+		"  *in-progress · scheduled 2026-05-01 · deadline 2026-05-20 · \\#work \\#q3*\n\n" +
+		"  - [x] repro on staging\n" +
+		"  - 1\\. check the\\_session cookie\n"
+	if out != want {
+		t.Errorf("markdown card:\n%s\nwant:\n%s", out, want)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "show", id, "--format", "markdwon"); err == nil || !strings.Contains(err.Error(), `did you mean "markdown"`) {
+		t.Errorf("--format typo: err = %v, want a suggestion", err)
+	}
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "show", id, "--format", "markdown", "--json"); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--format with --json: err = %v, want mutually exclusive", err)
+	}
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoSplitCmd = &cobra.Command{
+	Use:   "split <ref> --into <text> [--into <text>...]",
+	Short: "Break a durable todo into subtasks",
+	Long: "Create one new durable todo per --into, each carrying a parent: link back to <ref> and <ref>'s tags and assignee. " +
+		"With --complete-parent the original is marked done once every subtask is written.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runTodoSplitE,
+}
+
+// todoSplitResult is the structured summary of one `rk todo split` run.
+type todoSplitResult struct {
+	Parent          string          `json:"parent"` // the parent's ULID
+	ParentPath      string          `json:"parent_path"`
+	ParentCompleted bool            `json:"parent_completed"`
+	Subtasks        []todoAddResult `json:"subtasks"`
+}
+
+func (r todoSplitResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: split %s into %d subtask(s)", r.Parent, len(r.Subtasks))
+	for _, st := range r.Subtasks {
+		fmt.Fprintf(&b, "\n  %s", st.Path)
+	}
+	if r.ParentCompleted {
+		b.WriteString("\nparent marked done")
+	}
+	return b.String()
+}
+
+func runTodoSplitE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	var pieces []string
+	for _, p := range todoSplitIntoFlag {
+		if p = strings.TrimSpace(p); p != "" {
+			pieces = append(pieces, p)
+		}
+	}
+	if len(pieces) == 0 {
+		return fmt.Errorf("todo split: at least one non-empty --into is required")
+	}
+	author := resolveAuthor(todoAuthorFlag)
+	completeParent := todoSplitParentFlag
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo split: load config: %w", err)
+	}
+
+	res, err := splitDurableTodo(cfg.VaultDir, args[0], author, pieces, completeParent)
+	if err != nil {
+		return err
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitDurableTodo creates one open subtask per piece under ref's todo. Each
+// gets a parent: link to the parent's ULID and a copy of its tags and
+// assignee props.
+// Only an open or in-progress todo that is not itself a subtask can be
+// split (subtasks nest one level, as `rk todo parent` enforces), and a
+// recurring rule is refused under completeParent (completing it would just
+// advance its cursor). Subtasks are written before the parent is touched, so a failure
+// part-way leaves the parent as it was.
+func splitDurableTodo(vaultDir, ref, author string, pieces []string, completeParent bool) (todoSplitResult, error) {
+	parent, parentPath, err := resolveDurableTodo(vaultDir, ref, "todo split")
+	if err != nil {
+		return todoSplitResult{}, err
+	}
+	if parent.ULID == "" {
+		return todoSplitResult{}, fmt.Errorf("todo split: %s has no id", relTodoPath(vaultDir, parentPath))
+	}
+	if st := parent.Props["state"]; st != "open" && st != "in-progress" {
+		return todoSplitResult{}, fmt.Errorf("todo split: %s is %s; only open or in-progress todos can be split", ref, st)
+	}
+	if todoParentRef(parent) != "" {
+		return todoSplitResult{}, fmt.Errorf("todo split: %s is itself a subtask; subtasks nest one level only", ref)
+	}
+	if completeParent && parent.HasField("repeat") {
+		return todoSplitResult{}, fmt.Errorf("todo split: --complete-parent on a recurring todo would advance it, not finish it")
+	}
+
+	res := todoSplitResult{
+		Parent:     parent.ULID,
+		ParentPath: relTodoPath(vaultDir, parentPath),
+		Subtasks:   []todoAddResult{},
+	}
+	todosDir := filepath.Join(vaultDir, "todos")
+	for _, piece := range pieces {
+		props := map[string]string{"state": "open"}
+		if tags := parent.Props["tags"]; tags != "" {
+			props["tags"] = tags
+		}
+		if assignee := parent.Props["assignee"]; assignee != "" {
+			props["assignee"] = assignee
+		}
+		links := []node.Link{{Rel: "parent", To: parent.ULID}}
+		st, err := createDurableTodo(todosDir, todoProjectOf(res.ParentPath), "", author, piece, props, links)
+		if err != nil {
+			return res, fmt.Errorf("todo split: %w", err)
+		}
+		res.Subtasks = append(res.Subtasks, st)
+	}
+
+	if completeParent {
+		if _, err := completeDurableTodoNode(vaultDir, parent, parentPath, ref, false, false); err != nil {
+			return res, fmt.Errorf("todo split: complete parent: %w", err)
+		}
+		res.ParentCompleted = true
+	}
+	return res, nil
+}
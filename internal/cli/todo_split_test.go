@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoSplit: each --into becomes an open todo linked back to the parent
+// and carrying its tags; --complete-parent then marks the parent done.
+func TestTodoSplit(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	parent := node.Mint()
+	parentPath, _ := writeTodoFixture(t, vault, parent, "open", "", "Ship the release.", "tags: [work, q3]")
+
+	stdout, stderr, err := runTodo(t, vault, "split", parent, "--into", "Write notes", "--into", "Tag build", "--complete-parent", "--json")
+	if err != nil {
+		t.Fatalf("todo split: %v\nstderr: %s", err, stderr)
+	}
+	var res todoSplitResult
+	mustDecodeJSON(t, stdout, &res)
+	if res.Parent != parent || !res.ParentCompleted || len(res.Subtasks) != 2 {
+		t.Fatalf("result = %+v, want 2 subtasks and the parent completed", res)
+	}
+	for i, want := range []string{"Write notes", "Tag build"} {
+		got := mustReadFile(t, filepath.Join(vault, res.Subtasks[i].Path))
+		for _, line := range []string{"state: open\n", "tags: [work, q3]\n", `parent: "[[` + parent + `]]"`, want} {
+			if !strings.Contains(got, line) {
+				t.Errorf("subtask %d missing %q:\n%s", i, line, got)
+			}
+		}
+	}
+	if got := mustReadFile(t, parentPath); !strings.Contains(got, "state: done\n") {
+		t.Errorf("parent not completed:\n%s", got)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "split", parent, "--into", "Again"); err == nil || !strings.Contains(err.Error(), "only open or in-progress") {
+		t.Errorf("splitting a done todo: err = %v, want a state error", err)
+	}
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "split", parent); err == nil || !strings.Contains(err.Error(), "--into") {
+		t.Errorf("split without --into: err = %v, want a usage error", err)
+	}
+
+	resetCLIFlags()
+	sub := res.Subtasks[0].ID
+	if _, _, err := runTodo(t, vault, "split", sub, "--into", "Deeper"); err == nil || !strings.Contains(err.Error(), "nest one level only") {
+		t.Errorf("splitting a subtask: err = %v, want a nesting error", err)
+	}
+	if kids, _ := filepath.Glob(filepath.Join(vault, "todos", "*.md")); len(kids) != 3 {
+		t.Errorf("splitting a subtask wrote todos anyway: %d files, want 3", len(kids))
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// runTodoStdin is runTodo with stdin wired to the given text.
+func runTodoStdin(t *testing.T, vault, stdin string, args ...string) (stdout string, err error) {
+	t.Helper()
+	RootCmd.SetIn(strings.NewReader(stdin))
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+	stdout, _, err = runTodo(t, vault, args...)
+	return stdout, err
+}
+
+func TestTodoAddStdin_CreatesOnePerLine(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	out, err := runTodoStdin(t, vault, "first\n\n  second  \nthird\n",
+		"add", "--stdin", "--scheduled", "2026-08-01", "--json")
+	if err != nil {
+		t.Fatalf("todo add --stdin: %v", err)
+	}
+	var res todoAddBulkResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Created) != 3 || len(res.Errors) != 0 {
+		t.Fatalf("created=%d errors=%d, want 3/0: %+v", len(res.Created), len(res.Errors), res)
+	}
+	for i, want := range []string{"first", "second", "third"} {
+		raw := mustReadFile(t, filepath.Join(vault, res.Created[i].Path))
+		if !strings.Contains(raw, want) {
+			t.Errorf("todo %d missing body %q:\n%s", i, want, raw)
+		}
+		if !strings.Contains(raw, "scheduled: 2026-08-01") {
+			t.Errorf("todo %d missing --scheduled:\n%s", i, raw)
+		}
+	}
+}
+
+func TestTodoAddStdin_ContinuesPastFailures(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	// Force the second line's ULID onto a path a stray file already
+	// occupies, so only that line fails (the no-clobber guard).
+	ids := []string{node.Mint(), node.Mint(), node.Mint()}
+	mustWriteFile(t, filepath.Join(vault, "todos", ids[1]+".md"), "stray\n")
+	prevMint := mintTodoULID
+	calls := 0
+	mintTodoULID = func() string { calls++; return ids[calls-1] }
+	t.Cleanup(func() { mintTodoULID = prevMint })
+
+	out, err := runTodoStdin(t, vault, "ok one\nclobbers\nok two\n", "add", "--stdin", "--json")
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("err = %v, want a 1-of-3 failure summary", err)
+	}
+	var res todoAddBulkResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Created) != 2 {
+		t.Errorf("created = %d, want 2", len(res.Created))
+	}
+	if len(res.Errors) != 1 || res.Errors[0].Line != 2 {
+		t.Errorf("errors = %+v, want one error on line 2", res.Errors)
+	}
+}
+
+func TestTodoAddStdin_RejectsArgs(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	if _, err := runTodoStdin(t, vault, "x\n", "add", "--stdin", "extra"); err == nil {
+		t.Fatal("expected error combining --stdin with text arguments")
+	}
+	entries, _ := os.ReadDir(filepath.Join(vault, "todos"))
+	if len(entries) != 0 {
+		t.Errorf("todos written despite error: %v", entries)
+	}
+}
+
+func TestReadStdinLines_SizeLimit(t *testing.T) {
+	big := bytes.Repeat([]byte("a"), maxStdinSize+1)
+	if _, err := readStdinLines(bytes.NewReader(big)); err == nil {
+		t.Fatal("expected error for stdin over maxStdinSize")
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseTagList splits a todo's tags prop (a flow list like "[work, q3]", or
+// a bare "work") into its tags; "" and "[]" are nil.
+func parseTagList(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "["), "]")
+	var tags []string
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+var tagBrackets = strings.NewReplacer("[", "", "]", "")
+
+// parseTagInput turns comma-separated user input into a tag list: each tag
+// is trimmed and loses any leading '#' and any brackets (which would break
+// the flow list, or turn it into a [[link]]), empties are dropped, and
+// repeats keep their first position.
+func parseTagInput(input string) []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, t := range strings.Split(input, ",") {
+		t = strings.TrimLeft(strings.TrimSpace(tagBrackets.Replace(t)), "#")
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+// setTodoTags replaces ref's tags prop with tags, written as a flow list
+// ("[]" when tags is empty, so clearing never leaves a stale list behind).
+func setTodoTags(vaultDir, ref string, tags []string) error {
+	n, path, err := resolveDurableTodo(vaultDir, ref, "todo tags")
+	if err != nil {
+		return err
+	}
+	if err := setOrInsertField(n, "tags", "["+strings.Join(tags, ", ")+"]"); err != nil {
+		return fmt.Errorf("todo tags: set tags: %w", err)
+	}
+	if err := writeFileAtomic(path, n.Serialize()); err != nil {
+		return fmt.Errorf("todo tags: write: %w", err)
+	}
+	return nil
+}
@@ -89,10 +89,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/MikeBiancalana/reckon/internal/node"
+	"gopkg.in/yaml.v3"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -125,6 +127,14 @@ func mustDecodeJSON(t *testing.T, s string, v any) {
 	}
 }
 
+// markedDone is the open todo file src as marking it done rewrites it:
+// state open becomes done, and done: with today's journal date is inserted
+// as the frontmatter's first line.
+func markedDone(src string) string {
+	return "---\ndone: " + currentJournalDate() + "\n" +
+		strings.Replace(strings.TrimPrefix(src, "---\n"), "state: open", "state: done", 1)
+}
+
 // checklistLine renders one markdown task-list line in the exact form D2's
 // ephemeral container uses ("- [ ] text" / "- [x] text").
 func checklistLine(checked bool, text string) string {
@@ -881,6 +891,46 @@ func TestTodoList_Ephemeral_Unaffected(t *testing.T) {
 	}
 }
 
+// TestTodoList_NotTag: --not-tag drops todos carrying any excluded tag,
+// ignoring case, after --tag has filtered; it repeats and splits on commas.
+func TestTodoList_NotTag(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Ship the release", "tags: [work]")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Old project", "tags: [work, Archived]")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Waiting on legal", "tags: [work, blocked]")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Mow the lawn", "tags: [home]")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "No tags at all")
+
+	titles := func(args ...string) string {
+		t.Helper()
+		resetCLIFlags()
+		out, stderr, err := runTodo(t, vault, append([]string{"list", "--durable", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("todo list %v: %v\nstderr: %s", args, err, stderr)
+		}
+		var res todoListResult
+		mustDecodeJSON(t, out, &res)
+		var got []string
+		for _, it := range res.Items {
+			got = append(got, it.Title)
+		}
+		sort.Strings(got)
+		return strings.Join(got, ",")
+	}
+
+	if got, want := titles("--tag", "work", "--not-tag", "archived"), "Ship the release,Waiting on legal"; got != want {
+		t.Errorf("--tag work --not-tag archived = %q, want %q", got, want)
+	}
+	if got, want := titles("--tag", "work", "--not-tag", "#archived", "--not-tag", "blocked"), "Ship the release"; got != want {
+		t.Errorf("repeated --not-tag = %q, want %q", got, want)
+	}
+	if got, want := titles("--not-tag", "work,home"), "No tags at all"; got != want {
+		t.Errorf("--not-tag work,home = %q, want %q", got, want)
+	}
+}
+
 // TestTodoList_MultiLineBody_RoundTripByteIdentical (AC8b): a multi-line-body
 // todo's file bytes are unchanged after the reconcile `rk todo list` triggers
 // -- round-trip byte-identity is a structural guarantee of node.Parse/Serialize
@@ -941,7 +991,7 @@ func TestTodoDone_DurableBytePreservation(t *testing.T) {
 	}
 
 	got := mustReadFile(t, path)
-	want := strings.Replace(src, "state: open", "state: done", 1)
+	want := markedDone(src)
 	if got != want {
 		t.Fatalf("done() disturbed bytes beyond the state field\n--- want ---\n%q\n--- got ---\n%q", want, got)
 	}
@@ -1553,3 +1603,67 @@ func TestTodoList_InProgressVisibleByDefault(t *testing.T) {
 		t.Errorf("in-progress item %q missing from default (no --all) list: %+v", id, res.Items)
 	}
 }
+
+// TestTodoList_JSONIndentation: piped --json is compact, --pretty indents
+// it, and --pretty with --compact is rejected.
+func TestTodoList_JSONIndentation(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Indent me.")
+
+	out, _, err := runTodo(t, vault, "list", "--json")
+	if err != nil {
+		t.Fatalf("todo list --json: %v", err)
+	}
+	if strings.Count(strings.TrimSpace(out), "\n") != 0 {
+		t.Errorf("piped --json not compact:\n%s", out)
+	}
+
+	resetCLIFlags()
+	out, _, err = runTodo(t, vault, "list", "--json", "--pretty")
+	if err != nil {
+		t.Fatalf("todo list --json --pretty: %v", err)
+	}
+	if !strings.Contains(out, "\n  ") {
+		t.Errorf("--pretty output not indented:\n%s", out)
+	}
+	var v any
+	mustDecodeJSON(t, out, &v)
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--json", "--pretty", "--compact"); err == nil ||
+		!strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--pretty --compact: err = %v, want a mutually exclusive error", err)
+	}
+}
+
+// TestTodoList_YAML: --yaml prints the --json fields as YAML, without
+// unset dates, and cannot be combined with --json.
+func TestTodoList_YAML(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	writeTodoFixture(t, vault, id, "open", "2026-07-10", "Read me in YAML.")
+
+	out, _, err := runTodo(t, vault, "list", "--yaml")
+	if err != nil {
+		t.Fatalf("todo list --yaml: %v", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("decode yaml %q: %v", out, err)
+	}
+	for _, want := range []string{"items:\n", "id: " + id, "scheduled: \"2026-07-10\"", "title: Read me in YAML."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("--yaml output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "deadline") {
+		t.Errorf("--yaml output has an unset deadline:\n%s", out)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--yaml", "--json"); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--yaml --json: err = %v, want a mutually exclusive error", err)
+	}
+}
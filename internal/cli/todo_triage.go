@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var todoTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Tag and schedule inbox todos one at a time",
+	Long: "Walk every open durable todo carrying the inbox_tag setting's tag (default: inbox), oldest first, " +
+		"prompting on stderr for each. Answer with #tags and/or a date (YYYY-MM-DD, today, tomorrow, +3d): " +
+		"the tags are added, the date becomes the scheduled date, and the inbox tag is removed. " +
+		"A blank answer skips the todo, q stops.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTodoTriageE,
+}
+
+// todoTriageResult is the structured summary of one `rk todo triage` run.
+type todoTriageResult struct {
+	Triaged []todoTriagedItem `json:"triaged"`
+	Skipped int               `json:"skipped"`
+	// Remaining counts inbox todos never reached because triage stopped.
+	Remaining int `json:"remaining"`
+}
+
+// todoTriagedItem is one todo a triage run took out of the inbox.
+type todoTriagedItem struct {
+	ID        string   `json:"id"`
+	Path      string   `json:"path"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags"`
+	Scheduled string   `json:"scheduled,omitempty"`
+}
+
+func (r todoTriageResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "todo: triaged %d inbox todo(s), skipped %d", len(r.Triaged), r.Skipped)
+	if r.Remaining > 0 {
+		fmt.Fprintf(&b, ", %d left", r.Remaining)
+	}
+	for _, it := range r.Triaged {
+		fmt.Fprintf(&b, "\n  %s  %s", it.ID, it.Title)
+		for _, tag := range it.Tags {
+			b.WriteString(" #" + tag)
+		}
+		if it.Scheduled != "" {
+			fmt.Fprintf(&b, " (scheduled %s)", it.Scheduled)
+		}
+	}
+	return b.String()
+}
+
+// triageAnswer is one parsed reply to a triage prompt.
+type triageAnswer struct {
+	tags      []string
+	scheduled string
+	skip      bool
+	quit      bool
+}
+
+// parseTriageAnswer reads a triage reply: blank skips, q quits, and
+// otherwise every #word is a tag and at most one other word is a date.
+func parseTriageAnswer(line string) (triageAnswer, error) {
+	line = strings.TrimSpace(line)
+	switch strings.ToLower(line) {
+	case "":
+		return triageAnswer{skip: true}, nil
+	case "q", "quit":
+		return triageAnswer{quit: true}, nil
+	}
+	var a triageAnswer
+	var tagInput []string
+	for _, word := range strings.Fields(line) {
+		if strings.HasPrefix(word, "#") {
+			tagInput = append(tagInput, word)
+			continue
+		}
+		if a.scheduled != "" {
+			return triageAnswer{}, fmt.Errorf("more than one date (%s, %s); tags start with #", a.scheduled, word)
+		}
+		d, err := resolveDateEndpoint(word, journalNow())
+		if err != nil {
+			return triageAnswer{}, err
+		}
+		a.scheduled = d
+	}
+	a.tags = parseTagInput(strings.Join(tagInput, ","))
+	return a, nil
+}
+
+func runTodoTriageE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("todo triage: load config: %w", err)
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("todo triage: %w", err)
+	}
+
+	res, err := triageInbox(cfg.VaultDir, settings.InboxTag, cmd.InOrStdin(), cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// triageInbox prompts on prompt for each open durable todo tagged inbox, in
+// filename (ULID, i.e. creation) order, reading one answer per todo from
+// in. An invalid answer re-prompts; running out of input stops like q.
+func triageInbox(vaultDir, inbox string, in io.Reader, prompt io.Writer) (todoTriageResult, error) {
+	res := todoTriageResult{Triaged: []todoTriagedItem{}}
+
+	files, err := todoFiles(filepath.Join(vaultDir, "todos"), todoProjectFlag)
+	if err != nil {
+		return todoTriageResult{}, fmt.Errorf("todo triage: %w", err)
+	}
+	type inboxTodo struct {
+		n    *node.Node
+		path string
+	}
+	var queue []inboxTodo
+	for _, path := range files {
+		n, ok := parseCandidateFile(path)
+		if !ok || n.Type != "todo" || n.ULID == "" {
+			continue
+		}
+		if st := n.Props["state"]; st != "open" && st != "in-progress" {
+			continue
+		}
+		if containsString(parseTagList(n.Props["tags"]), inbox) {
+			queue = append(queue, inboxTodo{n, path})
+		}
+	}
+	if len(queue) == 0 {
+		fmt.Fprintf(prompt, "todo triage: no todos tagged %s\n", inbox)
+		return res, nil
+	}
+
+	sc := bufio.NewScanner(in)
+	for i, t := range queue {
+		title := firstBodyLine(t.n.Body)
+		var a triageAnswer
+		for {
+			fmt.Fprintf(prompt, "[%d/%d] %s\n  #tags and/or date, blank to skip, q to quit: ", i+1, len(queue), title)
+			if !sc.Scan() {
+				a = triageAnswer{quit: true}
+				break
+			}
+			if a, err = parseTriageAnswer(sc.Text()); err == nil {
+				break
+			}
+			fmt.Fprintf(prompt, "todo triage: %v\n", err)
+		}
+		if a.quit {
+			res.Remaining = len(queue) - i
+			break
+		}
+		if a.skip {
+			res.Skipped++
+			continue
+		}
+
+		item, err := applyTriage(vaultDir, t.n, t.path, inbox, a)
+		if err != nil {
+			return todoTriageResult{}, err
+		}
+		if w := scheduleDeadlineWarning(item.Scheduled, t.n.Props["deadline"]); w != "" {
+			fmt.Fprintf(prompt, "todo triage: warning: %s\n", w)
+		}
+		res.Triaged = append(res.Triaged, item)
+	}
+	if err := sc.Err(); err != nil {
+		return todoTriageResult{}, fmt.Errorf("todo triage: read answers: %w", err)
+	}
+	return res, nil
+}
+
+// applyTriage writes one answer to n: the inbox tag is swapped for the
+// answer's tags and the answer's date, if any, becomes the scheduled date.
+func applyTriage(vaultDir string, n *node.Node, path, inbox string, a triageAnswer) (todoTriagedItem, error) {
+	rel := relTodoPath(vaultDir, path)
+	tags := []string{}
+	for _, tag := range append(parseTagList(n.Props["tags"]), a.tags...) {
+		if tag != inbox && !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if err := setOrInsertField(n, "tags", "["+strings.Join(tags, ", ")+"]"); err != nil {
+		return todoTriagedItem{}, fmt.Errorf("todo triage: set tags on %s: %w", rel, err)
+	}
+	if a.scheduled != "" {
+		if err := setOrInsertField(n, "scheduled", a.scheduled); err != nil {
+			return todoTriagedItem{}, fmt.Errorf("todo triage: set scheduled on %s: %w", rel, err)
+		}
+	}
+	if err := writeFileAtomic(path, n.Serialize()); err != nil {
+		return todoTriagedItem{}, fmt.Errorf("todo triage: write %s: %w", rel, err)
+	}
+	return todoTriagedItem{ID: n.ULID, Path: rel, Title: firstBodyLine(n.Body), Tags: tags, Scheduled: a.scheduled}, nil
+}
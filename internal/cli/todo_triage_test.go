@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoTriage: auto_inbox tags untagged captures, --tag lists them, and
+// triage walks them oldest first, swapping the inbox tag for the answer's
+// tags and date; a bad answer re-prompts and blank skips.
+func TestTodoTriage(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	t.Cleanup(func() { RootCmd.SetIn(nil) })
+	pinTodoNow(t, "2026-07-10")
+	writeVaultSettings(t, vault, "auto_inbox: true\n")
+
+	if _, stderr, err := runTodo(t, vault, "add", "Quick capture"); err != nil {
+		t.Fatalf("todo add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	if _, stderr, err := runTodo(t, vault, "add", "Already filed", "--tags", "#work, home"); err != nil {
+		t.Fatalf("todo add --tags: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+	var listed todoListResult
+	out, _, err := runTodo(t, vault, "list", "--tag", "inbox", "--json")
+	if err != nil {
+		t.Fatalf("todo list --tag: %v", err)
+	}
+	mustDecodeJSON(t, out, &listed)
+	if len(listed.Items) != 1 || listed.Items[0].Title != "Quick capture" {
+		t.Fatalf("--tag inbox = %+v, want only the untagged capture", listed.Items)
+	}
+
+	// Mints are monotonic, so these sort after the capture above.
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Call the bank.", "tags: [inbox, money]")
+	laterPath, _ := writeTodoFixture(t, vault, node.Mint(), "open", "", "Read later.", "tags: [inbox]")
+
+	resetCLIFlags()
+	RootCmd.SetIn(strings.NewReader("#errand tomorrow\nsomeday\n#bills +3d\n\n"))
+	out, stderr, err := runTodo(t, vault, "triage", "--json")
+	if err != nil {
+		t.Fatalf("todo triage: %v\nstderr: %s", err, stderr)
+	}
+	if !strings.Contains(stderr, "[1/3] Quick capture") || !strings.Contains(stderr, "todo triage: invalid date") {
+		t.Errorf("prompts should name each todo and re-prompt on a bad date:\n%s", stderr)
+	}
+	var res todoTriageResult
+	mustDecodeJSON(t, out, &res)
+	if len(res.Triaged) != 2 || res.Skipped != 1 {
+		t.Fatalf("triage = %+v, want 2 triaged and 1 skipped", res)
+	}
+	if got := res.Triaged[0]; got.Scheduled != "2026-07-11" || strings.Join(got.Tags, ",") != "errand" {
+		t.Errorf("first triaged = %+v, want #errand scheduled tomorrow", got)
+	}
+	if got := res.Triaged[1]; got.Scheduled != "2026-07-13" || strings.Join(got.Tags, ",") != "money,bills" {
+		t.Errorf("second triaged = %+v, want money,bills scheduled +3d", got)
+	}
+	if src := mustReadFile(t, laterPath); !strings.Contains(src, "tags: [inbox]") {
+		t.Errorf("skipped todo should keep its inbox tag:\n%s", src)
+	}
+}
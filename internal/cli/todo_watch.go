@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todoListWatchFlag bool
+	todoListBellFlag  bool
+)
+
+func init() {
+	lf := todoListCmd.Flags()
+	lf.BoolVar(&todoListWatchFlag, "watch", false, "Redraw the list every few seconds until interrupted, marking overdue todos and reporting each one that falls overdue; with --scheduled, overdue todos are listed too, as with --include-overdue")
+	lf.BoolVar(&todoListBellFlag, "bell", false, "With --watch, ring the terminal bell when a todo falls overdue")
+}
+
+// todoWatchInterval is how often `rk todo list --watch` redraws.
+const todoWatchInterval = 5 * time.Second
+
+// todoWatchWait blocks until the next --watch refresh is due, returning
+// false when ctx ends first. Tests replace it to step through refreshes.
+var todoWatchWait = func(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(todoWatchInterval):
+		return true
+	}
+}
+
+// watchTodoList prints list's result on every refresh until interrupted.
+// Pretty output clears the screen first when writing to a terminal without
+// --plain; the structured modes print one document per refresh. Overdue
+// todos are marked, and --watch widens --scheduled the way
+// --include-overdue does: every overdue todo is listed alongside the
+// range, so one doesn't drop out the moment it falls overdue. A todo that
+// becomes overdue between refreshes is reported on stderr, with a bell
+// under --bell; todos already overdue at the first refresh are not
+// reported.
+func watchTodoList(cmd *cobra.Command, mode output.Mode, list func() (todoListResult, error)) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	var seen map[string]bool // overdue IDs as of the last refresh; nil before the first
+	for {
+		res, err := list()
+		if err != nil {
+			return err
+		}
+		if w := cmd.OutOrStdout(); mode == output.Pretty && isTerminal(w) && !plainFlag {
+			fmt.Fprint(w, "\x1b[H\x1b[2J")
+		}
+		if err := printTodoList(cmd, mode, res); err != nil {
+			return err
+		}
+
+		overdue := map[string]bool{}
+		var fresh []string
+		for _, it := range res.Items {
+			if !it.Overdue {
+				continue
+			}
+			overdue[it.ID] = true
+			if seen != nil && !seen[it.ID] {
+				fresh = append(fresh, fmt.Sprintf("%s (%s)", it.Title, it.ID))
+			}
+		}
+		if len(fresh) > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "todo list: now overdue: %s\n", strings.Join(fresh, ", "))
+			if todoListBellFlag {
+				fmt.Fprint(cmd.ErrOrStderr(), "\a")
+			}
+		}
+		seen = overdue
+
+		if !todoWatchWait(ctx) {
+			return nil
+		}
+	}
+}
+
+// resolveTodoListRange resolves a `rk todo list` date filter (--scheduled
+// or --done-on) against today, or nil when expr is unset.
+func resolveTodoListRange(expr string) (*dateRange, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	r, err := parseDateFilter(expr, journalNow())
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
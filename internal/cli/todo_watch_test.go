@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// TestTodoListWatch: --watch redraws until stopped, widens --scheduled to
+// every overdue todo (like --include-overdue), keeps a todo that falls
+// overdue at the day rollover listed and marked, and reports (and with
+// --bell rings for) only that transition, not todos already overdue.
+func TestTodoListWatch(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	today := node.Mint()
+	late := node.Mint()
+	writeTodoFixture(t, vault, today, "open", "2026-07-10", "Send the invoice")
+	writeTodoFixture(t, vault, late, "open", "2026-07-09", "Book the dentist")
+
+	refreshes := 0
+	prev := todoWatchWait
+	todoWatchWait = func(context.Context) bool {
+		refreshes++
+		if refreshes == 1 {
+			pinTodoNow(t, "2026-07-11")
+		}
+		return refreshes < 3
+	}
+	t.Cleanup(func() { todoWatchWait = prev })
+
+	out, stderr, err := runTodo(t, vault, "list", "--scheduled", "today", "--watch", "--bell")
+	if err != nil {
+		t.Fatalf("todo list --watch: %v\nstderr: %s", err, stderr)
+	}
+	// Not a terminal, so the redraws are appended without clearing the screen.
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("redirected --watch wrote escape codes:\n%q", out)
+	}
+	if n := strings.Count(out, "Send the invoice"); n != 3 {
+		t.Fatalf("want 3 redraws, got %d:\n%s", n, out)
+	}
+	if !strings.Contains(out, "Book the dentist (overdue)") {
+		t.Errorf("redraws should mark the already overdue todo:\n%s", out)
+	}
+	if !strings.Contains(out, "Send the invoice (overdue)") {
+		t.Errorf("later redraws should keep the newly overdue todo, marked:\n%s", out)
+	}
+	if want := "todo list: now overdue: Send the invoice (" + today + ")\n\a"; stderr != want {
+		t.Errorf("alerts = %q, want %q", stderr, want)
+	}
+
+	resetCLIFlags()
+	if _, _, err := runTodo(t, vault, "list", "--bell"); err == nil || !strings.Contains(err.Error(), "--bell requires --watch") {
+		t.Errorf("--bell without --watch: err = %v", err)
+	}
+}
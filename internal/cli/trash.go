@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List or empty the todos `rk todo delete` moved to .trash/",
+	Long: "`rk todo delete` moves todos to the vault's .trash/ directory, one batch per run, where " +
+		"`rk todo undelete` can restore them until the batch is undelete_seconds old.",
+}
+
+var trashListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the deleted todos still in the trash, newest batch first",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTrashListE,
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:          "empty",
+	Short:        "Permanently remove everything in the trash",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTrashEmptyE,
+}
+
+func init() {
+	trashCmd.AddCommand(trashListCmd, trashEmptyCmd)
+}
+
+// trashListResult is `rk trash list`'s output.
+type trashListResult struct {
+	Batches []todoTrashBatch `json:"batches"` // newest first
+}
+
+func (r trashListResult) Pretty() string {
+	if len(r.Batches) == 0 {
+		return "trash: empty"
+	}
+	var b strings.Builder
+	for i, batch := range r.Batches {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s: %d todo(s) deleted %s, purged after %s", batch.Batch, len(batch.Todos), batch.Deleted, batch.Expires)
+		for _, it := range batch.Todos {
+			fmt.Fprintf(&b, "\n  %s  %s", it.ID, it.Title)
+		}
+	}
+	return b.String()
+}
+
+// trashEmptyResult is `rk trash empty`'s output.
+type trashEmptyResult struct {
+	Batches int `json:"batches"`
+	Todos   int `json:"todos"`
+}
+
+func (r trashEmptyResult) Pretty() string {
+	return fmt.Sprintf("trash: permanently removed %d todo(s) in %d batch(es)", r.Todos, r.Batches)
+}
+
+func runTrashListE(cmd *cobra.Command, args []string) error {
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("trash list: load config: %w", err)
+	}
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("trash list: %w", err)
+	}
+	window := time.Duration(settings.UndeleteSeconds) * time.Second
+	if err := purgeTodoTrash(cfg.VaultDir, window); err != nil {
+		return fmt.Errorf("trash list: %w", err)
+	}
+	batches, err := loadTodoTrash(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("trash list: %w", err)
+	}
+
+	res := trashListResult{Batches: make([]todoTrashBatch, 0, len(batches))}
+	for i := len(batches) - 1; i >= 0; i-- {
+		b := batches[i]
+		if deleted, ok := todoTrashBatchTime(b.name); ok {
+			b.Expires = deleted.Add(window).Format(time.RFC3339)
+		}
+		res.Batches = append(res.Batches, b)
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
+
+func runTrashEmptyE(cmd *cobra.Command, args []string) error {
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("trash empty: load config: %w", err)
+	}
+	batches, err := loadTodoTrash(cfg.VaultDir)
+	if err != nil {
+		return fmt.Errorf("trash empty: %w", err)
+	}
+
+	var res trashEmptyResult
+	for _, b := range batches {
+		if err := os.RemoveAll(filepath.Join(cfg.VaultDir, todoTrashDir, b.name)); err != nil {
+			return fmt.Errorf("trash empty: remove %s: %w", b.Batch, err)
+		}
+		res.Batches++
+		res.Todos += len(b.Todos)
+	}
+	if !(mode == output.Pretty && quietFlag) {
+		if err := newOutput(cmd, mode).Print(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+	}
+	return nil
+}
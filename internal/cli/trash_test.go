@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// runTrash runs `rk trash <args...>` against vault.
+func runTrash(t *testing.T, vault string, args ...string) (stdout string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&errBuf)
+	RootCmd.SetArgs(append([]string{"trash", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), err
+}
+
+// TestTrash: undelete <ref> restores one todo out of any batch, `trash
+// list` shows what is left newest first, and `trash empty` removes it all.
+func TestTrash(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+	writeVaultSettings(t, vault, "undelete_seconds: 3600\n")
+
+	a, b, c := node.Mint(), node.Mint(), node.Mint()
+	pathA, srcA := writeTodoFixture(t, vault, a, "open", "", "Renew the passport.")
+	writeTodoFixture(t, vault, b, "open", "", "Book the flights.")
+	writeTodoFixture(t, vault, c, "open", "", "Pack.")
+
+	if _, _, err := runTodo(t, vault, "delete", a, b); err != nil {
+		t.Fatalf("todo delete %s %s: %v", a, b, err)
+	}
+	if _, _, err := runTodo(t, vault, "delete", c); err != nil {
+		t.Fatalf("todo delete %s: %v", c, err)
+	}
+
+	out, err := runTrash(t, vault, "list", "--json")
+	if err != nil {
+		t.Fatalf("trash list: %v", err)
+	}
+	resetCLIFlags()
+	var list trashListResult
+	mustDecodeJSON(t, out, &list)
+	if len(list.Batches) != 2 || len(list.Batches[0].Todos) != 1 || list.Batches[0].Todos[0].ID != c || len(list.Batches[1].Todos) != 2 {
+		t.Fatalf("trash list = %+v, want the %s batch then the two-todo batch", list.Batches, c)
+	}
+	if list.Batches[1].Expires != "2026-07-10T01:00:00Z" {
+		t.Errorf("expires = %q, want undelete_seconds after the delete", list.Batches[1].Expires)
+	}
+
+	// By ID prefix, from the older batch; the newer one is untouched.
+	if _, _, err := runTodo(t, vault, "undelete", a[:len(a)-4]); err != nil {
+		t.Fatalf("todo undelete %s: %v", a, err)
+	}
+	if got := mustReadFile(t, pathA); got != srcA {
+		t.Errorf("restored %s differs:\n%q\nwant\n%q", pathA, got, srcA)
+	}
+	if _, _, err := runTodo(t, vault, "undelete", a); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("undelete of a restored todo: err = %v, want not found", err)
+	}
+
+	out, err = runTrash(t, vault, "list")
+	if err != nil {
+		t.Fatalf("trash list: %v", err)
+	}
+	if strings.Contains(out, "passport") || !strings.Contains(out, "Book the flights.") || !strings.Contains(out, "Pack.") {
+		t.Errorf("trash list after undelete:\n%s", out)
+	}
+
+	out, err = runTrash(t, vault, "empty")
+	if err != nil {
+		t.Fatalf("trash empty: %v", err)
+	}
+	if !strings.Contains(out, "removed 2 todo(s) in 2 batch(es)") {
+		t.Errorf("trash empty output = %q", out)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(vault, todoTrashDir)); len(entries) != 0 {
+		t.Errorf("trash not empty: %d entries left", len(entries))
+	}
+	if out, _ := runTrash(t, vault, "list"); strings.TrimSpace(out) != "trash: empty" {
+		t.Errorf("trash list after empty = %q", out)
+	}
+}
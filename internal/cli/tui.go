@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/MikeBiancalana/reckon/internal/config"
 	"github.com/MikeBiancalana/reckon/internal/index"
@@ -19,15 +21,78 @@ import (
 // PersistentPreRunE-initialized package-level services — hence no
 // requiresDB annotation.
 var tuiCmd = &cobra.Command{
-	Use:          "tui",
-	Short:        "Launch the interactive terminal UI",
-	Long:         "Launch the full-screen terminal user interface: a persistent 4-pane porcelain (agenda, todos, log, notes) over the vault index.",
+	Use:   "tui",
+	Short: "Launch the interactive terminal UI",
+	Long: "Launch the full-screen terminal user interface: a persistent 4-pane porcelain (agenda, todos, log, notes) over the vault index. " +
+		"On a terminal smaller than tui_view.min_size (80x24 unless set; --min-size overrides it) it shows one pane at a time, and tab cycles which.",
 	SilenceUsage: true,
 	Args:         cobra.NoArgs,
 	RunE:         runTUIE,
 }
 
+// tuiNoAutoCarryFlag launches with a clean agenda: rows there only because
+// their scheduled date has passed are left out (dropCarried, today.go).
+// tuiMinSizeFlag overrides the tui_view.min_size setting for one run.
+var (
+	tuiNoAutoCarryFlag bool
+	tuiMinSizeFlag     string
+)
+
+// todoTUICmd is `rk todo tui`: the same porcelain with only its todos pane,
+// full screen, for working through todos without the journal around them.
+var todoTUICmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Manage todos in a full-screen terminal UI",
+	Long: "Launch the terminal UI with only its todos pane, full screen: add (n), complete (x), start (i), " +
+		"cancel (c), defer (d), set a deadline (D) or priority (p), pin to today (t), and tag (g) todos. " +
+		"Each change is written to the vault as it is made (with tui_save.mode buffered, by exit at the latest).",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runTodoTUIE,
+}
+
+func init() {
+	todoCmd.AddCommand(todoTUICmd)
+	tuiCmd.Flags().BoolVar(&tuiNoAutoCarryFlag, "no-auto-carry", false, "Leave out agenda rows that are there only because their scheduled date has passed")
+	tuiCmd.Flags().StringVar(&tuiMinSizeFlag, "min-size", "", "Smallest terminal (WxH) to show the pane grid on; smaller ones show one pane at a time (default: tui_view.min_size setting)")
+}
+
 func runTUIE(cmd *cobra.Command, args []string) error {
+	noAutoCarry, minSize := tuiNoAutoCarryFlag, tuiMinSizeFlag
+	tuiNoAutoCarryFlag, tuiMinSizeFlag = false, ""
+	for _, name := range []string{"no-auto-carry", "min-size"} {
+		if fl := cmd.Flags().Lookup(name); fl != nil {
+			fl.Changed = false
+		}
+	}
+	var minWidth, minHeight int
+	if minSize != "" {
+		var err error
+		if minWidth, minHeight, err = config.ParseTerminalSize(minSize); err != nil {
+			return fmt.Errorf("tui: --min-size: %w", err)
+		}
+	}
+
+	return runTUIProgram(func(m *tuiModel) {
+		m.noAutoCarry = noAutoCarry
+		if minSize != "" {
+			m.minWidth, m.minHeight = minWidth, minHeight
+		}
+	})
+}
+
+func runTodoTUIE(cmd *cobra.Command, args []string) error {
+	defer resetTodoFlags(cmd)
+	return runTUIProgram(func(m *tuiModel) {
+		m.todosOnly = true
+		m.focus = focusTodos
+	})
+}
+
+// runTUIProgram opens the vault's index and runs the TUI over it until the
+// user quits, with setup adjusting the model first, then writes any changes
+// still queued.
+func runTUIProgram(setup func(*tuiModel)) error {
 	// Reconfigure the logger for TUI mode: the alt-screen suppresses
 	// interleaved log lines (mirrors the retired stubs.go behavior).
 	if err := logger.InitializeWithConfig(buildLoggerConfig(true)); err != nil {
@@ -50,14 +115,22 @@ func runTUIE(cmd *cobra.Command, args []string) error {
 	}
 
 	model := newTUIModel(ix, cfg)
+	setup(model)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	_, err = p.Run()
+	if ferr := model.flushOnExit(); ferr != nil {
+		return errors.Join(err, fmt.Errorf("tui: save queued changes: %w", ferr))
+	}
 	return err
 }
 
-// newTUIModel constructs the top-level model and its 4 pane wrappers.
+// newTUIModel constructs the top-level model and its 4 pane wrappers, with
+// each re-sortable pane in the order the vault settings last saved, log
+// times in the configured time_format, keys as tui_keys rebinds them, and
+// the configured save mode. An unreadable settings file is surfaced as the
+// model's error, not fatal: the defaults apply instead.
 func newTUIModel(ix *index.Index, cfg *config.Config) *tuiModel {
-	return &tuiModel{
+	m := &tuiModel{
 		ix:         ix,
 		cfg:        cfg,
 		vaultDir:   cfg.VaultDir,
@@ -67,5 +140,44 @@ func newTUIModel(ix *index.Index, cfg *config.Config) *tuiModel {
 		notes:      newNotesPane(),
 		datePicker: components.NewDatePicker("Date"),
 		textEntry:  components.NewTextEntryBar(),
+		jumpPicker: newJumpPicker(),
+		status:     components.NewStatusBar(),
+		summary:    components.NewSummaryView(),
+		reader:     components.NewJournalReader(),
+	}
+	m.status.SetClock(time.Now()) // the first ClockTickMsg is up to a minute away
+	settings, err := config.LoadSettings(cfg.VaultDir)
+	if err != nil {
+		m.lastErr = err
+		settings = config.DefaultSettings()
+	}
+	if err := m.setupSaveMode(settings); err != nil {
+		m.lastErr = err
+	}
+	m.keys = newTUIKeymap(settings.TUIKeys)
+	m.todos.newKey = m.keys.key("new")
+	m.log.view.SetEmptyHint("No log entries yet - press " + m.keys.key("new") + " to add one")
+	m.todos.sortMode = settings.TUISort.Todos
+	m.todos.view = settings.TUIView.Todos
+	m.layout = settings.TUIView.Layout
+	if w, h, err := config.ParseTerminalSize(settings.TUIView.MinSize); err == nil {
+		m.minWidth, m.minHeight = w, h
+	}
+	m.notes.sortMode = settings.TUISort.Notes
+	m.notes.picker.SetMinScore(settings.MatchThreshold)
+	m.notes.picker.SetMaxMatches(settings.MatchMaxCandidates)
+	m.log.view.SetSortOrder(logSortOrder(settings.TUISort.Log))
+	if layout, err := config.TimeLayout(settings.TimeFormat); err == nil {
+		m.log.view.SetTimeLayout(layout)
+	}
+	m.log.view.SetGapThreshold(time.Duration(settings.LogGapMinutes) * time.Minute)
+	return m
+}
+
+// logSortOrder maps a tui_sort.log setting onto components.LogView's order.
+func logSortOrder(setting string) components.LogSortOrder {
+	if setting == config.LogSortOldest {
+		return components.LogOldestFirst
 	}
+	return components.LogNewestFirst
 }
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/MikeBiancalana/reckon/internal/config"
 	"github.com/MikeBiancalana/reckon/internal/index"
 	"github.com/MikeBiancalana/reckon/internal/tui/components"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,28 +16,79 @@ import (
 
 // handleKey is the keyboard priority-chain dispatcher: sub-flow-input-active
 // > focused-pane-normal > global (Tab focus-cycle across the 4 fixed panes,
-// quit). Also hosts the agenda actuator sub-flow state machine: read-only
-// guard first, then no-arg keys (t/x/i/c) dispatch immediately while arg
-// keys (d/D/p) open an input sub-flow before dispatching. The todos/log/notes
-// creation flows (addDurableTodo, appendLogEntry, createNote) reuse the same
-// text-entry sub-flow shape via their own "n" key in each pane's handler
-// below.
+// ctrl+n full-screen notes browser, ctrl+s save, S day-summary overlay,
+// r journal reader, quit). Keys resolve to actions through m.keys, so the
+// tui_keys setting can rebind any of them; the comments below name the
+// default keys. Also hosts the agenda actuator sub-flow state machine:
+// read-only guard first, then no-arg keys (t/x/i/c) dispatch immediately
+// while arg keys (d/D/p) open an input sub-flow before dispatching. The
+// todos/log/notes creation flows (addDurableTodo, appendLogEntry,
+// createNote) reuse the same text-entry sub-flow shape via their own "n"
+// key in each pane's handler below.
 func (m *tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.lastInfo = ""
 	if m.inputMode == inputModeSubFlow {
 		return m.handleSubFlowKey(msg)
 	}
 
-	// Global keys (no pane currently binds these, so ordering against the
-	// focused-pane handlers below is a non-issue in practice).
-	switch msg.Type {
-	case tea.KeyTab:
+	// Global keys (tui_keys validation keeps any pane action off these, so
+	// ordering against the focused-pane handlers below is a non-issue).
+	// Tab also leaves the full-screen notes browser, since the other panes
+	// are hidden behind it. ctrl+c always quits, whatever is rebound.
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+	action := m.keys.action(m.focus, msg)
+	// The journal reader takes every key but ctrl+c: esc (or r again)
+	// returns to the panes, the rest scroll, down and up by a line
+	// whatever they are bound to.
+	if m.reader.IsVisible() {
+		switch action {
+		case "read":
+			m.reader.SetVisible(false)
+			return m, nil
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		}
+		if msg.Type == tea.KeyEsc {
+			m.reader.SetVisible(false)
+			return m, nil
+		}
+		return m, m.reader.Update(msg)
+	}
+	switch action {
+	case "next-pane", "zoom-notes":
+		if m.todosOnly {
+			return m, nil
+		}
+	}
+	switch action {
+	case "next-pane":
+		if m.notesZoom {
+			m.toggleNotesZoom()
+		}
 		m.focus = nextFocus(m.focus)
 		return m, nil
-	case tea.KeyCtrlC:
+	case "zoom-notes":
+		m.toggleNotesZoom()
+		return m, nil
+	case "save":
+		return m, m.flushCmd()
+	case "quit":
 		return m, tea.Quit
 	}
-	if msg.String() == "q" {
-		return m, tea.Quit
+	if m.summary.IsVisible() && msg.Type == tea.KeyEsc {
+		m.summary.SetVisible(false)
+		return m, nil
+	}
+	if action == "summary" && !m.todosOnly && !(m.focus == focusNotes && m.notes.picker.IsFiltering()) {
+		m.summary.Toggle()
+		return m, m.refreshSummaryCmd()
+	}
+	if action == "read" && !m.todosOnly && !(m.focus == focusNotes && m.notes.picker.IsFiltering()) {
+		return m, m.readJournalCmd()
 	}
 
 	switch m.focus {
@@ -62,15 +115,15 @@ func nextFocus(f tuiFocus) tuiFocus {
 // ─────────────────────────────────────────────────────────────────────────────
 
 func (m *tuiModel) handleAgendaKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "j", "down":
+	switch action := m.keys.action(focusAgenda, msg); action {
+	case "down":
 		m.agenda.moveDown()
 		return m, nil
-	case "k", "up":
+	case "up":
 		m.agenda.moveUp()
 		return m, nil
-	case "t", "d", "D", "p", "x", "i", "c":
-		return m.dispatchAgendaActuator(msg.String())
+	case "today", "defer", "deadline", "priority", "done", "start", "cancel":
+		return m.dispatchAgendaActuator(tuiActuatorKeys[action])
 	}
 	return m, nil
 }
@@ -81,6 +134,7 @@ func (m *tuiModel) handleAgendaKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // open an input sub-flow that dispatches on completion.
 func (m *tuiModel) dispatchAgendaActuator(key string) (tea.Model, tea.Cmd) {
 	m.lastErr = nil
+	m.lastWarn = ""
 
 	item, ok := m.agenda.selectedItem()
 	if !ok {
@@ -98,33 +152,45 @@ func (m *tuiModel) dispatchAgendaActuator(key string) (tea.Model, tea.Cmd) {
 	}
 
 	m.agenda.selectedID = item.ID
+	return m.dispatchActuator(item.ID, key)
+}
 
+// dispatchActuator opens the input sub-flow for an arg key (d/D/p) on the
+// todo id, or dispatches a no-arg key (t/x/i/c) straight away; the agenda
+// and todos panes share it.
+func (m *tuiModel) dispatchActuator(id, key string) (tea.Model, tea.Cmd) {
 	switch key {
 	case "d":
-		return m, m.startDateSubFlow(subFlowAgendaDefer, item.ID)
+		return m, m.startDateSubFlow(subFlowAgendaDefer, id)
 	case "D":
-		return m, m.startDateSubFlow(subFlowAgendaDeadline, item.ID)
+		return m, m.startDateSubFlow(subFlowAgendaDeadline, id)
 	case "p":
-		return m, m.startPrioritySubFlow(item.ID)
+		return m, m.startPrioritySubFlow(id)
 	default: // t, x, i, c: no argument, dispatch immediately
-		return m, m.actuateCmd(item.ID, key, "")
+		return m, m.trackMutation(m.actuateCmd(id, key, ""))
 	}
 }
 
-// actuateCmd calls dispatchTodayAct (the same function `rk today act`
-// calls) and reconciles the index on success, emitting mutationDoneMsg so
-// the model re-fires the agenda reload. noLog is always false here, matching
-// today.go:570-571's CLI default (--no-log defaults off) -- the
-// complete-as-logging behavior that's the point of the agenda pane's 'x'
-// key existing at all.
+// actuateCmd dispatches the agenda actuator key through dispatchTodayAct
+// (the same function `rk today act` calls; see applyTUIOp). noLog is always
+// false, matching today.go:570-571's CLI default (--no-log defaults off) --
+// the complete-as-logging behavior that's the point of the agenda pane's
+// 'x' key existing at all. A non-fatal date warning (scheduled after
+// deadline) rides back on the mutationDoneMsg for the status line.
 func (m *tuiModel) actuateCmd(ref, key, arg string) tea.Cmd {
-	vaultDir := m.vaultDir
-	ix := m.ix
+	return m.mutate(tuiOp{Op: tuiOpAct, Ref: ref, Key: key, Arg: arg})
+}
+
+// trackMutation counts cmd as pending until it settles, for the status
+// bar's unsaved marker: its result comes back wrapped in a
+// mutationSettledMsg, which Update unwraps after decrementing the count.
+func (m *tuiModel) trackMutation(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	m.pending++
 	return func() tea.Msg {
-		if _, err := dispatchTodayAct(vaultDir, ref, key, arg, false); err != nil {
-			return errMsg{err: err}
-		}
-		return reconcileDone(ix, "agenda")
+		return mutationSettledMsg{msg: cmd()}
 	}
 }
 
@@ -140,29 +206,116 @@ func reconcileDone(ix *index.Index, kind string) tea.Msg {
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
-// Todos pane: navigation plus "n" (new) to add a durable todo.
+// Todos pane: navigation, "n" (new) to add a durable todo, "g" to edit the
+// selected todo's tags, "o" to open the note it links, "s" to cycle the sort
+// order, and "v" to toggle between the flat and date-grouped views.
 // ─────────────────────────────────────────────────────────────────────────────
 
 func (m *tuiModel) handleTodosKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "j", "down":
+	switch action := m.keys.action(focusTodos, msg); action {
+	case "down":
 		m.todos.moveDown()
-	case "k", "up":
+	case "up":
 		m.todos.moveUp()
-	case "n":
+	case "new":
 		return m, m.startCreateSubFlow(subFlowAddTodo, components.ModeTask)
+	case "tags":
+		return m, m.startEditTagsSubFlow()
+	case "open-link":
+		if len(m.todos.items) == 0 || m.todosOnly {
+			return m, nil
+		}
+		return m, m.openLinkedNoteCmd(m.todos.items[m.todos.selected].Body)
+	case "today", "defer", "deadline", "priority", "done", "start", "cancel":
+		m.lastErr = nil
+		m.lastWarn = ""
+		if len(m.todos.items) == 0 {
+			return m, nil
+		}
+		it := m.todos.items[m.todos.selected]
+		if it.Kind != "durable" {
+			m.lastErr = fmt.Errorf("%q is an inbox item; only durable todos can be scheduled or changed here", it.Body)
+			return m, nil
+		}
+		m.todos.selectedID = it.ID
+		return m.dispatchActuator(it.ID, tuiActuatorKeys[action])
+	case "sort":
+		mode := config.TodoSortState
+		if m.todos.sortMode == config.TodoSortState {
+			mode = config.TodoSortPosition
+		}
+		m.todos.setSortMode(mode)
+		return m, m.saveSortCmd("tui_sort.todos", mode)
+	case "group":
+		view := config.TodoViewGrouped
+		if m.todos.view == config.TodoViewGrouped {
+			view = config.TodoViewFlat
+		}
+		m.todos.setView(view)
+		return m, m.saveSettingCmd("tui_view.todos", view, "view")
 	}
 	return m, nil
 }
 
+// saveSortCmd persists a pane's newly cycled sort order to the vault
+// settings file, so the next `rk tui` opens with it.
+func (m *tuiModel) saveSortCmd(key, value string) tea.Cmd {
+	return m.saveSettingCmd(key, value, "sort order")
+}
+
+// saveSettingCmd persists one toggled pane preference (what names it in an
+// error) to the vault settings file.
+func (m *tuiModel) saveSettingCmd(key, value, what string) tea.Cmd {
+	vaultDir := m.vaultDir
+	return func() tea.Msg {
+		if err := config.SetSetting(vaultDir, key, value); err != nil {
+			return errMsg{err: fmt.Errorf("tui: save %s: %w", what, err)}
+		}
+		return nil
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
-// Log pane: navigation (delegated to components.LogView) plus "n" (new) to
-// append a log entry.
+// Log pane: navigation (delegated to components.LogView), "n" (new) to
+// append a log entry, "L" to create a note and log a [[slug]] link to it,
+// "e" to edit the selected entry's text, "o" to open the note it links, "J"
+// to jump to a date, and "s" to flip between newest- and oldest-first.
 // ─────────────────────────────────────────────────────────────────────────────
 
 func (m *tuiModel) handleLogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.String() == "n" {
+	switch m.keys.action(focusLog, msg) {
+	case "down":
+		// The list moves itself on j and the arrows; a rebound key moves
+		// it as the down arrow would.
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	case "new":
 		return m, m.startCreateSubFlow(subFlowAddLog, components.ModeLog)
+	case "linked-note":
+		return m, m.startCreateSubFlow(subFlowLinkedNote, components.ModeNote)
+	case "edit":
+		return m, m.startEditLogSubFlow()
+	case "open-link":
+		entry := m.log.view.SelectedLogEntry()
+		if entry == nil {
+			return m, nil
+		}
+		return m, m.openLinkedNoteCmd(entry.Content)
+	case "sort":
+		order, setting := components.LogOldestFirst, config.LogSortOldest
+		if m.log.view.SortOrder() == components.LogOldestFirst {
+			order, setting = components.LogNewestFirst, config.LogSortNewest
+		}
+		m.log.view.SetSortOrder(order)
+		return m, m.saveSortCmd("tui_sort.log", setting)
+	case "jump":
+		m.lastErr = nil
+		m.lastWarn = ""
+		m.subFlow = subFlowLogJump
+		m.subFlowRef = ""
+		m.inputMode = inputModeSubFlow
+		return m, m.jumpPicker.Show()
 	}
 	var cmd tea.Cmd
 	m.log.view, cmd = m.log.view.Update(msg)
@@ -171,15 +324,25 @@ func (m *tuiModel) handleLogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Notes pane: browse (NotePicker) / inspect (NotesPane). "n" (new) in browse
-// mode creates a note, unless the picker is mid-filter-typing (its own "n"
-// keystrokes must reach the filter input, not be stolen as a shortcut).
+// mode creates a note and "s" flips between most recently updated and
+// created first, unless the picker is mid-filter-typing (its own keystrokes
+// must reach the filter input, not be stolen as shortcuts).
 // ─────────────────────────────────────────────────────────────────────────────
 
 func (m *tuiModel) handleNotesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.notes.mode == notesShowBrowse {
-		if msg.String() == "n" && !m.notes.picker.IsFiltering() {
+		action := m.keys.action(focusNotes, msg)
+		if action == "new" && !m.notes.picker.IsFiltering() {
 			return m, m.startCreateSubFlow(subFlowNewNote, components.ModeNote)
 		}
+		if action == "sort" && !m.notes.picker.IsFiltering() {
+			mode := config.NoteSortCreated
+			if m.notes.sortMode == config.NoteSortCreated {
+				mode = config.NoteSortUpdated
+			}
+			m.notes.setSortMode(mode)
+			return m, m.saveSortCmd("tui_sort.notes", mode)
+		}
 		var cmd tea.Cmd
 		m.notes.picker, cmd = m.notes.picker.Update(msg)
 		return m, cmd
@@ -241,6 +404,7 @@ func (m *tuiModel) cancelSubFlow() {
 	m.subFlowRef = ""
 	m.inputMode = inputModeNormal
 	m.datePicker.Hide()
+	m.jumpPicker.Hide()
 	m.textEntry.Blur()
 	m.textEntry.SetMode(components.ModeInactive)
 }
@@ -259,6 +423,15 @@ func (m *tuiModel) handleSubFlowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.finishCreateSubFlow(msg, m.addLogCmd)
 	case subFlowNewNote:
 		return m.finishCreateSubFlow(msg, m.createNoteCmd)
+	case subFlowLinkedNote:
+		return m.finishCreateSubFlow(msg, m.createLinkedNoteCmd)
+	case subFlowLogJump:
+		return m.handleJumpSubFlowKey(msg)
+	case subFlowEditLog:
+		ref := m.subFlowRef
+		return m.finishCreateSubFlow(msg, func(text string) tea.Cmd { return m.editLogCmd(ref, text) })
+	case subFlowEditTags:
+		return m.handleEditTagsSubFlowKey(msg)
 	}
 	m.cancelSubFlow()
 	return m, nil
@@ -286,7 +459,7 @@ func (m *tuiModel) handleDateSubFlowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		ref := m.subFlowRef
 		m.cancelSubFlow()
-		return m, m.actuateCmd(ref, key, date.Format("2006-01-02"))
+		return m, m.trackMutation(m.actuateCmd(ref, key, date.Format("2006-01-02")))
 	}
 	var cmd tea.Cmd
 	m.datePicker, cmd = m.datePicker.Update(msg)
@@ -309,13 +482,172 @@ func (m *tuiModel) handlePrioritySubFlowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 			m.lastErr = fmt.Errorf("today act: priority: invalid value %q (want A, B, or C)", val)
 			return m, nil
 		}
-		return m, m.actuateCmd(ref, "p", val)
+		return m, m.trackMutation(m.actuateCmd(ref, "p", val))
+	}
+	var cmd tea.Cmd
+	m.textEntry, cmd = m.textEntry.Update(msg)
+	return m, cmd
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Log pane edit sub-flow (e).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// startEditLogSubFlow opens the text-entry bar pre-filled with the selected
+// log entry's text, targeting its ID. TextEntryBar is single-line, so a
+// multi-line entry is refused rather than silently flattened; those are
+// edited in the day file directly.
+func (m *tuiModel) startEditLogSubFlow() tea.Cmd {
+	m.lastErr = nil
+	m.lastWarn = ""
+	entry := m.log.view.SelectedLogEntry()
+	if entry == nil {
+		return nil
+	}
+	if strings.Contains(entry.Content, "\n") {
+		m.lastErr = fmt.Errorf("tui: edit log: entry %s spans several lines; edit its day file instead", entry.ID)
+		return nil
+	}
+	m.subFlow = subFlowEditLog
+	m.subFlowRef = entry.ID
+	m.inputMode = inputModeSubFlow
+	m.textEntry.SetMode(components.ModeLog)
+	m.textEntry.SetValue(entry.Content)
+	return m.textEntry.Focus()
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Following a [[slug]] link out of a log entry or todo (o).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// noteLinkRe matches a [[slug]] link, capturing the slug without any #frag
+// or |label suffix.
+var noteLinkRe = regexp.MustCompile(`\[\[([^\]#|]+)[^\]]*\]\]`)
+
+// openLinkedNoteCmd follows the first [[slug]] in text: the linkedNoteMsg it
+// resolves to opens that note in the notes pane, or the new-note input
+// pre-filled with the slug when no note has it yet.
+func (m *tuiModel) openLinkedNoteCmd(text string) tea.Cmd {
+	m.lastErr = nil
+	m.lastWarn = ""
+	match := noteLinkRe.FindStringSubmatch(text)
+	if match == nil {
+		m.lastInfo = "no [[note]] link here"
+		return nil
+	}
+	slug := strings.TrimSpace(match[1])
+	db := m.ix.DB()
+	return func() tea.Msg {
+		id, err := resolveNoteIDBySlug(db, slug)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return linkedNoteMsg{slug: slug, id: id}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Todos pane tag-edit sub-flow (g).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// startEditTagsSubFlow opens the text-entry bar pre-filled with the
+// selected durable todo's tags, comma-separated. Inbox items carry no
+// frontmatter, so they have no tags to edit.
+func (m *tuiModel) startEditTagsSubFlow() tea.Cmd {
+	m.lastErr = nil
+	m.lastWarn = ""
+	if len(m.todos.items) == 0 {
+		return nil
+	}
+	it := m.todos.items[m.todos.selected]
+	if it.Kind != "durable" {
+		m.lastErr = fmt.Errorf("tui: edit tags: inbox items have no tags")
+		return nil
+	}
+	m.subFlow = subFlowEditTags
+	m.subFlowRef = it.ID
+	m.inputMode = inputModeSubFlow
+	m.textEntry.SetMode(components.ModeEditTags)
+	m.textEntry.SetValue(strings.Join(it.Tags, ", "))
+	return m.textEntry.Focus()
+}
+
+// handleEditTagsSubFlowKey finalizes the g sub-flow. Unlike the creation
+// flows an empty submission is meaningful: it clears the todo's tags.
+func (m *tuiModel) handleEditTagsSubFlowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.cancelSubFlow()
+		return m, nil
+	case tea.KeyEnter:
+		tags := parseTagInput(m.textEntry.GetValue())
+		ref := m.subFlowRef
+		m.cancelSubFlow()
+		return m, m.trackMutation(m.setTagsCmd(ref, tags))
 	}
 	var cmd tea.Cmd
 	m.textEntry, cmd = m.textEntry.Update(msg)
 	return m, cmd
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Log pane jump-to-date sub-flow (J).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// newJumpPicker builds the jump-to-date input. Unlike the agenda's
+// forward-looking d/D picker it resolves dates with the CLI's
+// resolveDateEndpoint (today, yesterday, -7d, -2w, YYYY-MM-DD), the same
+// parser behind `rk todo list --scheduled`, and refuses future dates: there
+// is no log to jump to yet.
+func newJumpPicker() *components.DatePicker {
+	dp := components.NewDatePicker("Jump to date")
+	dp.SetPlaceholder("YYYY-MM-DD, today, yesterday, -3d, -2w")
+	dp.SetParser(parseJumpDate)
+	return dp
+}
+
+// parseJumpDate resolves a jump-to-date input to a UTC date, rejecting any
+// date after today.
+func parseJumpDate(input string) (time.Time, error) {
+	today := journalNow()
+	day, err := resolveDateEndpoint(input, today)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if day > today.Format("2006-01-02") {
+		return time.Time{}, fmt.Errorf("%s is in the future", day)
+	}
+	return parseSchedDate(day)
+}
+
+// handleJumpSubFlowKey finalizes the J sub-flow. An unparsable or future
+// date keeps the picker open with its inline error; a valid one moves the
+// log pane's cursor to that day's newest entry (or the nearest older one).
+func (m *tuiModel) handleJumpSubFlowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.cancelSubFlow()
+		return m, nil
+	case tea.KeyEnter:
+		date, err := m.jumpPicker.ParsedDate()
+		if err != nil || date.IsZero() {
+			// Let the picker's own Enter handling render the error.
+			var cmd tea.Cmd
+			m.jumpPicker, cmd = m.jumpPicker.Update(msg)
+			return m, cmd
+		}
+		m.cancelSubFlow()
+		day := date.Format("2006-01-02")
+		if !m.log.view.SelectDate(day) {
+			m.lastWarn = fmt.Sprintf("no log entries on or before %s", day)
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.jumpPicker, cmd = m.jumpPicker.Update(msg)
+	return m, cmd
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Creation sub-flows: todos pane "n" (add todo), log pane "n" (add log),
 // notes pane "n" in browse mode (create note). Each finalizes the same way
@@ -326,8 +658,9 @@ func (m *tuiModel) handlePrioritySubFlowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 // `rk todo add`/`rk add` without duplicating its exact error text.
 // ─────────────────────────────────────────────────────────────────────────────
 
-// finishCreateSubFlow finalizes one of the 3 creation sub-flows, shared
-// since they differ only in which mutation cmd Enter dispatches.
+// finishCreateSubFlow finalizes one of the 3 creation sub-flows (or the log
+// pane's edit sub-flow), shared since they differ only in which mutation cmd
+// Enter dispatches.
 func (m *tuiModel) finishCreateSubFlow(msg tea.KeyMsg, dispatch func(text string) tea.Cmd) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.KeyEsc:
@@ -339,7 +672,7 @@ func (m *tuiModel) finishCreateSubFlow(msg tea.KeyMsg, dispatch func(text string
 		if text == "" {
 			return m, nil
 		}
-		return m, dispatch(text)
+		return m, m.trackMutation(dispatch(text))
 	}
 	var cmd tea.Cmd
 	m.textEntry, cmd = m.textEntry.Update(msg)
@@ -347,73 +680,73 @@ func (m *tuiModel) finishCreateSubFlow(msg tea.KeyMsg, dispatch func(text string
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
-// Creation mutation cmds: mirror actuateCmd's shape (verb call -> Reconcile
-// -> mutationDoneMsg), each capturing only plain values, never a pointer
-// into pane state (async-closure-capture pitfall, docs/REVIEW_PATTERNS.md).
+// Creation mutation cmds: each names its verb call as a tuiOp and hands it
+// to mutate (tui_save.go), which either runs it now (verb call -> Reconcile
+// -> mutationDoneMsg) or, in buffered save mode, queues it for the next
+// flush.
 // ─────────────────────────────────────────────────────────────────────────────
 
-// addTodoCmd calls addDurableTodo (the same verb `rk todo add` calls) with
-// only a body -- no scheduled/deadline/depends/repeat, v1's minimal add
-// flow -- and reconciles the index on success.
+// setTagsCmd replaces the durable todo ref's tags.
+func (m *tuiModel) setTagsCmd(ref string, tags []string) tea.Cmd {
+	return m.mutate(tuiOp{Op: tuiOpSetTags, Ref: ref, Text: strings.Join(tags, ",")})
+}
+
+// addTodoCmd adds a durable todo with only a body -- no scheduled/deadline/
+// depends/repeat, v1's minimal add flow.
 func (m *tuiModel) addTodoCmd(body string) tea.Cmd {
-	vaultDir := m.vaultDir
-	ix := m.ix
-	author := resolveAuthor("")
-	return func() tea.Msg {
-		todosDir := filepath.Join(vaultDir, "todos")
-		if err := os.MkdirAll(todosDir, 0o755); err != nil {
-			return errMsg{err: fmt.Errorf("tui: add todo: create todos dir: %w", err)}
-		}
-		if _, err := addDurableTodo(todosDir, author, body, "", "", "", ""); err != nil {
-			return errMsg{err: err}
-		}
-		return reconcileDone(ix, "todos")
-	}
+	return m.mutate(tuiOp{Op: tuiOpAddTodo, Text: body})
 }
 
-// addLogCmd calls appendLogEntry (the same verb `rk add` calls) for the
-// current day/time and reconciles the index on success.
+// addLogCmd appends a log entry stamped with the current day/time.
 func (m *tuiModel) addLogCmd(body string) tea.Cmd {
-	vaultDir := m.vaultDir
-	ix := m.ix
-	author := resolveAuthor("")
-	return func() tea.Msg {
-		logDir := filepath.Join(vaultDir, "log")
-		if err := os.MkdirAll(logDir, 0o755); err != nil {
-			return errMsg{err: fmt.Errorf("tui: add log: create log dir: %w", err)}
-		}
-		day := time.Now().UTC().Format("2006-01-02")
-		hhmm := time.Now().UTC().Format("15:04")
-		if _, err := appendLogEntry(logDir, day, hhmm, author, body); err != nil {
-			return errMsg{err: err}
-		}
-		return reconcileDone(ix, "log")
-	}
+	return m.mutate(tuiOp{Op: tuiOpAddLog, Text: body})
 }
 
-// createNoteCmd calls createNote (the same verb `rk note create` calls) with
-// only a title -- the slug is self-minted from it via slugify, matching
-// createNote's own aliasing convention, and Body stays empty (v1's minimal
-// create flow; TextEntryBar is single-line) -- and reconciles the index on
-// success.
+// editLogCmd replaces the text of log entry id.
+func (m *tuiModel) editLogCmd(id, body string) tea.Cmd {
+	return m.mutate(tuiOp{Op: tuiOpEditLog, Ref: id, Text: body})
+}
+
+// createNoteCmd creates a note with only a title -- the slug is self-minted
+// from it via slugify, matching createNote's own aliasing convention, and
+// Body stays empty (v1's minimal create flow; TextEntryBar is single-line).
 func (m *tuiModel) createNoteCmd(title string) tea.Cmd {
-	vaultDir := m.vaultDir
-	ix := m.ix
-	author := resolveAuthor("")
+	return m.mutate(tuiOp{Op: tuiOpNewNote, Text: title})
+}
+
+// createLinkedNoteCmd creates a note titled title, then logs a new entry
+// holding a [[slug]] link to it, so the log records where the note came
+// from and the note gets a backlink.
+func (m *tuiModel) createLinkedNoteCmd(title string) tea.Cmd {
+	return m.mutate(tuiOp{Op: tuiOpLinkedNote, Text: title})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Reading a day's journal (r).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// journalReadMsg carries a day file's raw markdown for the journal reader;
+// raw is "" when nothing was logged that day.
+type journalReadMsg struct {
+	day string
+	raw string
+}
+
+// readJournalCmd loads the day file the reader should show: the selected
+// entry's day in the log pane (so a jump to an old date reads that day),
+// today's everywhere else.
+func (m *tuiModel) readJournalCmd() tea.Cmd {
+	m.lastErr = nil
+	day := currentJournalDate()
+	if entry := m.log.view.SelectedLogEntry(); m.focus == focusLog && entry != nil {
+		day = entry.Timestamp.Format("2006-01-02")
+	}
+	path, _ := logDayFile(filepath.Join(m.vaultDir, "log"), day)
 	return func() tea.Msg {
-		notesDir := filepath.Join(vaultDir, "notes")
-		slug := slugify(title)
-		if err := validateSlug(slug); err != nil {
-			return errMsg{err: fmt.Errorf("tui: create note: %w", err)}
-		}
-		if _, err := createNote(notesDir, noteCreateParams{
-			Title:  title,
-			Slug:   slug,
-			Type:   "note",
-			Author: author,
-		}); err != nil {
-			return errMsg{err: err}
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return errMsg{err: fmt.Errorf("tui: read journal: %w", err)}
 		}
-		return reconcileDone(ix, "notes")
+		return journalReadMsg{day: day, raw: string(data)}
 	}
 }
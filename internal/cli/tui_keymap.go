@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"regexp"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiPaneNames names each pane as config.TUIKeyActions' Panes do.
+var tuiPaneNames = [...]string{focusAgenda: "agenda", focusTodos: "todos", focusLog: "log", focusNotes: "notes"}
+
+// tuiActuatorKeys maps each actuator action onto the `rk today act` key
+// dispatchActuator hands on, whatever key triggered it.
+var tuiActuatorKeys = map[string]string{
+	"today": "t", "done": "x", "start": "i", "cancel": "c",
+	"defer": "d", "deadline": "D", "priority": "p",
+}
+
+// tuiKeymap resolves key presses to `rk tui` actions: the tui_keys setting
+// over config.TUIKeyActions' defaults. The same key may mean different
+// actions in different panes, so lookups are per pane.
+type tuiKeymap struct {
+	keys  map[string]string            // action -> key
+	panes map[string]map[string]string // pane -> key -> action
+}
+
+// newTUIKeymap builds the keymap for keys, a tui_keys setting that
+// Settings validation has already checked for conflicts.
+func newTUIKeymap(keys map[string]string) tuiKeymap {
+	km := tuiKeymap{keys: config.TUIKeyBindings(keys), panes: map[string]map[string]string{}}
+	for _, a := range config.TUIKeyActions {
+		for _, pane := range a.Panes {
+			if km.panes[pane] == nil {
+				km.panes[pane] = map[string]string{}
+			}
+			km.panes[pane][km.keys[a.Name]] = a.Name
+		}
+	}
+	return km
+}
+
+// action returns the action msg triggers in pane f, or "" when it is
+// bound to none there. The arrow keys always move, whatever up and down
+// are bound to.
+func (k tuiKeymap) action(f tuiFocus, msg tea.KeyMsg) string {
+	switch key := msg.String(); key {
+	case "up", "down":
+		return key
+	default:
+		return k.panes[tuiPaneNames[f]][key]
+	}
+}
+
+// key returns the key bound to action.
+func (k tuiKeymap) key(action string) string { return k.keys[action] }
+
+// tuiHintActionRe matches an {action} placeholder in a hint template.
+var tuiHintActionRe = regexp.MustCompile(`\{([a-z-]+)\}`)
+
+// hints fills each {action} in template with the key bound to it, so the
+// status bar shows the keys in use rather than the defaults.
+func (k tuiKeymap) hints(template string) string {
+	return tuiHintActionRe.ReplaceAllStringFunc(template, func(p string) string {
+		return k.key(p[1 : len(p)-1])
+	})
+}
@@ -1,6 +1,16 @@
 package cli
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tuiStatusBarHeight is the number of rows the status bar takes below the
+// pane grid.
+const tuiStatusBarHeight = 1
 
 // paneDims is the computed width/height for each of the 4 fixed panes,
 // derived from the terminal's total width/height by calcPaneDims.
@@ -36,7 +46,8 @@ func calcPaneDims(w, h int) paneDims {
 }
 
 // handleWindowSize recomputes pane dimensions for a tea.WindowSizeMsg and
-// propagates them via each pane wrapper's SetSize.
+// propagates them via each pane wrapper's SetSize. The bottom
+// tuiStatusBarHeight rows belong to the status bar, not the panes.
 func (m *tuiModel) handleWindowSize(msg tea.WindowSizeMsg) tea.Cmd {
 	w, h := msg.Width, msg.Height
 	if w < 0 {
@@ -48,10 +59,68 @@ func (m *tuiModel) handleWindowSize(msg tea.WindowSizeMsg) tea.Cmd {
 	m.width = w
 	m.height = h
 
-	dims := calcPaneDims(w, h)
+	m.status.SetWidth(w)
+	m.reader.SetSize(w, max(0, h-tuiStatusBarHeight))
+	if m.singlePane() {
+		// Every pane gets the whole screen, so tab needs no re-layout.
+		paneH := max(0, h-tuiStatusBarHeight)
+		m.agenda.SetSize(w, paneH)
+		m.todos.SetSize(w, paneH)
+		m.log.SetSize(w, paneH)
+		m.notes.SetSize(w, paneH)
+		return nil
+	}
+	dims := calcPaneDims(w, h-tuiStatusBarHeight)
 	m.agenda.SetSize(dims.agendaWidth, dims.agendaHeight)
 	m.todos.SetSize(dims.todosWidth, dims.todosHeight)
 	m.log.SetSize(dims.logWidth, dims.logHeight)
 	m.notes.SetSize(dims.notesWidth, dims.notesHeight)
+	if m.notesZoom {
+		m.notes.SetSize(w, h-tuiStatusBarHeight)
+	}
 	return nil
 }
+
+// singlePane reports whether the focused pane alone fills the screen: always
+// in `rk todo tui` and the focus layout, and in the auto layout on a
+// terminal under the grid's minimum size (tui_view.min_size or --min-size).
+func (m *tuiModel) singlePane() bool {
+	if m.todosOnly {
+		return true
+	}
+	switch m.layout {
+	case config.TUILayoutFocus:
+		return true
+	case config.TUILayoutGrid:
+		return false
+	}
+	return m.width < m.minWidth || m.height < m.minHeight
+}
+
+// toggleNotesZoom switches the full-screen notes browser on (focusing the
+// notes pane) or back off, re-laying the panes out for the current size.
+func (m *tuiModel) toggleNotesZoom() {
+	m.notesZoom = !m.notesZoom
+	if m.notesZoom {
+		m.focus = focusNotes
+	}
+	m.handleWindowSize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+}
+
+// overlayCenter draws box over the vertically-centred rows of base, each
+// box row centred across width; the rows it covers are replaced whole.
+func overlayCenter(base, box string, width int) string {
+	if box == "" {
+		return base
+	}
+	rows := strings.Split(base, "\n")
+	boxRows := strings.Split(box, "\n")
+	top := max(0, (len(rows)-len(boxRows))/2)
+	for i, br := range boxRows {
+		if top+i >= len(rows) {
+			rows = append(rows, "")
+		}
+		rows[top+i] = lipgloss.PlaceHorizontal(width, lipgloss.Center, br)
+	}
+	return strings.Join(rows, "\n")
+}
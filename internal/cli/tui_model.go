@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MikeBiancalana/reckon/internal/config"
 	"github.com/MikeBiancalana/reckon/internal/index"
@@ -37,7 +39,9 @@ const (
 // tuiSubFlowKind identifies which text-capture sub-flow (tui_keyboard.go) is
 // currently stealing key events, when inputMode is inputModeSubFlow: an
 // agenda actuator arg (defer/deadline/priority) or one of the 3 pane
-// creation flows (add todo, add log, new note).
+// creation flows (add todo, add log, new note), or one of the log pane's
+// jump-to-date, edit-entry, and linked-note inputs, or the todos pane's
+// tag editor.
 type tuiSubFlowKind int
 
 const (
@@ -48,6 +52,10 @@ const (
 	subFlowAddTodo
 	subFlowAddLog
 	subFlowNewNote
+	subFlowLogJump
+	subFlowEditLog
+	subFlowLinkedNote
+	subFlowEditTags
 )
 
 // tuiModel is the top-level bubbletea model for `rk tui`: a persistent
@@ -69,16 +77,66 @@ type tuiModel struct {
 
 	// subFlow/subFlowRef track the in-progress agenda actuator arg capture
 	// (d/D/p); datePicker and textEntry are the two widgets those sub-flows
-	// drive.
+	// drive. jumpPicker is the log pane's backward-looking jump-to-date
+	// input (J).
 	subFlow    tuiSubFlowKind
 	subFlowRef string
 	datePicker *components.DatePicker
 	textEntry  *components.TextEntryBar
+	jumpPicker *components.DatePicker
+
+	// status is the bottom status bar (date, clock, unsaved marker, key
+	// hints); pending counts mutation cmds dispatched but not yet settled,
+	// which together with an open sub-flow drives its unsaved marker.
+	status  *components.StatusBar
+	pending int
+
+	// buffer queues writes in buffered save mode (nil in immediate mode,
+	// the default); flushEvery is its periodic flush interval.
+	buffer     *tuiWriteBuffer
+	flushEvery time.Duration
+
+	// notesZoom is the full-screen notes browser (ctrl+n): the notes pane
+	// takes the whole grid until toggled off again.
+	notesZoom bool
+
+	// layout is the tui_view.layout setting: the pane grid, one pane at a
+	// time, or whichever fits the terminal (see singlePane), the grid
+	// needing at least minWidth x minHeight.
+	layout              string
+	minWidth, minHeight int
+
+	// summary is the day-summary overlay (S); day is the last
+	// buildDaySummary result it shows, nil until the first load.
+	summary *components.SummaryView
+	day     *daySummary
+
+	// reader is the full-screen, read-only journal reader (r).
+	reader *components.JournalReader
+
+	// noAutoCarry (--no-auto-carry) drops carried rows from every agenda
+	// load.
+	noAutoCarry bool
+
+	// keys maps key presses to actions, as rebound by the tui_keys
+	// setting.
+	keys tuiKeymap
+
+	// todosOnly is `rk todo tui`: the todos pane alone, full screen, with
+	// no way to the other panes (tab, ctrl+n, S, r, and o do nothing).
+	todosOnly bool
 
 	width  int
 	height int
 
-	lastErr error
+	// externalChanges counts files changed outside the TUI since its
+	// last reload (tui_watch.go); lastInfo is a passing status note,
+	// cleared on the next key.
+	externalChanges int
+	lastInfo        string
+
+	lastErr  error
+	lastWarn string // last mutation's non-fatal warning; cleared like lastErr
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -102,24 +160,48 @@ type logLoadedMsg struct {
 	entries []components.LogEntryRow
 }
 
-// notesListLoadedMsg carries listNotes's result (tui_read.go).
+// notesListLoadedMsg carries listNotes's and countUnresolvedLinks's
+// results (tui_read.go).
 type notesListLoadedMsg struct {
-	notes []*models.Note
+	notes      []*models.Note
+	unresolved int
 }
 
-// notesLinksLoadedMsg carries loadNotesPaneLinks's result (tui_read.go) for
-// one note.
+// notesLinksLoadedMsg carries loadNotesPaneLinks's and loadNoteContent's
+// results (tui_read.go) for one note.
 type notesLinksLoadedMsg struct {
 	noteID    string
+	title     string
+	body      string
 	outgoing  []components.LinkDisplayItem
 	backlinks []components.LinkDisplayItem
 }
 
+// linkedNoteMsg carries the note the "o" key's [[slug]] names: its id, or
+// "" when no note has that slug yet.
+type linkedNoteMsg struct {
+	slug string
+	id   string
+}
+
 // mutationDoneMsg signals a verb call (addDurableTodo, dispatchTodayAct,
-// appendLogEntry, createNote) completed and the index was reconciled; the
+// appendLogEntry, editLogEntry, createNote) completed and the index was reconciled; the
 // model responds by re-firing the affected pane's load cmd.
 type mutationDoneMsg struct {
-	kind string
+	kind    string
+	warning string // non-fatal problem to surface, e.g. scheduled after deadline
+}
+
+// mutationSettledMsg wraps whatever a tracked mutation cmd (trackMutation,
+// tui_keyboard.go) returned, so Update can retire it from the pending count
+// before handling the inner mutationDoneMsg/errMsg as usual.
+type mutationSettledMsg struct {
+	msg tea.Msg
+}
+
+// summaryLoadedMsg carries buildDaySummary's result (day_summary.go).
+type summaryLoadedMsg struct {
+	summary daySummary
 }
 
 // errMsg carries an error from any async load or mutation cmd.
@@ -131,14 +213,22 @@ type errMsg struct {
 // tea.Model
 // ─────────────────────────────────────────────────────────────────────────────
 
-// Init batches the 4 initial pane load cmds.
+// Init batches the 4 initial pane load cmds and arms the status bar clock
+// and the external-change poll (and, in buffered save mode, the flush
+// timer).
 func (m *tuiModel) Init() tea.Cmd {
-	return tea.Batch(m.loadAgendaCmd(), m.loadTodosCmd(), m.loadLogCmd(), m.loadNotesListCmd())
+	cmds := []tea.Cmd{m.loadAgendaCmd(), m.loadTodosCmd(), m.loadLogCmd(), m.loadNotesListCmd(), components.ClockTick(), m.watchTick()}
+	if m.buffer != nil {
+		cmds = append(cmds, m.flushTick())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update is the flat msg.(type) dispatcher for every message tuiModel
 // handles: window resize (tui_layout.go), key input (tui_keyboard.go), the
-// 4 panes' async load results, mutation completion, and errors.
+// 4 panes' async load results (which also refresh a showing day summary),
+// mutation completion, errors, the status bar's once-a-minute clock tick,
+// and the external-change poll (tui_watch.go).
 func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -154,30 +244,30 @@ func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.agenda.items = items
 		m.agenda.reselect()
-		return m, nil
+		return m, m.refreshSummaryCmd()
 
 	case todosLoadedMsg:
 		items := msg.items
 		if items == nil {
 			items = []todoListItem{}
 		}
-		m.todos.items = items
-		m.todos.reselect()
-		return m, nil
+		m.todos.setItems(items)
+		return m, m.refreshSummaryCmd()
 
 	case logLoadedMsg:
 		m.log.view.UpdateLogEntries(msg.entries)
-		return m, nil
+		return m, m.refreshSummaryCmd()
 
 	case notesListLoadedMsg:
-		m.notes.notes = msg.notes
-		m.notes.picker.Show(msg.notes)
+		m.notes.unresolved = msg.unresolved
+		m.notes.setNotes(msg.notes)
 		return m, nil
 
 	case components.NotePickerSelectMsg:
 		return m, m.selectNoteCmd(msg.NoteSlug)
 
 	case notesLinksLoadedMsg:
+		m.notes.setPreview(msg.title, msg.body)
 		m.notes.links.UpdateLinks(msg.noteID, msg.outgoing, msg.backlinks)
 		m.notes.links.SetFocused(true)
 		m.notes.mode = notesShowInspect
@@ -191,7 +281,57 @@ func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.notes.links.SetLoading(msg.NoteID, true)
 		return m, m.loadNotesLinksCmd(msg.NoteID)
 
+	case linkedNoteMsg:
+		if msg.id == "" {
+			m.subFlow = subFlowNewNote
+			m.subFlowRef = ""
+			m.inputMode = inputModeSubFlow
+			m.textEntry.SetMode(components.ModeNote)
+			m.textEntry.SetValue(msg.slug)
+			return m, m.textEntry.Focus()
+		}
+		m.focus = focusNotes
+		return m, m.loadNotesLinksCmd(msg.id)
+
+	case summaryLoadedMsg:
+		m.day = &msg.summary
+		return m, nil
+
+	case journalReadMsg:
+		m.reader.SetContent(msg.day, msg.raw)
+		m.reader.SetVisible(true)
+		return m, nil
+
+	case components.ClockTickMsg:
+		m.status.SetClock(time.Time(msg))
+		return m, components.ClockTick()
+
+	case tuiFlushTickMsg:
+		return m, tea.Batch(m.flushCmd(), m.flushTick())
+
+	case tuiFlushedMsg:
+		return m, m.handleFlushed(msg)
+
+	case tuiWatchTickMsg:
+		if m.pending > 0 {
+			return m, m.watchTick()
+		}
+		return m, m.watchCmd()
+
+	case tuiWatchedMsg:
+		return m, tea.Batch(m.watchTick(), m.handleWatched(msg))
+
+	case mutationSettledMsg:
+		if m.pending > 0 {
+			m.pending--
+		}
+		if msg.msg == nil {
+			return m, nil
+		}
+		return m.Update(msg.msg)
+
 	case mutationDoneMsg:
+		m.lastWarn = msg.warning
 		return m, m.reloadCmdFor(msg.kind)
 
 	case errMsg:
@@ -201,37 +341,170 @@ func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// View renders the modal-state branch (agenda actuator arg sub-flow) or
-// falls through to the 4-pane layout.
+// View renders the modal-state branch (agenda actuator arg sub-flow), the
+// journal reader, or falls through to the 4-pane layout, or just the focused pane in the
+// single-pane layout; either way the status bar is the last line.
 func (m *tuiModel) View() string {
+	m.syncStatusBar()
 	if m.inputMode == inputModeSubFlow {
 		switch m.subFlow {
 		case subFlowAgendaDefer, subFlowAgendaDeadline:
-			return m.datePicker.View()
-		case subFlowAgendaPriority, subFlowAddTodo, subFlowAddLog, subFlowNewNote:
-			return m.textEntry.View()
+			return m.datePicker.View() + "\n" + m.status.View()
+		case subFlowAgendaPriority, subFlowAddTodo, subFlowAddLog, subFlowNewNote, subFlowEditLog, subFlowLinkedNote, subFlowEditTags:
+			return m.textEntry.View() + "\n" + m.status.View()
+		case subFlowLogJump:
+			return m.jumpPicker.View() + "\n" + m.status.View()
 		}
 	}
 
-	agendaBox := renderPaneBox("Agenda", m.focus == focusAgenda, m.agenda.width, m.agenda.height, renderAgendaBody(m.agenda))
-	todosBox := renderPaneBox("Todos", m.focus == focusTodos, m.todos.width, m.todos.height, renderTodosBody(m.todos))
-	logBox := renderPaneBox("Log", m.focus == focusLog, m.log.width, m.log.height, m.log.view.View())
+	if m.reader.IsVisible() {
+		return m.reader.View() + m.statusLine()
+	}
+
 	var notesBody string
-	if m.notes.mode == notesShowBrowse {
+	switch {
+	case m.notes.mode == notesShowBrowse && len(m.notes.notes) == 0:
+		notesBody = tuiHintStyle.Render("No notes — press " + m.keys.key("new") + " to create one")
+	case m.notes.mode == notesShowBrowse:
 		notesBody = m.notes.picker.View()
-	} else {
-		notesBody = m.notes.links.View()
+	default:
+		notesBody = m.notes.inspectView()
+	}
+	notesTitle := "Notes"
+	if m.notes.sortMode == config.NoteSortCreated {
+		notesTitle += " · by created"
+	}
+	if m.notesZoom {
+		body := renderPaneBox(notesTitle, true, m.notes.width, m.notes.height, notesBody)
+		return m.withSummary(body) + m.statusLine()
+	}
+
+	todosTitle, logTitle := "Todos", "Log"
+	if m.todos.sortMode == config.TodoSortState {
+		todosTitle += " · by state"
+	}
+	if m.todos.view == config.TodoViewGrouped {
+		todosTitle += " · grouped"
+	}
+	if m.log.view.SortOrder() == components.LogOldestFirst {
+		logTitle += " · oldest first"
+	}
+	if m.singlePane() {
+		var body string
+		switch m.focus {
+		case focusAgenda:
+			body = renderPaneBox("Agenda", true, m.agenda.width, m.agenda.height, renderAgendaBody(m.agenda))
+		case focusTodos:
+			body = renderPaneBox(todosTitle, true, m.todos.width, m.todos.height, renderTodosBody(m.todos))
+		case focusLog:
+			body = renderPaneBox(logTitle, true, m.log.width, m.log.height, m.log.view.View())
+		default:
+			body = renderPaneBox(notesTitle, true, m.notes.width, m.notes.height, notesBody)
+		}
+		return m.withSummary(body) + m.statusLine()
 	}
-	notesBox := renderPaneBox("Notes", m.focus == focusNotes, m.notes.width, m.notes.height, notesBody)
+
+	agendaBox := renderPaneBox("Agenda", m.focus == focusAgenda, m.agenda.width, m.agenda.height, renderAgendaBody(m.agenda))
+	todosBox := renderPaneBox(todosTitle, m.focus == focusTodos, m.todos.width, m.todos.height, renderTodosBody(m.todos))
+	logBox := renderPaneBox(logTitle, m.focus == focusLog, m.log.width, m.log.height, m.log.view.View())
+	notesBox := renderPaneBox(notesTitle, m.focus == focusNotes, m.notes.width, m.notes.height, notesBody)
 
 	left := lipgloss.JoinVertical(lipgloss.Left, agendaBox, todosBox)
 	right := lipgloss.JoinVertical(lipgloss.Left, logBox, notesBox)
 	body := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+	return m.withSummary(body) + m.statusLine()
+}
 
+// withSummary overlays the day-summary box, when shown, on the middle rows
+// of body.
+func (m *tuiModel) withSummary(body string) string {
+	if !m.summary.IsVisible() {
+		return body
+	}
+	var sum *components.DaySummary
+	if m.day != nil {
+		sum = &components.DaySummary{
+			Date: m.day.Date, TodosDone: m.day.TodosDone, TodosTotal: m.day.TodosTotal,
+			Wins: m.day.Wins, LogEntries: m.day.LogEntries,
+			FirstEntry: m.day.FirstEntry, LastEntry: m.day.LastEntry, Active: formatMinutes(m.day.ActiveMinutes),
+		}
+		if counts := m.statusCounts(); counts != nil {
+			sum.OpenTodos, sum.DueTodos = counts.OpenTodos, counts.Due
+		}
+	}
+	m.summary.SetSummary(sum)
+	m.summary.SetWidth(m.width)
+	return overlayCenter(body, m.summary.View(), m.width)
+}
+
+// statusLine renders what follows the panes: the last error, warning, or
+// status note, if any, then the status bar.
+func (m *tuiModel) statusLine() string {
+	var out string
 	if m.lastErr != nil {
-		return body + "\n" + tuiErrStyle.Render("error: "+m.lastErr.Error())
+		out += "\n" + tuiErrStyle.Render("error: "+m.lastErr.Error())
+	} else if m.lastWarn != "" {
+		out += "\n" + tuiErrStyle.Render("warning: "+m.lastWarn)
+	} else if m.lastInfo != "" {
+		out += "\n" + tuiHintStyle.Render(m.lastInfo)
+	}
+	return out + "\n" + m.status.View()
+}
+
+// tuiPaneHints is the status bar's key-hint text per focused pane, with
+// each {action} standing for the key bound to it (tuiKeymap.hints).
+var tuiPaneHints = map[tuiFocus]string{
+	focusAgenda: "{down}/{up}:move {today}:today {done}:done {start}:start {cancel}:cancel {defer}:defer {deadline}:deadline {priority}:priority {summary}:summary {read}:read day {next-pane}:pane {quit}:quit",
+	focusTodos:  "{down}/{up}:move {new}:new {done}:done {start}:start {cancel}:cancel {defer}:defer {deadline}:deadline {priority}:priority {tags}:tags {open-link}:open [[note]] {sort}:sort {group}:group {summary}:summary {read}:read day {next-pane}:pane {quit}:quit",
+	focusLog:    "{down}/{up}:move {new}:new {linked-note}:new linked note {edit}:edit {open-link}:open [[note]] {jump}:jump to date {sort}:sort {summary}:summary {read}:read day {next-pane}:pane {quit}:quit",
+	focusNotes:  "{new}:new /:filter {sort}:sort enter:open esc:back {zoom-notes}:full screen {summary}:summary {read}:read day {next-pane}:pane {quit}:quit",
+}
+
+// tuiTodosOnlyHints is the status bar's key-hint text in `rk todo tui`.
+const tuiTodosOnlyHints = "{down}/{up}:move {new}:new {done}:done {start}:start {cancel}:cancel {defer}:defer {deadline}:deadline {priority}:priority {today}:today {tags}:tags {sort}:sort {group}:group {quit}:quit"
+
+// syncStatusBar copies the model state the status bar reflects onto it just
+// before rendering: today's date, the ambient counts, the focused pane's
+// hints, and the unsaved marker (an open sub-flow holds unsubmitted input;
+// a pending mutation has not been written and reconciled yet; a buffered
+// one waits for a flush).
+func (m *tuiModel) syncStatusBar() {
+	m.status.SetDate(currentJournalDate())
+	m.status.SetCounts(m.statusCounts())
+	hints := m.keys.hints(tuiPaneHints[m.focus])
+	if m.todosOnly {
+		hints = m.keys.hints(tuiTodosOnlyHints)
+	}
+	switch {
+	case m.inputMode == inputModeSubFlow:
+		m.status.SetHints("enter:submit esc:cancel")
+	case m.reader.IsVisible():
+		m.status.SetHints(m.keys.hints("{down}/{up}:scroll f/b:page esc:back"))
+	case m.buffer != nil:
+		m.status.SetHints("ctrl+s:save " + hints)
+	default:
+		m.status.SetHints(hints)
+	}
+	queued := m.buffer != nil && len(m.buffer.ops) > 0
+	m.status.SetDirty(m.inputMode == inputModeSubFlow || m.pending > 0 || queued)
+}
+
+// statusCounts derives the status bar's counts from the last loaded panes:
+// open todos from the todos pane, due ones from the agenda (native rows
+// whose deadline is today or past), unresolved links from the notes load.
+// Nil until the todos pane has loaded once, so startup shows no zeros.
+func (m *tuiModel) statusCounts() *components.StatusCounts {
+	if m.todos.loaded == nil {
+		return nil
+	}
+	today := currentJournalDate()
+	counts := &components.StatusCounts{OpenTodos: len(m.todos.loaded), UnresolvedLinks: m.notes.unresolved}
+	for _, it := range m.agenda.items {
+		if !it.ReadOnly && it.Deadline != "" && it.Deadline <= today {
+			counts.Due++
+		}
 	}
-	return body
+	return counts
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -243,12 +516,16 @@ func (m *tuiModel) View() string {
 
 func (m *tuiModel) loadAgendaCmd() tea.Cmd {
 	db := m.ix.DB()
-	today := todoNow().Format("2006-01-02")
+	today := currentJournalDate()
+	noAutoCarry := m.noAutoCarry
 	return func() tea.Msg {
 		items, warnings, err := buildAgenda(db, today)
 		if err != nil {
 			return errMsg{err: err}
 		}
+		if noAutoCarry {
+			items = dropCarried(items, today)
+		}
 		return agendaLoadedMsg{items: items, warnings: warnings}
 	}
 }
@@ -287,21 +564,56 @@ func (m *tuiModel) loadNotesListCmd() tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return notesListLoadedMsg{notes: notes}
+		unresolved, err := countUnresolvedLinks(db)
+		if err != nil {
+			return errMsg{err: fmt.Errorf("tui: %w", err)}
+		}
+		return notesListLoadedMsg{notes: notes, unresolved: unresolved}
 	}
 }
 
-func (m *tuiModel) loadNotesLinksCmd(noteID string) tea.Cmd {
+func (m *tuiModel) loadSummaryCmd() tea.Cmd {
 	db := m.ix.DB()
+	today := currentJournalDate()
 	return func() tea.Msg {
-		outgoing, backlinks, err := loadNotesPaneLinks(db, noteID)
+		sum, err := buildDaySummary(db, today)
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return notesLinksLoadedMsg{noteID: noteID, outgoing: outgoing, backlinks: backlinks}
+		return summaryLoadedMsg{summary: sum}
+	}
+}
+
+// refreshSummaryCmd recomputes the day summary after a pane reload, but
+// only while the overlay is showing; toggling it on loads it fresh.
+func (m *tuiModel) refreshSummaryCmd() tea.Cmd {
+	if !m.summary.IsVisible() {
+		return nil
+	}
+	return m.loadSummaryCmd()
+}
+
+func (m *tuiModel) loadNotesLinksCmd(noteID string) tea.Cmd {
+	db := m.ix.DB()
+	return func() tea.Msg {
+		return loadNoteInspectMsg(db, noteID)
 	}
 }
 
+// loadNoteInspectMsg loads everything the notes pane's inspect mode shows
+// for noteID: its title and body, then its links.
+func loadNoteInspectMsg(db *sql.DB, noteID string) tea.Msg {
+	title, body, err := loadNoteContent(db, noteID)
+	if err != nil {
+		return errMsg{err: err}
+	}
+	outgoing, backlinks, err := loadNotesPaneLinks(db, noteID)
+	if err != nil {
+		return errMsg{err: err}
+	}
+	return notesLinksLoadedMsg{noteID: noteID, title: title, body: body, outgoing: outgoing, backlinks: backlinks}
+}
+
 // selectNoteCmd resolves a NotePickerSelectMsg's slug to a note id and fires
 // the link-graph load for it.
 func (m *tuiModel) selectNoteCmd(slug string) tea.Cmd {
@@ -314,11 +626,7 @@ func (m *tuiModel) selectNoteCmd(slug string) tea.Cmd {
 		if id == "" {
 			return errMsg{err: fmt.Errorf("tui: notes pane: no note found matching slug %q", slug)}
 		}
-		outgoing, backlinks, err := loadNotesPaneLinks(db, id)
-		if err != nil {
-			return errMsg{err: err}
-		}
-		return notesLinksLoadedMsg{noteID: id, outgoing: outgoing, backlinks: backlinks}
+		return loadNoteInspectMsg(db, id)
 	}
 }
 
@@ -335,6 +643,8 @@ func (m *tuiModel) reloadCmdFor(kind string) tea.Cmd {
 		return m.loadLogCmd()
 	case "notes":
 		return m.loadNotesListCmd()
+	case "log+notes":
+		return tea.Batch(m.loadLogCmd(), m.loadNotesListCmd())
 	}
 	return nil
 }
@@ -358,6 +668,9 @@ var (
 	tuiPaneTitleStyle = lipgloss.NewStyle().Bold(true)
 
 	tuiErrStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	// tuiHintStyle dims a pane's empty-state guidance.
+	tuiHintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 )
 
 // paneContentDims computes the usable interior width/height for pane content
@@ -414,7 +727,7 @@ func truncateRow(line string, width int) string {
 // renderAgendaBody renders the agenda pane's hand-rolled row list.
 func renderAgendaBody(p *agendaPane) string {
 	if len(p.items) == 0 {
-		return "today: nothing due"
+		return tuiHintStyle.Render("Nothing due today")
 	}
 	innerW, _ := paneContentDims(p.width, p.height)
 	var b strings.Builder
@@ -434,15 +747,29 @@ func renderAgendaBody(p *agendaPane) string {
 	return b.String()
 }
 
+// todoPinGlyph marks a pinned todo's row in the todos pane.
+const todoPinGlyph = "📌"
+
 // renderTodosBody renders the todos pane's hand-rolled, subject-only row
-// list: the item's Title (or Body as fallback), never the full node body.
+// list: the item's Title (or Body as fallback), never the full node body,
+// with pinned rows marked. The grouped view heads each date group's rows
+// with its title.
 func renderTodosBody(p *todosPane) string {
 	if len(p.items) == 0 {
-		return "todo: no items"
+		return tuiHintStyle.Render("No todos — press " + p.newKey + " to add one")
 	}
 	innerW, _ := paneContentDims(p.width, p.height)
+	now := journalNow()
+	group := -1
 	var b strings.Builder
 	for i, it := range p.items {
+		if p.view == config.TodoViewGrouped {
+			if g := todoTimeGroup(it, now); g != group {
+				group = g
+				b.WriteString(tuiPaneTitleStyle.Render(todoGroupTitles[g]))
+				b.WriteString("\n")
+			}
+		}
 		cursor := "  "
 		if i == p.selected {
 			cursor = "> "
@@ -451,9 +778,76 @@ func renderTodosBody(p *todosPane) string {
 		if text == "" {
 			text = it.Body
 		}
+		if it.Pinned {
+			text = todoPinGlyph + " " + text
+		}
+		// Clip the title rather than the row so a narrow pane still shows
+		// the date badges.
+		badges := todoDateBadges(it, now)
+		if badges != "" && innerW > 0 {
+			room := innerW - lipgloss.Width(cursor) - lipgloss.Width(badges) - 1
+			if room < 1 {
+				room = 1
+			}
+			text = truncateRow(text, room) + " " + badges
+		}
 		line := cursor + text
 		b.WriteString(truncateRow(line, innerW))
 		b.WriteString("\n")
 	}
 	return b.String()
 }
+
+// todoDateBadges renders its deadline and scheduled dates as compact badges
+// ("⏰ tue 📅 fri"), relative to now; a date already past is shown red as
+// "Nd ago". Done items and items without dates get none.
+func todoDateBadges(it todoListItem, now time.Time) string {
+	if it.State == "done" {
+		return ""
+	}
+	var badges []string
+	for _, d := range []struct{ icon, date string }{{"⏰", it.Deadline}, {"📅", it.Scheduled}} {
+		if badge := todoDateBadge(d.icon, d.date, now); badge != "" {
+			badges = append(badges, badge)
+		}
+	}
+	return strings.Join(badges, " ")
+}
+
+// todoDateBadge renders one YYYY-MM-DD date as icon plus a short relative
+// description; an empty or unparseable date renders nothing.
+func todoDateBadge(icon, date string, now time.Time) string {
+	if date == "" {
+		return ""
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return ""
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if day.Before(today) {
+		ago := int(today.Sub(day).Hours() / 24)
+		return tuiErrStyle.Render(fmt.Sprintf("%s %dd ago", icon, ago))
+	}
+	return icon + " " + shortDateDescription(components.GetDateDescriptionFrom(day, today))
+}
+
+// shortDateDescription compacts a GetDateDescription phrase to fit a badge:
+// "Tuesday" → "tue", "tomorrow" → "tmrw", "in 2 weeks" → "2w",
+// "Jan 2, 2006" → "Jan 2".
+func shortDateDescription(desc string) string {
+	switch {
+	case desc == "today":
+		return desc
+	case desc == "tomorrow":
+		return "tmrw"
+	case strings.HasPrefix(desc, "in ") && strings.Contains(desc, " week"):
+		return strings.Fields(desc)[1] + "w"
+	case strings.Contains(desc, ", "):
+		md, _, _ := strings.Cut(desc, ", ")
+		return md
+	case len(desc) >= 3:
+		return strings.ToLower(desc[:3])
+	}
+	return desc
+}
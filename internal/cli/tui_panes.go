@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/MikeBiancalana/reckon/internal/config"
 	"github.com/MikeBiancalana/reckon/internal/models"
 	"github.com/MikeBiancalana/reckon/internal/tui/components"
 )
@@ -93,14 +97,114 @@ func (p *agendaPane) reselect() {
 // displays Title only, never the full body).
 type todosPane struct {
 	items      []todoListItem
+	loaded     []todoListItem // items in load order, before sortMode is applied
+	sortMode   string         // config.TodoSortPosition | config.TodoSortState
+	view       string         // config.TodoViewFlat | config.TodoViewGrouped
 	selected   int
 	selectedID string
+	newKey     string // the key that adds a todo, for the empty-pane hint
 	width      int
 	height     int
 }
 
 func newTodosPane() *todosPane {
-	return &todosPane{}
+	return &todosPane{sortMode: config.TodoSortPosition, view: config.TodoViewFlat, newKey: "n"}
+}
+
+// setItems replaces the pane's rows with a fresh load, listed in sortMode
+// order (within each date group, in the grouped view), keeping the selected
+// row selected.
+func (p *todosPane) setItems(items []todoListItem) {
+	p.loaded = items
+	p.items = sortTodoItems(items, p.sortMode)
+	if p.view == config.TodoViewGrouped {
+		p.items = groupTodoItems(p.items, journalNow())
+	}
+	p.reselect()
+}
+
+// setView re-lists the loaded rows in view's layout; the selected row stays
+// selected across the switch.
+func (p *todosPane) setView(view string) {
+	p.view = view
+	p.setItems(p.loaded)
+}
+
+// setSortMode re-lists the loaded rows in mode's order.
+func (p *todosPane) setSortMode(mode string) {
+	p.sortMode = mode
+	p.setItems(p.loaded)
+}
+
+// sortTodoItems returns items in mode's order without touching items
+// itself: load order for TodoSortPosition, or for TodoSortState open todos
+// (and unchecked inbox items) first, then in-progress, then any other
+// state, each group keeping its load order. Pinned todos come first in
+// either order.
+func sortTodoItems(items []todoListItem, mode string) []todoListItem {
+	out := append([]todoListItem{}, items...)
+	rank := func(it todoListItem) int {
+		if it.Pinned {
+			return 0
+		}
+		if mode != config.TodoSortState {
+			return 1
+		}
+		switch {
+		case it.Kind == "ephemeral" && !it.Checked, it.State == "open":
+			return 1
+		case it.State == "in-progress":
+			return 2
+		}
+		return 3
+	}
+	sort.SliceStable(out, func(i, j int) bool { return rank(out[i]) < rank(out[j]) })
+	return out
+}
+
+// Date groups for the todos pane's grouped view, in display order.
+const (
+	todoGroupPinned = iota // pinned with `rk todo pin`, whatever its dates
+	todoGroupToday         // scheduled or due today, or already past
+	todoGroupWeek          // within the next 6 days
+	todoGroupAll           // later, undated, or done
+)
+
+// todoGroupTitles heads each date group in the grouped view.
+var todoGroupTitles = [...]string{"PINNED", "TODAY", "THIS WEEK", "ALL"}
+
+// todoTimeGroup places it by its earlier of scheduled and deadline date,
+// relative to now's day.
+func todoTimeGroup(it todoListItem, now time.Time) int {
+	if it.State == "done" || it.Checked {
+		return todoGroupAll
+	}
+	if it.Pinned {
+		return todoGroupPinned
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	group := todoGroupAll
+	for _, date := range []string{it.Scheduled, it.Deadline} {
+		day, err := time.ParseInLocation("2006-01-02", date, now.Location())
+		if err != nil {
+			continue
+		}
+		switch {
+		case !day.After(today):
+			return todoGroupToday
+		case day.Before(today.AddDate(0, 0, 7)):
+			group = todoGroupWeek
+		}
+	}
+	return group
+}
+
+// groupTodoItems returns items ordered by todoTimeGroup, each group keeping
+// items' order, without touching items itself.
+func groupTodoItems(items []todoListItem, now time.Time) []todoListItem {
+	out := append([]todoListItem{}, items...)
+	sort.SliceStable(out, func(i, j int) bool { return todoTimeGroup(out[i], now) < todoTimeGroup(out[j], now) })
+	return out
 }
 
 // SetSize resizes the pane's viewport.
@@ -200,15 +304,28 @@ type notesPane struct {
 	width  int
 	height int
 
+	// previewTitle/previewBody are the inspected note's content, shown
+	// above its links.
+	previewTitle string
+	previewBody  string
+
 	// notes is the last list loaded via listNotes, kept so browse mode can
 	// re-Show the picker (Esc from inspect) without a fresh read.
 	notes []*models.Note
+
+	// unresolved is countUnresolvedLinks's result from the same load, for
+	// the status bar.
+	unresolved int
+
+	// sortMode orders notes: config.NoteSortUpdated | config.NoteSortCreated.
+	sortMode string
 }
 
 func newNotesPane() *notesPane {
 	p := &notesPane{
-		picker: components.NewNotePicker("Notes"),
-		links:  components.NewNotesPane(),
+		picker:   components.NewNotePicker("Notes"),
+		links:    components.NewNotesPane(),
+		sortMode: config.NoteSortUpdated,
 	}
 	// This picker is always mounted inline as part of the notes pane's
 	// region, never as a self-contained modal popup, so it must not draw
@@ -217,6 +334,39 @@ func newNotesPane() *notesPane {
 	return p
 }
 
+// setNotes replaces the pane's notes with a fresh load, listed in sortMode
+// order, and re-shows the picker over them.
+func (p *notesPane) setNotes(notes []*models.Note) {
+	p.notes = sortNotes(notes, p.sortMode)
+	p.picker.Show(p.notes)
+}
+
+// setSortMode re-lists the loaded notes in mode's order.
+func (p *notesPane) setSortMode(mode string) {
+	p.sortMode = mode
+	p.setNotes(p.notes)
+}
+
+// sortNotes returns notes newest first by mode's date (UpdatedAt for
+// NoteSortUpdated, CreatedAt for NoteSortCreated), ties by title, without
+// touching notes itself.
+func sortNotes(notes []*models.Note, mode string) []*models.Note {
+	out := append([]*models.Note{}, notes...)
+	date := func(n *models.Note) time.Time {
+		if mode == config.NoteSortCreated {
+			return n.CreatedAt
+		}
+		return n.UpdatedAt
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if di, dj := date(out[i]), date(out[j]); !di.Equal(dj) {
+			return di.After(dj)
+		}
+		return out[i].Title < out[j].Title
+	})
+	return out
+}
+
 // SetSize resizes the pane's viewport, propagating to both the picker
 // (browse) and the links inspector (inspect) since either may be visible.
 // p.width/p.height stay the outer dimensions renderPaneBox is later called
@@ -228,5 +378,55 @@ func (p *notesPane) SetSize(width, height int) {
 	innerW, innerH := paneContentDims(p.width, p.height)
 	p.picker.SetWidth(innerW)
 	p.picker.SetHeight(innerH)
-	p.links.SetSize(innerW, innerH)
+	p.links.SetSize(innerW, innerH-p.previewHeight(innerH))
+}
+
+// setPreview sets the note inspect mode shows above its links, giving the
+// preview up to half the content area and the links the rest.
+func (p *notesPane) setPreview(title, body string) {
+	p.previewTitle = title
+	p.previewBody = body
+	p.SetSize(p.width, p.height)
+}
+
+// previewLines is the inspect-mode preview: the title, a blank line, then
+// the body (or a placeholder for an empty one).
+func (p *notesPane) previewLines() []string {
+	body := p.previewBody
+	if body == "" {
+		body = "(empty note)"
+	}
+	return append([]string{p.previewTitle, ""}, strings.Split(body, "\n")...)
+}
+
+// previewHeight is how many of innerH rows the preview takes: all its lines
+// plus a separator, capped at half the content area.
+func (p *notesPane) previewHeight(innerH int) int {
+	if p.previewTitle == "" {
+		return 0
+	}
+	return min(len(p.previewLines())+1, innerH/2)
+}
+
+// inspectView renders inspect mode: the note preview, clipped to
+// previewHeight rows, over the links inspector.
+func (p *notesPane) inspectView() string {
+	innerW, innerH := paneContentDims(p.width, p.height)
+	h := p.previewHeight(innerH)
+	if h == 0 {
+		return p.links.View()
+	}
+	lines := p.previewLines()
+	if len(lines) > h-1 {
+		lines = lines[:h-1]
+	}
+	var b strings.Builder
+	for i, line := range lines {
+		if i == 0 {
+			line = tuiPaneTitleStyle.Render(line)
+		}
+		b.WriteString(truncateRow(line, innerW) + "\n")
+	}
+	b.WriteString(truncateRow(strings.Repeat("─", innerW), innerW) + "\n")
+	return b.String() + p.links.View()
 }
@@ -94,17 +94,30 @@ func listNotes(db *sql.DB) ([]*models.Note, error) {
 	return notes, nil
 }
 
+// countUnresolvedLinks counts `[[...]]` references anywhere in the vault
+// whose target resolves to no node (dst_key NULL after reconcile), for the
+// status bar's ⚠ segment and `rk stats`.
+func countUnresolvedLinks(db *sql.DB) (int, error) {
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM edges WHERE rel = 'references' AND dst_key IS NULL").Scan(&n); err != nil {
+		return 0, fmt.Errorf("count unresolved links: %w", err)
+	}
+	return n, nil
+}
+
 // loadNoteDisplay resolves id to a *models.Note{ID,Title,Slug} for display
 // (picker rows, link endpoints): slug from the file's loc stem (always the
 // note's current filename, so it stays correct across a rename without a
 // second alias-table query), title from the explicit `title` frontmatter
 // prop (notes carry no body-derived title -- internal/index/reconcile.go
 // only derives one for type=="todo") falling back to slug when absent.
+// CreatedAt is the node's time; UpdatedAt its `updated` prop (set by `rk
+// note touch`), or CreatedAt when the note was never touched.
 // Returns (nil, nil) if id doesn't resolve to any node (a dangling edge
 // target), which callers treat as "unresolved", not an error.
 func loadNoteDisplay(db *sql.DB, id string) (*models.Note, error) {
-	var loc string
-	err := db.QueryRow("SELECT loc FROM nodes WHERE id = ?", id).Scan(&loc)
+	var loc, created string
+	err := db.QueryRow("SELECT loc, time FROM nodes WHERE id = ?", id).Scan(&loc, &created)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -120,7 +133,29 @@ func loadNoteDisplay(db *sql.DB, id string) (*models.Note, error) {
 	if title == "" {
 		title = slug
 	}
-	return &models.Note{ID: id, Title: title, Slug: slug}, nil
+	n := &models.Note{ID: id, Title: title, Slug: slug}
+	n.CreatedAt, _ = time.Parse(time.RFC3339, created)
+	n.UpdatedAt = n.CreatedAt
+	if updated, err := time.Parse(time.RFC3339, props["updated"]); err == nil {
+		n.UpdatedAt = updated
+	}
+	return n, nil
+}
+
+// loadNoteContent loads id's display title (as loadNoteDisplay derives it)
+// and trimmed body, for the notes pane's inspect-mode preview.
+func loadNoteContent(db *sql.DB, id string) (title, body string, err error) {
+	n, err := loadNoteDisplay(db, id)
+	if err != nil {
+		return "", "", err
+	}
+	if n == nil {
+		return "", "", fmt.Errorf("tui: notes pane: note %s not found (not found)", id)
+	}
+	if err := db.QueryRow("SELECT body FROM nodes WHERE id = ?", id).Scan(&body); err != nil {
+		return "", "", fmt.Errorf("tui: load note body %q: %w", id, err)
+	}
+	return n.Title, strings.TrimSpace(body), nil
 }
 
 // resolveNoteIDBySlug resolves a note's slug (its self-minted first alias,
@@ -191,6 +226,9 @@ func loadNotesPaneLinks(db *sql.DB, noteID string) (outgoing, backlinks []compon
 		} else {
 			item.DisplayText = l.Src
 		}
+		if l.SrcType != "" && l.SrcType != "note" {
+			item.DisplayText = l.describe()
+		}
 		backlinks = append(backlinks, item)
 	}
 	return outgoing, backlinks, nil
@@ -0,0 +1,533 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/node"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiOp kinds: one per TUI write, each backed by the same verb the matching
+// CLI command calls.
+const (
+	tuiOpAct        = "act"         // dispatchTodayAct (Ref, Key, Arg)
+	tuiOpAddTodo    = "add-todo"    // addDurableTodo (Text)
+	tuiOpAddLog     = "add-log"     // appendLogEntry (Text, At)
+	tuiOpEditLog    = "edit-log"    // editLogEntry (Ref, Text)
+	tuiOpNewNote    = "new-note"    // createNote (Text = title)
+	tuiOpLinkedNote = "linked-note" // createNote + appendLogEntry of [[slug]]
+	tuiOpSetTags    = "set-tags"    // setTodoTags (Ref, Text = comma-joined tags)
+)
+
+// tuiOp is one TUI write, described as plain data so buffered save mode can
+// queue it and journal it to disk. Author and At are captured when the
+// user acts, not when the op is applied, so a flushed log entry keeps the
+// time it was typed. ID is the ULID an op that logs an entry or adds a todo
+// gives it, so replaying the op finds the entry or todo already there
+// rather than writing it twice.
+type tuiOp struct {
+	Op     string    `json:"op"`
+	ID     string    `json:"id,omitempty"`
+	Ref    string    `json:"ref,omitempty"`
+	Key    string    `json:"key,omitempty"`
+	Arg    string    `json:"arg,omitempty"`
+	Text   string    `json:"text,omitempty"`
+	Author string    `json:"author,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// mutate is every TUI write's entry point. In immediate save mode (the
+// default) it returns a cmd that applies op, reconciles the index, and
+// emits mutationDoneMsg for the pane to reload. In buffered mode it queues
+// op (journaling it first) and returns nil; the next flush applies it.
+func (m *tuiModel) mutate(op tuiOp) tea.Cmd {
+	op.Author = resolveAuthor("")
+	op.At = time.Now().UTC()
+	if op.Op == tuiOpAddLog || op.Op == tuiOpLinkedNote || op.Op == tuiOpAddTodo {
+		op.ID = node.Mint()
+	}
+	if m.buffer != nil {
+		if err := m.buffer.add(op); err != nil {
+			m.lastErr = err
+		}
+		return nil
+	}
+	vaultDir := m.vaultDir
+	ix := m.ix
+	return func() tea.Msg {
+		kind, warning, err := applyTUIOp(vaultDir, ix, op, nil)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		msg := reconcileDone(ix, kind)
+		if done, ok := msg.(mutationDoneMsg); ok {
+			done.warning = warning
+			return done
+		}
+		return msg
+	}
+}
+
+// applyTUIOp performs op against vaultDir, returning the pane kind to
+// reload (reloadCmdFor) and any non-fatal warning. It does not reconcile;
+// callers do, once per op or once per flush. written maps the IDs of log
+// entries earlier ops of the same flush wrote to their day files, which
+// the unreconciled index does not know yet; nil outside a flush.
+func applyTUIOp(vaultDir string, ix *index.Index, op tuiOp, written map[string]string) (kind, warning string, err error) {
+	switch op.Op {
+	case tuiOpAct:
+		res, err := dispatchTodayAct(vaultDir, op.Ref, op.Key, op.Arg, false, false)
+		if err != nil {
+			return "", "", err
+		}
+		return "agenda", res.Warning, nil
+
+	case tuiOpAddTodo:
+		todosDir := filepath.Join(vaultDir, "todos")
+		if err := os.MkdirAll(todosDir, 0o755); err != nil {
+			return "", "", fmt.Errorf("tui: add todo: create todos dir: %w", err)
+		}
+		if op.ID != "" {
+			if _, err := os.Stat(filepath.Join(todosDir, op.ID+".md")); err == nil {
+				return "todos", "", nil // written by a flush that got partway
+			}
+		}
+		if _, err := addDurableTodo(todosDir, op.ID, op.Author, op.Text, "", "", "", ""); err != nil {
+			return "", "", err
+		}
+		return "todos", "", nil
+
+	case tuiOpAddLog:
+		path, err := appendTUILogEntry(vaultDir, op.At, op.ID, op.Author, op.Text)
+		if written != nil {
+			written[op.ID] = path
+		}
+		return "log", "", err
+
+	case tuiOpSetTags:
+		if err := setTodoTags(vaultDir, op.Ref, parseTagInput(op.Text)); err != nil {
+			return "", "", err
+		}
+		return "todos", "", nil
+
+	case tuiOpEditLog:
+		path, ok := written[op.Ref]
+		if !ok {
+			var loc string
+			err := ix.DB().QueryRow("SELECT loc FROM nodes WHERE id = ? AND type = 'log-entry'", op.Ref).Scan(&loc)
+			if err != nil {
+				return "", "", fmt.Errorf("tui: edit log: locate entry %s: %w", op.Ref, err)
+			}
+			path = filepath.Join(vaultDir, filepath.FromSlash(loc))
+		}
+		if err := editLogEntry(path, op.Ref, op.Text); err != nil {
+			return "", "", err
+		}
+		return "log", "", nil
+
+	case tuiOpNewNote:
+		if _, err := createTUINote(vaultDir, op.Author, op.Text); err != nil {
+			return "", "", err
+		}
+		return "notes", "", nil
+
+	case tuiOpLinkedNote:
+		if logged, err := tuiLogEntryExists(vaultDir, op.At, op.ID); err != nil || logged {
+			return "log+notes", "", err
+		}
+		// A note already there by the title's slug was created by a flush
+		// that got partway, before it logged the link: link to it.
+		slug := slugify(op.Text)
+		if _, err := os.Stat(filepath.Join(vaultDir, "notes", slug+".md")); err != nil || slug == "" {
+			res, err := createTUINote(vaultDir, op.Author, op.Text)
+			if err != nil {
+				return "", "", err
+			}
+			slug = res.Slug
+		}
+		path, err := appendTUILogEntry(vaultDir, op.At, op.ID, op.Author, "[["+slug+"]]")
+		if err != nil {
+			return "", "", err
+		}
+		if written != nil {
+			written[op.ID] = path
+		}
+		return "log+notes", "", nil
+	}
+	return "", "", fmt.Errorf("tui: unknown op %q", op.Op)
+}
+
+// appendTUILogEntry logs body as entry id, through the same
+// writeLogEntryBlock `rk add` writes with, on at's journal day and time,
+// and returns the day file's path. An entry id the day file already holds
+// (an op replayed after a flush that got partway) is left as it is.
+func appendTUILogEntry(vaultDir string, at time.Time, id, author, body string) (string, error) {
+	logDir := filepath.Join(vaultDir, "log")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return "", fmt.Errorf("tui: add log: create log dir: %w", err)
+	}
+	at = at.UTC()
+	day := at.Add(-dayRollover).Format("2006-01-02")
+	path, _ := logDayFile(logDir, day)
+	if logged, err := tuiLogEntryExists(vaultDir, at, id); err != nil || logged {
+		return path, err
+	}
+	if id == "" {
+		id = node.Mint()
+	}
+	hhmm := journalHHMM(at.Format("15:04"))
+	_, err := writeLogEntryBlock(logDir, day, hhmm, id, node.RenderLogEntry(hhmm, author, id, body))
+	return path, err
+}
+
+// tuiLogEntryExists reports whether at's day file already holds entry id.
+// An op journaled before ops carried IDs has none, and never matches.
+func tuiLogEntryExists(vaultDir string, at time.Time, id string) (bool, error) {
+	if id == "" {
+		return false, nil
+	}
+	path, relPath := logDayFile(filepath.Join(vaultDir, "log"), at.UTC().Add(-dayRollover).Format("2006-01-02"))
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("tui: read %s: %w", relPath, err)
+	}
+	return bytes.Contains(raw, []byte("\nid:: "+id+"\n")), nil
+}
+
+// createTUINote calls createNote (the same verb `rk note create` calls)
+// with only a title, self-minting the slug from it via slugify.
+func createTUINote(vaultDir, author, title string) (noteCreateResult, error) {
+	slug := slugify(title)
+	if err := validateSlug(slug); err != nil {
+		return noteCreateResult{}, fmt.Errorf("tui: create note: %w", err)
+	}
+	return createNote(filepath.Join(vaultDir, "notes"), noteCreateParams{
+		Title:  title,
+		Slug:   slug,
+		Type:   "note",
+		Author: author,
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Buffered save mode (tui_save.mode: buffered)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// tuiBufferFile is the buffered-mode journal's name, kept beside the index
+// in the per-device cache dir: queued ops are this device's unsaved work,
+// not vault content to sync.
+const tuiBufferFile = "tui-pending.jsonl"
+
+// tuiWriteBuffer is buffered save mode's queue of not-yet-applied ops. Each
+// op is appended (and synced) to a JSONL journal before it is queued, so a
+// crash loses nothing the user already did: the next `rk tui` recovers the
+// journal into its queue. A flush renames the journal aside first, so ops
+// queued while it runs start a fresh one.
+//
+// Flushes run off the UI goroutine. taken holds each flush's ops, by
+// journal, until the flush claims them; applying holds one flush at a time,
+// so the exit flush waits out a running one and applies any never started.
+type tuiWriteBuffer struct {
+	path string
+	ops  []tuiOp
+
+	mu       sync.Mutex // guards taken
+	taken    map[string][]tuiOp
+	applying sync.Mutex
+}
+
+// openTUIWriteBuffer opens the journal at path, recovering any ops an
+// earlier session left in it or in a flush that never finished. A
+// half-finished flush's ops are all requeued; replaying one that did land
+// before the crash finds its log entry already there (tuiOp.ID) and skips
+// it.
+func openTUIWriteBuffer(path string) (*tuiWriteBuffer, error) {
+	b := &tuiWriteBuffer{path: path, taken: map[string][]tuiOp{}}
+	stale, err := filepath.Glob(path + ".*.flushing")
+	if err != nil {
+		return nil, fmt.Errorf("tui: find unflushed changes: %w", err)
+	}
+	sort.Strings(stale)
+	for _, p := range append(stale, path) {
+		ops, err := readTUIOps(p)
+		if err != nil {
+			return nil, err
+		}
+		b.ops = append(b.ops, ops...)
+	}
+	if len(stale) == 0 {
+		return b, nil
+	}
+	// Fold the stale flush journals back into the live one, oldest first.
+	if err := writeTUIOps(path, b.ops); err != nil {
+		return nil, err
+	}
+	for _, p := range stale {
+		if err := os.Remove(p); err != nil {
+			return nil, fmt.Errorf("tui: remove %s: %w", p, err)
+		}
+	}
+	return b, nil
+}
+
+// readTUIOps parses the JSONL journal at path; a missing file is no ops.
+// A torn final line (a crash mid-append) is dropped.
+func readTUIOps(path string) ([]tuiOp, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tui: read %s: %w", path, err)
+	}
+	defer f.Close()
+	var ops []tuiOp
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var op tuiOp
+		if err := json.Unmarshal([]byte(line), &op); err != nil {
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("tui: read %s: %w", path, err)
+	}
+	return ops, nil
+}
+
+// writeTUIOps replaces the journal at path with ops.
+func writeTUIOps(path string, ops []tuiOp) error {
+	var buf strings.Builder
+	for _, op := range ops {
+		line, err := json.Marshal(op)
+		if err != nil {
+			return fmt.Errorf("tui: encode queued change: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := writeFileAtomic(path, []byte(buf.String())); err != nil {
+		return fmt.Errorf("tui: write %s: %w", path, err)
+	}
+	return nil
+}
+
+// add journals op, then queues it. An edit of a log entry still queued is
+// folded into the queued op instead, since the entry is not on disk yet.
+func (b *tuiWriteBuffer) add(op tuiOp) error {
+	if op.Op == tuiOpEditLog {
+		for i, q := range b.ops {
+			if q.Op == tuiOpAddLog && q.ID == op.Ref {
+				b.ops[i].Text = op.Text
+				return writeTUIOps(b.path, b.ops)
+			}
+		}
+	}
+	line, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("tui: encode queued change: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o755); err != nil {
+		return fmt.Errorf("tui: create %s: %w", filepath.Dir(b.path), err)
+	}
+	f, err := os.OpenFile(b.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("tui: open %s: %w", b.path, err)
+	}
+	_, werr := f.Write(append(line, '\n'))
+	if werr == nil {
+		werr = f.Sync()
+	}
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		return fmt.Errorf("tui: journal queued change: %w", werr)
+	}
+	b.ops = append(b.ops, op)
+	return nil
+}
+
+// take hands every queued op to a flush, renaming the journal aside to a
+// per-flush name the flush removes once done. Returns no ops when the
+// queue is empty.
+func (b *tuiWriteBuffer) take() (ops []tuiOp, journal string, err error) {
+	if len(b.ops) == 0 {
+		return nil, "", nil
+	}
+	journal = fmt.Sprintf("%s.%d.flushing", b.path, time.Now().UnixNano())
+	if err := os.Rename(b.path, journal); err != nil {
+		return nil, "", fmt.Errorf("tui: set aside %s: %w", b.path, err)
+	}
+	ops, b.ops = b.ops, nil
+	b.mu.Lock()
+	b.taken[journal] = ops
+	b.mu.Unlock()
+	return ops, journal, nil
+}
+
+// claim hands journal's ops to the flush that applies them, once: false
+// when another flush (the exit flush) already has.
+func (b *tuiWriteBuffer) claim(journal string) ([]tuiOp, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ops, ok := b.taken[journal]
+	delete(b.taken, journal)
+	return ops, ok
+}
+
+// unclaimed returns the journals of flushes taken but not yet claimed,
+// oldest first.
+func (b *tuiWriteBuffer) unclaimed() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	journals := make([]string, 0, len(b.taken))
+	for j := range b.taken {
+		journals = append(journals, j)
+	}
+	sort.Strings(journals)
+	return journals
+}
+
+// tuiFlushedMsg reports a buffered-mode flush: how many ops landed, plus
+// any warnings and per-op errors. A failed op is reported, not requeued.
+type tuiFlushedMsg struct {
+	applied  int
+	warnings []string
+	errs     []error
+}
+
+// tuiFlushTickMsg is buffered mode's periodic flush trigger.
+type tuiFlushTickMsg struct{}
+
+// flushTick arms the next periodic flush.
+func (m *tuiModel) flushTick() tea.Cmd {
+	return tea.Tick(m.flushEvery, func(time.Time) tea.Msg { return tuiFlushTickMsg{} })
+}
+
+// flushCmd applies every queued op (ctrl+s, the flush timer). Nil outside
+// buffered mode or with nothing queued.
+func (m *tuiModel) flushCmd() tea.Cmd {
+	if m.buffer == nil {
+		return nil
+	}
+	ops, journal, err := m.buffer.take()
+	if err != nil {
+		m.lastErr = err
+		return nil
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	b := m.buffer
+	vaultDir := m.vaultDir
+	ix := m.ix
+	return m.trackMutation(func() tea.Msg {
+		b.applying.Lock()
+		defer b.applying.Unlock()
+		ops, ok := b.claim(journal)
+		if !ok {
+			return tuiFlushedMsg{}
+		}
+		return flushTUIOps(vaultDir, ix, ops, journal)
+	})
+}
+
+// flushTUIOps applies ops in the order they were queued, then reconciles
+// once and removes their journal.
+func flushTUIOps(vaultDir string, ix *index.Index, ops []tuiOp, journal string) tuiFlushedMsg {
+	var res tuiFlushedMsg
+	written := map[string]string{}
+	for _, op := range ops {
+		_, warning, err := applyTUIOp(vaultDir, ix, op, written)
+		if err != nil {
+			res.errs = append(res.errs, err)
+			continue
+		}
+		res.applied++
+		if warning != "" {
+			res.warnings = append(res.warnings, warning)
+		}
+	}
+	if _, err := ix.Reconcile(); err != nil {
+		res.errs = append(res.errs, err)
+	}
+	if err := os.Remove(journal); err != nil && !os.IsNotExist(err) {
+		res.errs = append(res.errs, fmt.Errorf("tui: remove %s: %w", journal, err))
+	}
+	return res
+}
+
+// handleFlushed surfaces a flush's outcome and reloads every pane, since a
+// flush may have touched any of them.
+func (m *tuiModel) handleFlushed(msg tuiFlushedMsg) tea.Cmd {
+	m.lastErr = errors.Join(msg.errs...)
+	m.lastWarn = strings.Join(msg.warnings, "; ")
+	return tea.Batch(m.loadAgendaCmd(), m.loadTodosCmd(), m.loadLogCmd(), m.loadNotesListCmd())
+}
+
+// flushOnExit applies whatever is still queued when the program exits,
+// synchronously, so quitting never leaves changes behind. It first waits
+// for a flush still running, then applies any flush the program quit
+// before starting, then the queue.
+func (m *tuiModel) flushOnExit() error {
+	b := m.buffer
+	if b == nil {
+		return nil
+	}
+	b.applying.Lock()
+	defer b.applying.Unlock()
+	if _, _, err := b.take(); err != nil {
+		return err
+	}
+	var errs []error
+	for _, journal := range b.unclaimed() {
+		ops, _ := b.claim(journal)
+		errs = append(errs, flushTUIOps(m.vaultDir, m.ix, ops, journal).errs...)
+	}
+	return errors.Join(errs...)
+}
+
+// setupSaveMode switches the model into buffered save mode when settings
+// ask for it, recovering any changes an earlier session left unsaved. On
+// error the model stays in immediate mode.
+func (m *tuiModel) setupSaveMode(settings *config.Settings) error {
+	if settings.TUISave.Mode != config.TUISaveBuffered {
+		return nil
+	}
+	dbPath, err := index.DBPath(m.cfg)
+	if err != nil {
+		return fmt.Errorf("tui: resolve buffer path: %w", err)
+	}
+	buf, err := openTUIWriteBuffer(filepath.Join(filepath.Dir(dbPath), tuiBufferFile))
+	if err != nil {
+		return err
+	}
+	m.buffer = buf
+	m.flushEvery = time.Duration(settings.TUISave.FlushSeconds) * time.Second
+	if n := len(buf.ops); n > 0 {
+		m.lastWarn = fmt.Sprintf("recovered %d unsaved change(s) from an earlier session; ctrl+s saves them", n)
+	}
+	return nil
+}
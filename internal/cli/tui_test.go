@@ -27,6 +27,8 @@ package cli
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -332,6 +334,91 @@ func TestNotesComposition(t *testing.T) {
 	}
 }
 
+// TestNotesBrowserZoomAndPreview: ctrl+n gives the notes pane the whole
+// screen (and focus), inspect mode shows the selected note's title and body
+// above its links, and ctrl+n again restores the 4-pane grid.
+func TestNotesBrowserZoomAndPreview(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	if _, err := createNote(filepath.Join(vault, "notes"), noteCreateParams{
+		Title: "Reading List", Slug: "reading-list", Type: "note", Author: "tester",
+		Body: "Gödel, Escher, Bach\n",
+	}); err != nil {
+		t.Fatalf("createNote: %v", err)
+	}
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if !m.notesZoom || m.focus != focusNotes {
+		t.Fatalf("ctrl+n: zoom=%v focus=%v, want the notes browser focused", m.notesZoom, m.focus)
+	}
+	if m.notes.width != 120 || m.notes.height != 40-tuiStatusBarHeight {
+		t.Errorf("zoomed notes pane = %dx%d, want the full 120x%d", m.notes.width, m.notes.height, 40-tuiStatusBarHeight)
+	}
+	if view := m.View(); strings.Contains(view, "Agenda") {
+		t.Errorf("zoomed view should hide the other panes:\n%s", view)
+	}
+
+	m = applyTUIMsg(t, m, components.NotePickerSelectMsg{NoteSlug: "reading-list"})
+	view := m.View()
+	if !strings.Contains(view, "Reading List") || !strings.Contains(view, "Gödel, Escher, Bach") {
+		t.Errorf("inspect view should preview the note's title and body:\n%s", view)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.notesZoom || m.notes.width != 60 {
+		t.Errorf("second ctrl+n: zoom=%v notes width=%d, want the grid back", m.notesZoom, m.notes.width)
+	}
+}
+
+// TestTUISinglePaneLayout: the auto layout shows one full-screen pane on a
+// terminal under tui_view.min_size (80x24 by default) and the grid on a
+// larger one; the focus layout always shows one, and tab cycles which.
+func TestTUISinglePaneLayout(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 50, Height: 20})
+	if !m.singlePane() {
+		t.Fatal("auto layout at 50x20: want the single-pane layout")
+	}
+	if m.agenda.width != 50 || m.log.width != 50 || m.log.height != 20-tuiStatusBarHeight {
+		t.Errorf("single-pane sizes: agenda width %d, log %dx%d, want every pane 50x%d",
+			m.agenda.width, m.log.width, m.log.height, 20-tuiStatusBarHeight)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Agenda") || strings.Contains(view, "Todos") {
+		t.Errorf("single-pane view should show only the focused agenda pane:\n%s", view)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	view = m.View()
+	if !strings.Contains(view, "Todos") || strings.Contains(view, "Agenda") {
+		t.Errorf("after tab the single-pane view should show only the todos pane:\n%s", view)
+	}
+
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if m.singlePane() || m.agenda.width != 60 {
+		t.Errorf("auto layout at 120x40: single=%v agenda width=%d, want the grid", m.singlePane(), m.agenda.width)
+	}
+
+	writeVaultSettings(t, vault, "tui_view:\n  min_size: 40x16\n")
+	m, _ = newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 50, Height: 20})
+	if m.singlePane() || m.agenda.width != 25 {
+		t.Errorf("auto layout at 50x20 with min_size 40x16: single=%v agenda width=%d, want the grid", m.singlePane(), m.agenda.width)
+	}
+
+	writeVaultSettings(t, vault, "tui_view:\n  layout: focus\n")
+	m, _ = newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if !m.singlePane() || m.agenda.width != 120 {
+		t.Errorf("focus layout at 120x40: single=%v agenda width=%d, want one full-width pane", m.singlePane(), m.agenda.width)
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Reads: agenda pane (scenario 4)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -399,7 +486,7 @@ func TestAddTodoFlow(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(vault, "todos"), 0o755); err != nil {
 		t.Fatalf("mkdir todos dir: %v", err)
 	}
-	addRes, err := addDurableTodo(filepath.Join(vault, "todos"), "tester", "buy milk", "", "", "", "")
+	addRes, err := addDurableTodo(filepath.Join(vault, "todos"), "", "tester", "buy milk", "", "", "", "")
 	if err != nil {
 		t.Fatalf("addDurableTodo: %v", err)
 	}
@@ -463,7 +550,7 @@ func TestAgendaActDone(t *testing.T) {
 	_ = m2
 
 	got := mustReadFile(t, path)
-	want := strings.Replace(src, "state: open", "state: done", 1)
+	want := markedDone(src)
 	if got != want {
 		t.Errorf("agenda 'x' on a native todo did not flip state->done\n--- want ---\n%q\n--- got (unchanged) ---\n%q", want, got)
 	}
@@ -665,12 +752,12 @@ func TestDurabilityAfterRebuild(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(vault, "todos"), 0o755); err != nil {
 		t.Fatalf("mkdir todos dir: %v", err)
 	}
-	addRes, err := addDurableTodo(filepath.Join(vault, "todos"), "tester", "Ship the report.", today, "", "", "")
+	addRes, err := addDurableTodo(filepath.Join(vault, "todos"), "", "tester", "Ship the report.", today, "", "", "")
 	if err != nil {
 		t.Fatalf("addDurableTodo: %v", err)
 	}
 
-	if _, err := dispatchTodayAct(vault, addRes.ID, "x", "", false); err != nil {
+	if _, err := dispatchTodayAct(vault, addRes.ID, "x", "", false, false); err != nil {
 		t.Fatalf("dispatchTodayAct x: %v", err)
 	}
 
@@ -724,7 +811,7 @@ func TestMutationVisibleToFreshCLI(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(vault, "todos"), 0o755); err != nil {
 		t.Fatalf("mkdir todos dir: %v", err)
 	}
-	addRes, err := addDurableTodo(filepath.Join(vault, "todos"), "tester", "Buy milk", "", "", "", "")
+	addRes, err := addDurableTodo(filepath.Join(vault, "todos"), "", "tester", "Buy milk", "", "", "", "")
 	if err != nil {
 		t.Fatalf("addDurableTodo: %v", err)
 	}
@@ -1135,3 +1222,1073 @@ func TestNotesPaneCreateKeybinding(t *testing.T) {
 		t.Errorf("notes pane after n -> submit -> reload: notes = %+v, want the new note included", m3.notes.notes)
 	}
 }
+
+// TestLogPaneLinkedNoteKeybinding: L on the log pane creates a note from the
+// typed title and logs a [[slug]] entry pointing at it, reloading both the
+// log and notes panes.
+func TestLogPaneLinkedNoteKeybinding(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	m, _ := newTUITestModel(t, vault)
+	m.focus = focusLog
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if m.inputMode != inputModeSubFlow || m.subFlow != subFlowLinkedNote {
+		t.Fatalf("L did not open the linked-note sub-flow: inputMode=%v subFlow=%v", m.inputMode, m.subFlow)
+	}
+	if view := m.View(); !strings.Contains(view, m.textEntry.View()) {
+		t.Errorf("linked-note sub-flow should render the text entry, got:\n%s", view)
+	}
+
+	m.textEntry.SetValue("Design Review")
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.lastErr != nil {
+		t.Fatalf("linked note: %v", m.lastErr)
+	}
+
+	if _, err := os.Stat(filepath.Join(vault, "notes", "design-review.md")); err != nil {
+		t.Fatalf("note not created: %v", err)
+	}
+	entry := m.log.view.SelectedLogEntry()
+	if entry == nil || entry.Content != "[[design-review]]" {
+		t.Errorf("log pane after L = %+v, want a [[design-review]] entry", entry)
+	}
+	var found bool
+	for _, n := range m.notes.notes {
+		found = found || n.Slug == "design-review"
+	}
+	if !found {
+		t.Errorf("notes pane after L = %+v, want the new note", m.notes.notes)
+	}
+}
+
+// TestTUIOpenLinkedNote: o on a log entry opens the note its [[slug]] names
+// in the notes pane; on a todo linking a note that does not exist yet it
+// opens the new-note input pre-filled with the slug.
+func TestTUIOpenLinkedNote(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	if _, stderr, err := runTodo(t, vault, "add", "follow up in [[design-notes|the notes]]"); err != nil {
+		t.Fatalf("rk todo add: %v\nstderr: %s", err, stderr)
+	}
+	resetCLIFlags()
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.focus = focusLog
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m.textEntry.SetValue("Design Review")
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+
+	_, cmd = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.lastErr != nil {
+		t.Fatalf("o on the log entry: %v", m.lastErr)
+	}
+	if m.focus != focusNotes || m.notes.mode != notesShowInspect || !strings.Contains(m.View(), "Design Review") {
+		t.Errorf("o on a [[design-review]] entry: focus=%v mode=%v, want the note open in the notes pane", m.focus, m.notes.mode)
+	}
+
+	for _, follow := range drainTUICmd(m.loadTodosCmd()) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	m.focus = focusTodos
+	_, cmd = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.inputMode != inputModeSubFlow || m.subFlow != subFlowNewNote || m.textEntry.GetValue() != "design-notes" {
+		t.Errorf("o on a todo linking a missing note: subFlow=%v value=%q, want the new-note input with design-notes",
+			m.subFlow, m.textEntry.GetValue())
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Status bar
+// ─────────────────────────────────────────────────────────────────────────────
+
+// TestStatusBarClockTick: the status bar shows the time from the start, and
+// a ClockTickMsg must update the clock and re-arm the tick.
+func TestStatusBarClockTick(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	before := time.Now().Format("15:04")
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 30})
+	if view, after := m.View(), time.Now().Format("15:04"); !strings.Contains(view, before) && !strings.Contains(view, after) {
+		t.Errorf("View before any ClockTickMsg should show the current time %s, got:\n%s", after, view)
+	}
+
+	_, cmd := m.Update(components.ClockTickMsg(time.Date(2026, 3, 4, 16, 42, 0, 0, time.Local)))
+	if cmd == nil {
+		t.Error("Update(ClockTickMsg) returned a nil cmd, want the next tick")
+	}
+	if view := m.View(); !strings.Contains(view, "16:42") {
+		t.Errorf("View after ClockTickMsg should show 16:42, got:\n%s", view)
+	}
+}
+
+// TestStatusBarDirtyWhileInputOrSavePending: the unsaved marker shows while
+// a creation sub-flow is open and while its mutation is still in flight,
+// and clears once the mutation settles.
+func TestStatusBarDirtyWhileInputOrSavePending(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m.focus = focusTodos
+
+	if strings.Contains(m.View(), "●") {
+		t.Fatal("unsaved marker shown before any input")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if !strings.Contains(m.View(), "●") {
+		t.Error("unsaved marker missing while the add-todo sub-flow is open")
+	}
+
+	m.textEntry.SetValue("water plants")
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.pending != 1 || !strings.Contains(m.View(), "●") {
+		t.Errorf("after submit: pending = %d, want 1 with the unsaved marker shown", m.pending)
+	}
+
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.pending != 0 || strings.Contains(m.View(), "●") {
+		t.Errorf("after the mutation settled: pending = %d, want 0 with no unsaved marker", m.pending)
+	}
+	if !containsTodoText(m.todos.items, "water plants") {
+		t.Errorf("todos pane after settle: items = %+v, want the new todo", m.todos.items)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Log pane jump-to-date
+// ─────────────────────────────────────────────────────────────────────────────
+
+// typeTUIRunes feeds s to m.handleKey one rune at a time.
+func typeTUIRunes(m *tuiModel, s string) {
+	for _, r := range s {
+		m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// TestLogPaneJumpToDate: J opens the jump input; an absolute or relative
+// past date moves the log cursor to that day's newest entry (or the nearest
+// older one), while a future or unparsable date keeps the input open with
+// an inline error.
+func TestLogPaneJumpToDate(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.focus = focusLog
+	at := func(day string) time.Time {
+		d := mustUTCDate(t, day)
+		return d.Add(9 * time.Hour)
+	}
+	m = applyTUIMsg(t, m, logLoadedMsg{entries: []components.LogEntryRow{
+		{ID: "e-0310", Timestamp: at("2026-03-10"), Content: "today"},
+		{ID: "e-0308", Timestamp: at("2026-03-08"), Content: "weekend"},
+		{ID: "e-0301", Timestamp: at("2026-03-01"), Content: "start of month"},
+	}})
+
+	jump := func(input string) {
+		t.Helper()
+		m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+		if m.inputMode != inputModeSubFlow || m.subFlow != subFlowLogJump {
+			t.Fatalf("J did not open the jump sub-flow: inputMode=%v subFlow=%v", m.inputMode, m.subFlow)
+		}
+		typeTUIRunes(m, input)
+		m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	jump("2026-03-08")
+	if got := m.log.view.SelectedLogEntry(); got == nil || got.ID != "e-0308" {
+		t.Errorf("jump to 2026-03-08 selected %+v, want e-0308", got)
+	}
+
+	jump("-5d") // 2026-03-05: no entries that day, nearest older is 03-01
+	if got := m.log.view.SelectedLogEntry(); got == nil || got.ID != "e-0301" {
+		t.Errorf("jump to -5d selected %+v, want e-0301", got)
+	}
+
+	jump("2026-02-01")
+	if m.lastWarn == "" {
+		t.Error("jump before every entry should leave a warning")
+	}
+
+	jump("+1d")
+	if m.inputMode != inputModeSubFlow {
+		t.Fatal("a future date must keep the jump input open")
+	}
+	if view := m.View(); !strings.Contains(view, "in the future") {
+		t.Errorf("jump input should explain the future-date refusal, got:\n%s", view)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.inputMode != inputModeNormal {
+		t.Errorf("Esc did not close the jump input: inputMode = %v", m.inputMode)
+	}
+}
+
+// TestLogPaneEditKeybinding: "e" on a selected log entry opens the text
+// entry pre-filled with its text; submitting rewrites the entry in its day
+// file and the log pane reloads with the new text.
+func TestLogPaneEditKeybinding(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	logDir := filepath.Join(vault, "log")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		t.Fatalf("mkdir log dir: %v", err)
+	}
+	day := utcToday()
+	res, err := appendLogEntry(logDir, day, "09:15", "tester", "wrote the tui tests")
+	if err != nil {
+		t.Fatalf("appendLogEntry: %v", err)
+	}
+
+	m, _ := newTUITestModel(t, vault)
+	m.focus = focusLog
+	m = applyTUIMsg(t, m, m.loadLogCmd()())
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if m.inputMode != inputModeSubFlow || m.subFlow != subFlowEditLog {
+		t.Fatalf("e did not open the edit sub-flow: inputMode=%v subFlow=%v lastErr=%v", m.inputMode, m.subFlow, m.lastErr)
+	}
+	if got := m.textEntry.GetValue(); got != "wrote the tui tests" {
+		t.Errorf("edit input pre-filled with %q, want the entry text", got)
+	}
+
+	m.textEntry.SetValue("wrote the tui tests, all green")
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.lastErr != nil {
+		t.Fatalf("edit failed: %v", m.lastErr)
+	}
+
+	want := node.RenderLogEntry("09:15", "tester", res.ID, "wrote the tui tests, all green")
+	if got := mustReadFile(t, dayLogPath(vault, day)); !strings.Contains(got, want) {
+		t.Errorf("day file after edit:\n%s\nwant it to contain:\n%s", got, want)
+	}
+	if sel := m.log.view.SelectedLogEntry(); sel == nil || sel.Content != "wrote the tui tests, all green" {
+		t.Errorf("log pane after edit: selected = %+v, want the edited text", sel)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Per-pane sort orders
+// ─────────────────────────────────────────────────────────────────────────────
+
+// TestTUISortCycle: "s" flips the log pane to oldest-first and the todos
+// pane to state order (open before in-progress), without touching the
+// files; each choice is saved to the vault settings, so a fresh model opens
+// with it.
+func TestTUISortCycle(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	base := mustUTCDate(t, "2026-03-10")
+	m = applyTUIMsg(t, m, logLoadedMsg{entries: []components.LogEntryRow{
+		{ID: "e-new", Timestamp: base.Add(10 * time.Hour), Content: "newer"},
+		{ID: "e-old", Timestamp: base.Add(9 * time.Hour), Content: "older"},
+	}})
+	m = applyTUIMsg(t, m, todosLoadedMsg{items: []todoListItem{
+		{Kind: "durable", ID: "T1", State: "in-progress", Title: "started"},
+		{Kind: "durable", ID: "T2", State: "open", Title: "fresh"},
+	}})
+
+	m.focus = focusLog
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	drainTUICmd(cmd)
+	if view := m.log.view.View(); strings.Index(view, "older") > strings.Index(view, "newer") {
+		t.Errorf("log pane after s should list oldest first:\n%s", view)
+	}
+	if !strings.Contains(m.View(), "oldest first") {
+		t.Error("log pane title should name the oldest-first order")
+	}
+
+	m.focus = focusTodos
+	_, cmd = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	drainTUICmd(cmd)
+	if m.todos.items[0].ID != "T2" || m.todos.loaded[0].ID != "T1" {
+		t.Errorf("todos after s = %+v, want the open todo first with load order kept", m.todos.items)
+	}
+
+	settings, err := config.LoadSettings(vault)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if settings.TUISort.Log != config.LogSortOldest || settings.TUISort.Todos != config.TodoSortState {
+		t.Errorf("saved TUISort = %+v, want oldest/state", settings.TUISort)
+	}
+
+	fresh, _ := newTUITestModel(t, vault)
+	if fresh.log.view.SortOrder() != components.LogOldestFirst || fresh.todos.sortMode != config.TodoSortState {
+		t.Errorf("fresh model sort = %v/%q, want the saved orders", fresh.log.view.SortOrder(), fresh.todos.sortMode)
+	}
+
+	m.focus = focusTodos
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.todos.items[0].ID != "T1" {
+		t.Errorf("second s should restore load order, got %+v", m.todos.items)
+	}
+}
+
+// TestTUINotesSortCreatedUpdated: the notes picker lists the most recently
+// updated note first, s flips to most recently created first, and the
+// order is saved for the next `rk tui`.
+func TestTUINotesSortCreatedUpdated(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeTestNode(t, vault, "notes/old-idea.md", node.Mint(), "note", "Old.",
+		"title: Old idea", "time: 2026-01-01T09:00:00Z", "updated: 2026-03-01T09:00:00Z")
+	writeTestNode(t, vault, "notes/new-idea.md", node.Mint(), "note", "New.",
+		"title: New idea", "time: 2026-02-01T09:00:00Z")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 160, Height: 40})
+	for _, msg := range drainTUICmd(m.loadNotesListCmd()) {
+		m = applyTUIMsg(t, m, msg)
+	}
+	titles := func() string {
+		var out []string
+		for _, n := range m.notes.notes {
+			out = append(out, n.Title)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := titles(); got != "Old idea,New idea" {
+		t.Errorf("default order = %s, want the touched note first", got)
+	}
+	if updated := m.notes.notes[0].UpdatedAt.Format("2006-01-02"); updated != "2026-03-01" {
+		t.Errorf("touched note UpdatedAt = %s, want its updated: field", updated)
+	}
+
+	m.focus = focusNotes
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	drainTUICmd(cmd)
+	if got := titles(); got != "New idea,Old idea" {
+		t.Errorf("order after s = %s, want the newest-created note first", got)
+	}
+	if !strings.Contains(m.View(), "Notes · by created") {
+		t.Error("notes pane title should name the created order")
+	}
+	if fresh, _ := newTUITestModel(t, vault); fresh.notes.sortMode != config.NoteSortCreated {
+		t.Errorf("fresh model notes sort = %q, want the saved created order", fresh.notes.sortMode)
+	}
+}
+
+// TestTUILogTimeFormat:the log pane shows entry times in the vault's
+// time_format.
+func TestTUILogTimeFormat(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "time_format: 12h\n")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	at := mustUTCDate(t, "2026-03-10").Add(14*time.Hour + 5*time.Minute)
+	m = applyTUIMsg(t, m, logLoadedMsg{entries: []components.LogEntryRow{{ID: "e1", Timestamp: at, Content: "afternoon"}}})
+	if view := m.log.view.View(); !strings.Contains(view, "2:05 PM") || strings.Contains(view, "14:05") {
+		t.Errorf("log pane should show the entry time as 2:05 PM:\n%s", view)
+	}
+}
+
+// TestTUIEmptyStateHints:on an empty vault every pane says what to do
+// next instead of rendering blank, and the hints go once there is content.
+func TestTUIEmptyStateHints(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 160, Height: 40})
+	for _, cmd := range []tea.Cmd{m.loadAgendaCmd(), m.loadTodosCmd(), m.loadLogCmd(), m.loadNotesListCmd()} {
+		for _, msg := range drainTUICmd(cmd) {
+			m = applyTUIMsg(t, m, msg)
+		}
+	}
+	view := m.View()
+	for _, hint := range []string{"Nothing due today", "No todos — press n to add one", "press n to add one", "No notes — press n to create one"} {
+		if !strings.Contains(view, hint) {
+			t.Errorf("empty vault view missing %q:\n%s", hint, view)
+		}
+	}
+
+	m = applyTUIMsg(t, m, todosLoadedMsg{items: []todoListItem{{Kind: "durable", ID: "T1", State: "open", Title: "something"}}})
+	if strings.Contains(m.View(), "No todos") {
+		t.Error("todos hint should go once the pane has a todo")
+	}
+}
+
+// TestTUITodosGroupedView:v toggles the todos pane between the flat list
+// and TODAY / THIS WEEK / ALL date groups, keeping the selected todo
+// selected, and the choice is saved for the next `rk tui`.
+func TestTUITodosGroupedView(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = applyTUIMsg(t, m, todosLoadedMsg{items: []todoListItem{
+		{Kind: "durable", ID: "T1", State: "open", Title: "someday"},
+		{Kind: "durable", ID: "T2", State: "open", Title: "friday", Scheduled: "2026-03-13"},
+		{Kind: "durable", ID: "T3", State: "open", Title: "due", Deadline: "2026-03-10"},
+	}})
+	m.focus = focusTodos
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.todos.selectedID != "d:T2" {
+		t.Fatalf("selected = %q, want d:T2", m.todos.selectedID)
+	}
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	drainTUICmd(cmd)
+	var order []string
+	for _, it := range m.todos.items {
+		order = append(order, it.ID)
+	}
+	if strings.Join(order, ",") != "T3,T2,T1" {
+		t.Errorf("grouped order = %v, want T3,T2,T1", order)
+	}
+	if m.todos.items[m.todos.selected].ID != "T2" {
+		t.Errorf("selection after v = %+v, want T2 kept", m.todos.items[m.todos.selected])
+	}
+	body := renderTodosBody(m.todos)
+	today, week, all := strings.Index(body, "TODAY"), strings.Index(body, "THIS WEEK"), strings.Index(body, "ALL")
+	if today < 0 || !(today < week && week < all) {
+		t.Errorf("grouped body should head each group in order:\n%s", body)
+	}
+
+	settings, err := config.LoadSettings(vault)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if settings.TUIView.Todos != config.TodoViewGrouped {
+		t.Errorf("saved tui_view.todos = %q, want grouped", settings.TUIView.Todos)
+	}
+	if fresh, _ := newTUITestModel(t, vault); fresh.todos.view != config.TodoViewGrouped {
+		t.Errorf("fresh model view = %q, want the saved grouped view", fresh.todos.view)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.todos.items[0].ID != "T1" || strings.Contains(renderTodosBody(m.todos), "THIS WEEK") {
+		t.Errorf("second v should restore the flat list, got %+v", m.todos.items)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Buffered save mode
+// ─────────────────────────────────────────────────────────────────────────────
+
+// TestBufferedSaveMode: with tui_save.mode buffered, a new todo is only
+// journaled (and marked unsaved) until ctrl+s flushes it to the vault.
+func TestBufferedSaveMode(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "tui_save:\n  mode: buffered\n")
+
+	m, _ := newTUITestModel(t, vault)
+	if m.buffer == nil {
+		t.Fatalf("buffered mode not enabled: lastErr = %v", m.lastErr)
+	}
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.focus = focusTodos
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	typeTUIRunes(m, "water plants")
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("a buffered add should not dispatch a write")
+	}
+
+	todos, _ := filepath.Glob(filepath.Join(vault, "todos", "*.md"))
+	if len(todos) != 0 {
+		t.Fatalf("buffered add wrote %v before a flush", todos)
+	}
+	if _, err := os.Stat(m.buffer.path); err != nil {
+		t.Errorf("queued change not journaled: %v", err)
+	}
+	if !strings.Contains(m.View(), "●") {
+		t.Error("queued change should show the unsaved marker")
+	}
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlS})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.lastErr != nil {
+		t.Fatalf("flush: %v", m.lastErr)
+	}
+	if !containsTodoText(m.todos.items, "water plants") {
+		t.Errorf("todos after ctrl+s = %+v, want the flushed todo", m.todos.items)
+	}
+	if _, err := os.Stat(m.buffer.path); !os.IsNotExist(err) {
+		t.Errorf("journal should be gone after a flush, stat err = %v", err)
+	}
+	if strings.Contains(m.View(), "●") {
+		t.Error("unsaved marker should clear after the flush")
+	}
+}
+
+// TestBufferedSaveModeRecoversJournal: changes queued by a session that
+// never flushed are recovered by the next one and saved on exit.
+func TestBufferedSaveModeRecoversJournal(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "tui_save:\n  mode: buffered\n")
+
+	crashed, _ := newTUITestModel(t, vault)
+	crashed.addLogCmd("queued before the crash")
+
+	m, _ := newTUITestModel(t, vault)
+	if len(m.buffer.ops) != 1 || !strings.Contains(m.lastWarn, "recovered 1") {
+		t.Fatalf("recovered ops = %+v, warning %q; want the crashed session's entry", m.buffer.ops, m.lastWarn)
+	}
+	if err := m.flushOnExit(); err != nil {
+		t.Fatalf("flushOnExit: %v", err)
+	}
+	entries, err := loadLogEntries(m.ix.DB())
+	if err != nil {
+		t.Fatalf("loadLogEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Content != "queued before the crash" {
+		t.Errorf("log after exit flush = %+v, want the recovered entry", entries)
+	}
+}
+
+// TestBufferedSaveModeReplayIsIdempotent: a flush that crashed after
+// writing some of its ops is replayed by the next session without logging
+// those entries twice.
+func TestBufferedSaveModeReplayIsIdempotent(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "tui_save:\n  mode: buffered\n")
+
+	crashed, _ := newTUITestModel(t, vault)
+	crashed.addLogCmd("landed before the crash")
+	crashed.addLogCmd("never landed")
+	ops, _, err := crashed.buffer.take()
+	if err != nil {
+		t.Fatalf("take: %v", err)
+	}
+	if _, _, err := applyTUIOp(vault, crashed.ix, ops[0], nil); err != nil {
+		t.Fatalf("apply first op: %v", err)
+	}
+
+	m, _ := newTUITestModel(t, vault)
+	if len(m.buffer.ops) != 2 {
+		t.Fatalf("recovered ops = %+v, want both of the crashed flush's", m.buffer.ops)
+	}
+	if err := m.flushOnExit(); err != nil {
+		t.Fatalf("flushOnExit: %v", err)
+	}
+	entries, err := loadLogEntries(m.ix.DB())
+	if err != nil {
+		t.Fatalf("loadLogEntries: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Content)
+	}
+	sort.Strings(got)
+	if want := []string{"landed before the crash", "never landed"}; !slices.Equal(got, want) {
+		t.Errorf("log after replay = %q, want %q", got, want)
+	}
+}
+
+// TestBufferedSaveModeReplaysTodoAndLinkedNote: replaying a crashed flush
+// that already added its todo does not add it again, and one that created
+// its linked note but not the log line links the note it finds.
+func TestBufferedSaveModeReplaysTodoAndLinkedNote(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "tui_save:\n  mode: buffered\n")
+
+	crashed, _ := newTUITestModel(t, vault)
+	crashed.mutate(tuiOp{Op: tuiOpAddTodo, Text: "water plants"})
+	crashed.mutate(tuiOp{Op: tuiOpLinkedNote, Text: "Garden Plan"})
+	ops, _, err := crashed.buffer.take()
+	if err != nil {
+		t.Fatalf("take: %v", err)
+	}
+	if _, _, err := applyTUIOp(vault, crashed.ix, ops[0], nil); err != nil {
+		t.Fatalf("apply the todo op: %v", err)
+	}
+	if _, err := createTUINote(vault, ops[1].Author, ops[1].Text); err != nil {
+		t.Fatalf("create the linked note: %v", err)
+	}
+
+	m, _ := newTUITestModel(t, vault)
+	if err := m.flushOnExit(); err != nil {
+		t.Fatalf("flushOnExit: %v", err)
+	}
+	if todos, _ := filepath.Glob(filepath.Join(vault, "todos", "*.md")); len(todos) != 1 || filepath.Base(todos[0]) != ops[0].ID+".md" {
+		t.Errorf("todos after replay = %v, want only %s.md", todos, ops[0].ID)
+	}
+	entries, err := loadLogEntries(m.ix.DB())
+	if err != nil {
+		t.Fatalf("loadLogEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Content != "[[garden-plan]]" {
+		t.Errorf("log after replay = %+v, want one link to the note", entries)
+	}
+}
+
+// TestBufferedSaveModeEditsAndExit: an edit of an entry still queued
+// lands on that entry, and the exit flush applies a flush the program quit
+// before running, which then finds nothing left to do.
+func TestBufferedSaveModeEditsAndExit(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "tui_save:\n  mode: buffered\n")
+
+	m, _ := newTUITestModel(t, vault)
+	m.addLogCmd("draft")
+	m.editLogCmd(m.buffer.ops[0].ID, "final")
+	if len(m.buffer.ops) != 1 || m.buffer.ops[0].Text != "final" {
+		t.Fatalf("queued ops = %+v, want the edit folded into the queued entry", m.buffer.ops)
+	}
+	if recovered, err := readTUIOps(m.buffer.path); err != nil || len(recovered) != 1 || recovered[0].Text != "final" {
+		t.Errorf("journal = %+v, %v; want the folded op", recovered, err)
+	}
+
+	flush := m.flushCmd()
+	if err := m.flushOnExit(); err != nil {
+		t.Fatalf("flushOnExit: %v", err)
+	}
+	if msg, ok := flush().(mutationSettledMsg); !ok || msg.msg.(tuiFlushedMsg).applied != 0 {
+		t.Errorf("late flush = %+v, want nothing left to apply", msg)
+	}
+	entries, err := loadLogEntries(m.ix.DB())
+	if err != nil {
+		t.Fatalf("loadLogEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Content != "final" {
+		t.Errorf("log after exit = %+v, want the one edited entry", entries)
+	}
+}
+
+// TestTUIStatusBarCounts: once the panes load, the status bar shows open
+// todos, those due by today, and the vault's unresolved links.
+func TestTUIStatusBarCounts(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Overdue.", "deadline: 2026-03-09")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Later.", "deadline: 2026-04-01")
+	writeTodoFixture(t, vault, node.Mint(), "done", "", "Finished.")
+	writeTestNode(t, vault, "notes/idea.md", node.Mint(), "note", "See [[nowhere]].", "title: Idea")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 160, Height: 40})
+	if view := m.View(); strings.Contains(view, "⚑") {
+		t.Errorf("status bar should show no counts before the panes load:\n%s", view)
+	}
+	for _, cmd := range []tea.Cmd{m.loadAgendaCmd(), m.loadTodosCmd(), m.loadNotesListCmd()} {
+		for _, msg := range drainTUICmd(cmd) {
+			m = applyTUIMsg(t, m, msg)
+		}
+	}
+
+	view := m.View()
+	for _, seg := range []string{"⚑2", "⏰1", "⚠1"} {
+		if !strings.Contains(view, seg) {
+			t.Errorf("status bar should show %q:\n%s", seg, view)
+		}
+	}
+}
+
+// TestTUIAgendaNoAutoCarry: with noAutoCarry set (--no-auto-carry), agenda
+// loads leave out rows there only because their scheduled date passed.
+func TestTUIAgendaNoAutoCarry(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+	carried := node.Mint()
+	writeTodoFixture(t, vault, carried, "open", "2026-03-08", "Carried.")
+	fresh := node.Mint()
+	writeTodoFixture(t, vault, fresh, "open", "2026-03-10", "Fresh.")
+
+	m, _ := newTUITestModel(t, vault)
+	m.noAutoCarry = true
+	for _, msg := range drainTUICmd(m.loadAgendaCmd()) {
+		m = applyTUIMsg(t, m, msg)
+	}
+	if len(m.agenda.items) != 1 || m.agenda.items[0].ID != fresh {
+		t.Errorf("agenda items = %+v, want only the fresh row", m.agenda.items)
+	}
+}
+
+// TestTUITodosDateBadges: todos pane rows carry compact deadline/schedule
+// badges relative to today, kept visible even when the title is clipped.
+func TestTUITodosDateBadges(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10") // a Tuesday
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-03-08", "Slipped.", "deadline: 2026-03-13")
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-03-11", "A rather long title that cannot fit the pane.")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Undated.")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 80, Height: 40})
+	for _, msg := range drainTUICmd(m.loadTodosCmd()) {
+		m = applyTUIMsg(t, m, msg)
+	}
+	rows := map[string]string{}
+	for _, row := range strings.Split(renderTodosBody(m.todos), "\n") {
+		for _, title := range []string{"Slipped.", "A rather", "Undated."} {
+			if strings.Contains(row, title) {
+				rows[title] = row
+			}
+		}
+	}
+	if row := rows["Slipped."]; !strings.Contains(row, "⏰ fri") || !strings.Contains(row, "📅 2d ago") {
+		t.Errorf("slipped row = %q, want deadline and overdue schedule badges", row)
+	}
+	if row := rows["A rather"]; !strings.Contains(row, "📅 tmrw") {
+		t.Errorf("long row = %q, want its schedule badge kept", row)
+	}
+	if row := rows["Undated."]; strings.ContainsAny(row, "⏰📅") {
+		t.Errorf("undated row = %q, want no badges", row)
+	}
+
+	for desc, want := range map[string]string{"today": "today", "Friday": "fri", "in 2 weeks": "2w", "Apr 20, 2026": "Apr 20"} {
+		if got := shortDateDescription(desc); got != want {
+			t.Errorf("shortDateDescription(%q) = %q, want %q", desc, got, want)
+		}
+	}
+}
+
+// TestTUISummaryOverlay: S toggles the day summary, loading it fresh;
+// pane reloads refresh it while shown; esc hides it.
+func TestTUISummaryOverlay(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	for _, msg := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, msg)
+	}
+	if view := m.View(); !strings.Contains(view, "Nothing logged or due yet.") {
+		t.Errorf("empty vault summary:\n%s", view)
+	}
+
+	writeTodoFixture(t, vault, node.Mint(), "open", "2026-03-10", "Today's task.")
+	if _, err := m.ix.Reconcile(); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	for _, load := range []tea.Cmd{m.loadTodosCmd(), m.loadAgendaCmd()} {
+		for _, msg := range drainTUICmd(load) {
+			m = applyTUIMsg(t, m, msg)
+		}
+	}
+	if view := m.View(); !strings.Contains(view, "0/1") || !strings.Contains(view, "1 (0 due)") {
+		t.Errorf("summary after reload should count the new todo:\n%s", view)
+	}
+
+	_, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(m.View(), "Summary ·") {
+		t.Error("esc should hide the summary")
+	}
+}
+
+// TestTUIJournalReader: r opens today's journal rendered full screen,
+// frontmatter dropped and links kept; esc returns to the panes; a day with
+// no file says nothing was logged.
+func TestTUIJournalReader(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	read := func() {
+		t.Helper()
+		_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		for _, msg := range drainTUICmd(cmd) {
+			m = applyTUIMsg(t, m, msg)
+		}
+	}
+
+	day := currentJournalDate()
+	read()
+	if view := m.View(); !strings.Contains(view, "Journal · "+day) || !strings.Contains(view, "Nothing logged on "+day+".") {
+		t.Errorf("reader on a day with no file:\n%s", view)
+	}
+	_, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+
+	path, _ := logDayFile(filepath.Join(vault, "log"), day)
+	mustWriteFile(t, path, "---\ndate: "+day+"\n---\n# "+day+"\n\n## Log\n- 09:00 met [[alice]] about the launch\n")
+	read()
+	view := m.View()
+	if !m.reader.IsVisible() || !strings.Contains(view, "• 09:00 met [[alice]] about the launch") {
+		t.Errorf("reader should render the day file:\n%s", view)
+	}
+	if strings.Contains(view, "date: ") || strings.Contains(view, "Agenda") {
+		t.Errorf("reader should hide the frontmatter and the panes:\n%s", view)
+	}
+	if !strings.Contains(view, "esc:back") {
+		t.Errorf("status bar should show the reader's hints:\n%s", view)
+	}
+
+	_, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.reader.IsVisible() || strings.Contains(m.View(), "Journal ·") {
+		t.Error("esc should close the reader")
+	}
+}
+
+// TestTodosPaneEditTags: g opens the tag editor pre-filled with the todo's
+// tags; the submitted list is trimmed and deduped, and an empty one clears
+// them.
+func TestTodosPaneEditTags(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	id := node.Mint()
+	path, _ := writeTodoFixture(t, vault, id, "open", "", "Tagged task.", "tags: [work]")
+
+	m, _ := newTUITestModel(t, vault)
+	m = applyTUIMsg(t, m, drainTUICmd(m.loadTodosCmd())[0])
+	m.focus = focusTodos
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if m.subFlow != subFlowEditTags || m.textEntry.GetValue() != "work" {
+		t.Fatalf("g: subFlow=%v value=%q, want the tag editor pre-filled with %q", m.subFlow, m.textEntry.GetValue(), "work")
+	}
+	if view := m.View(); !strings.Contains(view, m.textEntry.View()) {
+		t.Errorf("tag sub-flow should render the text entry, got:\n%s", view)
+	}
+
+	typeTUIRunes(m, ", #home , work,,")
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if m.lastErr != nil {
+		t.Fatalf("edit tags: %v", m.lastErr)
+	}
+	if got := mustReadFile(t, path); !strings.Contains(got, "tags: [work, home]\n") {
+		t.Errorf("todo after tag edit:\n%s", got)
+	}
+	if tags := m.todos.items[0].Tags; len(tags) != 2 || tags[1] != "home" {
+		t.Errorf("todos pane tags after reload = %v, want [work home]", tags)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m.textEntry.SetValue("")
+	_, cmd = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, follow := range drainTUICmd(cmd) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if got := mustReadFile(t, path); !strings.Contains(got, "tags: []\n") {
+		t.Errorf("todo after clearing tags:\n%s", got)
+	}
+}
+
+// TestTUIExternalChangeReload: a file written outside the TUI is picked up
+// by the poll, reloaded once the vault is quiet, and announced; an open
+// sub-flow holds the reload back so its typed text survives.
+func TestTUIExternalChangeReload(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	poll := func() tea.Cmd {
+		t.Helper()
+		msg, ok := m.watchCmd()().(tuiWatchedMsg)
+		if !ok || msg.err != nil {
+			t.Fatalf("watch poll = %+v", msg)
+		}
+		return m.handleWatched(msg)
+	}
+
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Added in an editor.")
+	if cmd := poll(); cmd != nil {
+		t.Error("the poll that sees a change should wait for a quiet one before reloading")
+	}
+	for _, follow := range drainTUICmd(poll()) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if !containsTodoText(m.todos.items, "Added in an editor.") {
+		t.Errorf("todos after the quiet poll = %+v, want the external todo", m.todos.items)
+	}
+	if !strings.Contains(m.View(), "reloaded (external change)") {
+		t.Error("an external reload should be announced in the status line")
+	}
+
+	m.focus = focusTodos
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	typeTUIRunes(m, "half typed")
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "Synced in.")
+	poll()
+	if cmd := poll(); cmd != nil {
+		t.Error("an open sub-flow should hold the reload back")
+	}
+	if got := m.textEntry.GetValue(); got != "half typed" {
+		t.Errorf("typed text = %q, want it kept across the external change", got)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	for _, follow := range drainTUICmd(poll()) {
+		m = applyTUIMsg(t, m, follow)
+	}
+	if !containsTodoText(m.todos.items, "Synced in.") {
+		t.Errorf("todos after the sub-flow closed = %+v, want the held-back reload applied", m.todos.items)
+	}
+}
+
+// TestTodosOnlyTUI: `rk todo tui`'s model shows only the todos pane, full
+// screen, ignores tab, and completes the selected durable todo on "x"; an
+// inbox item is refused with an error rather than touched.
+func TestTodosOnlyTUI(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-07-10")
+
+	id := node.Mint()
+	path, src := writeTodoFixture(t, vault, id, "open", "", "Ship the report.")
+	if _, _, err := runTodo(t, vault, "add", "--ephemeral", "call the bank"); err != nil {
+		t.Fatalf("todo add --ephemeral: %v", err)
+	}
+
+	m, _ := newTUITestModel(t, vault)
+	m.todosOnly = true
+	m.focus = focusTodos
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = applyTUIMsg(t, m, m.loadTodosCmd()())
+	if !m.singlePane() || m.todos.width != 120 {
+		t.Errorf("todos-only at 120x40: single=%v todos width=%d, want one full-width pane", m.singlePane(), m.todos.width)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	if m.focus != focusTodos {
+		t.Errorf("tab in todos-only mode moved focus to %v", m.focus)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Todos") || strings.Contains(view, "Agenda") {
+		t.Errorf("todos-only view should show only the todos pane:\n%s", view)
+	}
+
+	for i, it := range m.todos.items {
+		if it.Kind != "durable" {
+			m.todos.selected = i
+		}
+	}
+	m = applyTUIMsg(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.lastErr == nil || !strings.Contains(m.lastErr.Error(), "inbox item") {
+		t.Errorf("x on an inbox item: lastErr = %v, want an inbox-item error", m.lastErr)
+	}
+
+	for i, it := range m.todos.items {
+		if it.ID == id {
+			m.todos.selected = i
+		}
+	}
+	m = applyTUIMsg(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.lastErr != nil {
+		t.Fatalf("x on a durable todo: %v", m.lastErr)
+	}
+	if got, want := mustReadFile(t, path), markedDone(src); got != want {
+		t.Errorf("x in todos-only mode did not flip state->done\n--- want ---\n%q\n--- got ---\n%q", want, got)
+	}
+}
+
+// TestTUIKeyBindings: tui_keys rebinds actions, the old key stops working,
+// unrebound actions keep their defaults, and the hints show the keys in use.
+func TestTUIKeyBindings(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeVaultSettings(t, vault, "tui_keys:\n  new: a\n  down: ctrl+j\n")
+
+	m, _ := newTUITestModel(t, vault)
+	if m.lastErr != nil {
+		t.Fatalf("settings error: %v", m.lastErr)
+	}
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = applyTUIMsg(t, m, todosLoadedMsg{items: []todoListItem{
+		{Kind: "durable", ID: "T1", State: "open", Title: "first"},
+		{Kind: "durable", ID: "T2", State: "open", Title: "second"},
+	}})
+	m.focus = focusTodos
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.todos.selected != 0 {
+		t.Errorf("j moved the cursor after down was rebound")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlJ})
+	if m.todos.selected != 1 {
+		t.Errorf("ctrl+j did not move the cursor")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if m.todos.selected != 0 {
+		t.Errorf("k (still the default for up) did not move the cursor")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.inputMode == inputModeSubFlow {
+		t.Fatal("n opened the add-todo input after new was rebound")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.inputMode != inputModeSubFlow || m.subFlow != subFlowAddTodo {
+		t.Errorf("a did not open the add-todo input")
+	}
+	m.cancelSubFlow()
+
+	if hints := m.keys.hints(tuiPaneHints[focusTodos]); !strings.HasPrefix(hints, "ctrl+j/k:move a:new x:done") {
+		t.Errorf("todos hints = %q", hints)
+	}
+	m = applyTUIMsg(t, m, todosLoadedMsg{})
+	if !strings.Contains(m.View(), "No todos — press a to add one") {
+		t.Errorf("empty todos hint does not name the rebound key:\n%s", m.View())
+	}
+}
+
+// TestTUITodosPinned: pinned todos lead the todos pane in every sort order,
+// marked with a pin, and head their own PINNED group in the grouped view.
+func TestTUITodosPinned(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	pinTodoNow(t, "2026-03-10")
+
+	m, _ := newTUITestModel(t, vault)
+	m.handleWindowSize(tea.WindowSizeMsg{Width: 120, Height: 40})
+	items := []todoListItem{
+		{Kind: "durable", ID: "T1", State: "in-progress", Title: "due", Deadline: "2026-03-10"},
+		{Kind: "durable", ID: "T2", State: "open", Title: "someday"},
+		{Kind: "durable", ID: "T3", State: "in-progress", Title: "always", Pinned: true},
+	}
+	for _, mode := range []string{config.TodoSortPosition, config.TodoSortState} {
+		if got := sortTodoItems(items, mode); got[0].ID != "T3" {
+			t.Errorf("%s order starts with %s, want the pinned T3", mode, got[0].ID)
+		}
+	}
+
+	m = applyTUIMsg(t, m, todosLoadedMsg{items: items})
+	if body := renderTodosBody(m.todos); !strings.HasPrefix(body, "> "+todoPinGlyph+" always") {
+		t.Errorf("flat todos pane:\n%s", body)
+	}
+	m.todos.setView(config.TodoViewGrouped)
+	body := renderTodosBody(m.todos)
+	if pinned, today := strings.Index(body, "PINNED"), strings.Index(body, "TODAY"); pinned < 0 || today < pinned ||
+		strings.Index(body, "always") > today {
+		t.Errorf("grouped todos pane should lead with the PINNED group:\n%s", body)
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiWatchInterval is how often the TUI polls the vault for edits made
+// outside it (an editor, git, sync). Polling reuses the index's own
+// mtime/hash reconcile rather than a filesystem watcher, and doubles as the
+// debounce window: a burst of writes reloads the panes once, on the first
+// poll that finds the vault quiet again.
+const tuiWatchInterval = 2 * time.Second
+
+// tuiWatchTickMsg triggers the next external-change poll.
+type tuiWatchTickMsg struct{}
+
+// tuiWatchedMsg carries one poll's result: how many files the reconcile
+// re-read or dropped, i.e. changed since the index last saw them.
+type tuiWatchedMsg struct {
+	changed int
+	err     error
+}
+
+// watchTick arms the next external-change poll.
+func (m *tuiModel) watchTick() tea.Cmd {
+	return tea.Tick(tuiWatchInterval, func(time.Time) tea.Msg { return tuiWatchTickMsg{} })
+}
+
+// watchCmd reconciles the index against the vault. The TUI's own writes
+// reconcile as part of their mutation cmd, so anything a poll still finds
+// changed was written by something else.
+func (m *tuiModel) watchCmd() tea.Cmd {
+	ix := m.ix
+	return func() tea.Msg {
+		st, err := ix.Reconcile()
+		if err != nil {
+			return tuiWatchedMsg{err: err}
+		}
+		return tuiWatchedMsg{changed: st.Reparsed + st.Deleted}
+	}
+}
+
+// handleWatched folds one poll into the pending external change count and,
+// once a poll finds the vault quiet, reloads every pane and says so. While
+// a sub-flow is open the reload waits, so typed input is never disturbed;
+// an entry edit in progress is warned that the disk moved under it.
+func (m *tuiModel) handleWatched(msg tuiWatchedMsg) tea.Cmd {
+	switch {
+	case msg.err != nil:
+		m.lastErr = msg.err
+		return nil
+	case m.pending > 0:
+		// A TUI write landed mid-poll; its own reload covers the change.
+		return nil
+	case msg.changed > 0:
+		m.externalChanges += msg.changed
+		if m.inputMode == inputModeSubFlow && m.subFlow == subFlowEditLog {
+			m.lastWarn = "the log changed on disk while you were editing; enter saves over it, esc keeps the disk version"
+		}
+		return nil
+	case m.externalChanges == 0 || m.inputMode == inputModeSubFlow:
+		return nil
+	}
+	m.externalChanges = 0
+	m.lastInfo = "reloaded (external change)"
+	return tea.Batch(m.loadAgendaCmd(), m.loadTodosCmd(), m.loadLogCmd(), m.loadNotesListCmd())
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/MikeBiancalana/reckon/internal/config"
+	"github.com/MikeBiancalana/reckon/internal/index"
+	"github.com/MikeBiancalana/reckon/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Build metadata, injected at link time:
+//
+//	go build -ldflags "-X github.com/MikeBiancalana/reckon/internal/cli.version=v1.2.0 \
+//	  -X github.com/MikeBiancalana/reckon/internal/cli.commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/MikeBiancalana/reckon/internal/cli.buildDate=$(date -u +%Y-%m-%d)" ./cmd/rk
+//
+// A plain `go build` leaves them unset; buildInfo then falls back to what
+// the Go toolchain stamped into the binary (module version, VCS revision).
+var (
+	version   = "dev"
+	commit    = ""
+	buildDate = ""
+)
+
+var versionCmd = &cobra.Command{
+	Use:          "version",
+	Short:        "Print build metadata and the vault this binary points at",
+	Long:         "Print the build version, commit, and Go version, plus the resolved vault and index paths and how many todos, notes, and log entries the index holds.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE:         runVersionE,
+}
+
+// versionResult is the structured summary of `rk version`.
+type versionResult struct {
+	Version   string         `json:"version"`
+	Commit    string         `json:"commit,omitempty"`
+	BuildDate string         `json:"build_date,omitempty"`
+	GoVersion string         `json:"go_version"`
+	VaultDir  string         `json:"vault_dir"`
+	IndexPath string         `json:"index_path"`
+	Indexed   bool           `json:"indexed"`          // false = no index built yet, counts omitted
+	Counts    *versionCounts `json:"counts,omitempty"` // per node type, from the index
+}
+
+// versionCounts is how many nodes of each user-facing type the index holds.
+type versionCounts struct {
+	Todos      int `json:"todos"`
+	Notes      int `json:"notes"`
+	LogEntries int `json:"log_entries"`
+}
+
+func (r versionResult) Pretty() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rk %s", r.Version)
+	if r.Commit != "" {
+		fmt.Fprintf(&b, " (%s", r.Commit)
+		if r.BuildDate != "" {
+			fmt.Fprintf(&b, ", %s", r.BuildDate)
+		}
+		b.WriteString(")")
+	}
+	fmt.Fprintf(&b, "\ngo:    %s", r.GoVersion)
+	fmt.Fprintf(&b, "\nvault: %s", r.VaultDir)
+	fmt.Fprintf(&b, "\nindex: %s", r.IndexPath)
+	if r.Counts == nil {
+		b.WriteString(" (not built; run `rk index`)")
+	} else {
+		fmt.Fprintf(&b, "\n       %d todos, %d notes, %d log entries",
+			r.Counts.Todos, r.Counts.Notes, r.Counts.LogEntries)
+	}
+	return b.String()
+}
+
+func runVersionE(cmd *cobra.Command, args []string) error {
+	mode, err := output.ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadWithOverrides(vaultFlag, "")
+	if err != nil {
+		return fmt.Errorf("version: load config: %w", err)
+	}
+
+	res := buildInfo()
+	res.VaultDir = cfg.VaultDir
+	res.IndexPath, err = index.DBPath(cfg)
+	if err != nil {
+		return fmt.Errorf("version: resolve index path: %w", err)
+	}
+	if res.Counts, err = indexCounts(res.IndexPath); err != nil {
+		return err
+	}
+	res.Indexed = res.Counts != nil
+
+	return newOutput(cmd, mode).Print(res)
+}
+
+// buildInfo returns the build half of versionResult: the ldflags-injected
+// values, falling back to the toolchain-stamped module version and VCS
+// revision for any left unset.
+func buildInfo() versionResult {
+	res := versionResult{Version: version, Commit: commit, BuildDate: buildDate, GoVersion: runtime.Version()}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return res
+	}
+	if res.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		res.Version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && res.Commit == "":
+			res.Commit = s.Value
+			if len(res.Commit) > 12 {
+				res.Commit = res.Commit[:12]
+			}
+		case s.Key == "vcs.time" && res.BuildDate == "":
+			res.BuildDate = s.Value
+		}
+	}
+	return res
+}
+
+// indexCounts counts todos, notes, and log entries in the index at dbPath,
+// opened read-only so `rk version` never builds or touches it. A missing
+// index is (nil, nil).
+func indexCounts(dbPath string) (*versionCounts, error) {
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	db, err := openReadOnlyIndex(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("version: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT type, COUNT(*) FROM nodes WHERE type IN ('todo', 'note', 'log-entry') GROUP BY type")
+	if err != nil {
+		return nil, fmt.Errorf("version: count nodes: %w", err)
+	}
+	defer rows.Close()
+	counts := &versionCounts{}
+	for rows.Next() {
+		var typ string
+		var n int
+		if err := rows.Scan(&typ, &n); err != nil {
+			return nil, fmt.Errorf("version: scan count: %w", err)
+		}
+		switch typ {
+		case "todo":
+			counts.Todos = n
+		case "note":
+			counts.Notes = n
+		case "log-entry":
+			counts.LogEntries = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("version: iterate counts: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/MikeBiancalana/reckon/internal/node"
+)
+
+// runVersion executes `rk version --vault <vault> [args...]` through RootCmd.
+func runVersion(t *testing.T, vault string, args ...string) (stdout string, err error) {
+	t.Helper()
+	var outBuf bytes.Buffer
+	RootCmd.SetOut(&outBuf)
+	RootCmd.SetErr(&bytes.Buffer{})
+	RootCmd.SetArgs(append([]string{"version", "--vault", vault}, args...))
+	err = RootCmd.Execute()
+	return outBuf.String(), err
+}
+
+// TestVersion_ReportsBuildAndCounts: before `rk index` the counts are
+// omitted (and the index is not created); after it they match the vault.
+func TestVersion_ReportsBuildAndCounts(t *testing.T) {
+	vault, _ := setupQueryVault(t)
+	t.Cleanup(resetCLIFlags)
+	writeTodoFixture(t, vault, node.Mint(), "open", "", "First task.")
+	writeTodoFixture(t, vault, node.Mint(), "done", "", "Second task.")
+	writeTestNode(t, vault, "notes/idea.md", node.Mint(), "note", "An idea.", "title: Idea")
+
+	out, err := runVersion(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk version --json: %v", err)
+	}
+	var res versionResult
+	mustDecodeJSON(t, out, &res)
+	if res.Version == "" || res.GoVersion != runtime.Version() || res.VaultDir != vault {
+		t.Errorf("result = %+v, want a version, %s, and vault %s", res, runtime.Version(), vault)
+	}
+	if res.Indexed || res.Counts != nil {
+		t.Errorf("before rk index: indexed=%v counts=%+v, want neither", res.Indexed, res.Counts)
+	}
+
+	resetCLIFlags()
+	buildIndex(t, vault)
+	out, err = runVersion(t, vault, "--json")
+	if err != nil {
+		t.Fatalf("rk version --json: %v", err)
+	}
+	res = versionResult{}
+	mustDecodeJSON(t, out, &res)
+	if !res.Indexed || res.Counts == nil || res.Counts.Todos != 2 || res.Counts.Notes != 1 {
+		t.Errorf("after rk index: counts = %+v, want 2 todos and 1 note", res.Counts)
+	}
+
+	resetCLIFlags()
+	out, err = runVersion(t, vault)
+	if err != nil {
+		t.Fatalf("rk version: %v", err)
+	}
+	if !strings.Contains(out, "2 todos, 1 notes") || !strings.Contains(out, vault) {
+		t.Errorf("pretty output:\n%s", out)
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EstimateDay is how long one "d" of an effort estimate is: a working day,
+// not a calendar one.
+const EstimateDay = 8 * time.Hour
+
+// ParseEstimate parses an effort estimate such as "30m", "2h", "1d", or a
+// combination like "1h30m" (units m, h, d; see EstimateDay). The result is
+// always positive.
+func ParseEstimate(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty estimate (want e.g. 30m, 2h, or 1d)")
+	}
+	var total time.Duration
+	rest := s
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return 0, fmt.Errorf("invalid estimate %q (want e.g. 30m, 2h, or 1d)", s)
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid estimate %q: %w", s, err)
+		}
+		var unit time.Duration
+		switch rest[i] {
+		case 'm':
+			unit = time.Minute
+		case 'h':
+			unit = time.Hour
+		case 'd':
+			unit = EstimateDay
+		default:
+			return 0, fmt.Errorf("invalid estimate %q: unknown unit %q (want m, h, or d)", s, rest[i])
+		}
+		total += time.Duration(n) * unit
+		rest = rest[i+1:]
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid estimate %q (must be more than zero)", s)
+	}
+	return total, nil
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"30m":   30 * time.Minute,
+		"2h":    2 * time.Hour,
+		"1d":    EstimateDay,
+		"1h30m": 90 * time.Minute,
+		" 45m ": 45 * time.Minute,
+	} {
+		got, err := ParseEstimate(in)
+		if err != nil || got != want {
+			t.Errorf("ParseEstimate(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "2", "h", "2w", "0m", "1.5h"} {
+		if _, err := ParseEstimate(in); err == nil {
+			t.Errorf("ParseEstimate(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,591 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SettingsFile is the vault-relative path of the per-vault settings file.
+// It lives under .reckon/, which the index never walks, so it syncs with
+// the vault without ever being indexed as a node.
+const SettingsFile = ".reckon/config.yaml"
+
+// Task ID styles for Settings.TaskIDStyle. Every durable todo keeps its
+// ULID as its canonical ID and filename; the daily and slug styles add a
+// memorable alias on top, which every ref-taking verb also accepts.
+const (
+	TaskIDStyleULID  = "ulid"  // ULID only (default)
+	TaskIDStyleDaily = "daily" // plus a per-day sequence alias: 2026-01-15-01
+	TaskIDStyleSlug  = "slug"  // plus a title-derived slug alias: buy-milk
+)
+
+// Sort orders for Settings.TUISort, one set per `rk tui` pane that can be
+// re-sorted. Sorting only changes how a pane lists its items; the files
+// (and so every position a verb reports) are never reordered.
+const (
+	TodoSortPosition = "position" // index order, durable then ephemeral (default)
+	TodoSortState    = "state"    // open todos first, then in-progress and the rest
+	LogSortNewest    = "newest"   // newest entry first (default)
+	LogSortOldest    = "oldest"   // oldest entry first
+	NoteSortUpdated  = "updated"  // most recently updated note first (default)
+	NoteSortCreated  = "created"  // most recently created note first
+)
+
+// TUISortSettings holds the `rk tui` per-pane sort orders. The TUI's "s" key
+// cycles a pane's order and writes it back here via SetSetting.
+type TUISortSettings struct {
+	Todos string `yaml:"todos"`
+	Log   string `yaml:"log"`
+	Notes string `yaml:"notes"`
+}
+
+// Todos pane layouts for Settings.TUIView.Todos.
+const (
+	TodoViewFlat    = "flat"    // one list (default)
+	TodoViewGrouped = "grouped" // TODAY / THIS WEEK / ALL sections by date
+)
+
+// Screen layouts for Settings.TUIView.Layout.
+const (
+	TUILayoutAuto  = "auto"  // the pane grid, or one pane when the terminal is small (default)
+	TUILayoutGrid  = "grid"  // always the 2x2 pane grid
+	TUILayoutFocus = "focus" // always one pane, full screen; tab cycles which
+)
+
+// TUIViewSettings holds the `rk tui` screen and per-pane layouts. The TUI's
+// "v" key toggles the todos pane's layout and writes it back here via
+// SetSetting.
+type TUIViewSettings struct {
+	Layout string `yaml:"layout"`
+	// MinSize (WxH, see ParseTerminalSize) is the smallest terminal the
+	// auto layout shows the pane grid on.
+	MinSize string `yaml:"min_size"`
+	Todos   string `yaml:"todos"`
+}
+
+// Save modes for Settings.TUISave.Mode.
+const (
+	TUISaveImmediate = "immediate" // every TUI action writes straight through (default)
+	TUISaveBuffered  = "buffered"  // actions queue and flush on a timer, ctrl+s, or quit
+)
+
+// TUISaveSettings controls when `rk tui` writes its changes to the vault.
+type TUISaveSettings struct {
+	Mode string `yaml:"mode"`
+	// FlushSeconds is how often buffered mode flushes queued changes.
+	FlushSeconds int `yaml:"flush_seconds"`
+}
+
+// NextWeightsSettings weighs the parts of `rk next`'s score for an open
+// todo: points per day overdue, per day closer than two weeks to the
+// deadline, per priority step (C 1, B 2, A 3), and per week since the todo
+// was created. 0 leaves a part out.
+type NextWeightsSettings struct {
+	Overdue  int `yaml:"overdue"`
+	Due      int `yaml:"due"`
+	Priority int `yaml:"priority"`
+	Age      int `yaml:"age"`
+}
+
+// Commands for Settings.DefaultCommand: what a bare `rk` (no arguments)
+// runs. `rk --help` always prints help.
+const (
+	DefaultCommandHelp  = "help"  // print help (default)
+	DefaultCommandTUI   = "tui"   // launch `rk tui`
+	DefaultCommandToday = "today" // print `rk today`'s agenda
+)
+
+// Named presets for Settings.TimeFormat, how `rk tui` shows a log entry's
+// time. Any other value is taken as a Go time layout ("15:04:05 MST").
+const (
+	TimeFormat24h        = "24h"         // 14:05 (default)
+	TimeFormat24hSeconds = "24h-seconds" // 14:05:09
+	TimeFormat12h        = "12h"         // 2:05 PM
+	TimeFormat12hSeconds = "12h-seconds" // 2:05:09 PM
+)
+
+var timeFormatLayouts = map[string]string{
+	TimeFormat24h:        "15:04",
+	TimeFormat24hSeconds: "15:04:05",
+	TimeFormat12h:        "3:04 PM",
+	TimeFormat12hSeconds: "3:04:05 PM",
+}
+
+// TimeLayout returns the Go time layout for a time_format value: a named
+// preset's layout, or format itself when it is a layout. A value that
+// formats no part of a time (a typo'd preset, say) is an error.
+func TimeLayout(format string) (string, error) {
+	if layout, ok := timeFormatLayouts[format]; ok {
+		return layout, nil
+	}
+	ref := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	if strings.TrimSpace(format) == "" || ref.Format(format) == format {
+		return "", fmt.Errorf("%q is neither a preset (%s, %s, %s, %s) nor a Go time layout%s",
+			format, TimeFormat24h, TimeFormat24hSeconds, TimeFormat12h, TimeFormat12hSeconds,
+			Suggest(format, TimeFormat24h, TimeFormat24hSeconds, TimeFormat12h, TimeFormat12hSeconds))
+	}
+	return format, nil
+}
+
+// ParseTerminalSize parses a terminal size written WxH, such as "80x24",
+// into its width and height in cells; both must be positive.
+func ParseTerminalSize(size string) (width, height int, err error) {
+	w, h, ok := strings.Cut(strings.ToLower(strings.TrimSpace(size)), "x")
+	if ok {
+		width, err = strconv.Atoi(w)
+		if err == nil {
+			height, err = strconv.Atoi(h)
+		}
+	}
+	if !ok || err != nil || width < 1 || height < 1 {
+		return 0, 0, fmt.Errorf("%q is not a terminal size (want WIDTHxHEIGHT, like 80x24)", size)
+	}
+	return width, height, nil
+}
+
+// Journal layouts for Settings.JournalLayout: where under log/ each day's
+// file lives. The index finds a day by its date alias, not its path, so
+// either layout reads the same.
+const (
+	JournalLayoutFlat   = "flat"   // log/2026-01-15.md (default)
+	JournalLayoutNested = "nested" // log/2026/2026-01/2026-01-15.md
+)
+
+// JournalDayPath returns day's (YYYY-MM-DD) file path relative to log/
+// under layout, slash-separated.
+func JournalDayPath(layout, day string) string {
+	if layout == JournalLayoutNested && len(day) >= len("2006-01") {
+		return day[:4] + "/" + day[:7] + "/" + day + ".md"
+	}
+	return day + ".md"
+}
+
+// DayRolloverOffset parses a day_rollover value (HH:MM, 24-hour) into how
+// long after midnight the journal day turns over.
+func DayRolloverOffset(rollover string) (time.Duration, error) {
+	t, err := time.Parse("15:04", rollover)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day (want HH:MM, 24-hour)", rollover)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// MinTodoIDWidth is the narrowest todo_id_width: the shortest ULID prefix
+// rk resolves back to a todo, so every printed ID can be typed back in.
+const MinTodoIDWidth = 4
+
+// Settings holds the user-tunable, per-vault options read from
+// SettingsFile. The zero value is not meaningful; use DefaultSettings or
+// LoadSettings.
+type Settings struct {
+	TaskIDStyle string `yaml:"task_id_style"`
+	// TodoIDWidth is how many leading characters of a durable todo's ULID
+	// `rk todo list` prints (0 = the full ID, else at least MinTodoIDWidth).
+	TodoIDWidth int             `yaml:"todo_id_width"`
+	TUISort     TUISortSettings `yaml:"tui_sort"`
+	TUIView     TUIViewSettings `yaml:"tui_view"`
+	TUISave     TUISaveSettings `yaml:"tui_save"`
+	// TUIKeys rebinds `rk tui` actions (a TUIKeyActions name) to other
+	// keys; an action it leaves out keeps its default key.
+	TUIKeys map[string]string `yaml:"tui_keys"`
+	// AutoCompleteParent marks a parent todo done once its last open
+	// subtask is completed.
+	AutoCompleteParent bool `yaml:"auto_complete_parent"`
+	// DailyCapacity is the effort (ParseEstimate syntax) `rk today` warns
+	// the agenda's estimates exceed; "" turns the check off.
+	DailyCapacity string `yaml:"daily_capacity"`
+	// DefaultCommand is what a bare `rk` runs.
+	DefaultCommand string `yaml:"default_command"`
+	// MatchMargin is how far, in percent, a --match query's best fuzzy
+	// score must beat the runner-up's for the best candidate to be picked
+	// instead of reporting the query as ambiguous.
+	MatchMargin int `yaml:"match_margin"`
+	// MatchThreshold is the lowest fuzzy score a --match candidate (or a
+	// TUI note picker row) may have and still count as a match; 0 keeps
+	// every match.
+	MatchThreshold int `yaml:"match_threshold"`
+	// MatchMaxCandidates is how many matches a --match query may have
+	// before it is rejected as too broad to pick from, and how many the TUI
+	// note picker lists; 0 is no limit.
+	MatchMaxCandidates int `yaml:"match_max_candidates"`
+	// TimeFormat is how log entry times are shown: a TimeFormat* preset
+	// or a Go time layout (see TimeLayout).
+	TimeFormat string `yaml:"time_format"`
+	// InboxTag is the tag `rk todo triage` works through.
+	InboxTag string `yaml:"inbox_tag"`
+	// AutoInbox tags every new durable todo created without --tags with
+	// InboxTag, so quick captures wait for triage.
+	AutoInbox bool `yaml:"auto_inbox"`
+	// DefaultTodoTags are tags every new durable todo gets, ahead of any
+	// given with --tags.
+	DefaultTodoTags []string `yaml:"default_todo_tags"`
+	// ArchiveAfterDays is how many days after completion `rk todo gc`
+	// archives a done todo; 0 leaves done todos alone.
+	ArchiveAfterDays int `yaml:"archive_after_days"`
+	// TodoProject is the project (a subdirectory of todos/) `rk todo add`
+	// files new durable todos under when given no --project; "" is todos/
+	// itself.
+	TodoProject string `yaml:"todo_project"`
+	// DefaultAssignee is who `rk todo add` assigns new durable todos to
+	// when given no --assignee, and who `rk todo list --mine` means; ""
+	// leaves new todos unassigned.
+	DefaultAssignee string `yaml:"default_assignee"`
+	// DayRollover (HH:MM, UTC) is when one journal day ends and the next
+	// begins: until then "today" is still the previous date.
+	DayRollover string `yaml:"day_rollover"`
+	// JournalLayout is how day files are laid out under log/: a
+	// JournalLayout* value. `rk migrate log-layout` moves existing files
+	// after it changes.
+	JournalLayout string `yaml:"journal_layout"`
+	// LogGapMinutes is how long a stretch between two log entries must be
+	// for `rk today gaps` to report it and `rk tui` to mark it; 0 turns
+	// both off.
+	LogGapMinutes int `yaml:"log_gap_minutes"`
+	// UndeleteSeconds is how long `rk todo delete` keeps deleted todos in
+	// the vault's .trash/ directory, where `rk todo undelete` can restore
+	// them.
+	UndeleteSeconds int `yaml:"undelete_seconds"`
+	// NextWeights weighs `rk next`'s suggestions.
+	NextWeights NextWeightsSettings `yaml:"next_weights"`
+}
+
+// ValidateProjectName reports whether name can be a todo project: the name
+// of one subdirectory of todos/, so no path separators and no leading dot.
+func ValidateProjectName(name string) error {
+	if strings.TrimSpace(name) != name || name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("%q is not a project name (want one directory name, without slashes or a leading dot)", name)
+	}
+	return nil
+}
+
+// DefaultSettings returns the settings used when SettingsFile is absent,
+// and the base any keys it does set are layered over.
+func DefaultSettings() *Settings {
+	return &Settings{
+		TaskIDStyle: TaskIDStyleULID,
+		TUISort:     TUISortSettings{Todos: TodoSortPosition, Log: LogSortNewest, Notes: NoteSortUpdated},
+		TUIView:     TUIViewSettings{Layout: TUILayoutAuto, MinSize: "80x24", Todos: TodoViewFlat},
+		TUISave:     TUISaveSettings{Mode: TUISaveImmediate, FlushSeconds: 30},
+
+		DefaultCommand: DefaultCommandHelp,
+		MatchMargin:    100,
+		TimeFormat:     TimeFormat24h,
+		InboxTag:       "inbox",
+		LogGapMinutes:  90,
+		DayRollover:    "00:00",
+		JournalLayout:  JournalLayoutFlat,
+
+		UndeleteSeconds: 60,
+		NextWeights:     NextWeightsSettings{Overdue: 10, Due: 5, Priority: 8, Age: 1},
+	}
+}
+
+// LoadSettings reads SettingsFile from vaultDir. A missing file yields
+// DefaultSettings; unknown keys or invalid values are errors, so a typo
+// never silently falls back to a default.
+func LoadSettings(vaultDir string) (*Settings, error) {
+	s := DefaultSettings()
+	path := filepath.Join(vaultDir, filepath.FromSlash(SettingsFile))
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	if err := decodeSettings(raw, s); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// decodeSettings layers raw over s and validates the result.
+func decodeSettings(raw []byte, s *Settings) error {
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(s); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse: %w%s", err, suggestSettingKey(raw))
+	}
+	return s.validate()
+}
+
+// SetSetting sets one key of vaultDir's SettingsFile, creating the file if
+// needed. key is dot-separated for nested keys ("tui_sort.log"). Only that
+// key's node is touched, so the user's other keys keep their values; the
+// file is re-validated before it is written, so a bad key or value leaves
+// it as it was.
+func SetSetting(vaultDir, key, value string) error {
+	path := filepath.Join(vaultDir, filepath.FromSlash(SettingsFile))
+	raw, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	node := doc.Content[0]
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("config: %s: %s is not a mapping", path, key)
+		}
+		node = mappingValue(node, part)
+	}
+	*node = yaml.Node{Kind: yaml.ScalarNode, Value: value}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("config: encode %s: %w", path, err)
+	}
+	if err := decodeSettings(out, DefaultSettings()); err != nil {
+		return fmt.Errorf("config: set %s: %w", key, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("config: create %s: %w", filepath.Dir(path), err)
+	}
+	if err := writeFileAtomic(path, out); err != nil {
+		return fmt.Errorf("config: write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to path via a temp file in the same directory
+// followed by os.Rename over the original, so an interrupted write never
+// leaves a truncated settings file (mirrors internal/cli/adopt.go's
+// writeFileAtomic).
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".settings-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	ok := false
+	defer func() {
+		if !ok {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	ok = true
+	return nil
+}
+
+// mappingValue returns the value node for key in mapping m, appending an
+// empty mapping under key when it is absent.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	k := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+	v := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = append(m.Content, k, v)
+	return v
+}
+
+// suggestSettingKey returns a Suggest hint for the first key in raw that
+// Settings has no field for but is a likely typo of one, or "".
+func suggestSettingKey(raw []byte) string {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil || len(doc.Content) == 0 {
+		return ""
+	}
+	return suggestKey(doc.Content[0], reflect.TypeOf(Settings{}))
+}
+
+// suggestKey walks mapping m against the yaml-tagged fields of struct type
+// t, descending into nested structs.
+func suggestKey(m *yaml.Node, t reflect.Type) string {
+	if m.Kind != yaml.MappingNode {
+		return ""
+	}
+	fields := map[string]reflect.Type{}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = t.Field(i).Type
+		names = append(names, name)
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key := m.Content[i].Value
+		ft, ok := fields[key]
+		if !ok {
+			if hint := Suggest(key, names...); hint != "" {
+				return hint
+			}
+			continue
+		}
+		if ft.Kind() == reflect.Struct {
+			if hint := suggestKey(m.Content[i+1], ft); hint != "" {
+				return hint
+			}
+		}
+	}
+	return ""
+}
+
+func (s *Settings) validate() error {
+	switch s.TaskIDStyle {
+	case TaskIDStyleULID, TaskIDStyleDaily, TaskIDStyleSlug:
+	default:
+		return fmt.Errorf("invalid task_id_style %q (want %s, %s, or %s)%s",
+			s.TaskIDStyle, TaskIDStyleULID, TaskIDStyleDaily, TaskIDStyleSlug,
+			Suggest(s.TaskIDStyle, TaskIDStyleULID, TaskIDStyleDaily, TaskIDStyleSlug))
+	}
+	if s.TodoIDWidth != 0 && s.TodoIDWidth < MinTodoIDWidth {
+		return fmt.Errorf("invalid todo_id_width %d (want 0 for full IDs, or a width of at least %d)", s.TodoIDWidth, MinTodoIDWidth)
+	}
+	switch s.TUISort.Todos {
+	case TodoSortPosition, TodoSortState:
+	default:
+		return fmt.Errorf("invalid tui_sort.todos %q (want %s or %s)%s", s.TUISort.Todos, TodoSortPosition, TodoSortState,
+			Suggest(s.TUISort.Todos, TodoSortPosition, TodoSortState))
+	}
+	switch s.TUISort.Log {
+	case LogSortNewest, LogSortOldest:
+	default:
+		return fmt.Errorf("invalid tui_sort.log %q (want %s or %s)%s", s.TUISort.Log, LogSortNewest, LogSortOldest,
+			Suggest(s.TUISort.Log, LogSortNewest, LogSortOldest))
+	}
+	switch s.TUISort.Notes {
+	case NoteSortUpdated, NoteSortCreated:
+	default:
+		return fmt.Errorf("invalid tui_sort.notes %q (want %s or %s)%s", s.TUISort.Notes, NoteSortUpdated, NoteSortCreated,
+			Suggest(s.TUISort.Notes, NoteSortUpdated, NoteSortCreated))
+	}
+	switch s.TUIView.Layout {
+	case TUILayoutAuto, TUILayoutGrid, TUILayoutFocus:
+	default:
+		return fmt.Errorf("invalid tui_view.layout %q (want %s, %s, or %s)%s",
+			s.TUIView.Layout, TUILayoutAuto, TUILayoutGrid, TUILayoutFocus,
+			Suggest(s.TUIView.Layout, TUILayoutAuto, TUILayoutGrid, TUILayoutFocus))
+	}
+	if _, _, err := ParseTerminalSize(s.TUIView.MinSize); err != nil {
+		return fmt.Errorf("invalid tui_view.min_size: %w", err)
+	}
+	switch s.TUIView.Todos {
+	case TodoViewFlat, TodoViewGrouped:
+	default:
+		return fmt.Errorf("invalid tui_view.todos %q (want %s or %s)%s", s.TUIView.Todos, TodoViewFlat, TodoViewGrouped,
+			Suggest(s.TUIView.Todos, TodoViewFlat, TodoViewGrouped))
+	}
+	switch s.TUISave.Mode {
+	case TUISaveImmediate, TUISaveBuffered:
+	default:
+		return fmt.Errorf("invalid tui_save.mode %q (want %s or %s)%s", s.TUISave.Mode, TUISaveImmediate, TUISaveBuffered,
+			Suggest(s.TUISave.Mode, TUISaveImmediate, TUISaveBuffered))
+	}
+	if s.TUISave.FlushSeconds < 1 {
+		return fmt.Errorf("invalid tui_save.flush_seconds %d (want a positive number of seconds)", s.TUISave.FlushSeconds)
+	}
+	if err := validateTUIKeys(s.TUIKeys); err != nil {
+		return err
+	}
+	if s.DailyCapacity != "" {
+		if _, err := ParseEstimate(s.DailyCapacity); err != nil {
+			return fmt.Errorf("invalid daily_capacity: %w", err)
+		}
+	}
+	switch s.DefaultCommand {
+	case DefaultCommandHelp, DefaultCommandTUI, DefaultCommandToday:
+	default:
+		return fmt.Errorf("invalid default_command %q (want %s, %s, or %s)%s",
+			s.DefaultCommand, DefaultCommandHelp, DefaultCommandTUI, DefaultCommandToday,
+			Suggest(s.DefaultCommand, DefaultCommandHelp, DefaultCommandTUI, DefaultCommandToday))
+	}
+	if s.MatchMargin < 0 {
+		return fmt.Errorf("invalid match_margin %d (want a percentage, 0 or more)", s.MatchMargin)
+	}
+	if s.MatchThreshold < 0 {
+		return fmt.Errorf("invalid match_threshold %d (want a fuzzy score, 0 or more)", s.MatchThreshold)
+	}
+	if s.MatchMaxCandidates < 0 {
+		return fmt.Errorf("invalid match_max_candidates %d (want 0 for no limit, or a count)", s.MatchMaxCandidates)
+	}
+	if _, err := TimeLayout(s.TimeFormat); err != nil {
+		return fmt.Errorf("invalid time_format: %w", err)
+	}
+	if s.ArchiveAfterDays < 0 {
+		return fmt.Errorf("invalid archive_after_days %d (want 0 to keep done todos, or a number of days)", s.ArchiveAfterDays)
+	}
+	if s.UndeleteSeconds <= 0 {
+		return fmt.Errorf("invalid undelete_seconds %d (want a positive number of seconds)", s.UndeleteSeconds)
+	}
+	for _, w := range []struct {
+		key    string
+		weight int
+	}{
+		{"overdue", s.NextWeights.Overdue}, {"due", s.NextWeights.Due},
+		{"priority", s.NextWeights.Priority}, {"age", s.NextWeights.Age},
+	} {
+		if w.weight < 0 {
+			return fmt.Errorf("invalid next_weights.%s %d (want 0 to leave it out, or a positive weight)", w.key, w.weight)
+		}
+	}
+	if s.LogGapMinutes < 0 {
+		return fmt.Errorf("invalid log_gap_minutes %d (want 0 to turn gap checks off, or a number of minutes)", s.LogGapMinutes)
+	}
+	if _, err := DayRolloverOffset(s.DayRollover); err != nil {
+		return fmt.Errorf("invalid day_rollover: %w", err)
+	}
+	switch s.JournalLayout {
+	case JournalLayoutFlat, JournalLayoutNested:
+	default:
+		return fmt.Errorf("invalid journal_layout %q (want %s or %s)%s", s.JournalLayout, JournalLayoutFlat, JournalLayoutNested,
+			Suggest(s.JournalLayout, JournalLayoutFlat, JournalLayoutNested))
+	}
+	if s.TodoProject != "" {
+		if err := ValidateProjectName(s.TodoProject); err != nil {
+			return fmt.Errorf("invalid todo_project: %w", err)
+		}
+	}
+	if s.DefaultAssignee != strings.TrimSpace(s.DefaultAssignee) || strings.ContainsAny(s.DefaultAssignee, "\r\n") {
+		return fmt.Errorf("invalid default_assignee %q (want a name without surrounding spaces or line breaks)", s.DefaultAssignee)
+	}
+	if s.InboxTag == "" || strings.ContainsAny(s.InboxTag, " \t,[]#") {
+		return fmt.Errorf("invalid inbox_tag %q (want one tag, without spaces, commas, brackets, or #)", s.InboxTag)
+	}
+	for _, tag := range s.DefaultTodoTags {
+		if tag == "" || strings.ContainsAny(tag, " \t,[]#") {
+			return fmt.Errorf("invalid default_todo_tags entry %q (want tags without spaces, commas, brackets, or #)", tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSettings writes body as vault's SettingsFile.
+func writeSettings(t *testing.T, vault, body string) {
+	t.Helper()
+	path := filepath.Join(vault, filepath.FromSlash(SettingsFile))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write settings: %v", err)
+	}
+}
+
+func TestLoadSettings_MissingFileUsesDefaults(t *testing.T) {
+	s, err := LoadSettings(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.TaskIDStyle != TaskIDStyleULID {
+		t.Errorf("TaskIDStyle = %q, want %q", s.TaskIDStyle, TaskIDStyleULID)
+	}
+}
+
+func TestLoadSettings_EmptyFileUsesDefaults(t *testing.T) {
+	vault := t.TempDir()
+	writeSettings(t, vault, "")
+	s, err := LoadSettings(vault)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.TaskIDStyle != TaskIDStyleULID {
+		t.Errorf("TaskIDStyle = %q, want %q", s.TaskIDStyle, TaskIDStyleULID)
+	}
+}
+
+func TestLoadSettings_TaskIDStyle(t *testing.T) {
+	vault := t.TempDir()
+	writeSettings(t, vault, "task_id_style: daily\n")
+	s, err := LoadSettings(vault)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.TaskIDStyle != TaskIDStyleDaily {
+		t.Errorf("TaskIDStyle = %q, want %q", s.TaskIDStyle, TaskIDStyleDaily)
+	}
+}
+
+func TestLoadSettings_Rejections(t *testing.T) {
+	for name, tc := range map[string]struct{ body, want string }{
+		"invalid style": {"task_id_style: fancy\n", "invalid task_id_style"},
+		"unknown key":   {"task_id_stlye: slug\n", "task_id_stlye"},
+		"bad yaml":      {"task_id_style: [\n", "parse"},
+		"save mode":     {"tui_save:\n  mode: lazy\n", "invalid tui_save.mode"},
+		"flush seconds": {"tui_save:\n  flush_seconds: 0\n", "invalid tui_save.flush_seconds"},
+		"capacity":      {"daily_capacity: lots\n", "invalid daily_capacity"},
+		"default cmd":   {"default_command: agenda\n", "invalid default_command"},
+		"todos view":    {"tui_view:\n  todos: tree\n", "invalid tui_view.todos"},
+		"tui layout":    {"tui_view:\n  layout: tabs\n", "invalid tui_view.layout"},
+		"tui min size":  {"tui_view:\n  min_size: 80\n", "invalid tui_view.min_size"},
+		"time format":   {"time_format: military\n", "invalid time_format"},
+		"notes sort":    {"tui_sort:\n  notes: title\n", "invalid tui_sort.notes"},
+		"match margin":  {"match_margin: -5\n", "invalid match_margin"},
+		"match floor":   {"match_threshold: -1\n", "invalid match_threshold"},
+		"match cap":     {"match_max_candidates: -1\n", "invalid match_max_candidates"},
+		"archive days":  {"archive_after_days: -1\n", "invalid archive_after_days"},
+		"todo project":  {"todo_project: work/urgent\n", "invalid todo_project"},
+		"assignee":      {"default_assignee: \" sam\"\n", "invalid default_assignee"},
+		"log gap":       {"log_gap_minutes: -5\n", "invalid log_gap_minutes"},
+		"undelete":      {"undelete_seconds: 0\n", "invalid undelete_seconds"},
+		"day rollover":  {"day_rollover: 3am\n", "invalid day_rollover"},
+		"todo id width": {"todo_id_width: 2\n", "invalid todo_id_width"},
+		"log layout":    {"journal_layout: yearly\n", "invalid journal_layout"},
+		"next weights":  {"next_weights:\n  due: -1\n", "invalid next_weights.due"},
+		"tui key name":  {"tui_keys:\n  quitt: Q\n", `invalid tui_keys action "quitt" (did you mean "quit"?)`},
+		"tui key clash": {"tui_keys:\n  quit: x\n", `"x" is bound to both done and quit in the agenda pane`},
+		"tui key esc":   {"tui_keys:\n  new: esc\n", "invalid tui_keys.new"},
+		"inbox tag":     {"inbox_tag: \"to do\"\n", "invalid inbox_tag"},
+		"default tags":  {"default_todo_tags: [sprint, \"#q3\"]\n", "invalid default_todo_tags"},
+	} {
+		vault := t.TempDir()
+		writeSettings(t, vault, tc.body)
+		_, err := LoadSettings(vault)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want one containing %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestLoadSettings_TUISort(t *testing.T) {
+	vault := t.TempDir()
+	s, err := LoadSettings(vault)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.TUISort.Todos != TodoSortPosition || s.TUISort.Log != LogSortNewest {
+		t.Errorf("default TUISort = %+v, want position/newest", s.TUISort)
+	}
+
+	writeSettings(t, vault, "tui_sort:\n  log: sideways\n")
+	if _, err := LoadSettings(vault); err == nil || !strings.Contains(err.Error(), "invalid tui_sort.log") {
+		t.Errorf("err = %v, want an invalid tui_sort.log error", err)
+	}
+}
+
+func TestSetSetting_KeepsOtherKeys(t *testing.T) {
+	vault := t.TempDir()
+	if err := SetSetting(vault, "tui_sort.log", LogSortOldest); err != nil {
+		t.Fatalf("SetSetting on a missing file: %v", err)
+	}
+	writeSettings(t, vault, "# my settings\ntask_id_style: slug\ntui_sort:\n  log: oldest\n")
+	if err := SetSetting(vault, "tui_sort.todos", TodoSortState); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+
+	s, err := LoadSettings(vault)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.TaskIDStyle != TaskIDStyleSlug || s.TUISort.Log != LogSortOldest || s.TUISort.Todos != TodoSortState {
+		t.Errorf("settings = %+v, want slug style, oldest log, state todos", s)
+	}
+	raw, _ := os.ReadFile(filepath.Join(vault, filepath.FromSlash(SettingsFile)))
+	if !strings.Contains(string(raw), "# my settings") {
+		t.Errorf("comment lost:\n%s", raw)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(vault, filepath.Dir(filepath.FromSlash(SettingsFile)), ".settings-*.tmp")); len(tmps) != 0 {
+		t.Errorf("SetSetting left temp files behind: %v", tmps)
+	}
+}
+
+func TestSetSetting_RejectsInvalidValue(t *testing.T) {
+	vault := t.TempDir()
+	writeSettings(t, vault, "tui_sort:\n  log: oldest\n")
+	if err := SetSetting(vault, "tui_sort.log", "sideways"); err == nil {
+		t.Fatal("SetSetting accepted an invalid value")
+	}
+	s, err := LoadSettings(vault)
+	if err != nil || s.TUISort.Log != LogSortOldest {
+		t.Errorf("settings after rejected set = %+v, %v; want the file untouched", s, err)
+	}
+}
+
+func TestTimeLayout(t *testing.T) {
+	for format, want := range map[string]string{
+		TimeFormat24h:        "15:04",
+		TimeFormat12hSeconds: "3:04:05 PM",
+		"15:04:05 MST":       "15:04:05 MST",
+	} {
+		if got, err := TimeLayout(format); err != nil || got != want {
+			t.Errorf("TimeLayout(%q) = %q, %v; want %q", format, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "military", "HH:mm"} {
+		if _, err := TimeLayout(bad); err == nil {
+			t.Errorf("TimeLayout(%q) accepted a value that formats no time", bad)
+		}
+	}
+}
+
+func TestParseTerminalSize(t *testing.T) {
+	if w, h, err := ParseTerminalSize(" 100X30 "); err != nil || w != 100 || h != 30 {
+		t.Errorf("ParseTerminalSize(100X30) = %d, %d, %v; want 100, 30", w, h, err)
+	}
+	for _, in := range []string{"", "80", "80x", "x24", "0x24", "80x-1", "wide x tall"} {
+		if _, _, err := ParseTerminalSize(in); err == nil {
+			t.Errorf("ParseTerminalSize(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Suggest returns ` (did you mean "x"?)` naming the value in valid closest
+// to got, or "" when none is close enough to be a likely typo. Callers
+// append it to an error message that already stands on its own, so output
+// parsed by scripts keeps its shape.
+func Suggest(got string, valid ...string) string {
+	if best := Nearest(got, valid...); best != "" {
+		return fmt.Sprintf(" (did you mean %q?)", best)
+	}
+	return ""
+}
+
+// Nearest returns the value in valid with the smallest edit distance from
+// got (ignoring case), or "" when got is empty, already valid, or more than
+// a third of its length (at least 1, at most 3 edits) away from every value.
+// Ties go to the earlier value.
+func Nearest(got string, valid ...string) string {
+	g := strings.ToLower(strings.TrimSpace(got))
+	if g == "" {
+		return ""
+	}
+	limit := min(max(len(g)/3, 1), 3)
+	best, bestDist := "", limit+1
+	for _, v := range valid {
+		if v == got {
+			return ""
+		}
+		if d := editDistance(g, strings.ToLower(v)); d < bestDist {
+			best, bestDist = v, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b, counted in
+// bytes, with swapping two adjacent bytes also counted as one edit (the
+// optimal string alignment variant), since transposed letters are the
+// commonest typo.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNearest(t *testing.T) {
+	states := []string{"open", "in-progress", "done", "cancelled", "archived"}
+	for got, want := range map[string]string{
+		"inprogress": "in-progress",
+		"canceled":   "cancelled",
+		"DONE":       "done",
+		"opne":       "open",
+		"done":       "", // already valid
+		"blocked":    "", // nothing close
+		"":           "",
+	} {
+		if n := Nearest(got, states...); n != want {
+			t.Errorf("Nearest(%q) = %q, want %q", got, n, want)
+		}
+	}
+	if s := Suggest("grid2", TUILayoutAuto, TUILayoutGrid); s != ` (did you mean "grid"?)` {
+		t.Errorf("Suggest = %q", s)
+	}
+}
+
+func TestLoadSettings_Suggestions(t *testing.T) {
+	for body, want := range map[string]string{
+		"task_id_stlye: slug\n":         `did you mean "task_id_style"?`,
+		"tui_sort:\n  nots: created\n":  `did you mean "notes"?`,
+		"tui_view:\n  layout: foucs\n":  `did you mean "focus"?`,
+		"default_command: todya\n":      `did you mean "today"?`,
+		"tui_sort:\n  log: olderst\n":   `did you mean "oldest"?`,
+		"default_command: something\n":  "",
+		"completely_unrelated_key: 1\n": "",
+	} {
+		vault := t.TempDir()
+		writeSettings(t, vault, body)
+		_, err := LoadSettings(vault)
+		if err == nil {
+			t.Errorf("%q: want an error", body)
+			continue
+		}
+		if want == "" {
+			if strings.Contains(err.Error(), "did you mean") {
+				t.Errorf("%q: err = %v, want no suggestion", body, err)
+			}
+		} else if !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want one containing %q", body, err, want)
+		}
+	}
+}
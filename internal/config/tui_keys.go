@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// TUIKeyAction is one `rk tui` action Settings.TUIKeys can rebind: its
+// name in tui_keys, the key it has by default, and the panes it works in
+// ("agenda", "todos", "log", "notes").
+type TUIKeyAction struct {
+	Name  string
+	Key   string
+	Panes []string
+}
+
+var (
+	tuiAllPanes      = []string{"agenda", "todos", "log", "notes"}
+	tuiActuatorPanes = []string{"agenda", "todos"}
+)
+
+// TUIKeyActions lists every rebindable `rk tui` action, in the order the
+// help lists them.
+var TUIKeyActions = []TUIKeyAction{
+	{"down", "j", []string{"agenda", "todos", "log"}},
+	{"up", "k", []string{"agenda", "todos", "log"}},
+	{"new", "n", []string{"todos", "log", "notes"}},
+	{"linked-note", "L", []string{"log"}},
+	{"edit", "e", []string{"log"}},
+	{"today", "t", tuiActuatorPanes},
+	{"done", "x", tuiActuatorPanes},
+	{"start", "i", tuiActuatorPanes},
+	{"cancel", "c", tuiActuatorPanes},
+	{"defer", "d", tuiActuatorPanes},
+	{"deadline", "D", tuiActuatorPanes},
+	{"priority", "p", tuiActuatorPanes},
+	{"tags", "g", []string{"todos"}},
+	{"open-link", "o", []string{"todos", "log"}},
+	{"jump", "J", []string{"log"}},
+	{"sort", "s", []string{"todos", "log", "notes"}},
+	{"group", "v", []string{"todos"}},
+	{"summary", "S", tuiAllPanes},
+	{"read", "r", tuiAllPanes},
+	{"next-pane", "tab", tuiAllPanes},
+	{"zoom-notes", "ctrl+n", tuiAllPanes},
+	{"save", "ctrl+s", tuiAllPanes},
+	{"quit", "q", tuiAllPanes},
+}
+
+// tuiReservedKeys are keys `rk tui` handles itself and never lets
+// tui_keys take: ctrl+c always quits, esc and enter drive every input, and
+// the arrow keys always move.
+var tuiReservedKeys = []string{"ctrl+c", "esc", "enter", "up", "down"}
+
+// TUIKeyBindings returns every action's key: its tui_keys entry, or its
+// default when keys does not name it.
+func TUIKeyBindings(keys map[string]string) map[string]string {
+	out := make(map[string]string, len(TUIKeyActions))
+	for _, a := range TUIKeyActions {
+		out[a.Name] = a.Key
+		if k, ok := keys[a.Name]; ok {
+			out[a.Name] = k
+		}
+	}
+	return out
+}
+
+// validateTUIKeys rejects a tui_keys entry naming no action, an empty or
+// reserved key, and two actions sharing a key in a pane both work in.
+func validateTUIKeys(keys map[string]string) error {
+	names := make([]string, 0, len(TUIKeyActions))
+	for _, a := range TUIKeyActions {
+		names = append(names, a.Name)
+	}
+	// Report the first problem in a stable order.
+	for _, name := range slices.Sorted(maps.Keys(keys)) {
+		key := keys[name]
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("invalid tui_keys action %q%s", name, Suggest(name, names...))
+		}
+		if key == "" {
+			return fmt.Errorf("invalid tui_keys.%s %q (want a key, like x, ctrl+x, or f2)", name, key)
+		}
+		if slices.Contains(tuiReservedKeys, key) {
+			return fmt.Errorf("invalid tui_keys.%s %q (%s is reserved)", name, key, key)
+		}
+	}
+
+	bound := TUIKeyBindings(keys)
+	for i, a := range TUIKeyActions {
+		for _, b := range TUIKeyActions[i+1:] {
+			if bound[a.Name] != bound[b.Name] {
+				continue
+			}
+			for _, pane := range a.Panes {
+				if slices.Contains(b.Panes, pane) {
+					return fmt.Errorf("invalid tui_keys: %q is bound to both %s and %s in the %s pane",
+						bound[a.Name], a.Name, b.Name, pane)
+				}
+			}
+		}
+	}
+	return nil
+}
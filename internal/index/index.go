@@ -34,6 +34,10 @@ type Index struct {
 	dir      string // cache subdir holding index.db + index.lock
 	lockPath string
 	parser   node.Parser
+
+	// progress, when set, is called after each file a reconcile pass visits
+	// with the running count and the pass's total file count.
+	progress func(done, total int)
 }
 
 // vaultID derives a stable per-vault identifier from the absolute vault path, so
@@ -135,6 +139,12 @@ func (ix *Index) tableExists(name string) (bool, error) {
 	return n > 0, nil
 }
 
+// SetProgress registers fn to be called after every file a Rebuild or
+// Reconcile pass visits, with the number of files handled so far and the
+// pass's total. Setting a callback costs one extra directory walk per pass
+// (to learn the total); nil disables reporting.
+func (ix *Index) SetProgress(fn func(done, total int)) { ix.progress = fn }
+
 // DB exposes the underlying connection. Callers should treat the public views as
 // the stable contract and the physical tables as private.
 func (ix *Index) DB() *sql.DB { return ix.db }
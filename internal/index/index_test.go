@@ -359,6 +359,50 @@ func TestReconcileRenameKeyedByULID(t *testing.T) {
 	}
 }
 
+// TestReconcileMarkdownLinks: a relative [text](note.md) link is a
+// references edge resolved to the file at that path, from a note or from a
+// log entry; images, URLs, code spans and links out of the vault are not.
+func TestReconcileMarkdownLinks(t *testing.T) {
+	cfg, vault := testVault(t)
+	idB, idC := node.Mint(), node.Mint()
+	writeFile(t, vault, "notes/b.md", noteFile(idB, "b body"))
+	writeFile(t, vault, "c.md", noteFile(idC, "c body"))
+
+	ix, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer ix.Close()
+	if _, err := ix.Rebuild(); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+
+	idA, entry := node.Mint(), node.Mint()
+	writeFile(t, vault, "notes/a.md", noteFile(idA, "See [b](b.md#intro) and [c](../c.md), "+
+		"[gone](missing.md), [site](https://example.com/x.md), ![img](b.md), `[code](b.md)`, [out](../../x.md)."))
+	writeFile(t, vault, "log/2026-07-10.md", "---\nid: "+node.Mint()+"\ntype: log-day\naliases: [2026-07-10]\n---\n# 2026-07-10\n\n"+
+		node.RenderLogEntry("09:00", "mike", entry, "Read [the note](../notes/b.md)."))
+	if _, err := ix.Reconcile(); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+
+	if got := count(t, ix, "SELECT count(*) FROM edges WHERE src=? AND rel='references'", idA); got != 3 {
+		t.Errorf("a.md has %d references edges, want 3 (b, c, missing)", got)
+	}
+	if got := count(t, ix, "SELECT count(*) FROM edges WHERE src=? AND dst='notes/b.md' AND dst_key=? AND to_frag='intro'", idA, idB); got != 1 {
+		t.Errorf("[b](b.md#intro) did not resolve to notes/b.md")
+	}
+	if got := count(t, ix, "SELECT count(*) FROM edges WHERE src=? AND dst='c.md' AND dst_key=?", idA, idC); got != 1 {
+		t.Errorf("[c](../c.md) did not resolve to c.md")
+	}
+	if got := count(t, ix, "SELECT count(*) FROM edges WHERE src=? AND dst='notes/missing.md' AND dst_key IS NULL", idA); got != 1 {
+		t.Errorf("[gone](missing.md) is not a dangling reference")
+	}
+	if got := count(t, ix, "SELECT count(*) FROM edges WHERE src=? AND dst_key=?", entry, idB); got != 1 {
+		t.Errorf("the log entry's markdown link did not resolve to notes/b.md")
+	}
+}
+
 func TestReconcileMtimeFastPath(t *testing.T) {
 	cfg, vault := testVault(t)
 	writeFile(t, vault, "a.md", noteFile(node.Mint(), "body"))
@@ -434,9 +478,18 @@ func TestIgnoreGlobsAndConflictMarkers(t *testing.T) {
 		t.Fatalf("Open: %v", err)
 	}
 	defer ix.Close()
-	if _, err := ix.Rebuild(); err != nil {
+	st, err := ix.Rebuild()
+	if err != nil {
 		t.Fatalf("rebuild must tolerate malformed files: %v", err)
 	}
+	if len(st.Warnings) != 1 || st.Warnings[0].Kind != "unparsable" || st.Warnings[0].Files[0] != "bad.md" ||
+		!strings.Contains(st.Warnings[0].Error, "conflict marker") {
+		t.Errorf("warnings = %+v, want bad.md reported unparsable", st.Warnings)
+	}
+	// Still unindexed, so still reported on the next pass.
+	if st, err := ix.Reconcile(); err != nil || len(st.Warnings) != 1 {
+		t.Errorf("reconcile: warnings = %+v, err %v; want bad.md again", st.Warnings, err)
+	}
 	if got := count(t, ix, "SELECT count(*) FROM nodes"); got != 1 {
 		t.Errorf("nodes = %d, want 1 (only good.md indexed)", got)
 	}
@@ -867,3 +920,35 @@ func TestReconcileDuplicateULIDSurvivesRename(t *testing.T) {
 		t.Errorf("duplicate_ulid Files after rename = %q, want %q", got, "a.md,b-renamed.md")
 	}
 }
+
+func TestRebuildReportsProgress(t *testing.T) {
+	cfg, vault := testVault(t)
+	writeFile(t, vault, "a.md", noteFile(node.Mint(), "a"))
+	writeFile(t, vault, "sub/b.md", noteFile(node.Mint(), "b"))
+	writeFile(t, vault, "notes.txt", "not markdown")
+	writeFile(t, vault, ".git/c.md", noteFile(node.Mint(), "skipped dir"))
+
+	ix, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer ix.Close()
+
+	var calls [][2]int
+	ix.SetProgress(func(done, total int) { calls = append(calls, [2]int{done, total}) })
+	if _, err := ix.Rebuild(); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	want := [][2]int{{1, 2}, {2, 2}}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Fatalf("progress calls = %v, want %v", calls, want)
+	}
+
+	ix.SetProgress(nil)
+	if _, err := ix.Reconcile(); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("progress called after SetProgress(nil): %v", calls)
+	}
+}
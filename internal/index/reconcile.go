@@ -24,19 +24,20 @@ type Stats struct {
 	Deleted  int // files removed from the index (gone from disk)
 
 	// Warnings lists non-fatal data-quality issues found during this pass
-	// (e.g. duplicate ULIDs, alias collisions). Recomputed fresh every pass;
-	// a resolved issue simply stops appearing.
+	// (e.g. duplicate ULIDs, alias collisions, unparsable files). Recomputed
+	// fresh every pass; a resolved issue simply stops appearing.
 	Warnings []Warning
 }
 
 // Warning is a non-fatal data-quality issue found during a reconcile pass.
 // Warnings are recomputed every pass; a resolved collision stops appearing.
 type Warning struct {
-	Kind     string   `json:"kind"`                // "duplicate_ulid" | "alias_collision"
+	Kind     string   `json:"kind"`                // "duplicate_ulid" | "alias_collision" | "unparsable"
 	ULID     string   `json:"ulid,omitempty"`      // duplicate_ulid only (== the shared node_key)
 	Alias    string   `json:"alias,omitempty"`     // alias_collision only
 	NodeKeys []string `json:"node_keys,omitempty"` // alias_collision only (sorted)
-	Files    []string `json:"files"`               // colliding file paths (sorted, deduped)
+	Error    string   `json:"error,omitempty"`     // unparsable only: why the parser refused the file
+	Files    []string `json:"files"`               // colliding file paths (sorted, deduped); unparsable: the one file
 }
 
 // Rebuild performs a full, deterministic rebuild from vault text: it drops and
@@ -129,6 +130,12 @@ func (ix *Index) reconcileTx(tx *sql.Tx) (Stats, error) {
 	present := map[string]bool{}   // node keys that exist after this pass
 	diskPaths := map[string]bool{} // relpaths seen on disk
 	occ := map[string][]string{}   // node key -> relpaths that claimed it this pass (dup detection)
+	var unparsable []Warning       // files the parser refused this pass
+
+	total := 0
+	if ix.progress != nil {
+		total = ix.countIndexable()
+	}
 
 	walkErr := filepath.WalkDir(ix.cfg.VaultDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -150,6 +157,11 @@ func (ix *Index) reconcileTx(tx *sql.Tx) (Stats, error) {
 		rel = filepath.ToSlash(rel)
 		diskPaths[rel] = true
 		st.Scanned++
+		if ix.progress != nil {
+			// Deferred so the callback fires once this file is fully handled,
+			// whichever return path below it takes.
+			defer ix.progress(st.Scanned, max(total, st.Scanned))
+		}
 
 		info, err := d.Info()
 		if err != nil {
@@ -186,10 +198,13 @@ func (ix *Index) reconcileTx(tx *sql.Tx) (Stats, error) {
 			// Malformed (conflict markers, etc.): log + skip, never crash the
 			// reconcile. Drop any stored meta so the file is retried next pass and
 			// its old nodes get swept (its keys are not added to present).
+			// Having no meta, it is reparsed, and so reported, every pass
+			// until fixed.
 			logger.Warn("index: skipping unparsable file", "path", rel, "err", perr)
 			if err := deleteFileMeta(tx, rel); err != nil {
 				return err
 			}
+			unparsable = append(unparsable, Warning{Kind: "unparsable", Error: perr.Error(), Files: []string{rel}})
 			return nil
 		}
 		st.Reparsed++
@@ -214,7 +229,7 @@ func (ix *Index) reconcileTx(tx *sql.Tx) (Stats, error) {
 		return st, err
 	}
 
-	warnings, err := collectWarnings(tx, occ)
+	warnings, err := collectWarnings(tx, occ, unparsable)
 	if err != nil {
 		return st, err
 	}
@@ -222,6 +237,29 @@ func (ix *Index) reconcileTx(tx *sql.Tx) (Stats, error) {
 	return st, nil
 }
 
+// countIndexable walks the vault with reconcileTx's own skip rules and
+// returns how many files a pass will visit. It only feeds progress totals,
+// so walk errors are ignored (the real pass reports them).
+func (ix *Index) countIndexable() int {
+	n := 0
+	_ = filepath.WalkDir(ix.cfg.VaultDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != ix.cfg.VaultDir && shouldSkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if indexable(d.Name()) {
+			n++
+		}
+		return nil
+	})
+	return n
+}
+
 // indexFile parses one file and upserts its nodes (and their edges/props/aliases/
 // fts rows), returning the node keys it produced.
 func (ix *Index) indexFile(tx *sql.Tx, rel string, raw []byte, hash string, mtime int64) ([]string, error) {
@@ -377,14 +415,18 @@ func sweepFileMeta(tx *sql.Tx, stored map[string]fileMeta, diskPaths map[string]
 }
 
 // resolveEdges recomputes dst_key for every edge: a target resolves to a node by
-// ULID first, then by alias; an unresolvable target stays NULL (dangling).
+// ULID first, then by alias, then -- for a markdown link's vault-relative .md
+// path -- to the node of the file at that path (a log day's day node rather
+// than one of its entries); an unresolvable target stays NULL (dangling).
 func resolveEdges(tx *sql.Tx) error {
 	// ORDER BY keeps an ambiguous alias (same alias on >1 node) resolving
 	// deterministically rather than picking an arbitrary row.
 	_, err := tx.Exec(`
 		UPDATE _edges SET dst_key = COALESCE(
 			(SELECT n.node_key FROM _nodes n WHERE n.ulid = _edges.dst AND n.ulid <> ''),
-			(SELECT a.node_key FROM _aliases a WHERE a.alias = _edges.dst ORDER BY a.node_key LIMIT 1)
+			(SELECT a.node_key FROM _aliases a WHERE a.alias = _edges.dst ORDER BY a.node_key LIMIT 1),
+			(SELECT n.node_key FROM _nodes n WHERE n.loc_file = _edges.dst
+				ORDER BY n.type = 'log-entry', n.node_key LIMIT 1)
 		)`)
 	if err != nil {
 		return fmt.Errorf("index: resolve edges: %w", err)
@@ -394,12 +436,13 @@ func resolveEdges(tx *sql.Tx) error {
 
 // collectWarnings builds the non-fatal data-quality warnings for this pass:
 // duplicate-ULID collisions from the live occurrence map built during the walk
-// (occ), and alias collisions from a post-sweep query over the surviving
-// _aliases/_nodes state. The result is sorted by (Kind, ULID-or-Alias) for
-// determinism, with each warning's Files/NodeKeys sorted and deduped. Always
-// returns a non-nil slice ([]Warning{} when clean) so JSON marshals to [].
-func collectWarnings(tx *sql.Tx, occ map[string][]string) ([]Warning, error) {
-	warnings := []Warning{}
+// (occ), alias collisions from a post-sweep query over the surviving
+// _aliases/_nodes state, and the walk's unparsable files. The result is sorted
+// by (Kind, ULID-or-Alias-or-file) for determinism, with each warning's
+// Files/NodeKeys sorted and deduped. Always returns a non-nil slice
+// ([]Warning{} when clean) so JSON marshals to [].
+func collectWarnings(tx *sql.Tx, occ map[string][]string, unparsable []Warning) ([]Warning, error) {
+	warnings := append([]Warning{}, unparsable...)
 	for key, files := range occ {
 		if len(files) < 2 {
 			continue
@@ -428,10 +471,14 @@ func collectWarnings(tx *sql.Tx, occ map[string][]string) ([]Warning, error) {
 }
 
 // warningSortKey returns the value collectWarnings sorts a Warning by within
-// its Kind: the shared ULID for duplicate_ulid, the shared alias otherwise.
+// its Kind: the shared ULID for duplicate_ulid, the file for unparsable, the
+// shared alias otherwise.
 func warningSortKey(w Warning) string {
-	if w.Kind == "duplicate_ulid" {
+	switch w.Kind {
+	case "duplicate_ulid":
 		return w.ULID
+	case "unparsable":
+		return w.Files[0]
 	}
 	return w.Alias
 }
@@ -597,9 +644,10 @@ func hashBytes(b []byte) string {
 
 func nowStamp() string { return time.Now().UTC().Format(time.RFC3339Nano) }
 
-// skipDirs are directories never descended into during a walk.
+// skipDirs are directories never descended into during a walk. .trash
+// holds the todos `rk todo delete` removed, until they expire.
 var skipDirs = map[string]bool{
-	".git": true, ".obsidian": true, ".reckon": true, ".stversions": true,
+	".git": true, ".obsidian": true, ".reckon": true, ".stversions": true, ".trash": true,
 }
 
 func shouldSkipDir(name string) bool {
@@ -9,7 +9,11 @@ package index
 // the public, MATCH-capable `fts_search` vtable.
 // v3: added the derived `_nodes.title` column (first non-empty body line,
 // computed in insertNode).
-const SchemaVersion = 3
+// v4: a log entry's body [[refs]] are edges from the entry rather than its
+// log-day (node.LogParser); the bump rebuilds edges indexed the old way.
+// v5: a relative markdown link to a .md file, [text](note.md), is a
+// references edge too, resolved by the target file's path.
+const SchemaVersion = 5
 
 // BuilderVersion identifies the code that built the index (display/debounce only,
 // never correctness).
@@ -41,6 +45,7 @@ CREATE TABLE _nodes (
 );
 CREATE INDEX _nodes_ulid ON _nodes(ulid);
 CREATE INDEX _nodes_type ON _nodes(type);
+CREATE INDEX _nodes_loc ON _nodes(loc_file);
 
 CREATE TABLE _edges (
     src_key   TEXT NOT NULL,
@@ -31,6 +31,27 @@ var (
 	frontmatterRe = regexp.MustCompile(`^---\s*$`)
 )
 
+// noteContinuationIndent starts each line after the first of a multi-line
+// note. A note is written as its "- <id> <text>" line holding the text's
+// first line, then one line per further line of text with this indent, so
+// newlines from stdin capture or an editor survive a parse/write round
+// trip. A blank line of the text is written as the bare indent; a fully
+// empty line between continuation lines (an editor trimming trailing
+// spaces) is read back the same way.
+const noteContinuationIndent = "    "
+
+// formatNoteText renders note text for a note line: newlines become
+// continuation lines.
+func formatNoteText(text string) string {
+	return strings.ReplaceAll(text, "\n", "\n"+noteContinuationIndent)
+}
+
+// noteContinuation reports whether line continues the note above it, and
+// returns its text without the indent.
+func noteContinuation(line string) (string, bool) {
+	return strings.CutPrefix(line, noteContinuationIndent)
+}
+
 var ErrNoFrontmatter = errors.New("no frontmatter found")
 
 type TaskFileFrontmatter struct {
@@ -56,11 +77,27 @@ func ParseTasksFile(content string) ([]Task, error) {
 	var currentTask *Task
 	taskPos := 0
 	notePos := 0
+	inNote := false // the last line read was a note or its continuation
+	blanks := 0     // empty lines since then, kept if the note continues
 
 	for scanner.Scan() {
 		line := scanner.Text()
 		trimmed := strings.TrimSpace(line)
 
+		if inNote {
+			if text, ok := noteContinuation(line); ok {
+				last := &currentTask.Notes[len(currentTask.Notes)-1]
+				last.Text += strings.Repeat("\n", blanks+1) + text
+				blanks = 0
+				continue
+			}
+			if line == "" {
+				blanks++
+				continue
+			}
+			inNote, blanks = false, 0
+		}
+
 		// Skip empty lines and header
 		if trimmed == "" || trimmed == "# Tasks" {
 			continue
@@ -82,6 +119,7 @@ func ParseTasksFile(content string) ([]Task, error) {
 				}
 				currentTask.Notes = append(currentTask.Notes, note)
 				notePos++
+				inNote = true
 			}
 			continue
 		}
@@ -344,7 +382,7 @@ func WriteTasksFile(tasks []Task) string {
 		})
 
 		for _, note := range sortedNotes {
-			sb.WriteString(fmt.Sprintf("  - %s %s\n", note.ID, note.Text))
+			sb.WriteString(fmt.Sprintf("  - %s %s\n", note.ID, formatNoteText(note.Text)))
 		}
 	}
 
@@ -1122,3 +1122,62 @@ This has **bold** and ## Log mentioned in text
 	require.NoError(t, err)
 	assert.Equal(t, "This has **bold** and ## Log mentioned in text", parsed.Description)
 }
+
+func TestRoundTrip_MultiLineNotes(t *testing.T) {
+	texts := []string{
+		"single line",
+		"first line\nsecond line",
+		"output:\n\n  indented\n- [ ] not a task\n  - not a note\n---\n",
+		"ends with a blank\n",
+	}
+	notes := make([]TaskNote, len(texts))
+	for i, text := range texts {
+		notes[i] = TaskNote{ID: "note-00" + string(rune('1'+i)), Text: text, Position: i}
+	}
+	task := Task{ID: "task-001", Text: "Debug it", Status: TaskOpen, Notes: notes, CreatedAt: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)}
+	next := Task{ID: "task-002", Text: "Next task", Status: TaskOpen, Notes: []TaskNote{}, Position: 1}
+
+	t.Run("tasks file", func(t *testing.T) {
+		written := WriteTasksFile([]Task{task, next})
+		assert.Contains(t, written, "  - note-002 first line\n    second line\n")
+		parsed, err := ParseTasksFile(written)
+		require.NoError(t, err)
+		require.Len(t, parsed, 2)
+		require.Len(t, parsed[0].Notes, len(texts))
+		for i, text := range texts {
+			assert.Equal(t, text, parsed[0].Notes[i].Text, "note %d", i)
+		}
+		assert.Equal(t, "Next task", parsed[1].Text)
+		assert.Equal(t, written, WriteTasksFile(parsed))
+	})
+
+	t.Run("tasks file with trailing spaces trimmed", func(t *testing.T) {
+		written := strings.ReplaceAll(WriteTasksFile([]Task{task}), "\n    \n", "\n\n")
+		parsed, err := ParseTasksFile(written)
+		require.NoError(t, err)
+		require.Len(t, parsed, 1)
+		// Blank lines inside a note survive; a trailing one trimmed away
+		// has nothing after it to continue.
+		assert.Equal(t, strings.TrimSuffix(texts[2], "\n"), parsed[0].Notes[2].Text)
+	})
+
+	t.Run("task file", func(t *testing.T) {
+		written, err := WriteTaskFile(task)
+		require.NoError(t, err)
+		parsed, err := ParseTaskFile(written)
+		require.NoError(t, err)
+		require.Len(t, parsed.Notes, len(texts))
+		for i, text := range texts {
+			assert.Equal(t, text, parsed.Notes[i].Text, "note %d", i)
+		}
+	})
+
+	t.Run("task service file", func(t *testing.T) {
+		_, _, parsed, err := parseTaskFile(writeTaskFile(task))
+		require.NoError(t, err)
+		require.Len(t, parsed, len(texts))
+		for i, text := range texts {
+			assert.Equal(t, text, parsed[i].Text, "note %d", i)
+		}
+	})
+}
@@ -227,7 +227,9 @@ func (s *TaskService) GetAllTasks() ([]Task, error) {
 
 // parseTaskFile extracts and parses the YAML frontmatter, description, and notes from task content
 func parseTaskFile(content string) (*TaskFrontmatter, string, []TaskNote, error) {
-	parts := strings.Split(content, "---")
+	// Only the frontmatter's fences split: a "---" in the body (a note's
+	// pasted output, say) is body text.
+	parts := strings.SplitN(content, "---", 3)
 	if len(parts) < 3 {
 		return nil, "", nil, fmt.Errorf("invalid frontmatter format")
 	}
@@ -244,24 +246,49 @@ func parseTaskFile(content string) (*TaskFrontmatter, string, []TaskNote, error)
 	return &fm, description, notes, nil
 }
 
-// parseNotesFromBody parses notes from the task file body
+// parseNotesFromBody parses notes from the task file body: the "## Log"
+// section's "- <id> <text>" lines (indented two spaces under a "### date"
+// line, or not at all as WriteTaskFile writes them), each with its
+// continuation lines (see noteContinuationIndent).
 func parseNotesFromBody(body string) []TaskNote {
 	lines := strings.Split(body, "\n")
 	var notes []TaskNote
 	inLog := false
+	inNote := false
+	blanks := 0
 
 	for _, line := range lines {
+		if inNote {
+			if text, ok := noteContinuation(line); ok {
+				notes[len(notes)-1].Text += strings.Repeat("\n", blanks+1) + text
+				blanks = 0
+				continue
+			}
+			if line == "" {
+				blanks++
+				continue
+			}
+			inNote, blanks = false, 0
+		}
 		trimmed := strings.TrimSpace(line)
 		if trimmed == "## Log" {
 			inLog = true
 			continue
 		}
+		if inLog && strings.HasPrefix(trimmed, "## ") {
+			inLog = false
+			continue
+		}
 		if inLog && strings.HasPrefix(trimmed, "### ") {
 			// date line, skip
 			continue
 		}
-		if inLog && strings.HasPrefix(line, "  - ") {
-			noteText := strings.TrimPrefix(line, "  - ")
+		noteText, ok := strings.CutPrefix(line, "  - ")
+		if !ok {
+			noteText, ok = strings.CutPrefix(line, "- ")
+		}
+		if inLog && ok {
+			inNote = true
 			id, text := extractID(noteText)
 			if id == "" {
 				id = xid.New().String()
@@ -633,7 +660,7 @@ func writeTaskFile(task Task) string {
 	if len(task.Notes) > 0 {
 		logSection += fmt.Sprintf("### %s\n", task.CreatedAt.Format("2006-01-02"))
 		for _, note := range task.Notes {
-			logSection += fmt.Sprintf("  - %s %s\n", note.ID, note.Text)
+			logSection += fmt.Sprintf("  - %s %s\n", note.ID, formatNoteText(note.Text))
 		}
 	}
 
@@ -61,7 +61,7 @@ func WriteTaskFile(task Task) (string, error) {
 		})
 
 		for _, note := range sortedNotes {
-			sb.WriteString(fmt.Sprintf("- %s %s\n", note.ID, note.Text))
+			sb.WriteString(fmt.Sprintf("- %s %s\n", note.ID, formatNoteText(note.Text)))
 		}
 	}
 
@@ -48,3 +48,32 @@ func (n *Node) InsertField(key, value string) error {
 	*n = *reparsed
 	return nil
 }
+
+// RemoveField deletes a scalar frontmatter key, splicing out its whole
+// "key: value" line (line ending included) and re-parsing; every other byte
+// is unchanged. A key that appears more than once loses every occurrence,
+// so the parser's "last line wins" never resurrects an older value. A key
+// with no scalar span (absent, or a block-style list) is left alone, so
+// removing an absent key is a no-op.
+func (n *Node) RemoveField(key string) error {
+	for {
+		span, ok := n.fieldSpans[key]
+		if !ok {
+			return nil
+		}
+		start := bytes.LastIndexByte(n.Raw[:span.Start], '\n') + 1
+		end := len(n.Raw)
+		if nl := bytes.IndexByte(n.Raw[span.End:], '\n'); nl >= 0 {
+			end = span.End + nl + 1
+		}
+		out := make([]byte, 0, len(n.Raw)-(end-start))
+		out = append(out, n.Raw[:start]...)
+		out = append(out, n.Raw[end:]...)
+
+		reparsed, err := ParseAt(out, n.Loc)
+		if err != nil {
+			return fmt.Errorf("RemoveField: re-parse after splice failed: %w", err)
+		}
+		*n = *reparsed
+	}
+}
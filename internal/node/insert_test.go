@@ -233,3 +233,33 @@ func TestInsertField_RoundTrip(t *testing.T) {
 	}
 	sameView(t, "parse(render) after InsertField", reparsedFromRender, n)
 }
+
+// RemoveField splices out the key's whole line and nothing else; repeated
+// keys all go, and an absent key is a no-op.
+func TestRemoveField(t *testing.T) {
+	src := []byte("---\nid: 01J9Z3K7Q2W8XR4M6N0V5BYHED\ntype: todo\nscheduled: 2026-01-02\nstate: open\nscheduled: 2026-01-05\n---\nBuy milk\n")
+	n, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if err := n.RemoveField("scheduled"); err != nil {
+		t.Fatalf("RemoveField: %v", err)
+	}
+	want := "---\nid: 01J9Z3K7Q2W8XR4M6N0V5BYHED\ntype: todo\nstate: open\n---\nBuy milk\n"
+	if got := string(n.Serialize()); got != want {
+		t.Fatalf("remove not surgical\n--- want ---\n%q\n--- got ---\n%q", want, got)
+	}
+	if n.HasField("scheduled") || n.Props["scheduled"] != "" {
+		t.Errorf("scheduled still present after RemoveField: %q", n.Props["scheduled"])
+	}
+	if n.Props["state"] != "open" || n.Type != "todo" {
+		t.Errorf("view changed: state %q, type %q", n.Props["state"], n.Type)
+	}
+
+	if err := n.RemoveField("deadline"); err != nil {
+		t.Fatalf("RemoveField of an absent key: %v", err)
+	}
+	if got := string(n.Serialize()); got != want {
+		t.Errorf("removing an absent key changed the bytes: %q", got)
+	}
+}
@@ -17,7 +17,9 @@ package node
 import (
 	"bytes"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // entryHeaderFieldsRe parses one log-entry header line's HH:MM, optional kind
@@ -53,6 +55,16 @@ func (LogParser) Parse(raw []byte, loc Loc) ([]*Node, error) {
 	nodes := make([]*Node, 0, len(entries)+1)
 	nodes = append(nodes, day)
 
+	if len(entries) > 0 {
+		// A [[ref]] in an entry's text is the entry's reference, not the
+		// day's: keep only the preamble's body links on the day node.
+		// extractBody appends body links after deriveView's typed ones, so
+		// they are the tail of day.Links.
+		all := bodyLinks(day.Raw, day.bodySpan.Start, loc)
+		day.Links = append(day.Links[:len(day.Links)-len(all)],
+			bodyLinks(day.Raw[:entries[0].Span.Start], day.bodySpan.Start, loc)...)
+	}
+
 	for _, e := range entries {
 		entry := buildLogEntry(e, day.Raw, dayDate, loc)
 		nodes = append(nodes, entry)
@@ -85,6 +97,22 @@ func logDayDate(day *Node, loc Loc) string {
 	return strings.TrimSuffix(base, ".md")
 }
 
+// EntryTime is the RFC3339 instant of a day file's "## HH:MM" entry header.
+// An hour of 24 or more is past midnight into the next date: a vault with a
+// day_rollover writes entries logged before it into the previous day's file
+// as, e.g., 25:30, so they sort after that day's evening.
+func EntryTime(dayDate, hhmm string) string {
+	day, err := time.Parse("2006-01-02", dayDate)
+	h, m, ok := strings.Cut(hhmm, ":")
+	hour, herr := strconv.Atoi(h)
+	minute, merr := strconv.Atoi(m)
+	if err != nil || !ok || herr != nil || merr != nil {
+		return dayDate + "T" + hhmm + ":00Z"
+	}
+	t := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	return t.Format(time.RFC3339)
+}
+
 // buildLogEntry derives one log-entry *Node from a SplitEntries block. raw is
 // the day node's full Raw bytes (e.Span indexes into it).
 func buildLogEntry(e Entry, raw []byte, dayDate string, loc Loc) *Node {
@@ -109,7 +137,7 @@ func buildLogEntry(e Entry, raw []byte, dayDate string, loc Loc) *Node {
 	// C2, reckon-uv09 review).
 	entryTime := ""
 	if hhmm != "" {
-		entryTime = dayDate + "T" + hhmm + ":00Z"
+		entryTime = EntryTime(dayDate, hhmm)
 	}
 
 	n := &Node{
@@ -131,9 +159,18 @@ func buildLogEntry(e Entry, raw []byte, dayDate string, loc Loc) *Node {
 	if didTarget != "" {
 		n.Links = append(n.Links, Link{Rel: "did", To: didTarget})
 	}
+	n.Links = append(n.Links, bodyLinks(body, 0, loc)...)
 	return n
 }
 
+// bodyLinks returns the references links extractBody derives from
+// raw[bodyStart:] of the file at loc, without touching any node.
+func bodyLinks(raw []byte, bodyStart int, loc Loc) []Link {
+	scratch := Node{Loc: loc}
+	extractBody(&scratch, raw, bodyStart)
+	return scratch.Links
+}
+
 // extractEntryID reports whether rest's first line is an inline `id:: <ULID>`
 // marker (plan.md Decision 3): if so, the ULID is returned and the line is
 // dropped from the returned body; otherwise ulid is "" and body is rest
@@ -161,6 +161,32 @@ func TestLogParser_TimeReconstruction(t *testing.T) {
 			t.Errorf("Time = %q, want %q (derived from Loc.File, no alias present)", nodes[1].Time, want)
 		}
 	})
+
+	t.Run("past_midnight_hour", func(t *testing.T) {
+		src := "---\n" +
+			"id: 01J9Z3K7Q2W8XR4M6N0V5BYHFA\n" +
+			"type: log-day\n" +
+			"aliases: [2026-07-10]\n" +
+			"---\n" +
+			"# 2026-07-10\n\n" +
+			"## 23:10 · mike\n" +
+			"Evening.\n\n" +
+			"## 25:30 · mike\n" +
+			"Logged after midnight, before a 03:00 day_rollover.\n"
+		nodes, err := LogParser{}.Parse([]byte(src), Loc{File: "log/2026-07-10.md"})
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		if len(nodes) != 3 {
+			t.Fatalf("want 3 nodes, got %d", len(nodes))
+		}
+		if got, want := nodes[2].Time, "2026-07-11T01:30:00Z"; got != want {
+			t.Errorf("Time = %q, want %q (25:30 is 01:30 the next date)", got, want)
+		}
+		if nodes[2].Time <= nodes[1].Time {
+			t.Errorf("past-midnight entry %q sorts before the evening's %q", nodes[2].Time, nodes[1].Time)
+		}
+	})
 }
 
 // ─────────────────────────────────────────────────────────────────────────
@@ -588,3 +614,40 @@ func TestLogParser_DidRoundTripIdentity(t *testing.T) {
 			logDayWithDid, out)
 	}
 }
+
+// ─────────────────────────────────────────────────────────────────────────
+// Body [[refs]] inside an entry are that entry's references links; the day
+// node keeps only those in its preamble.
+// ─────────────────────────────────────────────────────────────────────────
+
+func TestLogParser_EntryBodyLinksBelongToEntry(t *testing.T) {
+	raw := "---\nid: 01J9Z3K7Q2W8XR4M6N0V5BYHFA\ntype: log-day\naliases: [2026-07-05]\n---\n" +
+		"# 2026-07-05 see [[weekly-plan]]\n\n" +
+		RenderLogEntry("09:00", "alice", "01J9Z3K7Q2W8XR4M6N0V5BYHFB", "read [[reading-list]] and `[[not-a-link]]`") +
+		RenderLogEntry("10:00", "alice", "01J9Z3K7Q2W8XR4M6N0V5BYHFC", "nothing linked")
+	nodes, err := LogParser{}.Parse([]byte(raw), Loc{File: "log/2026-07-05.md"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	refs := func(n *Node) []string {
+		var out []string
+		for _, l := range n.Links {
+			if l.Rel == "references" {
+				out = append(out, l.To)
+			}
+		}
+		return out
+	}
+	if got := refs(nodes[0]); len(got) != 1 || got[0] != "weekly-plan" {
+		t.Errorf("day references = %v, want only the preamble's [weekly-plan]", got)
+	}
+	if got := refs(nodes[1]); len(got) != 1 || got[0] != "reading-list" {
+		t.Errorf("first entry references = %v, want [reading-list]", got)
+	}
+	if got := refs(nodes[2]); len(got) != 0 {
+		t.Errorf("second entry references = %v, want none", got)
+	}
+	if n := len(nodes[0].Links) - len(refs(nodes[0])); n != 2 {
+		t.Errorf("day has %d non-references links, want its 2 contains links", n)
+	}
+}
@@ -8,7 +8,8 @@
 // span splices, never a regenerate-from-model (the lossy anti-pattern the design
 // exists to prevent).
 //
-// Link routing (spec invariant 3): a body [[ref]] becomes a `references` edge; a
+// Link routing (spec invariant 3): a body [[ref]], or a relative markdown link
+// to a .md file, becomes a `references` edge; a
 // ref-valued frontmatter prop `K: [[X]]` becomes a typed edge with rel = the prop
 // key (the generic core's rule — per-type rel vocab, e.g. depends->depends-on, is
 // a per-tool parser's job) and is dropped from props. Resolution of alias/ULID
@@ -31,6 +32,8 @@ package node
 import (
 	"bytes"
 	"fmt"
+	"net/url"
+	"path"
 	"regexp"
 	"strings"
 )
@@ -107,6 +110,8 @@ var (
 	blockItemRe = regexp.MustCompile(`^[ \t]+-[ \t]*(.*)$`)
 
 	wikilinkRe    = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+	mdLinkRe      = regexp.MustCompile(`(!?)\[[^\]]*\]\(([^)\s]+)\)`)
+	urlSchemeRe   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
 	blockAnchorRe = regexp.MustCompile(`\s\^([A-Za-z0-9][A-Za-z0-9-]*)\s*$`)
 	fenceRe       = regexp.MustCompile("^(```|~~~)")
 )
@@ -392,31 +397,53 @@ func deriveView(n *Node) {
 	}
 }
 
-// extractBody appends body-derived links (rel=references) and block-anchor
-// fragments, treating fenced code blocks AND inline code spans as inert (so
+// extractBody appends body-derived links (rel=references: [[wikilinks]], then
+// markdown links to .md files, see mdLinkTarget) and block-anchor fragments,
+// treating fenced code blocks AND inline code spans as inert (so
 // [[notalink]] / #nottag inside either is correctly ignored — a correctness
 // requirement for the index). Indented (4-space) code blocks are not treated
 // as code — an explicit non-goal, see internal/node/AGENTS.md.
 func extractBody(n *Node, raw []byte, bodyStart int) {
-	body := raw[bodyStart:]
+	for _, masked := range CodeMaskedLines(string(raw[bodyStart:])) {
+		for _, lm := range wikilinkRe.FindAllStringSubmatch(masked, -1) {
+			n.Links = append(n.Links, parseBodyLink(lm[1]))
+		}
+		for _, lm := range mdLinkRe.FindAllStringSubmatch(masked, -1) {
+			if lm[1] == "!" {
+				continue // an image, not a link
+			}
+			if to, frag, ok := mdLinkTarget(n.Loc.File, lm[2]); ok {
+				n.Links = append(n.Links, Link{Rel: "references", To: to, ToFrag: frag})
+			}
+		}
+		if bm := blockAnchorRe.FindStringSubmatch(masked); bm != nil {
+			n.Fragments = append(n.Fragments, Fragment{ID: bm[1]})
+		}
+	}
+}
+
+// CodeMaskedLines splits body into lines with code made inert, as the index
+// reads them: fence lines and everything inside a fenced code block come
+// back "", and inline code spans are blanked to spaces (maskInlineCode).
+// There is one result per line of strings.Split(body, "\n"), and a masked
+// line keeps its original's byte offsets, so a caller can find [[links]] or
+// ![[embeds]] in the masked line and edit the original.
+func CodeMaskedLines(body string) []string {
+	lines := strings.Split(body, "\n")
 	inFence := false
-	for _, lineB := range bytes.Split(body, []byte("\n")) {
-		line := string(lineB)
+	for i, line := range lines {
 		if fenceRe.MatchString(strings.TrimSpace(line)) {
 			inFence = !inFence
+			lines[i] = ""
 			continue
 		}
 		if inFence {
+			lines[i] = ""
 			continue
 		}
-		masked := maskInlineCode(line)
-		for _, lm := range wikilinkRe.FindAllStringSubmatch(masked, -1) {
-			n.Links = append(n.Links, parseBodyLink(lm[1]))
-		}
-		if bm := blockAnchorRe.FindStringSubmatch(masked); bm != nil {
-			n.Fragments = append(n.Fragments, Fragment{ID: bm[1]})
-		}
+		lines[i] = maskInlineCode(line)
 	}
+	return lines
 }
 
 // maskInlineCode replaces backtick-delimited inline code spans within a single
@@ -554,6 +581,36 @@ func parseBodyLink(inner string) Link {
 	return Link{Rel: "references", To: to, ToFrag: frag}
 }
 
+// mdLinkTarget resolves a markdown link's target, as written in the file at
+// from (vault-relative; "" for a node parsed without a location), to the
+// vault-relative path of the .md file it names, plus any #fragment. Links
+// with a URL scheme, bare #anchors, non-.md targets and paths that climb out
+// of the vault are not references (ok is false). The index resolves the path
+// to the node of the file at it.
+func mdLinkTarget(from, target string) (to, frag string, ok bool) {
+	if i := strings.Index(target, "#"); i >= 0 {
+		target, frag = target[:i], strings.TrimPrefix(target[i+1:], "^")
+	}
+	if target == "" || urlSchemeRe.MatchString(target) {
+		return "", "", false
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	if !strings.HasSuffix(strings.ToLower(target), ".md") {
+		return "", "", false
+	}
+	if strings.HasPrefix(target, "/") {
+		to = path.Clean(strings.TrimPrefix(target, "/"))
+	} else {
+		to = path.Join(path.Dir(from), target)
+	}
+	if to == ".." || strings.HasPrefix(to, "../") {
+		return "", "", false
+	}
+	return to, frag, true
+}
+
 // splitRef strips an optional |label and #fragment (or #^block) from a wikilink
 // inner, returning the bare target and the fragment.
 func splitRef(inner string) (to, frag string) {
@@ -581,6 +581,15 @@ func TestCRLFFrontmatter(t *testing.T) {
 // Today's bug: extractBody only tracks fenced (``` / ~~~) blocks via inFence;
 // it has no notion of an inline backtick span and runs wikilinkRe against the
 // raw line text unconditionally.
+func TestCodeMaskedLines(t *testing.T) {
+	body := "See [[a]] and `[[b]]`.\n  ```\n[[c]]\n  ```\n~~~go\n[[d]]\n~~~\nLast [[e]]"
+	want := []string{"See [[a]] and " + strings.Repeat(" ", len("`[[b]]`")) + ".", "", "", "", "", "", "", "Last [[e]]"}
+	got := CodeMaskedLines(body)
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("CodeMaskedLines = %q, want %q", got, want)
+	}
+}
+
 func TestInlineCodeInert(t *testing.T) {
 	t.Run("single_backtick_span_is_inert", func(t *testing.T) {
 		body := "Use the `[[target]]` syntax to link notes.\n"
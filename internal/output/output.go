@@ -1,10 +1,12 @@
-// Package output provides a unified Writer for pretty, JSON, and NDJSON output modes.
+// Package output provides a unified Writer for pretty, JSON, NDJSON, and YAML output modes.
 package output
 
 import (
 	"encoding/json"
 	"fmt"
 	"io"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Mode selects how records are serialised by a Writer.
@@ -14,12 +16,14 @@ const (
 	Pretty Mode = iota // human-readable text
 	JSON               // single JSON object or array
 	NDJSON             // newline-delimited JSON, one object per line
+	YAML               // one YAML document, with the same fields as JSON
 )
 
 // Writer writes records to an io.Writer in a consistent output mode.
 type Writer struct {
-	w    io.Writer
-	mode Mode
+	w      io.Writer
+	mode   Mode
+	indent bool
 }
 
 // New constructs a Writer that serialises to w using the given mode.
@@ -27,18 +31,61 @@ func New(w io.Writer, mode Mode) *Writer {
 	return &Writer{w: w, mode: mode}
 }
 
-// ModeFromFlags derives a Mode from the --json / --ndjson flag values.
-// Returns an error when both are true (mutually exclusive).
-func ModeFromFlags(jsonFlag, ndjsonFlag bool) (Mode, error) {
+// Indent sets whether JSON mode pretty-prints with two-space indentation.
+// NDJSON is one line per record by definition and Pretty is not JSON, so
+// neither is affected. Returns wr for chaining onto New.
+func (wr *Writer) Indent(on bool) *Writer {
+	wr.indent = on
+	return wr
+}
+
+// marshal encodes v compactly, or indented under Indent in JSON mode.
+func (wr *Writer) marshal(v any) ([]byte, error) {
+	if wr.indent && wr.mode == JSON {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
+}
+
+// toYAML re-encodes JSON data as block-style YAML. Going through JSON keeps
+// the records' json tags, omitempty included, as the one field schema, and
+// decoding into a yaml.Node keeps the keys in JSON's order.
+func toYAML(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	clearStyle(&doc)
+	return yaml.Marshal(&doc)
+}
+
+// clearStyle drops the flow and quoting styles decoding JSON leaves on n
+// and its children, so the encoder picks YAML's plain block forms.
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// ModeFromFlags derives a Mode from the --json / --ndjson / --yaml flag
+// values. Returns an error when more than one is true (mutually exclusive).
+func ModeFromFlags(jsonFlag, ndjsonFlag, yamlFlag bool) (Mode, error) {
 	if jsonFlag && ndjsonFlag {
 		return 0, fmt.Errorf("output: --json and --ndjson are mutually exclusive")
 	}
+	if yamlFlag && (jsonFlag || ndjsonFlag) {
+		return 0, fmt.Errorf("output: --yaml and --json/--ndjson are mutually exclusive")
+	}
 	if jsonFlag {
 		return JSON, nil
 	}
 	if ndjsonFlag {
 		return NDJSON, nil
 	}
+	if yamlFlag {
+		return YAML, nil
+	}
 	return Pretty, nil
 }
 
@@ -48,11 +95,14 @@ type prettyPrinter interface {
 }
 
 // Print writes a single record. JSON mode emits one object; NDJSON emits one
-// compact line; Pretty prefers Pretty(), then fmt.Stringer, then fmt.Sprintf("%v").
+// compact line; YAML emits one document; Pretty prefers Pretty(), then
+// fmt.Stringer, then fmt.Sprintf("%v").
 func (wr *Writer) Print(v any) error {
 	switch wr.mode {
+	case YAML:
+		return wr.writeYAML(v)
 	case JSON, NDJSON:
-		data, err := json.Marshal(v)
+		data, err := wr.marshal(v)
 		if err != nil {
 			return fmt.Errorf("output marshal: %w", err)
 		}
@@ -78,12 +128,14 @@ func (wr *Writer) Print(v any) error {
 }
 
 // PrintAll writes a slice of records. JSON mode marshals the whole slice as one
-// array; NDJSON emits one compact JSON line per element; Pretty renders each
-// element individually.
+// array and YAML as one sequence; NDJSON emits one compact JSON line per
+// element; Pretty renders each element individually.
 func (wr *Writer) PrintAll(vs []any) error {
 	switch wr.mode {
+	case YAML:
+		return wr.writeYAML(vs)
 	case JSON:
-		data, err := json.Marshal(vs)
+		data, err := wr.marshal(vs)
 		if err != nil {
 			return fmt.Errorf("output marshal array: %w", err)
 		}
@@ -100,3 +152,18 @@ func (wr *Writer) PrintAll(vs []any) error {
 		return nil
 	}
 }
+
+// writeYAML writes v as one YAML document.
+func (wr *Writer) writeYAML(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("output marshal: %w", err)
+	}
+	if data, err = toYAML(data); err != nil {
+		return fmt.Errorf("output marshal yaml: %w", err)
+	}
+	if _, err := wr.w.Write(data); err != nil {
+		return fmt.Errorf("output write: %w", err)
+	}
+	return nil
+}
@@ -164,42 +164,98 @@ func TestPrintAll_JSONArray(t *testing.T) {
 	}
 }
 
-// TestModeFromFlags_MutualExclusion: ModeFromFlags must return an error when both
-// --json and --ndjson are set (mutually exclusive). Other combinations must succeed
-// with the expected Mode value.
+// TestModeFromFlags_MutualExclusion: ModeFromFlags must return an error when more
+// than one of --json, --ndjson and --yaml is set (mutually exclusive). Other
+// combinations must succeed with the expected Mode value.
 func TestModeFromFlags_MutualExclusion(t *testing.T) {
 	tests := []struct {
 		name    string
 		json    bool
 		ndjson  bool
+		yaml    bool
 		want    Mode
 		wantErr bool
 	}{
 		// both flags → error (AC-3 mutual exclusion)
-		{"both flags set", true, true, 0, true},
+		{"both flags set", true, true, false, 0, true},
 		// json only → JSON mode
-		{"json only", true, false, JSON, false},
+		{"json only", true, false, false, JSON, false},
 		// ndjson only → NDJSON mode
-		{"ndjson only", false, true, NDJSON, false},
+		{"ndjson only", false, true, false, NDJSON, false},
+		// yaml only → YAML mode
+		{"yaml only", false, false, true, YAML, false},
+		// yaml with either JSON flag → error
+		{"yaml and json", true, false, true, 0, true},
+		{"yaml and ndjson", false, true, true, 0, true},
 		// neither → Pretty mode (default)
-		{"neither flag", false, false, Pretty, false},
+		{"neither flag", false, false, false, Pretty, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ModeFromFlags(tt.json, tt.ndjson)
+			got, err := ModeFromFlags(tt.json, tt.ndjson, tt.yaml)
 			if tt.wantErr {
 				if err == nil {
-					t.Errorf("ModeFromFlags(%v, %v): expected error, got nil (mode=%v)", tt.json, tt.ndjson, got)
+					t.Errorf("ModeFromFlags(%v, %v, %v): expected error, got nil (mode=%v)", tt.json, tt.ndjson, tt.yaml, got)
 				}
 			} else {
 				if err != nil {
-					t.Errorf("ModeFromFlags(%v, %v): unexpected error: %v", tt.json, tt.ndjson, err)
+					t.Errorf("ModeFromFlags(%v, %v, %v): unexpected error: %v", tt.json, tt.ndjson, tt.yaml, err)
 					return
 				}
 				if got != tt.want {
-					t.Errorf("ModeFromFlags(%v, %v) = %v, want %v", tt.json, tt.ndjson, got, tt.want)
+					t.Errorf("ModeFromFlags(%v, %v, %v) = %v, want %v", tt.json, tt.ndjson, tt.yaml, got, tt.want)
 				}
 			}
 		})
 	}
 }
+
+// TestIndent: Indent pretty-prints JSON mode and leaves NDJSON one line per
+// record.
+// TestPrint_YAML: YAML mode writes block-style YAML with the JSON fields,
+// in the same order, omitempty fields left out; PrintAll writes one
+// sequence.
+func TestPrint_YAML(t *testing.T) {
+	type dated struct {
+		ID       int      `json:"id"`
+		Title    string   `json:"title"`
+		Deadline string   `json:"deadline,omitempty"`
+		Tags     []string `json:"tags"`
+	}
+	var buf bytes.Buffer
+	w := New(&buf, YAML)
+	if err := w.Print(dated{ID: 1, Title: "Ship: v2", Tags: []string{"work"}}); err != nil {
+		t.Fatalf("Print (YAML): %v", err)
+	}
+	want := "id: 1\ntitle: 'Ship: v2'\ntags:\n    - work\n"
+	if got := buf.String(); got != want {
+		t.Errorf("YAML output:\n%q\nwant\n%q", got, want)
+	}
+
+	buf.Reset()
+	if err := w.PrintAll([]any{testRecord{ID: 1, Value: "a"}, testRecord{ID: 2, Value: "b"}}); err != nil {
+		t.Fatalf("PrintAll (YAML): %v", err)
+	}
+	want = "- id: 1\n  value: a\n- id: 2\n  value: b\n"
+	if got := buf.String(); got != want {
+		t.Errorf("YAML sequence:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestIndent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New(&buf, JSON).Indent(true).Print(testRecord{ID: 1, Value: "alpha"}); err != nil {
+		t.Fatalf("Print (JSON, indent): %v", err)
+	}
+	if want := "{\n  \"id\": 1,\n  \"value\": \"alpha\"\n}\n"; buf.String() != want {
+		t.Errorf("indented JSON = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := New(&buf, NDJSON).Indent(true).PrintAll([]any{testRecord{ID: 1}, testRecord{ID: 2}}); err != nil {
+		t.Fatalf("PrintAll (NDJSON, indent): %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 {
+		t.Errorf("NDJSON under Indent: %d lines, want 2\noutput: %s", len(lines), buf.String())
+	}
+}
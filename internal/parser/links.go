@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 	"unicode"
@@ -30,8 +31,31 @@ var inlineCodePattern = regexp.MustCompile("`[^`]+`")
 // Matches: [[target-slug]] or [[target-slug|display text]]
 var wikiLinkPattern = regexp.MustCompile(`\[\[([^|\]]+)(?:\|([^\]]+))?\]\]`)
 
+// ExtractOptions narrows which parts of a note link extraction looks at,
+// on top of the frontmatter and code blocks it always skips.
+type ExtractOptions struct {
+	// SkipLine, if set, drops every line of the body it reports true for,
+	// e.g. SkipHeadingLines.
+	SkipLine func(line string) bool
+}
+
+// SkipHeadingLines is an ExtractOptions.SkipLine that drops ATX heading
+// lines ("# Title", "## Section"), so a link in a title is not counted.
+func SkipHeadingLines(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return false // indented code, not a heading
+	}
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > 6 {
+		return false
+	}
+	return len(trimmed) == level || trimmed[level] == ' ' || trimmed[level] == '\t'
+}
+
 // ExtractWikiLinks parses markdown content and extracts all wiki-style links,
-// excluding links found within code blocks (both fenced and inline).
+// excluding links found within YAML frontmatter and code blocks (both fenced
+// and inline).
 //
 // The function supports two syntaxes:
 //   - [[note-slug]] - simple link with no display text
@@ -40,8 +64,15 @@ var wikiLinkPattern = regexp.MustCompile(`\[\[([^|\]]+)(?:\|([^\]]+))?\]\]`)
 // Slugs are normalized to lowercase with spaces replaced by hyphens.
 // Empty or whitespace-only links are skipped.
 func ExtractWikiLinks(content string) []WikiLink {
-	// First, remove all code blocks to prevent extracting links from code
-	contentWithoutCode := removeCodeBlocks(content)
+	return ExtractWikiLinksWithOptions(content, ExtractOptions{})
+}
+
+// ExtractWikiLinksWithOptions is ExtractWikiLinks, additionally skipping
+// whatever opts excludes.
+func ExtractWikiLinksWithOptions(content string, opts ExtractOptions) []WikiLink {
+	// First, remove frontmatter and code blocks to prevent extracting links
+	// from metadata or code
+	contentWithoutCode := linkableContent(content, opts)
 
 	// Find all wiki-link matches
 	matches := wikiLinkPattern.FindAllStringSubmatch(contentWithoutCode, -1)
@@ -140,6 +171,46 @@ func NormalizeSlug(slug string) string {
 	return slug
 }
 
+// linkableContent is the part of content link extraction scans: content
+// without its frontmatter, code blocks, or any line opts.SkipLine drops.
+func linkableContent(content string, opts ExtractOptions) string {
+	content = removeCodeBlocks(removeFrontmatter(content))
+	if opts.SkipLine == nil {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !opts.SkipLine(strings.TrimSuffix(line, "\r")) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// removeFrontmatter removes a leading YAML frontmatter block: everything from
+// an opening "---" line through the next "---" (or "...") line. Content
+// without a closed block is returned unchanged.
+func removeFrontmatter(content string) string {
+	body := strings.TrimPrefix(content, "\ufeff")
+	first, rest, ok := strings.Cut(body, "\n")
+	if !ok || strings.TrimRight(first, " \r") != "---" {
+		return content
+	}
+	for offset := 0; offset < len(rest); {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		next := offset + len(line) + 1
+		if fence := strings.TrimRight(line, " \r"); fence == "---" || fence == "..." {
+			if next > len(rest) {
+				return ""
+			}
+			return rest[next:]
+		}
+		offset = next
+	}
+	return content
+}
+
 // removeCodeBlocks removes both fenced code blocks (```) and inline code (`)
 // from the content to prevent extracting links from code.
 func removeCodeBlocks(content string) string {
@@ -151,3 +222,76 @@ func removeCodeBlocks(content string) string {
 
 	return content
 }
+
+// MarkdownLink represents a parsed relative markdown link to another note
+// file, e.g. [text](../2025-01/other-note.md).
+type MarkdownLink struct {
+	Path        string // The link target, unescaped, without any #fragment
+	DisplayText string // The bracketed link text
+	RawLink     string // The original link text for debugging
+}
+
+// markdownLinkPattern matches inline markdown links, capturing an optional
+// leading "!" so images can be told apart: ![alt](img.png) or [text](path).
+var markdownLinkPattern = regexp.MustCompile(`(!?)\[([^\]]*)\]\(([^)\s]+)\)`)
+
+// ExtractMarkdownLinks parses markdown content and extracts inline links to
+// other markdown files, excluding links within frontmatter and code blocks
+// (like ExtractWikiLinks), images, bare #anchors, and any link with a URL scheme
+// (http:, https:, mailto:, ...). Only targets ending in .md are returned, each
+// at most once.
+func ExtractMarkdownLinks(content string) []MarkdownLink {
+	return ExtractMarkdownLinksWithOptions(content, ExtractOptions{})
+}
+
+// ExtractMarkdownLinksWithOptions is ExtractMarkdownLinks, additionally
+// skipping whatever opts excludes.
+func ExtractMarkdownLinksWithOptions(content string, opts ExtractOptions) []MarkdownLink {
+	matches := markdownLinkPattern.FindAllStringSubmatch(linkableContent(content, opts), -1)
+	if matches == nil {
+		return nil
+	}
+
+	links := make([]MarkdownLink, 0, len(matches))
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		if match[1] == "!" {
+			continue // image
+		}
+		target := match[3]
+		if i := strings.IndexByte(target, '#'); i >= 0 {
+			target = target[:i]
+		}
+		if target == "" || hasURLScheme(target) {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		if !strings.HasSuffix(strings.ToLower(target), ".md") || seen[target] {
+			continue
+		}
+		seen[target] = true
+		links = append(links, MarkdownLink{
+			Path:        target,
+			DisplayText: strings.TrimSpace(match[2]),
+			RawLink:     match[0],
+		})
+	}
+	return links
+}
+
+// hasURLScheme reports whether target starts with a URL scheme ("https:",
+// "mailto:"), i.e. points outside the notes directory.
+func hasURLScheme(target string) bool {
+	i := strings.IndexByte(target, ':')
+	if i <= 0 {
+		return false
+	}
+	for j, r := range target[:i] {
+		if !(unicode.IsLetter(r) || (j > 0 && (unicode.IsDigit(r) || r == '+' || r == '-' || r == '.'))) {
+			return false
+		}
+	}
+	return true
+}
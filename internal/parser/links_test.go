@@ -233,6 +233,23 @@ Related: [[project-retrospective]]`,
 	}
 }
 
+func TestExtractMarkdownLinks(t *testing.T) {
+	content := "See [the other note](../2025-01/2025-01-02-other-note.md) and [a section](same.md#intro).\n" +
+		"Not [the web](https://example.com/page.md), [mail](mailto:a@b.md), ![img](pic.md), [anchor](#top),\n" +
+		"[a file](notes.txt), or `[code](code.md)`. Again: [dup](same.md) [[wiki-link]].\n" +
+		"Escaped: [spaced](my%20note.md)"
+
+	links := ExtractMarkdownLinks(content)
+	require.Len(t, links, 3)
+	assert.Equal(t, MarkdownLink{
+		Path:        "../2025-01/2025-01-02-other-note.md",
+		DisplayText: "the other note",
+		RawLink:     "[the other note](../2025-01/2025-01-02-other-note.md)",
+	}, links[0])
+	assert.Equal(t, "same.md", links[1].Path)
+	assert.Equal(t, "my note.md", links[2].Path)
+}
+
 func TestNormalizeSlug(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -394,6 +411,77 @@ func TestNormalizeSlug(t *testing.T) {
 	}
 }
 
+func TestRemoveFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "no frontmatter",
+			content:  "# Title\nBody",
+			expected: "# Title\nBody",
+		},
+		{
+			name:     "frontmatter block",
+			content:  "---\nrelated: [[x]]\n---\nBody [[y]]",
+			expected: "Body [[y]]",
+		},
+		{
+			name:     "dots close the block",
+			content:  "---\ntitle: T\n...\nBody",
+			expected: "Body",
+		},
+		{
+			name:     "CRLF fences",
+			content:  "---\r\ntitle: T\r\n---\r\nBody",
+			expected: "Body",
+		},
+		{
+			name:     "only frontmatter",
+			content:  "---\ntitle: T\n---",
+			expected: "",
+		},
+		{
+			name:     "unclosed block left alone",
+			content:  "---\nnot frontmatter [[x]]",
+			expected: "---\nnot frontmatter [[x]]",
+		},
+		{
+			name:     "rule later in the body is not frontmatter",
+			content:  "Intro\n---\nMore",
+			expected: "Intro\n---\nMore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, removeFrontmatter(tt.content))
+		})
+	}
+}
+
+func TestExtractWikiLinks_SkipsFrontmatter(t *testing.T) {
+	content := "---\nrelated: [[in-frontmatter]]\n---\n# Title\n\nSee [[in-body]]."
+	links := ExtractWikiLinks(content)
+	require.Len(t, links, 1)
+	assert.Equal(t, "in-body", links[0].TargetSlug)
+
+	md := ExtractMarkdownLinks("---\nsee: [x](frontmatter.md)\n---\n[y](body.md)")
+	require.Len(t, md, 1)
+	assert.Equal(t, "body.md", md[0].Path)
+}
+
+func TestExtractWikiLinksWithOptions_SkipHeadingLines(t *testing.T) {
+	content := "# About [[title-link]]\n\n#tag line with [[tag-link]]\n\n## Section\nBody [[body-link]]."
+	links := ExtractWikiLinksWithOptions(content, ExtractOptions{SkipLine: SkipHeadingLines})
+	var slugs []string
+	for _, l := range links {
+		slugs = append(slugs, l.TargetSlug)
+	}
+	assert.Equal(t, []string{"tag-link", "body-link"}, slugs)
+}
+
 func TestRemoveCodeBlocks(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -112,6 +112,38 @@ func (r *NotesRepository) GetNoteBySlug(slug string) (*models.Note, error) {
 	return &note, nil
 }
 
+// GetNoteByFilePath retrieves a note by its file path (as stored: relative
+// to the notes directory). Returns nil, nil if no note has that path.
+func (r *NotesRepository) GetNoteByFilePath(filePath string) (*models.Note, error) {
+	logger.Debug("GetNoteByFilePath", "file_path", filePath)
+
+	var note models.Note
+	var tagsStr sql.NullString
+	var createdUnix, updatedUnix int64
+
+	err := r.db.DB().QueryRow(
+		"SELECT id, title, slug, file_path, created_at, updated_at, tags FROM notes WHERE file_path = ?",
+		filePath,
+	).Scan(&note.ID, &note.Title, &note.Slug, &note.FilePath, &createdUnix, &updatedUnix, &tagsStr)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error("GetNoteByFilePath", "error", err, "file_path", filePath)
+		return nil, fmt.Errorf("failed to get note by file path: %w", err)
+	}
+
+	note.CreatedAt = time.Unix(createdUnix, 0)
+	note.UpdatedAt = time.Unix(updatedUnix, 0)
+
+	if tagsStr.Valid && tagsStr.String != "" {
+		note.Tags = strings.Split(tagsStr.String, ",")
+	}
+
+	return &note, nil
+}
+
 // DeleteNoteLinks deletes all links for a source note (used before re-inserting).
 func (r *NotesRepository) DeleteNoteLinks(tx *sql.Tx, sourceNoteID string, linkType models.LinkType) error {
 	logger.Debug("DeleteNoteLinks", "source_note_id", sourceNoteID, "link_type", linkType)
@@ -43,7 +43,7 @@ func (s *NotesService) GetNoteBySlug(slug string) (*models.Note, error) {
 //
 // The function:
 // 1. Reads the note's markdown file
-// 2. Extracts all wiki-style links ([[slug]] or [[slug|text]])
+// 2. Extracts all wiki-style links ([[slug]] or [[slug|text]]) and relative markdown note links
 // 3. Deletes old wiki links for this note
 // 4. Creates new link records with resolved target_note_id when possible
 // 5. Commits everything in a transaction
@@ -77,6 +77,13 @@ func (s *NotesService) UpdateNoteLinks(note *models.Note, notesDir string) error
 	wikiLinks := parser.ExtractWikiLinks(string(content))
 	logger.Debug("UpdateNoteLinks", "note_id", note.ID, "links_found", len(wikiLinks))
 
+	// Resolve markdown links by file path, before the transaction opens
+	mdLinks, err := s.resolveMarkdownLinks(note, string(content), wikiLinks)
+	if err != nil {
+		logger.Error("UpdateNoteLinks", "error", err, "note_id", note.ID, "operation", "resolve_markdown_links")
+		return err
+	}
+
 	// Begin transaction
 	tx, err := s.repo.db.BeginTx()
 	if err != nil {
@@ -122,16 +129,65 @@ func (s *NotesService) UpdateNoteLinks(note *models.Note, notesDir string) error
 		}
 	}
 
+	for _, link := range mdLinks {
+		if err := s.repo.SaveNoteLink(tx, link); err != nil {
+			logger.Error("UpdateNoteLinks", "error", err, "note_id", note.ID, "link_id", link.ID)
+			return fmt.Errorf("failed to save link: %w", err)
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		logger.Error("UpdateNoteLinks", "error", err, "note_id", note.ID, "operation", "commit_transaction")
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	logger.Info("UpdateNoteLinks", "note_id", note.ID, "links_created", len(wikiLinks))
+	logger.Info("UpdateNoteLinks", "note_id", note.ID, "links_created", len(wikiLinks)+len(mdLinks))
 	return nil
 }
 
+// resolveMarkdownLinks turns content's relative markdown links into
+// reference NoteLinks. Each target path is resolved against the source
+// note's directory and looked up by file path; a link escaping the notes
+// directory is skipped, as is one to a slug wikiLinks already covers. An
+// unresolved link keeps the target file's name as its slug.
+func (s *NotesService) resolveMarkdownLinks(note *models.Note, content string, wikiLinks []parser.WikiLink) ([]*models.NoteLink, error) {
+	seen := make(map[string]bool, len(wikiLinks))
+	for _, wl := range wikiLinks {
+		seen[wl.TargetSlug] = true
+	}
+
+	var links []*models.NoteLink
+	for _, ml := range parser.ExtractMarkdownLinks(content) {
+		target := filepath.Clean(filepath.Join(filepath.Dir(note.FilePath), filepath.FromSlash(ml.Path)))
+		if target == ".." || strings.HasPrefix(target, ".."+string(filepath.Separator)) || filepath.IsAbs(target) {
+			logger.Debug("UpdateNoteLinks", "note_id", note.ID, "markdown_link", ml.RawLink, "skipped", "outside notes dir")
+			continue
+		}
+
+		targetNote, err := s.repo.GetNoteByFilePath(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up target note: %w", err)
+		}
+		slug := parser.NormalizeSlug(strings.TrimSuffix(filepath.Base(target), filepath.Ext(target)))
+		if targetNote != nil {
+			slug = targetNote.Slug
+		}
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		link := models.NewNoteLink(note.ID, slug, models.LinkTypeReference)
+		if targetNote != nil {
+			link.UpdateTargetNoteID(targetNote.ID)
+		}
+		logger.Debug("UpdateNoteLinks", "note_id", note.ID, "markdown_link", ml.RawLink, "target_slug", slug, "resolved", targetNote != nil)
+		links = append(links, link)
+	}
+	return links, nil
+}
+
 // ResolveOrphanedBacklinks finds all links with NULL target_note_id where the target now exists,
 // and updates them to point to the correct note.
 //
@@ -237,6 +237,31 @@ func TestUpdateNoteLinks_ExcludesCodeBlocks(t *testing.T) {
 	assert.NotContains(t, targetSlugs, "inline-link")
 }
 
+func TestUpdateNoteLinks_ExcludesFrontmatter(t *testing.T) {
+	service, _, tempDir := setupNotesTestService(t)
+	defer cleanupNotesTestService(t, tempDir)
+
+	notesDir := filepath.Join(tempDir, "notes")
+
+	// The related: field's link must not be counted alongside the body's
+	content := "---\ntitle: Frontmatter Note\nrelated: [[frontmatter-link]]\n---\n" +
+		"# Frontmatter Note\n\nBody link: [[body-link]]"
+
+	absFilePath := createTestNoteFile(t, notesDir, "frontmatter-note.md", content)
+
+	relFilePath, err := filepath.Rel(notesDir, absFilePath)
+	require.NoError(t, err)
+	note := models.NewNote("Frontmatter Note", "frontmatter-note", relFilePath, nil)
+	require.NoError(t, service.SaveNote(note))
+
+	require.NoError(t, service.UpdateNoteLinks(note, notesDir))
+
+	links, err := service.GetLinksBySourceNote(note.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, "body-link", links[0].TargetSlug)
+}
+
 func TestUpdateNoteLinks_UpdateExistingLinks(t *testing.T) {
 	service, _, tempDir := setupNotesTestService(t)
 	defer cleanupNotesTestService(t, tempDir)
@@ -864,3 +889,37 @@ Links to [[target]].`
 		assert.Nil(t, backlinks[0].SourceNote, "deleted source note should result in nil SourceNote")
 	}
 }
+
+func TestUpdateNoteLinks_MarkdownLinks(t *testing.T) {
+	service, _, tempDir := setupNotesTestService(t)
+	defer cleanupNotesTestService(t, tempDir)
+
+	notesDir := filepath.Join(tempDir, "notes")
+	require.NoError(t, os.MkdirAll(filepath.Join(notesDir, "2025-01"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(notesDir, "2025-02"), 0755))
+
+	target := models.NewNote("Other Note", "other-note", filepath.Join("2025-01", "2025-01-02-other-note.md"), nil)
+	require.NoError(t, service.SaveNote(target))
+
+	content := `# Source
+
+See [the other note](../2025-01/2025-01-02-other-note.md), [a missing one](missing-note.md),
+[[other-note]] again, [the web](https://example.com/x.md), and [outside](../../secret.md).`
+	createTestNoteFile(t, filepath.Join(notesDir, "2025-02"), "source.md", content)
+
+	source := models.NewNote("Source", "source", filepath.Join("2025-02", "source.md"), nil)
+	require.NoError(t, service.SaveNote(source))
+	require.NoError(t, service.UpdateNoteLinks(source, notesDir))
+
+	links, err := service.GetLinksBySourceNote(source.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	bySlug := map[string]string{}
+	for _, link := range links {
+		bySlug[link.TargetSlug] = link.TargetNoteID
+	}
+	assert.Equal(t, target.ID, bySlug["other-note"], "markdown link resolves by file path")
+	id, ok := bySlug["missing-note"]
+	assert.True(t, ok, "unresolved markdown link keeps the file name as its slug")
+	assert.Empty(t, id)
+}
@@ -36,6 +36,10 @@ type Importer struct {
 	// DryRun computes the same create/skip/error plan a real run would,
 	// without writing any file or touching the destination's index/cache.
 	DryRun bool
+	// Progress, when set, is called after each source type is processed
+	// with the running count of records handled (created, skipped, or
+	// errored) so far.
+	Progress func(done int)
 }
 
 // RecordOutcome is one migrated (or, under DryRun, would-migrate) node.
@@ -115,19 +119,36 @@ func (imp *Importer) Run() (*Report, error) {
 	if err := imp.runTasks(report); err != nil {
 		return nil, fmt.Errorf("textmigrate: tasks: %w", err)
 	}
+	imp.reportProgress(report)
 	if err := imp.runNotes(report); err != nil {
 		return nil, fmt.Errorf("textmigrate: notes: %w", err)
 	}
+	imp.reportProgress(report)
 	if err := imp.runChecklists(report); err != nil {
 		return nil, fmt.Errorf("textmigrate: checklists: %w", err)
 	}
+	imp.reportProgress(report)
 	if err := imp.runJournal(report); err != nil {
 		return nil, fmt.Errorf("textmigrate: journal: %w", err)
 	}
+	imp.reportProgress(report)
 
 	return report, nil
 }
 
+// reportProgress forwards the report's running record count to
+// imp.Progress, if one is set.
+func (imp *Importer) reportProgress(r *Report) {
+	if imp.Progress == nil {
+		return
+	}
+	done := 0
+	for _, tr := range []TypeResult{r.Tasks, r.Notes, r.ChecklistTemplates, r.ChecklistRuns, r.JournalDays} {
+		done += len(tr.Created) + len(tr.Skipped) + len(tr.Errored)
+	}
+	imp.Progress(done)
+}
+
 // renderAndParse is the shared tail of the NewNode -> set fields -> Render ->
 // Parse -> writeFileAtomic recipe every converter follows: it turns a
 // freshly-built node into the byte-preserving, round-trip-stable form that is
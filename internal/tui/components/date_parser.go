@@ -152,6 +152,12 @@ func GetDateDescription(date time.Time) string {
 	return getDateDescriptionWithNow(date, time.Now())
 }
 
+// GetDateDescriptionFrom is GetDateDescription relative to now instead of the
+// wall clock, for callers that carry their own notion of "today".
+func GetDateDescriptionFrom(date, now time.Time) string {
+	return getDateDescriptionWithNow(date, now)
+}
+
 // getDateDescriptionWithNow is an internal function that accepts a "now" parameter for testing
 func getDateDescriptionWithNow(date time.Time, now time.Time) string {
 	// Normalize to start of day for comparison
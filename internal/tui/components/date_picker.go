@@ -38,6 +38,7 @@ type DatePicker struct {
 	error     string
 	preview   string
 	width     int
+	parse     func(string) (time.Time, error)
 }
 
 // NewDatePicker creates a new date picker component
@@ -52,9 +53,23 @@ func NewDatePicker(title string) *DatePicker {
 		visible:   false,
 		title:     title,
 		width:     40,
+		parse:     ParseRelativeDate,
 	}
 }
 
+// SetParser replaces the parser that validates, previews, and resolves the
+// input (ParseRelativeDate by default), for pickers whose accepted dates
+// differ, e.g. one that looks backward instead of forward.
+func (dp *DatePicker) SetParser(parse func(string) (time.Time, error)) {
+	dp.parse = parse
+}
+
+// SetPlaceholder sets the input's placeholder text, typically to describe a
+// parser installed with SetParser.
+func (dp *DatePicker) SetPlaceholder(placeholder string) {
+	dp.textInput.Placeholder = placeholder
+}
+
 // Show displays the date picker and focuses the input
 func (dp *DatePicker) Show() tea.Cmd {
 	dp.visible = true
@@ -109,7 +124,7 @@ func (dp *DatePicker) Update(msg tea.Msg) (*DatePicker, tea.Cmd) {
 			}
 
 			// Try to parse the date
-			_, err := ParseRelativeDate(input)
+			_, err := dp.parse(input)
 			if err != nil {
 				dp.error = "Invalid date: " + err.Error()
 				return dp, nil
@@ -141,7 +156,7 @@ func (dp *DatePicker) updatePreview() {
 	}
 
 	// Try to parse the date
-	date, err := ParseRelativeDate(input)
+	date, err := dp.parse(input)
 	if err != nil {
 		dp.error = err.Error()
 		dp.preview = ""
@@ -193,5 +208,5 @@ func (dp *DatePicker) ParsedDate() (time.Time, error) {
 	if input == "" {
 		return time.Time{}, nil
 	}
-	return ParseRelativeDate(input)
+	return dp.parse(input)
 }
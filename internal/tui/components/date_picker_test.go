@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -151,6 +152,34 @@ func TestDatePickerUpdateEnterInvalid(t *testing.T) {
 	}
 }
 
+func TestDatePickerSetParser(t *testing.T) {
+	dp := NewDatePicker("Test")
+	want := time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)
+	dp.SetParser(func(s string) (time.Time, error) {
+		if s != "then" {
+			return time.Time{}, fmt.Errorf("not then")
+		}
+		return want, nil
+	})
+	dp.Show()
+
+	dp.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("then")})
+	dp.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if dp.error != "" {
+		t.Errorf("Expected the custom parser to accept %q, got error: %s", "then", dp.error)
+	}
+	if got, err := dp.ParsedDate(); err != nil || !got.Equal(want) {
+		t.Errorf("ParsedDate() = %v, %v; want %v from the custom parser", got, err, want)
+	}
+
+	dp.Show()
+	dp.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(futureDate())})
+	dp.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(dp.error, "not then") {
+		t.Errorf("Expected the custom parser's error, got: %q", dp.error)
+	}
+}
+
 func TestDatePickerUpdateEnterEmpty(t *testing.T) {
 	dp := NewDatePicker("Test")
 	dp.Show()
@@ -305,7 +305,9 @@ func (f *Form) handleSubmit() (*Form, tea.Cmd) {
 	}
 }
 
-// updateDatePreview updates the date preview for the current field if it's a date field
+// updateDatePreview updates the date preview for the current field if it's a date field.
+// An unparseable value shows its error inline as it is typed rather than only on submit;
+// clearing the field (date fields may be optional) clears the error too.
 func (f *Form) updateDatePreview() {
 	f.datePreview = ""
 
@@ -318,15 +320,18 @@ func (f *Form) updateDatePreview() {
 		return
 	}
 
-	value := field.textInput.Value()
+	value := strings.TrimSpace(field.textInput.Value())
 	if value == "" {
+		delete(f.errors, field.Key)
 		return
 	}
 
 	date, err := ParseRelativeDate(value)
 	if err != nil {
+		f.errors[field.Key] = err.Error()
 		return
 	}
+	delete(f.errors, field.Key)
 
 	description := GetDateDescription(date)
 	f.datePreview = FormatDate(date) + " (" + description + ")"
@@ -435,6 +435,42 @@ func TestForm_DatePreviewUpdate(t *testing.T) {
 	assert.Empty(t, form.datePreview)
 }
 
+func TestForm_DateFieldLiveError(t *testing.T) {
+	form := NewForm("Test Form")
+	form.AddField(FormField{
+		Label: "Due Date",
+		Key:   "due_date",
+		Type:  FieldTypeDate,
+	})
+	form.Show()
+
+	// Typing a bad date shows the error before any submit
+	for _, r := range "nxt" {
+		form, _ = form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.NotEmpty(t, form.errors["due_date"])
+	assert.Contains(t, form.View(), "invalid date format")
+
+	// Submission stays blocked while the error stands
+	form, cmd := form.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.False(t, form.submitted)
+
+	// Fixing the value clears the error and shows the parsed date
+	form.fields[0].textInput.SetValue("tm")
+	form.updateDatePreview()
+	assert.Empty(t, form.errors["due_date"])
+	assert.Contains(t, form.datePreview, "tomorrow")
+
+	// Emptying the optional field clears the error as well
+	form.fields[0].textInput.SetValue("bogus")
+	form.updateDatePreview()
+	assert.NotEmpty(t, form.errors["due_date"])
+	form.fields[0].textInput.SetValue("")
+	form.updateDatePreview()
+	assert.Empty(t, form.errors["due_date"])
+}
+
 func TestForm_UpdateNotVisibleDoesNothing(t *testing.T) {
 	form := NewForm("Test Form")
 	form.AddField(FormField{
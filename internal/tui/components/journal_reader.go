@@ -0,0 +1,159 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	readerTitleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
+	readerHeadingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	readerDimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	readerCodeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	readerLinkStyle    = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("141"))
+	readerBoldStyle    = lipgloss.NewStyle().Bold(true)
+)
+
+// JournalReader is a full-screen, read-only view of one day's journal: its
+// markdown rendered for reading, scrollable with the usual pager keys.
+type JournalReader struct {
+	vp      viewport.Model
+	day     string
+	raw     string
+	width   int
+	visible bool
+}
+
+func NewJournalReader() *JournalReader {
+	return &JournalReader{vp: viewport.New(0, 0)}
+}
+
+// SetContent shows raw, day's journal file, from the top. An empty raw
+// (nothing logged, or no file yet) renders a single note instead.
+func (jr *JournalReader) SetContent(day, raw string) {
+	jr.day = day
+	jr.raw = raw
+	jr.render()
+	jr.vp.GotoTop()
+}
+
+// SetSize fits the reader to width x height, the title row included.
+func (jr *JournalReader) SetSize(width, height int) {
+	jr.width = width
+	jr.vp.Width = width
+	jr.vp.Height = max(0, height-1)
+	jr.render()
+}
+
+func (jr *JournalReader) render() {
+	body := RenderMarkdown(jr.raw, jr.width)
+	if strings.TrimSpace(body) == "" {
+		body = RenderMarkdown("Nothing logged on "+jr.day+".", jr.width)
+	}
+	jr.vp.SetContent(body)
+}
+
+// Update scrolls the view: j/k and the arrows by a line, f/b, space and
+// pgup/pgdn by a page, u/d by half a page.
+func (jr *JournalReader) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	jr.vp, cmd = jr.vp.Update(msg)
+	return cmd
+}
+
+// View renders the title row over the scrolled body, or "" while hidden.
+func (jr *JournalReader) View() string {
+	if !jr.visible {
+		return ""
+	}
+	return readerTitleStyle.Render("Journal · "+jr.day) + "\n" + jr.vp.View()
+}
+
+func (jr *JournalReader) IsVisible() bool {
+	return jr.visible
+}
+
+func (jr *JournalReader) SetVisible(visible bool) {
+	jr.visible = visible
+}
+
+var (
+	mdHeadingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdCheckboxRe = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	mdBulletRe   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdInlineRe   = regexp.MustCompile("\\[\\[[^\\]]+\\]\\]|`[^`]+`|\\*\\*[^*]+\\*\\*")
+)
+
+// RenderMarkdown renders a journal file's markdown for the terminal,
+// wrapped to width (0 leaves lines unwrapped): the frontmatter is dropped,
+// headings are bold, bullets and checkboxes get glyphs, code and quotes are
+// dimmed, and [[wiki links]] are highlighted. It covers what journals use,
+// not all of CommonMark.
+func RenderMarkdown(src string, width int) string {
+	lines := strings.Split(strings.TrimRight(src, "\n"), "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+
+	wrap := lipgloss.NewStyle()
+	if width > 0 {
+		wrap = wrap.Width(width)
+	}
+	var out []string
+	inFence := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		var r string
+		switch {
+		case inFence:
+			r = readerCodeStyle.Render(line)
+		case mdHeadingRe.MatchString(line):
+			r = readerHeadingStyle.Render(mdHeadingRe.FindStringSubmatch(line)[2])
+		case mdCheckboxRe.MatchString(line):
+			m := mdCheckboxRe.FindStringSubmatch(line)
+			box := "☐ "
+			if m[2] != " " {
+				box = "☑ "
+			}
+			r = m[1] + box + renderInline(m[3])
+		case mdBulletRe.MatchString(line):
+			m := mdBulletRe.FindStringSubmatch(line)
+			r = m[1] + "• " + renderInline(m[2])
+		case strings.HasPrefix(line, ">"):
+			r = readerDimStyle.Render("│ " + strings.TrimSpace(strings.TrimPrefix(line, ">")))
+		default:
+			r = renderInline(line)
+		}
+		if width > 0 && lipgloss.Width(r) > width {
+			r = wrap.Render(r)
+		}
+		out = append(out, r)
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderInline styles a line's [[wiki links]], `code` and **bold** spans.
+func renderInline(s string) string {
+	return mdInlineRe.ReplaceAllStringFunc(s, func(span string) string {
+		switch {
+		case strings.HasPrefix(span, "[["):
+			return readerLinkStyle.Render(span)
+		case strings.HasPrefix(span, "`"):
+			return readerCodeStyle.Render(span[1 : len(span)-1])
+		default:
+			return readerBoldStyle.Render(span[2 : len(span)-2])
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	src := "---\ndate: 2026-07-10\n---\n# Friday\n\n## Log\n- 09:00 Met [[alice]] about `rk sync`\n- [x] shipped **it**\n- [ ] write docs\n> quoted\n```\n# not a heading\n```\n"
+	got := RenderMarkdown(src, 0)
+	for _, want := range []string{"Friday", "• 09:00 Met [[alice]] about rk sync", "☑ shipped it", "☐ write docs", "│ quoted", "# not a heading"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered markdown should contain %q, got:\n%s", want, got)
+		}
+	}
+	for _, bad := range []string{"date: 2026-07-10", "## Log", "```", "**"} {
+		if strings.Contains(got, bad) {
+			t.Errorf("rendered markdown should not contain %q, got:\n%s", bad, got)
+		}
+	}
+}
+
+func TestJournalReaderScrollsAndWraps(t *testing.T) {
+	jr := NewJournalReader()
+	jr.SetSize(20, 4)
+	var b strings.Builder
+	for _, w := range []string{"one", "two", "three", "four", "five"} {
+		b.WriteString("- " + w + "\n")
+	}
+	b.WriteString("a line long enough that it has to wrap\n")
+	jr.SetContent("2026-07-10", b.String())
+	if view := jr.View(); view != "" {
+		t.Errorf("hidden reader should render nothing, got: %s", view)
+	}
+
+	jr.SetVisible(true)
+	view := jr.View()
+	if !strings.Contains(view, "Journal · 2026-07-10") || !strings.Contains(view, "• one") || strings.Contains(view, "• four") {
+		t.Errorf("reader should show the title and the first 3 lines, got:\n%s", view)
+	}
+	jr.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if view := jr.View(); strings.Contains(view, "• one") || !strings.Contains(view, "• four") {
+		t.Errorf("j should scroll a line, got:\n%s", view)
+	}
+	for _, l := range strings.Split(RenderMarkdown(b.String(), 20), "\n") {
+		if len([]rune(l)) > 20 {
+			t.Errorf("line %q is wider than 20", l)
+		}
+	}
+
+	jr.SetSize(40, 4)
+	jr.SetContent("2026-07-11", "---\ndate: 2026-07-11\n---\n")
+	if view := jr.View(); !strings.Contains(view, "Nothing logged on 2026-07-11.") {
+		t.Errorf("empty day should say so, got:\n%s", view)
+	}
+}
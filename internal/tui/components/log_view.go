@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/MikeBiancalana/reckon/internal/logger"
@@ -45,7 +46,10 @@ func (l LogEntryItem) FilterValue() string { return l.entry.Content }
 
 // LogDelegate handles rendering of log entry items
 type LogDelegate struct {
-	width int
+	width      int
+	timeLayout string // entry time layout; "" means 15:04
+	order      LogSortOrder
+	gap        time.Duration // mark entries this long after the previous one; 0 = off
 }
 
 func (d LogDelegate) Height() int                               { return 1 }
@@ -59,18 +63,29 @@ func (d LogDelegate) Render(w io.Writer, m list.Model, index int, listItem list.
 	}
 
 	// Render log entry with icon
-	timeStr := item.entry.Timestamp.Format("15:04")
+	layout := d.timeLayout
+	if layout == "" {
+		layout = "15:04"
+	}
+	timeStr := item.entry.Timestamp.Format(layout)
 	var icon string
 	switch item.entry.EntryType {
 	case "meeting":
 		icon = "📅"
 	case "break":
 		icon = "☕"
+	case "win":
+		icon = "🏆"
+	case "intention":
+		icon = "🎯"
 	default:
 		icon = "📝"
 	}
 
 	text := fmt.Sprintf("%s %s: %s", timeStr, icon, item.entry.Content)
+	if gap := d.gapBefore(m, index, item.entry); gap > 0 {
+		text = fmt.Sprintf("%s %s %s: %s", timeStr, formatGap(gap), icon, item.entry.Content)
+	}
 
 	// Truncate to available width to prevent pane from expanding horizontally
 	if d.width > 0 {
@@ -87,6 +102,42 @@ func (d LogDelegate) Render(w io.Writer, m list.Model, index int, listItem list.
 	fmt.Fprintf(w, "%s", text)
 }
 
+// gapBefore returns how long before entry the chronologically previous
+// entry of the same UTC day was written, when that is at least d.gap, else
+// 0. The previous entry is the row below in newest-first order, above in
+// oldest-first.
+func (d LogDelegate) gapBefore(m list.Model, index int, entry LogEntryRow) time.Duration {
+	if d.gap <= 0 || entry.Timestamp.IsZero() {
+		return 0
+	}
+	prev := index + 1
+	if d.order == LogOldestFirst {
+		prev = index - 1
+	}
+	items := m.Items()
+	if prev < 0 || prev >= len(items) {
+		return 0
+	}
+	other, ok := items[prev].(LogEntryItem)
+	if !ok || other.entry.Timestamp.IsZero() ||
+		other.entry.Timestamp.UTC().Format("2006-01-02") != entry.Timestamp.UTC().Format("2006-01-02") {
+		return 0
+	}
+	if gap := entry.Timestamp.Sub(other.entry.Timestamp); gap >= d.gap {
+		return gap
+	}
+	return 0
+}
+
+// formatGap renders a gap marker: "⋯45m", or "⋯2h15m" from an hour up.
+func formatGap(gap time.Duration) string {
+	m := int(gap.Minutes())
+	if m < 60 {
+		return fmt.Sprintf("⋯%dm", m)
+	}
+	return fmt.Sprintf("⋯%dh%02dm", m/60, m%60)
+}
+
 // buildLogItems converts log entries into list items.
 func buildLogItems(logEntries []LogEntryRow) []list.Item {
 	defer func() {
@@ -109,12 +160,38 @@ func buildLogItems(logEntries []LogEntryRow) []list.Item {
 	return items
 }
 
+// LogSortOrder is the order LogView lists its entries in. It only affects
+// display: the rows passed to UpdateLogEntries are never reordered.
+type LogSortOrder int
+
+const (
+	LogNewestFirst LogSortOrder = iota // default
+	LogOldestFirst
+)
+
+// sortLogEntries returns a copy of entries ordered by order, stable so
+// entries sharing a timestamp keep their given relative order.
+func sortLogEntries(entries []LogEntryRow, order LogSortOrder) []LogEntryRow {
+	out := append([]LogEntryRow(nil), entries...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if order == LogOldestFirst {
+			return out[i].Timestamp.Before(out[j].Timestamp)
+		}
+		return out[i].Timestamp.After(out[j].Timestamp)
+	})
+	return out
+}
+
 // LogView represents the log entries component
 type LogView struct {
 	list       list.Model
 	logEntries []LogEntryRow // keep track of original log entries for state management
+	order      LogSortOrder
+	timeLayout string
+	gap        time.Duration
 	focused    bool
 	width      int
+	emptyHint  string
 }
 
 func NewLogView(logEntries []LogEntryRow) *LogView {
@@ -130,6 +207,7 @@ func NewLogView(logEntries []LogEntryRow) *LogView {
 	return &LogView{
 		list:       l,
 		logEntries: logEntries,
+		emptyHint:  "No log entries yet - press n to add one",
 	}
 }
 
@@ -152,7 +230,7 @@ func (lv *LogView) Update(msg tea.Msg) (*LogView, tea.Cmd) {
 // View renders the log view
 func (lv *LogView) View() string {
 	if len(lv.list.Items()) == 0 {
-		return "Log Entries\n\nNo log entries yet - press n to add one"
+		return "Log Entries\n\n" + dimmedStyle.Render(lv.emptyHint)
 	}
 	return lv.list.View()
 }
@@ -161,7 +239,7 @@ func (lv *LogView) View() string {
 func (lv *LogView) SetSize(width, height int) {
 	lv.width = width
 	lv.list.SetSize(width, height)
-	lv.list.SetDelegate(LogDelegate{width: lv.width})
+	lv.list.SetDelegate(lv.delegate())
 }
 
 // SetFocused sets whether this component is focused
@@ -186,7 +264,7 @@ func (lv *LogView) UpdateLogEntries(logEntries []LogEntryRow) {
 	}
 
 	lv.logEntries = logEntries
-	items := buildLogItems(logEntries)
+	items := buildLogItems(sortLogEntries(logEntries, lv.order))
 	lv.list.SetItems(items)
 
 	// Restore cursor to the previously selected log entry
@@ -199,7 +277,74 @@ func (lv *LogView) UpdateLogEntries(logEntries []LogEntryRow) {
 		}
 	}
 
-	lv.list.SetDelegate(LogDelegate{width: lv.width})
+	lv.list.SetDelegate(lv.delegate())
+}
+
+// SetTimeLayout sets the Go time layout entry times are shown in ("" for
+// the default 15:04).
+func (lv *LogView) SetTimeLayout(layout string) {
+	lv.timeLayout = layout
+	lv.list.SetDelegate(lv.delegate())
+}
+
+// SetEmptyHint sets the text shown in place of an empty log, for a host
+// that binds adding an entry to another key.
+func (lv *LogView) SetEmptyHint(hint string) {
+	lv.emptyHint = hint
+}
+
+// SetGapThreshold marks each entry written at least gap after the one
+// before it on the same day with the length of that gap, a cue to backfill
+// (0 turns the markers off).
+func (lv *LogView) SetGapThreshold(gap time.Duration) {
+	lv.gap = gap
+	lv.list.SetDelegate(lv.delegate())
+}
+
+// delegate returns a LogDelegate for the view's current width, layout,
+// order, and gap threshold.
+func (lv *LogView) delegate() LogDelegate {
+	return LogDelegate{width: lv.width, timeLayout: lv.timeLayout, order: lv.order, gap: lv.gap}
+}
+
+// SetSortOrder re-lists the current entries in order, keeping the cursor on
+// the same entry.
+func (lv *LogView) SetSortOrder(order LogSortOrder) {
+	if order == lv.order {
+		return
+	}
+	lv.order = order
+	lv.UpdateLogEntries(lv.logEntries)
+}
+
+// SortOrder returns the order the entries are currently listed in.
+func (lv *LogView) SortOrder() LogSortOrder {
+	return lv.order
+}
+
+// SelectDate moves the cursor to the newest entry dated on or before day
+// (YYYY-MM-DD, compared against each entry's UTC date, the log's day-file
+// convention): the latest entry of day itself when it has any, else the
+// nearest older one, whichever way the entries are sorted. Reports false,
+// leaving the cursor alone, when every entry is newer than day.
+func (lv *LogView) SelectDate(day string) bool {
+	best := -1
+	var bestTime time.Time
+	for i, item := range lv.list.Items() {
+		logItem, ok := item.(LogEntryItem)
+		if !ok || logItem.entry.Timestamp.IsZero() {
+			continue
+		}
+		ts := logItem.entry.Timestamp
+		if ts.UTC().Format("2006-01-02") <= day && (best < 0 || ts.After(bestTime)) {
+			best, bestTime = i, ts
+		}
+	}
+	if best < 0 {
+		return false
+	}
+	lv.list.Select(best)
+	return true
 }
 
 // SelectedLogEntry returns the currently selected log entry
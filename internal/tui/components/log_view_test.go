@@ -0,0 +1,37 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLogViewGapMarker: an entry written at least the gap threshold after
+// the previous one that day shows the gap, in either sort order; shorter
+// gaps and the first entry of a day show none.
+func TestLogViewGapMarker(t *testing.T) {
+	at := func(s string) time.Time {
+		ts, _ := time.Parse(time.RFC3339, s)
+		return ts
+	}
+	entries := []LogEntryRow{
+		{ID: "a", Timestamp: at("2026-07-04T23:00:00Z"), Content: "late"},
+		{ID: "b", Timestamp: at("2026-07-05T09:00:00Z"), Content: "start"},
+		{ID: "c", Timestamp: at("2026-07-05T09:30:00Z"), Content: "standup"},
+		{ID: "d", Timestamp: at("2026-07-05T12:00:00Z"), Content: "lunch done"},
+	}
+	for _, order := range []LogSortOrder{LogNewestFirst, LogOldestFirst} {
+		lv := NewLogView(nil)
+		lv.SetSize(80, 20)
+		lv.SetSortOrder(order)
+		lv.UpdateLogEntries(entries)
+		lv.SetGapThreshold(90 * time.Minute)
+		view := lv.View()
+		if !strings.Contains(view, "12:00 ⋯2h30m") {
+			t.Errorf("order %d: no gap marker on the 12:00 entry:\n%s", order, view)
+		}
+		if strings.Count(view, "⋯") != 1 {
+			t.Errorf("order %d: want exactly one gap marker:\n%s", order, view)
+		}
+	}
+}
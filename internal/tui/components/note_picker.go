@@ -69,10 +69,13 @@ func (i notePickerItem) Description() string {
 		parts = append(parts, "tags: "+strings.Join(i.note.Tags, ", "))
 	}
 
-	// Add created date
+	// Add created date, and the updated date once it differs
 	if !i.note.CreatedAt.IsZero() {
 		parts = append(parts, "created: "+i.note.CreatedAt.Format("2006-01-02"))
 	}
+	if updated := i.note.UpdatedAt.Format("2006-01-02"); !i.note.UpdatedAt.IsZero() && updated != i.note.CreatedAt.Format("2006-01-02") {
+		parts = append(parts, "updated: "+updated)
+	}
 
 	return strings.Join(parts, " | ")
 }
@@ -121,6 +124,8 @@ type NotePicker struct {
 	selectedNote *models.Note
 	width        int
 	height       int
+	minScore     int // see SetMinScore
+	maxMatches   int // see SetMaxMatches
 
 	// embedded is true when the picker is mounted inline as part of a larger
 	// pane region rather than shown as a self-contained modal popup. View()
@@ -129,23 +134,33 @@ type NotePicker struct {
 	embedded bool
 }
 
-// notePickerFuzzyFilter implements fuzzy matching for note picker items
-func notePickerFuzzyFilter(term string, targets []string) []list.Rank {
-	if term == "" {
-		return nil
-	}
+// notePickerFuzzyFilter returns the picker's fuzzy matching filter, which
+// drops matches scoring below minScore (0 keeps them all) and keeps only
+// the maxMatches best (0 keeps them all).
+func notePickerFuzzyFilter(minScore, maxMatches int) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		if term == "" {
+			return nil
+		}
 
-	matches := fuzzy.Find(term, targets)
-	ranks := make([]list.Rank, len(matches))
+		matches := fuzzy.Find(term, targets)
+		ranks := make([]list.Rank, 0, len(matches))
 
-	for i, match := range matches {
-		ranks[i] = list.Rank{
-			Index:          match.Index,
-			MatchedIndexes: match.MatchedIndexes,
+		for _, match := range matches {
+			if minScore > 0 && match.Score < minScore {
+				continue
+			}
+			if maxMatches > 0 && len(ranks) == maxMatches {
+				break
+			}
+			ranks = append(ranks, list.Rank{
+				Index:          match.Index,
+				MatchedIndexes: match.MatchedIndexes,
+			})
 		}
-	}
 
-	return ranks
+		return ranks
+	}
 }
 
 // NewNotePicker creates a new note picker component
@@ -160,7 +175,7 @@ func NewNotePicker(title string) *NotePicker {
 	l.SetShowHelp(false)
 
 	// Configure fuzzy matching filter
-	l.Filter = notePickerFuzzyFilter
+	l.Filter = notePickerFuzzyFilter(0, 0)
 
 	return &NotePicker{
 		list:    l,
@@ -187,6 +202,20 @@ func (np *NotePicker) Show(notes []*models.Note) tea.Cmd {
 	return nil
 }
 
+// SetMinScore sets the lowest fuzzy score a note must have to stay in the
+// filtered list (the match_threshold setting); 0 keeps every match.
+func (np *NotePicker) SetMinScore(score int) {
+	np.minScore = score
+	np.list.Filter = notePickerFuzzyFilter(np.minScore, np.maxMatches)
+}
+
+// SetMaxMatches caps the filtered list at the n best-scoring notes (the
+// match_max_candidates setting); 0 keeps every match.
+func (np *NotePicker) SetMaxMatches(n int) {
+	np.maxMatches = n
+	np.list.Filter = notePickerFuzzyFilter(np.minScore, np.maxMatches)
+}
+
 // Hide hides the note picker
 func (np *NotePicker) Hide() {
 	np.visible = false
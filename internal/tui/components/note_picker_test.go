@@ -0,0 +1,29 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotePickerFuzzyFilter_MinScore(t *testing.T) {
+	targets := []string{"Billing", "Billing pipeline", "Entity model"}
+
+	assert.Len(t, notePickerFuzzyFilter(0, 0)("bil", targets), 2)
+
+	// "bil" scores Billing above 20 and Billing pipeline below it.
+	ranks := notePickerFuzzyFilter(20, 0)("bil", targets)
+	if assert.Len(t, ranks, 1) {
+		assert.Equal(t, 0, ranks[0].Index)
+	}
+}
+
+func TestNotePickerFuzzyFilter_MaxMatches(t *testing.T) {
+	targets := []string{"Billing", "Billing pipeline", "Entity model"}
+
+	// The best-scoring match is the one kept.
+	ranks := notePickerFuzzyFilter(0, 1)("bil", targets)
+	if assert.Len(t, ranks, 1) {
+		assert.Equal(t, 0, ranks[0].Index)
+	}
+}
@@ -1,12 +1,22 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// statusBarClockMinWidth is the narrowest status bar that still shows the
+// clock; below it the date and hints already compete for space.
+const statusBarClockMinWidth = 60
+
+// statusBarMinHints is how many columns of hints the counts segment must
+// leave free; counts are dropped, last first, rather than squeeze them out.
+const statusBarMinHints = 20
+
 var (
 	statusBarStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241")).
@@ -17,8 +27,47 @@ var (
 			Foreground(lipgloss.Color("255")).
 			Background(lipgloss.Color("236")).
 			Bold(true)
+
+	dirtyStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Background(lipgloss.Color("236"))
+
+	countsStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252")).
+			Background(lipgloss.Color("236"))
 )
 
+// StatusCounts are the ambient vault counts the status bar shows after the
+// date: open todos, todos due today or earlier, and unresolved note links.
+type StatusCounts struct {
+	OpenTodos       int
+	Due             int
+	UnresolvedLinks int
+}
+
+// segments renders the counts as compact "⚑3" style segments, most
+// important first. Open todos always show; the others only when non-zero.
+func (c StatusCounts) segments() []string {
+	segs := []string{fmt.Sprintf("⚑%d", c.OpenTodos)}
+	if c.Due > 0 {
+		segs = append(segs, fmt.Sprintf("⏰%d", c.Due))
+	}
+	if c.UnresolvedLinks > 0 {
+		segs = append(segs, fmt.Sprintf("⚠%d", c.UnresolvedLinks))
+	}
+	return segs
+}
+
+// ClockTickMsg carries the wall-clock time for the status bar's clock.
+type ClockTickMsg time.Time
+
+// ClockTick returns a command that fires one ClockTickMsg on the next
+// minute boundary of the system clock. Hosts re-arm it from their Update on
+// every ClockTickMsg, so the clock costs one message a minute.
+func ClockTick() tea.Cmd {
+	return tea.Every(time.Minute, func(t time.Time) tea.Msg { return ClockTickMsg(t) })
+}
+
 // StatusBar represents the status bar component
 type StatusBar struct {
 	width          int
@@ -26,6 +75,10 @@ type StatusBar struct {
 	focusedSection string
 	inputMode      bool
 	noteSelected   bool
+	clock          time.Time // zero = no clock shown
+	dirty          bool
+	hints          string        // host-supplied hints; overrides generateHints when set
+	counts         *StatusCounts // nil = no counts segment
 }
 
 // NewStatusBar creates a new status bar
@@ -58,8 +111,34 @@ func (sb *StatusBar) SetNoteSelected(noteSelected bool) {
 	sb.noteSelected = noteSelected
 }
 
+// SetClock sets the time the clock displays (typically from a
+// ClockTickMsg). A zero time hides the clock.
+func (sb *StatusBar) SetClock(t time.Time) {
+	sb.clock = t
+}
+
+// SetDirty toggles the unsaved indicator: set it while an input is in
+// progress or a save is still pending.
+func (sb *StatusBar) SetDirty(dirty bool) {
+	sb.dirty = dirty
+}
+
+// SetHints overrides the built-in per-section hints with host-specific
+// text; an empty string restores the built-in hints.
+func (sb *StatusBar) SetHints(hints string) {
+	sb.hints = hints
+}
+
+// SetCounts sets the ambient counts shown after the date; nil hides them.
+func (sb *StatusBar) SetCounts(counts *StatusCounts) {
+	sb.counts = counts
+}
+
 // generateHints generates context-sensitive hints based on current section and input mode
 func (sb *StatusBar) generateHints() string {
+	if sb.hints != "" {
+		return sb.hints
+	}
 	if sb.inputMode {
 		return "enter:submit esc:cancel"
 	}
@@ -86,11 +165,18 @@ func (sb *StatusBar) generateHints() string {
 
 // View renders the status bar
 func (sb *StatusBar) View() string {
-	// Format the date display
+	// Format the date display, followed by the dirty marker and clock
 	dateDisplay := sb.formatDate()
+	if sb.dirty {
+		dateDisplay += dirtyStyle.Render(" ●")
+	}
+	if !sb.clock.IsZero() && sb.width >= statusBarClockMinWidth {
+		dateDisplay += statusBarStyle.UnsetPadding().Render(" " + sb.clock.Format("15:04"))
+	}
 
 	// Generate context-sensitive hints
 	hints := sb.generateHints()
+	dateDisplay += sb.countsView(sb.width - lipgloss.Width(dateDisplay) - statusBarMinHints - 5)
 
 	// Calculate available space
 	dateLen := lipgloss.Width(dateDisplay)
@@ -111,11 +197,28 @@ func (sb *StatusBar) View() string {
 		hints = hints[:availableForHints] + "..."
 	}
 
-	spacer := strings.Repeat(" ", sb.width-dateLen-len(hints)-2)
+	spacer := strings.Repeat(" ", max(0, sb.width-dateLen-len(hints)-2))
 	content := dateDisplay + spacer + hints
 	return statusBarStyle.Width(sb.width).Render(content)
 }
 
+// countsView renders as many count segments as fit in room columns,
+// dropping them from the end; "" when none fit or no counts are set.
+func (sb *StatusBar) countsView(room int) string {
+	if sb.counts == nil {
+		return ""
+	}
+	segs := sb.counts.segments()
+	for len(segs) > 0 {
+		text := " " + strings.Join(segs, " ")
+		if lipgloss.Width(text) <= room {
+			return countsStyle.Render(text)
+		}
+		segs = segs[:len(segs)-1]
+	}
+	return ""
+}
+
 // formatDate formats the current date for display
 func (sb *StatusBar) formatDate() string {
 	if sb.currentDate == "" {
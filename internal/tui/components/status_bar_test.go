@@ -3,6 +3,7 @@ package components
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestStatusBarTasksSectionShowsSchedulingHints(t *testing.T) {
@@ -108,3 +109,70 @@ func TestStatusBarSetNoteSelectedResetsOnNewSection(t *testing.T) {
 		t.Errorf("After SetNoteSelected(false), Tasks section should show s:schedule, got: %s", view)
 	}
 }
+
+func TestStatusBarClockHiddenOnNarrowTerminal(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetClock(time.Date(2026, 3, 4, 9, 7, 0, 0, time.Local))
+
+	sb.SetWidth(120)
+	if view := sb.View(); !strings.Contains(view, "09:07") {
+		t.Errorf("wide status bar should show the clock, got: %s", view)
+	}
+
+	sb.SetWidth(statusBarClockMinWidth - 1)
+	if view := sb.View(); strings.Contains(view, "09:07") {
+		t.Errorf("narrow status bar should hide the clock, got: %s", view)
+	}
+}
+
+func TestStatusBarDirtyIndicator(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetWidth(120)
+
+	if view := sb.View(); strings.Contains(view, "●") {
+		t.Errorf("clean status bar should not show the unsaved marker, got: %s", view)
+	}
+	sb.SetDirty(true)
+	if view := sb.View(); !strings.Contains(view, "●") {
+		t.Errorf("dirty status bar should show the unsaved marker, got: %s", view)
+	}
+}
+
+func TestStatusBarSetHintsOverridesBuiltins(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetSection("Tasks")
+	sb.SetWidth(200)
+	sb.SetHints("n:new q:quit")
+
+	view := sb.View()
+	if !strings.Contains(view, "n:new q:quit") || strings.Contains(view, "s:schedule") {
+		t.Errorf("host hints should replace the built-in ones, got: %s", view)
+	}
+}
+
+func TestStatusBarCountsDropWhenCramped(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetHints("q:quit")
+	sb.SetCounts(&StatusCounts{OpenTodos: 3, Due: 1, UnresolvedLinks: 2})
+
+	sb.SetWidth(120)
+	view := sb.View()
+	for _, seg := range []string{"⚑3", "⏰1", "⚠2"} {
+		if !strings.Contains(view, seg) {
+			t.Errorf("wide status bar should show %q, got: %s", seg, view)
+		}
+	}
+
+	// Room for the first segment only: the later ones go first.
+	sb.SetWidth(statusBarMinHints + 5 + 3)
+	view = sb.View()
+	if !strings.Contains(view, "⚑3") || strings.Contains(view, "⏰1") || strings.Contains(view, "⚠2") {
+		t.Errorf("cramped status bar should keep only the open count, got: %s", view)
+	}
+
+	sb.SetCounts(&StatusCounts{OpenTodos: 4})
+	sb.SetWidth(120)
+	if view := sb.View(); !strings.Contains(view, "⚑4") || strings.Contains(view, "⏰") || strings.Contains(view, "⚠") {
+		t.Errorf("zero due/unresolved counts should be omitted, got: %s", view)
+	}
+}
@@ -1,45 +1,86 @@
 package components
 
 import (
-	"github.com/MikeBiancalana/reckon/internal/time"
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 var (
-	summaryStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Padding(0, 1)
+	summaryStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("39")).
+			Padding(0, 2)
+	summaryTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
 	summaryLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
 	summaryValueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
 )
 
+// DaySummary is the data SummaryView renders: one day's wrap-up plus the
+// vault's current todo counts. Hosts fill it from their own sources.
+type DaySummary struct {
+	Date       string
+	TodosDone  int // todos completed that day
+	TodosTotal int // done plus still on the day's agenda
+	Wins       int
+	LogEntries int
+	FirstEntry string // HH:MM, "" when nothing was logged
+	LastEntry  string
+	Active     string // the span between them, formatted by the host ("1h30m")
+	OpenTodos  int
+	DueTodos   int
+}
+
+// SummaryView is a toggleable overlay showing a DaySummary.
 type SummaryView struct {
-	summary *time.TimeSummary
+	summary *DaySummary
 	width   int
 	visible bool
 }
 
 func NewSummaryView() *SummaryView {
-	return &SummaryView{
-		summary: nil,
-		width:   0,
-		visible: false,
-	}
+	return &SummaryView{}
 }
 
+// View renders the overlay box, or "" while hidden. Before the first
+// SetSummary it shows a loading line; a day with nothing logged and no
+// todos says so instead of a grid of zeros.
 func (sv *SummaryView) View() string {
-	if !sv.visible || sv.summary == nil {
+	if !sv.visible {
 		return ""
 	}
-
-	s := sv.summary
-	content := summaryLabelStyle.Render("Meetings: ") + summaryValueStyle.Render(s.MeetingsFormatted()) + "  " +
-		summaryLabelStyle.Render("Tasks: ") + summaryValueStyle.Render(s.TasksFormatted()) + "  " +
-		summaryLabelStyle.Render("Breaks: ") + summaryValueStyle.Render(s.BreaksFormatted()) + "  " +
-		summaryLabelStyle.Render("Untracked: ") + summaryValueStyle.Render(s.UntrackedFormatted())
-
-	return summaryStyle.Render(content)
+	var lines []string
+	switch s := sv.summary; {
+	case s == nil:
+		lines = []string{summaryTitleStyle.Render("Summary"), summaryLabelStyle.Render("Loading…")}
+	case s.LogEntries == 0 && s.TodosTotal == 0 && s.OpenTodos == 0:
+		lines = []string{summaryTitleStyle.Render("Summary · " + s.Date), summaryLabelStyle.Render("Nothing logged or due yet.")}
+	default:
+		row := func(label, value string) string {
+			return summaryLabelStyle.Render(fmt.Sprintf("%-12s", label)) + summaryValueStyle.Render(value)
+		}
+		active := "—"
+		if s.FirstEntry != "" {
+			active = fmt.Sprintf("%s-%s (%s)", s.FirstEntry, s.LastEntry, s.Active)
+		}
+		lines = []string{
+			summaryTitleStyle.Render("Summary · " + s.Date),
+			row("Done", fmt.Sprintf("%d/%d", s.TodosDone, s.TodosTotal)),
+			row("Wins", fmt.Sprint(s.Wins)),
+			row("Log entries", fmt.Sprint(s.LogEntries)),
+			row("Active", active),
+			row("Open todos", fmt.Sprintf("%d (%d due)", s.OpenTodos, s.DueTodos)),
+		}
+	}
+	style := summaryStyle
+	if sv.width > 0 {
+		style = style.MaxWidth(sv.width)
+	}
+	return style.Render(strings.Join(lines, "\n"))
 }
 
-func (sv *SummaryView) SetSummary(summary *time.TimeSummary) {
+func (sv *SummaryView) SetSummary(summary *DaySummary) {
 	sv.summary = summary
 }
 
@@ -0,0 +1,35 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummaryViewRendersOnlyWhenVisible(t *testing.T) {
+	sv := NewSummaryView()
+	sv.SetSummary(&DaySummary{Date: "2026-07-10", TodosDone: 1, TodosTotal: 3, Wins: 2, LogEntries: 4,
+		FirstEntry: "09:00", LastEntry: "10:30", Active: "1h30m", OpenTodos: 5, DueTodos: 1})
+	if view := sv.View(); view != "" {
+		t.Errorf("hidden summary should render nothing, got: %s", view)
+	}
+
+	sv.Toggle()
+	view := sv.View()
+	for _, want := range []string{"Summary · 2026-07-10", "1/3", "09:00-10:30 (1h30m)", "5 (1 due)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("summary should contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestSummaryViewEmptyAndLoading(t *testing.T) {
+	sv := NewSummaryView()
+	sv.SetVisible(true)
+	if view := sv.View(); !strings.Contains(view, "Loading") {
+		t.Errorf("summary before any data should say it is loading, got:\n%s", view)
+	}
+	sv.SetSummary(&DaySummary{Date: "2026-07-10"})
+	if view := sv.View(); !strings.Contains(view, "Nothing logged or due yet.") || strings.Contains(view, "Wins") {
+		t.Errorf("empty day should render a single note, got:\n%s", view)
+	}
+}
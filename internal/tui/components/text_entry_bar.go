@@ -22,6 +22,7 @@ const (
 	ModeEditIntention EntryMode = "edit_intention"
 	ModeEditWin       EntryMode = "edit_win"
 	ModeEditLog       EntryMode = "edit_log"
+	ModeEditTags      EntryMode = "edit_tags"
 )
 
 var (
@@ -121,6 +122,8 @@ func (teb *TextEntryBar) getPromptForMode() string {
 		return "Edit win: "
 	case ModeEditLog:
 		return "Edit log entry: "
+	case ModeEditTags:
+		return "Tags (comma-separated): "
 	default:
 		return ""
 	}